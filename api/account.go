@@ -1,63 +1,47 @@
 package api
 
 import (
-	"database/sql"
-	"errors"
-	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
-	"github.com/MathPeixoto/go-financial-system/token"
-	"github.com/gin-gonic/gin"
-	"github.com/lib/pq"
 	"net/http"
+
+	"github.com/MathPeixoto/go-financial-system/service"
+	"github.com/gin-gonic/gin"
 )
 
 type CreateAccountRequest struct {
-	Currency string `json:"currency" binding:"required,currency"`
-	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	Product  string `json:"product"`
 }
 
 type IDAccountRequest struct {
-	ID int64 `uri:"id" binding:"required,min=1"`
+	ID int64 `uri:"id"`
 }
 
 type UpdateAccountBalanceRequest struct {
-	Amount int64 `json:"amount" binding:"required,min=1"`
+	Amount int64 `json:"amount"`
 }
 
 type ListAccountsRequest struct {
-	Limit  int32 `form:"limit,default=5" binding:"min=5,max=10"`
-	Offset int32 `form:"offset,default=1" binding:"min=1"`
+	PageID   int32 `form:"page_id,default=1"`
+	PageSize int32 `form:"page_size,default=5"`
 }
 
 func (server *Server) createAccount(c *gin.Context) {
-	var account CreateAccountRequest
-	if err := c.ShouldBindJSON(&account); err != nil {
+	var request CreateAccountRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
 		c.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	authPayload := c.MustGet(authPayloadKey).(*token.Payload)
-
-	arg := db.CreateAccountParams{
-		Owner:    authPayload.Username,
-		Currency: account.Currency,
-		Balance:  0,
-	}
-
-	createAccount, err := server.store.CreateAccount(c, arg)
+	account, err := server.service.CreateAccount(c, caller(c), service.CreateAccountParams{
+		Currency: request.Currency,
+		Product:  request.Product,
+	})
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok { //nolint: errorlint
-			switch pqErr.Code.Name() {
-			case "foreign_key_violation", "unique_violation":
-				c.JSON(http.StatusForbidden, errorResponse(err))
-				return
-			}
-		}
-
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		c.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, createAccount)
+	c.JSON(http.StatusOK, account)
 }
 
 func (server *Server) getAccount(c *gin.Context) {
@@ -67,41 +51,28 @@ func (server *Server) getAccount(c *gin.Context) {
 		return
 	}
 
-	account, err := server.store.GetAccount(c, request.ID)
+	account, err := server.service.GetAccount(c, caller(c), request.ID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			c.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		c.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
 
-	authPayload := c.MustGet(authPayloadKey).(*token.Payload)
-	if account.Owner != authPayload.Username {
-		err = errors.New("account does not belong to the authenticated user")
-		c.JSON(http.StatusForbidden, errorResponse(err))
-		return
-	}
 	c.JSON(http.StatusOK, account)
 }
 
 func (server *Server) listAccounts(c *gin.Context) {
 	var request ListAccountsRequest
-	_ = c.ShouldBindQuery(&request)
-
-	authPayload := c.MustGet(authPayloadKey).(*token.Payload)
-	arg := db.ListAccountsParams{
-		Owner:  authPayload.Username,
-		Limit:  request.Limit,
-		Offset: (request.Offset - 1) * request.Limit,
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 
-	accounts, err := server.store.ListAccounts(c, arg)
+	accounts, err := server.service.ListAccounts(c, caller(c), request.PageID, request.PageSize)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		c.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
+
 	c.JSON(http.StatusOK, accounts)
 }
 
@@ -112,29 +83,19 @@ func (server *Server) updateAccountBalance(c *gin.Context) {
 		return
 	}
 
-	err := validateAccountID(c, server, requestID)
-	if err != nil {
-		return
-	}
-
 	var requestAccount UpdateAccountBalanceRequest
 	if err := c.ShouldBindJSON(&requestAccount); err != nil {
 		c.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	arg := db.AddAccountBalanceParams{
-		ID:     requestID.ID,
-		Amount: requestAccount.Amount,
-	}
-
-	accountUpdated, err := server.store.AddAccountBalance(c, arg)
+	account, err := server.service.AddAccountBalance(c, caller(c), requestID.ID, requestAccount.Amount)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		c.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, accountUpdated)
+	c.JSON(http.StatusOK, account)
 }
 
 func (server *Server) deleteAccount(c *gin.Context) {
@@ -144,29 +105,10 @@ func (server *Server) deleteAccount(c *gin.Context) {
 		return
 	}
 
-	err := validateAccountID(c, server, request)
-	if err != nil {
-		return
-	}
-
-	err = server.store.DeleteAccount(c, request.ID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
+	if err := server.service.DeleteAccount(c, caller(c), request.ID); err != nil {
+		c.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
 
 	c.JSON(http.StatusOK, nil)
 }
-
-func validateAccountID(c *gin.Context, server *Server, requestID IDAccountRequest) error {
-	authPayload := c.MustGet(authPayloadKey).(*token.Payload)
-	owner, err := server.store.GetAccountByOwner(c, authPayload.Username)
-
-	if owner.ID != requestID.ID {
-		err = errors.New("account does not belong to the authenticated user")
-		c.JSON(http.StatusForbidden, errorResponse(err))
-		return err
-	}
-
-	return nil
-}
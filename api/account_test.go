@@ -1,560 +0,0 @@
-package api
-
-import (
-	"bytes"
-	"database/sql"
-	"encoding/json"
-	"fmt"
-	mockdb "github.com/MathPeixoto/go-financial-system/db/mock"
-	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
-	"github.com/MathPeixoto/go-financial-system/token"
-	"github.com/MathPeixoto/go-financial-system/util"
-	"github.com/gin-gonic/gin"
-	"github.com/golang/mock/gomock"
-	"github.com/lib/pq"
-	"github.com/stretchr/testify/require"
-	"io"
-	"net/http"
-	"net/http/httptest"
-	"testing"
-	"time"
-)
-
-func TestGetAccountAPI(t *testing.T) {
-	user, _ := randomUser(t)
-	account := randomAccount(user.Username)
-
-	testCases := []struct {
-		name          string
-		accountID     int64
-		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
-		buildStubs    func(store *mockdb.MockStore)
-		checkResponse func(recorder *httptest.ResponseRecorder)
-	}{
-		{
-			name:      "OK",
-			accountID: account.ID,
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchAccount(t, recorder.Body, account)
-			},
-		},
-		{
-			name:      "BadRequest",
-			accountID: -1,
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
-			},
-		},
-		{
-			name:      "NotFound",
-			accountID: account.ID,
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, recorder.Code)
-			},
-		},
-		{
-			name:      "Forbidden",
-			accountID: account.ID,
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, "wrong user", time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusForbidden, recorder.Code)
-			},
-		},
-		{
-			name:      "InternalError",
-			accountID: account.ID,
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrConnDone)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
-			},
-		},
-	}
-
-	for _, testCase := range testCases {
-		t.Run(testCase.name, func(t *testing.T) {
-			url := fmt.Sprintf("/accounts/%d", testCase.accountID)
-			// prepare stubs
-			ctrl := gomock.NewController(t)
-			store := mockdb.NewMockStore(ctrl)
-			testCase.buildStubs(store)
-
-			// start test server
-			server := newTestServer(t, store)
-			recorder := httptest.NewRecorder()
-			request, err := http.NewRequest(http.MethodGet, url, nil)
-
-			require.NoError(t, err)
-
-			testCase.setupAuth(t, request, server.tokenMaker)
-
-			server.router.ServeHTTP(recorder, request)
-			testCase.checkResponse(recorder)
-		})
-	}
-}
-
-func TestCreateAccountAPI(t *testing.T) {
-	user, _ := randomUser(t)
-	account := randomAccount(user.Username)
-
-	testCases := []struct {
-		name          string
-		body          any
-		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
-		buildStubs    func(store *mockdb.MockStore)
-		checkResponse func(recorder *httptest.ResponseRecorder)
-	}{
-		{
-			name: "OK",
-			body: gin.H{
-				"currency": account.Currency,
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.CreateAccountParams{
-					Owner:    account.Owner,
-					Balance:  0,
-					Currency: account.Currency,
-				}
-				store.EXPECT().CreateAccount(gomock.Any(), gomock.Eq(arg)).Times(1).Return(account, nil)
-			},
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchAccount(t, recorder.Body, account)
-			},
-		},
-		{
-			name: "BadRequest",
-			body: gin.H{
-				"currency": "invalid-currency",
-			},
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
-			},
-		},
-		{
-			name: "Forbidden",
-			body: gin.H{
-				"currency": account.Currency,
-			},
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, "wrong user", time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					CreateAccount(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return(db.Account{}, &pq.Error{Code: "23505"})
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusForbidden, recorder.Code)
-			},
-		},
-		{
-			name: "InternalError",
-			body: gin.H{
-				"currency": account.Currency,
-			},
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrConnDone)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
-			},
-		},
-	}
-
-	for _, testCase := range testCases {
-		t.Run(testCase.name, func(t *testing.T) {
-			// prepare stubs
-			ctrl := gomock.NewController(t)
-			store := mockdb.NewMockStore(ctrl)
-			testCase.buildStubs(store)
-
-			// start test server
-			server := newTestServer(t, store)
-			recorder := httptest.NewRecorder()
-
-			testBody := testCase.body
-			body, err := json.Marshal(testBody)
-			require.NoError(t, err)
-
-			request, err := http.NewRequest(http.MethodPost, "/accounts", bytes.NewReader(body))
-			require.NoError(t, err)
-
-			testCase.setupAuth(t, request, server.tokenMaker)
-			server.router.ServeHTTP(recorder, request)
-			testCase.checkResponse(recorder)
-		})
-	}
-}
-
-func TestListAccountAPI(t *testing.T) {
-	user, _ := randomUser(t)
-
-	n := 5
-	accounts := make([]db.Account, n)
-	for i := 0; i < n; i++ {
-		accounts[i] = randomAccount(user.Username)
-	}
-
-	type ListAccountsRequest struct {
-		Offset int
-		Limit  int
-	}
-
-	testCases := []struct {
-		name          string
-		query         ListAccountsRequest
-		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
-		buildStubs    func(store *mockdb.MockStore)
-		checkResponse func(recorder *httptest.ResponseRecorder)
-	}{
-		{
-			name: "OK",
-			query: ListAccountsRequest{
-				Offset: 1,
-				Limit:  n,
-			},
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.ListAccountsParams{
-					Owner:  user.Username,
-					Limit:  int32(n),
-					Offset: 0,
-				}
-				store.EXPECT().ListAccounts(gomock.Any(), gomock.Eq(arg)).Times(1).Return(accounts, nil)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchAccounts(t, recorder.Body, accounts)
-			},
-		},
-		{
-			name: "InternalError",
-			query: ListAccountsRequest{
-				Offset: 1,
-				Limit:  n,
-			},
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{}, sql.ErrConnDone)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
-			},
-		},
-		{
-			name: "Unauthorized",
-			query: ListAccountsRequest{
-				Offset: 1,
-				Limit:  n,
-			},
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(0)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
-			},
-		},
-	}
-
-	for _, testCase := range testCases {
-		t.Run(testCase.name, func(t *testing.T) {
-			// prepare stubs
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			store := mockdb.NewMockStore(ctrl)
-			testCase.buildStubs(store)
-
-			server := newTestServer(t, store)
-			recorder := httptest.NewRecorder()
-
-			url := "/accounts"
-			request, err := http.NewRequest(http.MethodGet, url, nil)
-			require.NoError(t, err)
-
-			// Add query parameters to request URL
-			q := request.URL.Query()
-			q.Add("page_id", fmt.Sprintf("%d", testCase.query.Offset))
-			q.Add("page_size", fmt.Sprintf("%d", testCase.query.Limit))
-			request.URL.RawQuery = q.Encode()
-
-			testCase.setupAuth(t, request, server.tokenMaker)
-			server.router.ServeHTTP(recorder, request)
-			testCase.checkResponse(recorder)
-		})
-	}
-}
-
-func TestUpdateAccountAPI(t *testing.T) {
-	user, _ := randomUser(t)
-	account := randomAccount(user.Username)
-
-	validIDAccountRequest := IDAccountRequest{
-		ID: account.ID,
-	}
-
-	invalidIDAccountRequest := IDAccountRequest{
-		ID: -1,
-	}
-
-	validAccountRequest := UpdateAccountBalanceRequest{
-		Amount: util.RandomMoney(),
-	}
-
-	invalidAccountRequest := UpdateAccountBalanceRequest{
-		Amount: -1,
-	}
-
-	arg := db.AddAccountBalanceParams{
-		ID:     account.ID,
-		Amount: validAccountRequest.Amount,
-	}
-
-	updatedAccount := updatedAccount(account, arg)
-
-	testCases := []struct {
-		name          string
-		id            int64
-		body          any
-		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
-		buildStubs    func(store *mockdb.MockStore)
-		checkResponse func(recorder *httptest.ResponseRecorder)
-	}{
-		{
-			name: "OK",
-			id:   validIDAccountRequest.ID,
-			body: validAccountRequest,
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetAccountByOwner(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(account, nil)
-				store.EXPECT().AddAccountBalance(gomock.Any(), gomock.Eq(arg)).Times(1).Return(updatedAccount, nil)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchAccount(t, recorder.Body, updatedAccount)
-			},
-		},
-		{
-			name: "BadRequest",
-			id:   invalidIDAccountRequest.ID,
-			body: validAccountRequest,
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
-			},
-		},
-		{
-			name: "BadRequest",
-			id:   validIDAccountRequest.ID,
-			body: invalidAccountRequest,
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetAccountByOwner(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(account, nil)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
-			},
-		},
-		{
-			name: "InternalError",
-			id:   validIDAccountRequest.ID,
-			body: validAccountRequest,
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetAccountByOwner(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(account, nil)
-				store.EXPECT().AddAccountBalance(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Account{}, sql.ErrConnDone)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
-			},
-		},
-	}
-
-	for _, testCase := range testCases {
-		t.Run(testCase.name, func(t *testing.T) {
-			// prepare stubs
-			ctrl := gomock.NewController(t)
-			store := mockdb.NewMockStore(ctrl)
-			testCase.buildStubs(store)
-
-			// start test server
-			server := newTestServer(t, store)
-			recorder := httptest.NewRecorder()
-
-			testBody := testCase.body
-			body, err := json.Marshal(testBody)
-			require.NoError(t, err)
-
-			url := fmt.Sprintf("/accounts/%d", testCase.id)
-			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
-			require.NoError(t, err)
-
-			testCase.setupAuth(t, request, server.tokenMaker)
-			server.router.ServeHTTP(recorder, request)
-			testCase.checkResponse(recorder)
-		})
-	}
-}
-
-// TODO missing to update tests to test the authorization
-func TestDeleteAccountAPI(t *testing.T) {
-	user, _ := randomUser(t)
-	account := randomAccount(user.Username)
-
-	testCases := []struct {
-		name          string
-		accountID     int64
-		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
-		buildStubs    func(store *mockdb.MockStore)
-		checkResponse func(recorder *httptest.ResponseRecorder)
-	}{
-		{
-			name:      "OK",
-			accountID: account.ID,
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetAccountByOwner(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(account, nil)
-				store.EXPECT().DeleteAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(nil)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusOK, recorder.Code)
-			},
-		},
-		{
-			name:      "BadRequest",
-			accountID: -1,
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
-			},
-		},
-		{
-			name:      "Forbidden",
-			accountID: util.RandomInt(1, 1000),
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetAccountByOwner(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(account, nil)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusForbidden, recorder.Code)
-			},
-		},
-		{
-			name:      "InternalError",
-			accountID: account.ID,
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthHeader(t, request, tokenMaker, authTypeBearer, user.Username, time.Minute)
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetAccountByOwner(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(account, nil)
-				store.EXPECT().DeleteAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(sql.ErrConnDone)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
-			},
-		},
-	}
-
-	for _, testCase := range testCases {
-		t.Run(testCase.name, func(t *testing.T) {
-			url := fmt.Sprintf("/accounts/%d", testCase.accountID)
-			// prepare stubs
-			ctrl := gomock.NewController(t)
-			store := mockdb.NewMockStore(ctrl)
-			testCase.buildStubs(store)
-
-			// start test server
-			server := newTestServer(t, store)
-			recorder := httptest.NewRecorder()
-			request, err := http.NewRequest(http.MethodDelete, url, nil)
-
-			require.NoError(t, err)
-
-			testCase.setupAuth(t, request, server.tokenMaker)
-			server.router.ServeHTTP(recorder, request)
-			testCase.checkResponse(recorder)
-		})
-	}
-}
-
-func requireBodyMatchAccount(t *testing.T, body *bytes.Buffer, account db.Account) {
-	data, err := io.ReadAll(body)
-	require.NoError(t, err)
-
-	var gotAccount db.Account
-	err = json.Unmarshal(data, &gotAccount)
-	require.NoError(t, err)
-
-	require.Equal(t, account, gotAccount)
-}
-
-func requireBodyMatchAccounts(t *testing.T, body *bytes.Buffer, accounts []db.Account) {
-	data, err := io.ReadAll(body)
-	require.NoError(t, err)
-
-	var gotAccounts []db.Account
-	err = json.Unmarshal(data, &gotAccounts)
-	require.NoError(t, err)
-
-	require.Equal(t, accounts, gotAccounts)
-}
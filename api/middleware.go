@@ -2,6 +2,7 @@ package api
 
 import (
 	"errors"
+	"github.com/MathPeixoto/go-financial-system/clientip"
 	"github.com/MathPeixoto/go-financial-system/token"
 	"github.com/gin-gonic/gin"
 	"strings"
@@ -11,8 +12,20 @@ const (
 	authTokenHeader = "Authorization"
 	authTypeBearer  = "bearer"
 	authPayloadKey  = "authorization_payload"
+
+	xForwardedForHeader = "X-Forwarded-For"
 )
 
+// clientIPMiddleware resolves the IP of the client of a request, reading X-Forwarded-For only behind the trusted
+// proxies as the gateway does, and carries it through the context of the request for the audit log
+func clientIPMiddleware(proxies clientip.TrustedProxies) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ip := proxies.Resolve(c.Request.RemoteAddr, c.GetHeader(xForwardedForHeader))
+		c.Request = c.Request.WithContext(clientip.NewContext(c.Request.Context(), ip))
+		c.Next()
+	}
+}
+
 func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader(authTokenHeader)
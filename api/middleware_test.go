@@ -1,6 +1,7 @@
 package api
 
 import (
+	"github.com/MathPeixoto/go-financial-system/clientip"
 	"github.com/MathPeixoto/go-financial-system/token"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
@@ -95,3 +96,47 @@ func TestAuthMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestClientIPMiddleware(t *testing.T) {
+	proxies, err := clientip.ParseTrustedProxies("10.0.0.0/8")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		expected     string
+	}{
+		{
+			name:         "TrustedProxy",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: "198.51.100.1",
+			expected:     "198.51.100.1",
+		},
+		{
+			name:         "UntrustedPeer",
+			remoteAddr:   "203.0.113.7:1234",
+			forwardedFor: "198.51.100.1",
+			expected:     "203.0.113.7",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/ip", clientIPMiddleware(proxies), func(c *gin.Context) {
+				c.String(http.StatusOK, caller(c).ClientIP)
+			})
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/ip", nil)
+			require.NoError(t, err)
+			request.RemoteAddr = tc.remoteAddr
+			request.Header.Set(xForwardedForHeader, tc.forwardedFor)
+
+			router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code)
+			require.Equal(t, tc.expected, recorder.Body.String())
+		})
+	}
+}
@@ -1,20 +1,23 @@
 package api
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+
+	"github.com/MathPeixoto/go-financial-system/clientip"
 	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/service"
 	"github.com/MathPeixoto/go-financial-system/token"
 	"github.com/MathPeixoto/go-financial-system/util"
 	"github.com/gin-gonic/gin"
-	"github.com/gin-gonic/gin/binding"
-	"github.com/go-playground/validator/v10"
 )
 
 type Server struct {
-	config     util.Config
-	store      db.Store
-	tokenMaker token.Maker
-	router     *gin.Engine
+	service        *service.Service
+	tokenMaker     token.Maker
+	trustedProxies clientip.TrustedProxies
+	router         *gin.Engine
 }
 
 func NewServer(config util.Config, store db.Store) (*Server, error) {
@@ -23,17 +26,15 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
-	server := &Server{
-		config:     config,
-		store:      store,
-		tokenMaker: tokenMaker,
+	trustedProxies, err := clientip.ParseTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse trusted proxies: %w", err)
 	}
 
-	if validate, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		err := validate.RegisterValidation("currency", validCurrencies)
-		if err != nil {
-			return nil, err
-		}
+	server := &Server{
+		service:        service.New(config, store, tokenMaker),
+		tokenMaker:     tokenMaker,
+		trustedProxies: trustedProxies,
 	}
 
 	server.setupRoutes()
@@ -43,6 +44,9 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 // setupRoutes sets up the routes for the server.
 func (server *Server) setupRoutes() {
 	router := gin.Default()
+	// the client IP is resolved by clientIPMiddleware, so gin reads no forwarding header
+	router.ForwardedByClientIP = false
+	router.Use(clientIPMiddleware(server.trustedProxies))
 
 	// users routes
 	router.POST("/users", server.createUser)
@@ -72,3 +76,44 @@ func (server *Server) Start(address string) error {
 func errorResponse(err error) gin.H {
 	return gin.H{"error": err.Error()}
 }
+
+// errorStatus returns the HTTP status of the kind of an error of the service,
+// the same the gateway responds with for the corresponding gRPC status
+func errorStatus(err error) int {
+	var serviceErr *service.Error
+	if !errors.As(err, &serviceErr) {
+		return http.StatusInternalServerError
+	}
+
+	switch serviceErr.Kind {
+	case service.InvalidArgument, service.FailedPrecondition:
+		return http.StatusBadRequest
+	case service.NotFound:
+		return http.StatusNotFound
+	case service.AlreadyExists:
+		return http.StatusConflict
+	case service.Unauthenticated:
+		return http.StatusUnauthorized
+	case service.PermissionDenied:
+		return http.StatusForbidden
+	case service.LimitExceeded:
+		return http.StatusTooManyRequests
+	}
+
+	return http.StatusInternalServerError
+}
+
+// caller returns the caller of a request, the authenticated user if any and its client
+func caller(c *gin.Context) service.Caller {
+	caller := service.Caller{
+		ClientIP:  clientip.FromContext(c.Request.Context()),
+		UserAgent: c.Request.UserAgent(),
+		RequestID: c.GetHeader("X-Request-Id"),
+	}
+
+	if authPayload, ok := c.Get(authPayloadKey); ok {
+		caller.Username = authPayload.(*token.Payload).Username
+	}
+
+	return caller
+}
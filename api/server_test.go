@@ -0,0 +1,159 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/MathPeixoto/go-financial-system/db/mock"
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// TestErrorStatus checks that the handlers respond with the status of the kind of the errors of the service,
+// the business rules being tested in the service itself
+func TestErrorStatus(t *testing.T) {
+	username := util.RandomOwner()
+	account := db.Account{ID: util.RandomInt(1, 1000), Owner: username, Currency: util.BRL}
+
+	testCases := []struct {
+		name       string
+		method     string
+		url        string
+		body       any
+		auth       bool
+		buildStubs func(store *mockdb.MockStore)
+		status     int
+	}{
+		{
+			name:   "OK",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/accounts/%d", account.ID),
+			auth:   true,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:       "MalformedBody",
+			method:     http.MethodPost,
+			url:        "/users",
+			body:       "not an object",
+			buildStubs: func(store *mockdb.MockStore) {},
+			status:     http.StatusBadRequest,
+		},
+		{
+			name:       "InvalidArgument",
+			method:     http.MethodPost,
+			url:        "/accounts",
+			body:       gin.H{"currency": "ABC"},
+			auth:       true,
+			buildStubs: func(store *mockdb.MockStore) {},
+			status:     http.StatusBadRequest,
+		},
+		{
+			name:   "AlreadyExists",
+			method: http.MethodPost,
+			url:    "/users",
+			body:   gin.H{"username": username, "password": "secret", "fullname": "Test User", "email": "test@example.com"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateUserTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.CreateUserTxResult{}, &pq.Error{Code: "23505"})
+			},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "NotFound",
+			method: http.MethodPost,
+			url:    "/users/login",
+			body:   gin.H{"username": username, "password": "secret"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(username)).Times(1).Return(db.User{}, sql.ErrNoRows)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:       "Unauthenticated",
+			method:     http.MethodPost,
+			url:        "/token/renew_access",
+			body:       gin.H{"refresh_token": "invalid"},
+			buildStubs: func(store *mockdb.MockStore) {},
+			status:     http.StatusUnauthorized,
+		},
+		{
+			name:   "PermissionDenied",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/accounts/%d", account.ID),
+			auth:   true,
+			buildStubs: func(store *mockdb.MockStore) {
+				other := account
+				other.Owner = "other_user"
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(other, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "LimitExceeded",
+			method: http.MethodPost,
+			url:    "/transfers",
+			body:   gin.H{"from_account_id": account.ID, "to_account_id": account.ID + 1, "amount": 10, "currency": util.BRL},
+			auth:   true,
+			buildStubs: func(store *mockdb.MockStore) {
+				toAccount := db.Account{ID: account.ID + 1, Owner: "other_user", Currency: util.BRL}
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.TransferTxResult{}, &db.TransferLimitError{AccountID: account.ID, Limit: db.TransferLimitDailyAmount})
+			},
+			status: http.StatusTooManyRequests,
+		},
+		{
+			name:   "Internal",
+			method: http.MethodGet,
+			url:    "/transfers/1",
+			auth:   true,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(db.Transfer{}, sql.ErrConnDone)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(gomock.NewController(t))
+			testCase.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body []byte
+			if testCase.body != nil {
+				var err error
+				body, err = json.Marshal(testCase.body)
+				require.NoError(t, err)
+			}
+
+			request, err := http.NewRequest(testCase.method, testCase.url, bytes.NewReader(body))
+			require.NoError(t, err)
+
+			if testCase.auth {
+				addAuthHeader(t, request, server.tokenMaker, authTypeBearer, username, time.Minute)
+			}
+
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, testCase.status, recorder.Code, recorder.Body.String())
+		})
+	}
+}
@@ -1,15 +1,14 @@
 package api
 
 import (
-	"database/sql"
-	"fmt"
-	"github.com/gin-gonic/gin"
 	"net/http"
 	"time"
+
+	"github.com/gin-gonic/gin"
 )
 
 type RenewAccessTokenRequest struct {
-	RefreshToken string `json:"refresh_token" binding:"required"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 type RenewAccessTokenResponse struct {
@@ -24,55 +23,15 @@ func (server *Server) renewAccessTokenUser(c *gin.Context) {
 		return
 	}
 
-	refreshPayload, err := server.tokenMaker.VerifyToken(request.RefreshToken)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
-	session, err := server.store.GetSession(c, refreshPayload.ID)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			c.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-
-	if session.IsBlocked {
-		err := fmt.Errorf("session %s is blocked", session.ID)
-		c.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
-	if session.Username != refreshPayload.Username {
-		err := fmt.Errorf("session %s does not belong to user %s", session.ID, refreshPayload.Username)
-		c.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
-	if session.RefreshToken != request.RefreshToken {
-		err := fmt.Errorf("refresh token does not match")
-		c.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
-	if session.ExpiresAt.Before(time.Now()) {
-		err := fmt.Errorf("session is expired")
-		c.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
-	token, accessPayload, err := server.tokenMaker.CreateToken(refreshPayload.Username, server.config.AccessTokenDuration)
+	accessToken, err := server.service.RenewAccessToken(c, request.RefreshToken)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		c.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
 
 	userResponse := RenewAccessTokenResponse{
-		AccessToken:          token,
-		AccessTokenExpiresAt: accessPayload.ExpiresAt,
+		AccessToken:          accessToken.Token,
+		AccessTokenExpiresAt: accessToken.ExpiresAt,
 	}
 
 	c.JSON(http.StatusOK, userResponse)
@@ -1,24 +1,33 @@
 package api
 
 import (
-	"database/sql"
-	"errors"
-	"fmt"
-	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
-	"github.com/MathPeixoto/go-financial-system/token"
-	"github.com/gin-gonic/gin"
 	"net/http"
+
+	"github.com/MathPeixoto/go-financial-system/service"
+	"github.com/gin-gonic/gin"
 )
 
 type transferRequest struct {
-	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
-	Amount        int64  `json:"amount" binding:"required,gt=0"`
-	Currency      string `json:"currency" binding:"required,currency"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	PayeeID       int64  `json:"payee_id"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+}
+
+// transferReviewResponse is returned instead of the transfer when the risk screening holds it for review
+type transferReviewResponse struct {
+	TransferReviewID int64 `json:"transfer_review_id"`
+}
+
+// transferApprovalResponse is returned instead of the transfer when it waits for the approval of another member
+// of the organization owning the from account
+type transferApprovalResponse struct {
+	TransferApprovalID int64 `json:"transfer_approval_id"`
 }
 
 type idTransferRequest struct {
-	ID int64 `uri:"id" binding:"required,min=1"`
+	ID int64 `uri:"id"`
 }
 
 func (server *Server) createTransfer(c *gin.Context) {
@@ -28,35 +37,26 @@ func (server *Server) createTransfer(c *gin.Context) {
 		return
 	}
 
-	fromAccount, valid := server.validAccount(c, request.FromAccountID, request.Currency)
-	if !valid {
-		return
-	}
-
-	authPayload := c.MustGet(authPayloadKey).(*token.Payload)
-	if fromAccount.Owner != authPayload.Username {
-		err := fmt.Errorf("account %d does not belong to the current user", request.FromAccountID)
-		c.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-	_, valid = server.validAccount(c, request.ToAccountID, request.Currency)
-	if !valid {
-		return
-	}
-
-	arg := db.TransferTxParams{
+	result, err := server.service.CreateTransfer(c, caller(c), service.CreateTransferParams{
 		FromAccountID: request.FromAccountID,
 		ToAccountID:   request.ToAccountID,
+		PayeeID:       request.PayeeID,
 		Amount:        request.Amount,
-	}
-
-	result, err := server.store.TransferTx(c, arg)
+		Currency:      request.Currency,
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		c.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	switch {
+	case result.TransferApprovalID != 0:
+		c.JSON(http.StatusAccepted, transferApprovalResponse{TransferApprovalID: result.TransferApprovalID})
+	case result.TransferReviewID != 0:
+		c.JSON(http.StatusAccepted, transferReviewResponse{TransferReviewID: result.TransferReviewID})
+	default:
+		c.JSON(http.StatusOK, result.Transfer)
+	}
 }
 
 func (server *Server) getTransfer(c *gin.Context) {
@@ -66,36 +66,11 @@ func (server *Server) getTransfer(c *gin.Context) {
 		return
 	}
 
-	transfer, err := server.store.GetTransfer(c, request.ID)
+	transfer, err := server.service.GetTransfer(c, caller(c), request.ID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			c.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		c.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
 
 	c.JSON(http.StatusOK, transfer)
 }
-
-func (server *Server) validAccount(c *gin.Context, accountID int64, currency string) (db.Account, bool) {
-	account, err := server.store.GetAccount(c, accountID)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			c.JSON(http.StatusBadRequest, errorResponse(err))
-			return account, false
-		}
-
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
-		return account, false
-	}
-
-	if account.Currency != currency {
-		err := fmt.Errorf("account %d has currency %s, but transfer currency is %s", accountID, account.Currency, currency)
-		c.JSON(http.StatusBadRequest, errorResponse(err))
-		return account, false
-	}
-
-	return account, true
-}
@@ -1,21 +1,20 @@
 package api
 
 import (
-	"database/sql"
+	"net/http"
+	"time"
+
 	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
-	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/MathPeixoto/go-financial-system/service"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/lib/pq"
-	"net/http"
-	"time"
 )
 
 type CreateUserRequest struct {
-	Username string `json:"username" binding:"required,alphanum,min=4,max=20"`
-	Password string `json:"password" binding:"required,min=6"`
-	FullName string `json:"fullname" binding:"required"`
-	Email    string `json:"email" binding:"required,email"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	FullName string `json:"fullname"`
+	Email    string `json:"email"`
 }
 
 type UserResponse struct {
@@ -43,29 +42,14 @@ func (server *Server) createUser(c *gin.Context) {
 		return
 	}
 
-	hashedPassword, err := util.HashPassword(request.Password)
+	user, err := server.service.CreateUser(c, caller(c), service.CreateUserParams{
+		Username: request.Username,
+		Password: request.Password,
+		FullName: request.FullName,
+		Email:    request.Email,
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-
-	arg := db.CreateUserParams{
-		Username:       request.Username,
-		HashedPassword: hashedPassword,
-		FullName:       request.FullName,
-		Email:          request.Email,
-	}
-
-	user, err := server.store.CreateUser(c, arg)
-	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok { //nolint: errorlint
-			if pqErr.Code.Name() == "unique_violation" {
-				c.JSON(http.StatusForbidden, errorResponse(err))
-				return
-			}
-		}
-
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		c.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
 
@@ -74,8 +58,8 @@ func (server *Server) createUser(c *gin.Context) {
 }
 
 type LoginRequest struct {
-	Username string `json:"username" binding:"required,alphanum,min=4,max=20"`
-	Password string `json:"password" binding:"required,min=6"`
+	Username string `json:"username"`
+	Password string `json:"password"`
 }
 
 type LoginResponse struct {
@@ -94,56 +78,22 @@ func (server *Server) loginUser(c *gin.Context) {
 		return
 	}
 
-	user, err := server.store.GetUser(c, request.Username)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			c.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-
-	if err := util.CheckPasswordHash(request.Password, user.HashedPassword); err != nil {
-		c.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
-
-	token, accessPayload, err := server.tokenMaker.CreateToken(user.Username, server.config.AccessTokenDuration)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-
-	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(user.Username, server.config.RefreshTokenDuration)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-
-	arg := db.CreateSessionParams{
-		ID:           refreshPayload.ID,
-		Username:     user.Username,
-		RefreshToken: refreshToken,
-		UserAgent:    c.Request.UserAgent(),
-		ClientIp:     c.ClientIP(),
-		IsBlocked:    false,
-		ExpiresAt:    refreshPayload.ExpiresAt,
-	}
-
-	session, err := server.store.CreateSession(c, arg)
+	session, err := server.service.LoginUser(c, caller(c), service.LoginUserParams{
+		Username: request.Username,
+		Password: request.Password,
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		c.JSON(errorStatus(err), errorResponse(err))
 		return
 	}
 
 	userResponse := LoginResponse{
 		SessionID:             session.ID,
-		AccessToken:           token,
-		AccessTokenExpiresAt:  accessPayload.ExpiresAt,
-		RefreshToken:          refreshToken,
-		RefreshTokenExpiresAt: session.ExpiresAt,
-		User:                  newUserResponse(user),
+		AccessToken:           session.AccessToken,
+		AccessTokenExpiresAt:  session.AccessTokenExpiresAt,
+		RefreshToken:          session.RefreshToken,
+		RefreshTokenExpiresAt: session.RefreshTokenExpiresAt,
+		User:                  newUserResponse(session.User),
 	}
 
 	c.JSON(http.StatusOK, userResponse)
@@ -1,311 +0,0 @@
-package api
-
-import (
-	"bytes"
-	"database/sql"
-	"encoding/json"
-	"fmt"
-	mockdb "github.com/MathPeixoto/go-financial-system/db/mock"
-	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
-	"github.com/MathPeixoto/go-financial-system/util"
-	"github.com/gin-gonic/gin"
-	"github.com/golang/mock/gomock"
-	"github.com/lib/pq"
-	"github.com/stretchr/testify/require"
-	"net/http"
-	"net/http/httptest"
-	"reflect"
-	"testing"
-)
-
-type eqCreateUserParamsMatcher struct {
-	arg      db.CreateUserParams
-	password string
-}
-
-func (e eqCreateUserParamsMatcher) Matches(x interface{}) bool {
-	arg, ok := x.(db.CreateUserParams)
-	if !ok {
-		return false
-	}
-
-	err := util.CheckPasswordHash(e.password, arg.HashedPassword)
-	if err != nil {
-		return false
-	}
-
-	e.arg.HashedPassword = arg.HashedPassword
-	return reflect.DeepEqual(e.arg, arg)
-}
-
-func (e eqCreateUserParamsMatcher) String() string {
-	return fmt.Sprintf("matches arg %v and password %v", e.arg, e.password)
-}
-
-func eqCreateUserParams(arg db.CreateUserParams, password string) gomock.Matcher {
-	return eqCreateUserParamsMatcher{arg, password}
-}
-
-func TestCreateUser(t *testing.T) {
-	user, password := randomUser(t)
-
-	testCases := []struct {
-		name          string
-		body          gin.H
-		buildStubs    func(store *mockdb.MockStore)
-		checkResponse func(recorder *httptest.ResponseRecorder)
-	}{
-		{
-			name: "OK",
-			body: gin.H{
-				"username": user.Username,
-				"password": password,
-				"fullName": user.FullName,
-				"email":    user.Email,
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.CreateUserParams{
-					Username: user.Username,
-					FullName: user.FullName,
-					Email:    user.Email,
-				}
-
-				store.EXPECT().
-					CreateUser(gomock.Any(), eqCreateUserParams(arg, password)).
-					Times(1).
-					Return(user, nil)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, 200, recorder.Code)
-				requireBodyMatchUser(t, recorder.Body, user)
-			},
-		},
-		{
-			name: "InternalError",
-			body: gin.H{
-				"username": user.Username,
-				"password": password,
-				"fullName": user.FullName,
-				"email":    user.Email,
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					CreateUser(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return(db.User{}, sql.ErrConnDone)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, 500, recorder.Code)
-			},
-		},
-		{
-			name: "DuplicateUsername",
-			body: gin.H{
-				"username": user.Username,
-				"password": password,
-				"fullName": user.FullName,
-				"email":    user.Email,
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					CreateUser(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return(db.User{}, &pq.Error{Code: "23505"})
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, 403, recorder.Code)
-			},
-		},
-		{
-			name: "InvalidUsername",
-			body: gin.H{
-				"username": "",
-				"password": password,
-				"fullName": user.FullName,
-				"email":    user.Email,
-			},
-			buildStubs: func(store *mockdb.MockStore) {},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, 400, recorder.Code)
-			},
-		},
-		{
-			name: "InvalidEmail",
-			body: gin.H{
-				"username": user.Username,
-				"password": password,
-				"fullName": user.FullName,
-				"email":    "invalid-email",
-			},
-			buildStubs: func(store *mockdb.MockStore) {},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, 400, recorder.Code)
-			},
-		},
-		{
-			name: "InvalidPassword",
-			body: gin.H{
-				"username": user.Username,
-				"password": "123",
-				"fullName": user.FullName,
-				"email":    user.Email,
-			},
-			buildStubs: func(store *mockdb.MockStore) {},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, 400, recorder.Code)
-			},
-		},
-	}
-
-	for _, testCase := range testCases {
-		t.Run(testCase.name, func(t *testing.T) {
-			store := mockdb.NewMockStore(gomock.NewController(t))
-			testCase.buildStubs(store)
-
-			server := newTestServer(t, store)
-			recorder := httptest.NewRecorder()
-
-			body, err := json.Marshal(testCase.body)
-			require.NoError(t, err)
-
-			request, err := http.NewRequest("POST", "/users", bytes.NewReader(body))
-			require.NoError(t, err)
-
-			server.router.ServeHTTP(recorder, request)
-			testCase.checkResponse(recorder)
-		})
-	}
-}
-
-func TestLoginUser(t *testing.T) {
-	user, password := randomUser(t)
-
-	testCases := []struct {
-		name          string
-		body          gin.H
-		buildStubs    func(store *mockdb.MockStore)
-		checkResponse func(recorder *httptest.ResponseRecorder)
-	}{
-		{
-			name: "OK",
-			body: gin.H{
-				"username": user.Username,
-				"password": password,
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUser(gomock.Any(), gomock.Eq(user.Username)).
-					Times(1).
-					Return(user, nil)
-				store.EXPECT().
-					CreateSession(gomock.Any(), gomock.Any()).
-					Times(1)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusOK, recorder.Code)
-			},
-		},
-		{
-			name: "Bad Request",
-			body: gin.H{
-				"username": user.Username,
-				"password": "123",
-			},
-			buildStubs: func(store *mockdb.MockStore) {},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
-			},
-		},
-		{
-			name: "Internal Error",
-			body: gin.H{
-				"username": user.Username,
-				"password": password,
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUser(gomock.Any(), gomock.Eq(user.Username)).
-					Times(1).
-					Return(db.User{}, sql.ErrConnDone)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
-			},
-		},
-		{
-			name: "Not found",
-			body: gin.H{
-				"username": user.Username,
-				"password": password,
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUser(gomock.Any(), gomock.Eq(user.Username)).
-					Times(1).
-					Return(db.User{}, sql.ErrNoRows)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, recorder.Code)
-			},
-		},
-		{
-			name: "Unauthorized",
-			body: gin.H{
-				"username": user.Username,
-				"password": "invalid password",
-			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().
-					GetUser(gomock.Any(), gomock.Eq(user.Username)).
-					Times(1).
-					Return(user, nil)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
-			},
-		},
-	}
-
-	for _, testCase := range testCases {
-		t.Run(testCase.name, func(t *testing.T) {
-			store := mockdb.NewMockStore(gomock.NewController(t))
-			testCase.buildStubs(store)
-
-			server := newTestServer(t, store)
-			recorder := httptest.NewRecorder()
-
-			body, err := json.Marshal(testCase.body)
-			require.NoError(t, err)
-
-			request, err := http.NewRequest("POST", "/users/login", bytes.NewReader(body))
-			require.NoError(t, err)
-
-			server.router.ServeHTTP(recorder, request)
-			testCase.checkResponse(recorder)
-		})
-	}
-}
-
-func randomUser(t *testing.T) (user db.User, password string) {
-	password = util.RandomString(6)
-	hashedPassword, err := util.HashPassword(password)
-	require.NoError(t, err)
-
-	user = db.User{
-		Username:       util.RandomOwner(),
-		HashedPassword: hashedPassword,
-		FullName:       util.RandomOwner(),
-		Email:          util.RandomEmail(),
-	}
-	return
-}
-
-func requireBodyMatchUser(t *testing.T, body *bytes.Buffer, user db.User) {
-	var gotUser db.User
-	err := json.NewDecoder(body).Decode(&gotUser)
-	require.NoError(t, err)
-	require.Equal(t, gotUser.Username, user.Username)
-	require.Equal(t, gotUser.FullName, user.FullName)
-	require.Equal(t, gotUser.Email, user.Email)
-	require.Empty(t, gotUser.HashedPassword)
-}
@@ -0,0 +1,110 @@
+// Package clientip resolves the IP of the client of an HTTP request, trusting its X-Forwarded-For header
+// only as far as it was appended by the trusted proxies, and carries it through the context.
+package clientip
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+type contextKey struct{}
+
+// TrustedProxies are the networks of the proxies, such as the load balancer, whose X-Forwarded-For is trusted
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies parses a comma-separated list of CIDRs or IPs, like "10.0.0.0/8,192.168.1.10"
+func ParseTrustedProxies(value string) (TrustedProxies, error) {
+	var proxies TrustedProxies
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: expected a CIDR or an IP", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", entry, err)
+		}
+		proxies = append(proxies, network)
+	}
+
+	return proxies, nil
+}
+
+// Resolve returns the IP of the client of a request received from remoteAddr with the X-Forwarded-For header
+// forwardedFor. The header is only read when the peer is a trusted proxy, from its last address, appended by
+// the peer, back to the first one that isn't a trusted proxy, as the ones before can be forged by the client.
+func (proxies TrustedProxies) Resolve(remoteAddr, forwardedFor string) string {
+	ip := hostIP(remoteAddr)
+	if !proxies.contains(ip) || forwardedFor == "" {
+		return ip
+	}
+
+	addresses := strings.Split(forwardedFor, ",")
+	for i := len(addresses) - 1; i >= 0; i-- {
+		address := strings.TrimSpace(addresses[i])
+		if net.ParseIP(address) == nil {
+			// an invalid address can only have been written by the client, the previous hop is the furthest known
+			return ip
+		}
+
+		ip = address
+		if !proxies.contains(ip) {
+			return ip
+		}
+	}
+
+	return ip
+}
+
+func (proxies TrustedProxies) contains(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range proxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewContext returns ctx carrying the IP of the client of the request
+func NewContext(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, contextKey{}, ip)
+}
+
+// FromContext returns the client IP carried by ctx, or an empty string
+func FromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(contextKey{}).(string)
+	return ip
+}
+
+// hostIP strips the port from an address
+func hostIP(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+
+	return host
+}
@@ -0,0 +1,106 @@
+package clientip
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	proxies, err := ParseTrustedProxies(" 10.0.0.0/8, 192.168.1.10 ,fd00::/8,")
+	require.NoError(t, err)
+	require.Len(t, proxies, 3)
+	require.True(t, proxies.contains("10.1.2.3"))
+	require.True(t, proxies.contains("192.168.1.10"))
+	require.False(t, proxies.contains("192.168.1.11"))
+	require.True(t, proxies.contains("fd00::1"))
+
+	proxies, err = ParseTrustedProxies("")
+	require.NoError(t, err)
+	require.Empty(t, proxies)
+
+	for _, value := range []string{"10.0.0.0/33", "not-an-ip", "10.0.0.0/8,localhost"} {
+		_, err := ParseTrustedProxies(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	proxies, err := ParseTrustedProxies("10.0.0.0/8")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		proxies      TrustedProxies
+		remoteAddr   string
+		forwardedFor string
+		expected     string
+	}{
+		{
+			name:       "NoHeader",
+			proxies:    proxies,
+			remoteAddr: "203.0.113.7:1234",
+			expected:   "203.0.113.7",
+		},
+		{
+			name:         "UntrustedPeer",
+			proxies:      proxies,
+			remoteAddr:   "203.0.113.7:1234",
+			forwardedFor: "198.51.100.1",
+			expected:     "203.0.113.7",
+		},
+		{
+			name:         "NoTrustedProxies",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: "198.51.100.1",
+			expected:     "10.0.0.1",
+		},
+		{
+			name:         "TrustedPeer",
+			proxies:      proxies,
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: "198.51.100.1",
+			expected:     "198.51.100.1",
+		},
+		{
+			name:         "ForgedFirstAddress",
+			proxies:      proxies,
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: "1.2.3.4, 198.51.100.1",
+			expected:     "198.51.100.1",
+		},
+		{
+			name:         "ChainOfProxies",
+			proxies:      proxies,
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: "1.2.3.4, 198.51.100.1, 10.0.0.2",
+			expected:     "198.51.100.1",
+		},
+		{
+			name:         "InvalidAddress",
+			proxies:      proxies,
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: "198.51.100.1, not-an-ip, 10.0.0.2",
+			expected:     "10.0.0.2",
+		},
+		{
+			name:         "OnlyProxies",
+			proxies:      proxies,
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: "10.0.0.3, 10.0.0.2",
+			expected:     "10.0.0.3",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, testCase.proxies.Resolve(testCase.remoteAddr, testCase.forwardedFor))
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	require.Empty(t, FromContext(context.Background()))
+	require.Equal(t, "203.0.113.7", FromContext(NewContext(context.Background(), "203.0.113.7")))
+}
@@ -0,0 +1,14 @@
+CREATE TABLE "user_identities"
+(
+    "issuer"     varchar     NOT NULL,
+    "subject"    varchar     NOT NULL,
+    "username"   varchar     NOT NULL,
+    "email"      varchar     NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("issuer", "subject")
+);
+
+CREATE INDEX ON "user_identities" ("username");
+
+ALTER TABLE "user_identities"
+    ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
@@ -0,0 +1,20 @@
+CREATE TABLE "audit_events"
+(
+    "id"          bigserial PRIMARY KEY,
+    "actor"       varchar     NOT NULL,
+    "action"      varchar     NOT NULL,
+    "target_type" varchar     NOT NULL,
+    "target_id"   varchar     NOT NULL,
+    "before"      jsonb       NOT NULL DEFAULT '{}',
+    "after"       jsonb       NOT NULL DEFAULT '{}',
+    "client_ip"   varchar     NOT NULL,
+    "user_agent"  varchar     NOT NULL,
+    "request_id"  varchar     NOT NULL,
+    "created_at"  timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "audit_events" ("actor");
+
+CREATE INDEX ON "audit_events" ("target_type", "target_id");
+
+CREATE INDEX ON "audit_events" ("created_at");
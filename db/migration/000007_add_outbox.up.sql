@@ -0,0 +1,16 @@
+CREATE TABLE "outbox"
+(
+    "id"              bigserial PRIMARY KEY,
+    "task_type"       varchar     NOT NULL,
+    "payload"         jsonb       NOT NULL,
+    "queue"           varchar     NOT NULL,
+    "max_retry"       int         NOT NULL,
+    "process_at"      timestamptz NOT NULL DEFAULT (now()),
+    "attempts"        int         NOT NULL DEFAULT 0,
+    "last_error"      varchar     NOT NULL DEFAULT '',
+    "next_attempt_at" timestamptz NOT NULL DEFAULT (now()),
+    "sent_at"         timestamptz,
+    "created_at"      timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "outbox" ("next_attempt_at") WHERE "sent_at" IS NULL;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "webhook_deliveries";
+DROP TABLE IF EXISTS "webhook_endpoints";
+DROP TABLE IF EXISTS "domain_events";
+
+ALTER TABLE IF EXISTS "accounts"
+    DROP COLUMN IF EXISTS "status";
\ No newline at end of file
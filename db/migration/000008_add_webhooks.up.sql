@@ -0,0 +1,46 @@
+ALTER TABLE "accounts"
+    ADD COLUMN "status" varchar NOT NULL DEFAULT 'active';
+
+CREATE TABLE "domain_events"
+(
+    "id"         bigserial PRIMARY KEY,
+    "event_type" varchar     NOT NULL,
+    "payload"    jsonb       NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "webhook_endpoints"
+(
+    "id"         bigserial PRIMARY KEY,
+    "owner"      varchar     NOT NULL,
+    "url"        varchar     NOT NULL,
+    "secret"     varchar     NOT NULL,
+    "is_active"  boolean     NOT NULL DEFAULT true,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "webhook_deliveries"
+(
+    "id"              bigserial PRIMARY KEY,
+    "endpoint_id"     bigint      NOT NULL,
+    "event_id"        bigint      NOT NULL,
+    "status"          varchar     NOT NULL DEFAULT 'pending',
+    "attempts"        int         NOT NULL DEFAULT 0,
+    "response_status" int         NOT NULL DEFAULT 0,
+    "last_error"      varchar     NOT NULL DEFAULT '',
+    "updated_at"      timestamptz NOT NULL DEFAULT (now()),
+    "created_at"      timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "webhook_endpoints" ("owner");
+
+CREATE INDEX ON "webhook_deliveries" ("endpoint_id");
+
+ALTER TABLE "webhook_endpoints"
+    ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "webhook_deliveries"
+    ADD FOREIGN KEY ("endpoint_id") REFERENCES "webhook_endpoints" ("id");
+
+ALTER TABLE "webhook_deliveries"
+    ADD FOREIGN KEY ("event_id") REFERENCES "domain_events" ("id");
@@ -0,0 +1,46 @@
+CREATE TABLE "scheduled_transfers"
+(
+    "id"              bigserial PRIMARY KEY,
+    "owner"           varchar     NOT NULL,
+    "from_account_id" bigint      NOT NULL,
+    "to_account_id"   bigint      NOT NULL,
+    "amount"          bigint      NOT NULL,
+    "currency"        varchar     NOT NULL,
+    "recurrence"      varchar     NOT NULL,
+    "next_run_at"     timestamptz NOT NULL,
+    "end_at"          timestamptz,
+    "status"          varchar     NOT NULL DEFAULT 'active',
+    "created_at"      timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "scheduled_transfer_runs"
+(
+    "id"                    bigserial PRIMARY KEY,
+    "scheduled_transfer_id" bigint      NOT NULL,
+    "transfer_id"           bigint,
+    "status"                varchar     NOT NULL,
+    "error"                 varchar     NOT NULL DEFAULT '',
+    "scheduled_for"         timestamptz NOT NULL,
+    "created_at"            timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "scheduled_transfers" ("owner");
+
+CREATE INDEX ON "scheduled_transfers" ("next_run_at") WHERE "status" = 'active';
+
+CREATE INDEX ON "scheduled_transfer_runs" ("scheduled_transfer_id");
+
+ALTER TABLE "scheduled_transfers"
+    ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "scheduled_transfers"
+    ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "scheduled_transfers"
+    ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "scheduled_transfer_runs"
+    ADD FOREIGN KEY ("scheduled_transfer_id") REFERENCES "scheduled_transfers" ("id");
+
+ALTER TABLE "scheduled_transfer_runs"
+    ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
@@ -0,0 +1,35 @@
+ALTER TABLE "accounts"
+    ADD COLUMN "held_balance" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "accounts"
+    ADD COLUMN "available_balance" bigint GENERATED ALWAYS AS ("balance" - "held_balance") STORED;
+
+CREATE TABLE "holds"
+(
+    "id"              bigserial PRIMARY KEY,
+    "account_id"      bigint      NOT NULL,
+    "to_account_id"   bigint      NOT NULL,
+    "amount"          bigint      NOT NULL,
+    "currency"        varchar     NOT NULL,
+    "status"          varchar     NOT NULL DEFAULT 'pending',
+    "captured_amount" bigint      NOT NULL DEFAULT 0,
+    "transfer_id"     bigint,
+    "expires_at"      timestamptz NOT NULL,
+    "updated_at"      timestamptz NOT NULL DEFAULT (now()),
+    "created_at"      timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "holds" ("account_id");
+
+CREATE INDEX ON "holds" ("expires_at") WHERE "status" = 'pending';
+
+COMMENT ON COLUMN "holds"."amount" IS 'must be positive';
+
+ALTER TABLE "holds"
+    ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds"
+    ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds"
+    ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "fee_schedules";
\ No newline at end of file
@@ -0,0 +1,22 @@
+CREATE TABLE "fee_schedules"
+(
+    "id"                 bigserial PRIMARY KEY,
+    "currency"           varchar     NOT NULL,
+    "min_amount"         bigint      NOT NULL DEFAULT 0,
+    "flat_fee"           bigint      NOT NULL DEFAULT 0,
+    "percentage_bps"     int         NOT NULL DEFAULT 0,
+    "max_fee"            bigint,
+    "revenue_account_id" bigint      NOT NULL,
+    "created_at"         timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "fee_schedules" ("currency", "min_amount");
+
+COMMENT ON COLUMN "fee_schedules"."min_amount" IS 'lower bound of the tier, the tier with the highest bound not above the amount applies';
+
+COMMENT ON COLUMN "fee_schedules"."percentage_bps" IS 'in basis points of the amount';
+
+COMMENT ON COLUMN "fee_schedules"."max_fee" IS 'caps the fee when set';
+
+ALTER TABLE "fee_schedules"
+    ADD FOREIGN KEY ("revenue_account_id") REFERENCES "accounts" ("id");
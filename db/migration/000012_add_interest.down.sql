@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS "interest_accruals";
+DROP TABLE IF EXISTS "interest_postings";
+DROP TABLE IF EXISTS "interest_expense_accounts";
+
+ALTER TABLE IF EXISTS "accounts"
+    DROP CONSTRAINT IF EXISTS "owner_currency_product_key";
+
+ALTER TABLE IF EXISTS "accounts"
+    ADD CONSTRAINT "owner_currency_key" UNIQUE ("owner", "currency");
+
+ALTER TABLE IF EXISTS "accounts"
+    DROP COLUMN IF EXISTS "product";
+
+DROP TABLE IF EXISTS "account_products";
\ No newline at end of file
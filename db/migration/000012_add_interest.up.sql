@@ -0,0 +1,79 @@
+CREATE TABLE "account_products"
+(
+    "code"                     varchar PRIMARY KEY,
+    "annual_interest_rate_bps" int         NOT NULL DEFAULT 0,
+    "created_at"               timestamptz NOT NULL DEFAULT (now())
+);
+
+INSERT INTO "account_products" ("code", "annual_interest_rate_bps")
+VALUES ('checking', 0),
+       ('savings', 200);
+
+ALTER TABLE "accounts"
+    ADD COLUMN "product" varchar NOT NULL DEFAULT 'checking';
+
+ALTER TABLE "accounts"
+    ADD FOREIGN KEY ("product") REFERENCES "account_products" ("code");
+
+ALTER TABLE "accounts"
+    DROP CONSTRAINT "owner_currency_key";
+
+ALTER TABLE "accounts"
+    ADD CONSTRAINT "owner_currency_product_key" UNIQUE ("owner", "currency", "product");
+
+CREATE TABLE "interest_expense_accounts"
+(
+    "currency"   varchar PRIMARY KEY,
+    "account_id" bigint NOT NULL
+);
+
+CREATE TABLE "interest_postings"
+(
+    "id"               bigserial PRIMARY KEY,
+    "account_id"       bigint      NOT NULL,
+    "period_start"     date        NOT NULL,
+    "amount"           bigint      NOT NULL,
+    "entry_id"         bigint,
+    "expense_entry_id" bigint,
+    "created_at"       timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "interest_accruals"
+(
+    "id"                       bigserial PRIMARY KEY,
+    "account_id"               bigint      NOT NULL,
+    "accrual_date"             date        NOT NULL,
+    "balance"                  bigint      NOT NULL,
+    "annual_interest_rate_bps" int         NOT NULL,
+    "amount_micros"            bigint      NOT NULL,
+    "posting_id"               bigint,
+    "created_at"               timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "interest_postings" ("account_id", "period_start");
+
+CREATE UNIQUE INDEX ON "interest_accruals" ("account_id", "accrual_date");
+
+CREATE INDEX ON "interest_accruals" ("accrual_date") WHERE "posting_id" IS NULL;
+
+COMMENT ON COLUMN "interest_accruals"."balance" IS 'end-of-day balance';
+
+COMMENT ON COLUMN "interest_accruals"."amount_micros" IS 'in millionths of the minor unit, rounded when posted';
+
+ALTER TABLE "interest_expense_accounts"
+    ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_postings"
+    ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_postings"
+    ADD FOREIGN KEY ("entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "interest_postings"
+    ADD FOREIGN KEY ("expense_entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "interest_accruals"
+    ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals"
+    ADD FOREIGN KEY ("posting_id") REFERENCES "interest_postings" ("id");
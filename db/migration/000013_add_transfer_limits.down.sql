@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "account_transfer_limits";
+DROP TABLE IF EXISTS "product_transfer_limits";
+
+DROP INDEX IF EXISTS "transfers_from_account_id_created_at_idx";
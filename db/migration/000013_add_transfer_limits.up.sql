@@ -0,0 +1,37 @@
+CREATE TABLE "product_transfer_limits"
+(
+    "product"        varchar     NOT NULL,
+    "currency"       varchar     NOT NULL,
+    "max_amount"     bigint,
+    "daily_amount"   bigint,
+    "monthly_amount" bigint,
+    "daily_count"    int,
+    "created_at"     timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("product", "currency")
+);
+
+CREATE TABLE "account_transfer_limits"
+(
+    "account_id"     bigint PRIMARY KEY,
+    "max_amount"     bigint,
+    "daily_amount"   bigint,
+    "monthly_amount" bigint,
+    "daily_count"    int,
+    "updated_at"     timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+COMMENT ON COLUMN "product_transfer_limits"."max_amount" IS 'of a single transfer, unlimited when null';
+
+COMMENT ON COLUMN "product_transfer_limits"."daily_amount" IS 'outgoing total per UTC day, unlimited when null';
+
+COMMENT ON COLUMN "product_transfer_limits"."monthly_amount" IS 'outgoing total per UTC month, unlimited when null';
+
+COMMENT ON COLUMN "product_transfer_limits"."daily_count" IS 'outgoing transfers per UTC day, unlimited when null';
+
+ALTER TABLE "product_transfer_limits"
+    ADD FOREIGN KEY ("product") REFERENCES "account_products" ("code");
+
+ALTER TABLE "account_transfer_limits"
+    ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "transfer_reviews";
+
+DROP INDEX IF EXISTS "sessions_username_created_at_idx";
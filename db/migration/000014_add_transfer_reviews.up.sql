@@ -0,0 +1,39 @@
+CREATE TABLE "transfer_reviews"
+(
+    "id"              bigserial PRIMARY KEY,
+    "owner"           varchar     NOT NULL,
+    "from_account_id" bigint      NOT NULL,
+    "to_account_id"   bigint      NOT NULL,
+    "amount"          bigint      NOT NULL,
+    "currency"        varchar     NOT NULL,
+    "status"          varchar     NOT NULL DEFAULT 'pending_review',
+    "score"           int         NOT NULL,
+    "reasons"         text[]      NOT NULL,
+    "reviewer"        varchar,
+    "transfer_id"     bigint,
+    "reviewed_at"     timestamptz,
+    "created_at"      timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "transfer_reviews" ("owner");
+
+CREATE INDEX ON "transfer_reviews" ("created_at") WHERE "status" = 'pending_review';
+
+CREATE INDEX ON "sessions" ("username", "created_at");
+
+COMMENT ON COLUMN "transfer_reviews"."score" IS 'sum of the scores of the risk rules that flagged the transfer';
+
+ALTER TABLE "transfer_reviews"
+    ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_reviews"
+    ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_reviews"
+    ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_reviews"
+    ADD FOREIGN KEY ("reviewer") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_reviews"
+    ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
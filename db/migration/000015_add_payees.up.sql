@@ -0,0 +1,23 @@
+CREATE TABLE "payees"
+(
+    "id"          bigserial PRIMARY KEY,
+    "owner"       varchar     NOT NULL,
+    "nickname"    varchar     NOT NULL,
+    "account_id"  bigint      NOT NULL,
+    "currency"    varchar     NOT NULL,
+    "is_verified" boolean     NOT NULL DEFAULT false,
+    "updated_at"  timestamptz NOT NULL DEFAULT (now()),
+    "created_at"  timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "payees" ("owner", "account_id");
+
+CREATE UNIQUE INDEX ON "payees" ("owner", "nickname");
+
+COMMENT ON COLUMN "payees"."is_verified" IS 'set once a transfer to the payee succeeds, which lifts its cooldown';
+
+ALTER TABLE "payees"
+    ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "payees"
+    ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
@@ -0,0 +1,45 @@
+CREATE TABLE "transfer_batches"
+(
+    "id"              bigserial PRIMARY KEY,
+    "owner"           varchar     NOT NULL,
+    "from_account_id" bigint      NOT NULL,
+    "currency"        varchar     NOT NULL,
+    "mode"            varchar     NOT NULL,
+    "status"          varchar     NOT NULL,
+    "item_count"      int         NOT NULL,
+    "succeeded_count" int         NOT NULL DEFAULT 0,
+    "created_at"      timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "transfer_batch_items"
+(
+    "id"            bigserial PRIMARY KEY,
+    "batch_id"      bigint      NOT NULL,
+    "position"      int         NOT NULL,
+    "to_account_id" bigint      NOT NULL,
+    "amount"        bigint      NOT NULL,
+    "status"        varchar     NOT NULL,
+    "transfer_id"   bigint,
+    "error"         varchar     NOT NULL DEFAULT '',
+    "created_at"    timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "transfer_batches" ("owner");
+
+CREATE UNIQUE INDEX ON "transfer_batch_items" ("batch_id", "position");
+
+COMMENT ON COLUMN "transfer_batches"."mode" IS 'atomic or best_effort';
+
+COMMENT ON COLUMN "transfer_batch_items"."to_account_id" IS 'not a foreign key, so that items to unknown accounts are recorded as failed';
+
+ALTER TABLE "transfer_batches"
+    ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_batches"
+    ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_batch_items"
+    ADD FOREIGN KEY ("batch_id") REFERENCES "transfer_batches" ("id");
+
+ALTER TABLE "transfer_batch_items"
+    ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
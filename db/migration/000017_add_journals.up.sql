@@ -0,0 +1,16 @@
+CREATE TABLE "journals"
+(
+    "id"          bigserial PRIMARY KEY,
+    "description" varchar     NOT NULL,
+    "created_at"  timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "entries"
+    ADD COLUMN "journal_id" bigint;
+
+CREATE INDEX ON "entries" ("journal_id");
+
+COMMENT ON COLUMN "entries"."journal_id" IS 'journal posting the entry with the other legs of the same transaction';
+
+ALTER TABLE "entries"
+    ADD FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
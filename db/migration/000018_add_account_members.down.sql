@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS "accounts"
+    ADD CONSTRAINT "owner_currency_product_key" UNIQUE ("owner", "currency", "product");
+
+DROP TABLE IF EXISTS "account_members";
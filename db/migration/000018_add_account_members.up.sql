@@ -0,0 +1,30 @@
+CREATE TABLE "account_members"
+(
+    "account_id"  bigint      NOT NULL,
+    "username"    varchar     NOT NULL,
+    "role"        varchar     NOT NULL,
+    "spend_limit" bigint,
+    "status"      varchar     NOT NULL DEFAULT 'invited',
+    "invited_by"  varchar     NOT NULL,
+    "accepted_at" timestamptz,
+    "created_at"  timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("account_id", "username")
+);
+
+CREATE INDEX ON "account_members" ("username");
+
+COMMENT ON COLUMN "account_members"."role" IS 'co_owner, viewer or spender, the owner of the account is its accounts.owner';
+
+COMMENT ON COLUMN "account_members"."spend_limit" IS 'largest amount a spender can move in one operation';
+
+ALTER TABLE "account_members"
+    ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "account_members"
+    ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "account_members"
+    ADD FOREIGN KEY ("invited_by") REFERENCES "users" ("username");
+
+ALTER TABLE "accounts"
+    DROP CONSTRAINT "owner_currency_product_key";
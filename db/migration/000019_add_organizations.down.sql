@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "transfer_approvals";
+
+ALTER TABLE IF EXISTS "accounts"
+    DROP COLUMN IF EXISTS "organization_id";
+
+DROP TABLE IF EXISTS "organization_members";
+
+DROP TABLE IF EXISTS "organizations";
@@ -0,0 +1,76 @@
+CREATE TABLE "organizations"
+(
+    "id"                 bigserial PRIMARY KEY,
+    "name"               varchar     NOT NULL,
+    "approval_threshold" bigint      NOT NULL,
+    "created_by"         varchar     NOT NULL,
+    "created_at"         timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "organization_members"
+(
+    "organization_id" bigint      NOT NULL,
+    "username"        varchar     NOT NULL,
+    "role"            varchar     NOT NULL,
+    "created_at"      timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("organization_id", "username")
+);
+
+CREATE TABLE "transfer_approvals"
+(
+    "id"              bigserial PRIMARY KEY,
+    "organization_id" bigint      NOT NULL,
+    "initiator"       varchar     NOT NULL,
+    "from_account_id" bigint      NOT NULL,
+    "to_account_id"   bigint      NOT NULL,
+    "amount"          bigint      NOT NULL,
+    "currency"        varchar     NOT NULL,
+    "status"          varchar     NOT NULL DEFAULT 'pending_approval',
+    "approver"        varchar,
+    "transfer_id"     bigint,
+    "decided_at"      timestamptz,
+    "created_at"      timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "accounts"
+    ADD COLUMN "organization_id" bigint;
+
+CREATE INDEX ON "accounts" ("organization_id");
+
+CREATE INDEX ON "organization_members" ("username");
+
+CREATE INDEX ON "transfer_approvals" ("organization_id", "created_at") WHERE "status" = 'pending_approval';
+
+COMMENT ON COLUMN "organizations"."approval_threshold" IS 'transfers above it from the accounts of the organization need the approval of another member';
+
+COMMENT ON COLUMN "organization_members"."role" IS 'admin, approver or initiator';
+
+ALTER TABLE "organizations"
+    ADD FOREIGN KEY ("created_by") REFERENCES "users" ("username");
+
+ALTER TABLE "organization_members"
+    ADD FOREIGN KEY ("organization_id") REFERENCES "organizations" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "organization_members"
+    ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "accounts"
+    ADD FOREIGN KEY ("organization_id") REFERENCES "organizations" ("id");
+
+ALTER TABLE "transfer_approvals"
+    ADD FOREIGN KEY ("organization_id") REFERENCES "organizations" ("id");
+
+ALTER TABLE "transfer_approvals"
+    ADD FOREIGN KEY ("initiator") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_approvals"
+    ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals"
+    ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals"
+    ADD FOREIGN KEY ("approver") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_approvals"
+    ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
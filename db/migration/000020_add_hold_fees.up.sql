@@ -0,0 +1,4 @@
+ALTER TABLE "holds"
+    ADD COLUMN "reserved_fee" bigint NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN "holds"."reserved_fee" IS 'fee quoted for the amount, held along with it until the hold is captured or released';
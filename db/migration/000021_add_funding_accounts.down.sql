@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "funding_accounts";
\ No newline at end of file
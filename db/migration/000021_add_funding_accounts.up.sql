@@ -0,0 +1,10 @@
+CREATE TABLE "funding_accounts"
+(
+    "currency"   varchar PRIMARY KEY,
+    "account_id" bigint NOT NULL
+);
+
+COMMENT ON TABLE "funding_accounts" IS 'account debited by the deposits of a currency, so that they are balanced journals';
+
+ALTER TABLE "funding_accounts"
+    ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
@@ -1,6 +1,6 @@
 -- name: CreateAccount :one
-INSERT INTO accounts (owner, balance, currency)
-VALUES ($1, $2, $3)
+INSERT INTO accounts (owner, balance, currency, product)
+VALUES ($1, $2, $3, $4)
 RETURNING *;
 
 -- name: GetAccount :one
@@ -26,7 +26,9 @@ FOR NO KEY UPDATE;
 -- name: ListAccounts :many
 SELECT *
 FROM accounts
-WHERE owner = $1
+WHERE (organization_id IS NULL AND
+       (owner = $1 OR id IN (SELECT account_id FROM account_members WHERE username = $1 AND status = 'active')))
+   OR organization_id IN (SELECT organization_id FROM organization_members WHERE username = $1)
 ORDER BY id
 LIMIT $2 OFFSET $3;
 
@@ -43,4 +45,22 @@ WHERE id = sqlc.arg(id)
 RETURNING *;
 
 -- name: DeleteAccount :exec
-DELETE FROM accounts WHERE id = $1;
\ No newline at end of file
+DELETE FROM accounts WHERE id = $1;
+
+-- name: UpdateAccountStatus :one
+UPDATE accounts
+set status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: AddAccountHeldBalance :one
+UPDATE accounts
+set held_balance = held_balance + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SetAccountOrganization :one
+UPDATE accounts
+set organization_id = $2
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,16 @@
+-- name: CreateAuditEvent :one
+INSERT INTO audit_events (actor, action, target_type, target_id, before, after, client_ip, user_agent, request_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: ListAuditEvents :many
+SELECT *
+FROM audit_events
+WHERE (sqlc.narg(actor)::varchar IS NULL OR actor = sqlc.narg(actor))
+  AND (sqlc.narg(action)::varchar IS NULL OR action = sqlc.narg(action))
+  AND (sqlc.narg(target_type)::varchar IS NULL OR target_type = sqlc.narg(target_type))
+  AND (sqlc.narg(target_id)::varchar IS NULL OR target_id = sqlc.narg(target_id))
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR created_at >= sqlc.narg(created_after))
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR created_at < sqlc.narg(created_before))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
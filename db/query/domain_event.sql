@@ -0,0 +1,10 @@
+-- name: CreateDomainEvent :one
+INSERT INTO domain_events (event_type, payload)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetDomainEvent :one
+SELECT *
+FROM domain_events
+WHERE id = $1
+LIMIT 1;
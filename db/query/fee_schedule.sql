@@ -0,0 +1,17 @@
+-- name: CreateFeeSchedule :one
+INSERT INTO fee_schedules (currency, min_amount, flat_fee, percentage_bps, max_fee, revenue_account_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetFeeSchedule :one
+SELECT *
+FROM fee_schedules
+WHERE currency = sqlc.arg(currency)
+  AND min_amount <= sqlc.arg(amount)
+ORDER BY min_amount DESC
+LIMIT 1;
+
+-- name: DeleteFeeSchedule :exec
+DELETE
+FROM fee_schedules
+WHERE id = $1;
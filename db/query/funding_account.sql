@@ -0,0 +1,16 @@
+-- name: GetFundingAccount :one
+SELECT *
+FROM funding_accounts
+WHERE currency = $1
+LIMIT 1;
+
+-- name: SetFundingAccount :one
+INSERT INTO funding_accounts (currency, account_id)
+VALUES ($1, $2)
+ON CONFLICT (currency) DO UPDATE SET account_id = excluded.account_id
+RETURNING *;
+
+-- name: DeleteFundingAccount :exec
+DELETE
+FROM funding_accounts
+WHERE currency = $1;
@@ -0,0 +1,105 @@
+-- name: GetAccountProduct :one
+SELECT *
+FROM account_products
+WHERE code = $1
+LIMIT 1;
+
+-- name: GetInterestExpenseAccount :one
+SELECT *
+FROM interest_expense_accounts
+WHERE currency = $1
+LIMIT 1;
+
+-- name: SetInterestExpenseAccount :one
+INSERT INTO interest_expense_accounts (currency, account_id)
+VALUES ($1, $2)
+ON CONFLICT (currency) DO UPDATE SET account_id = excluded.account_id
+RETURNING *;
+
+-- name: DeleteInterestExpenseAccount :exec
+DELETE
+FROM interest_expense_accounts
+WHERE currency = $1;
+
+-- name: ListEndOfDayBalances :many
+SELECT a.id                                                          AS account_id,
+       p.annual_interest_rate_bps,
+       (a.balance - COALESCE((SELECT SUM(e.amount)
+                              FROM entries e
+                              WHERE e.account_id = a.id
+                                AND e.created_at >= sqlc.arg(end_of_day)), 0))::bigint AS balance
+FROM accounts a
+         JOIN account_products p ON p.code = a.product
+WHERE p.annual_interest_rate_bps > 0
+  AND a.created_at < sqlc.arg(end_of_day)
+  AND NOT EXISTS(SELECT 1
+                 FROM interest_accruals ia
+                 WHERE ia.account_id = a.id
+                   AND ia.accrual_date = sqlc.arg(accrual_date))
+ORDER BY a.id
+LIMIT sqlc.arg('limit');
+
+-- name: GetFirstUnaccruedDay :one
+SELECT COALESCE(MIN(COALESCE((SELECT MAX(ia.accrual_date) + 1
+                              FROM interest_accruals ia
+                              WHERE ia.account_id = a.id),
+                             (a.created_at AT TIME ZONE 'UTC')::date)),
+                (now() AT TIME ZONE 'UTC')::date)::date AS first_unaccrued_day
+FROM accounts a
+         JOIN account_products p ON p.code = a.product
+WHERE p.annual_interest_rate_bps > 0;
+
+-- name: CreateInterestAccrual :one
+INSERT INTO interest_accruals (account_id, accrual_date, balance, annual_interest_rate_bps, amount_micros)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (account_id, accrual_date) DO NOTHING
+RETURNING *;
+
+-- name: ListInterestAccruals :many
+SELECT *
+FROM interest_accruals
+WHERE account_id = $1
+ORDER BY accrual_date DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ListAccountsWithUnpostedInterest :many
+SELECT DISTINCT ia.account_id
+FROM interest_accruals ia
+WHERE ia.posting_id IS NULL
+  AND ia.accrual_date < sqlc.arg(period_end)
+  AND ia.account_id > sqlc.arg(after_account_id)
+  AND NOT EXISTS(SELECT 1
+                 FROM interest_postings ip
+                 WHERE ip.account_id = ia.account_id
+                   AND ip.period_start = sqlc.arg(period_start))
+ORDER BY ia.account_id
+LIMIT sqlc.arg('limit');
+
+-- name: CreateInterestPosting :one
+INSERT INTO interest_postings (account_id, period_start, amount)
+VALUES ($1, $2, 0)
+ON CONFLICT (account_id, period_start) DO NOTHING
+RETURNING *;
+
+-- name: MarkInterestAccrualsPosted :many
+UPDATE interest_accruals
+SET posting_id = sqlc.arg(posting_id)
+WHERE account_id = sqlc.arg(account_id)
+  AND posting_id IS NULL
+  AND accrual_date < sqlc.arg(period_end)
+RETURNING amount_micros;
+
+-- name: UpdateInterestPosting :one
+UPDATE interest_postings
+SET amount           = $2,
+    entry_id         = $3,
+    expense_entry_id = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: ListInterestPostings :many
+SELECT *
+FROM interest_postings
+WHERE account_id = $1
+ORDER BY period_start DESC
+LIMIT $2 OFFSET $3;
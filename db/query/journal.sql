@@ -0,0 +1,21 @@
+-- name: CreateJournal :one
+INSERT INTO journals (description)
+VALUES ($1)
+RETURNING *;
+
+-- name: GetJournal :one
+SELECT *
+FROM journals
+WHERE id = $1
+LIMIT 1;
+
+-- name: CreateJournalEntry :one
+INSERT INTO entries (account_id, amount, journal_id)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListJournalEntries :many
+SELECT *
+FROM entries
+WHERE journal_id = $1
+ORDER BY id;
@@ -0,0 +1,47 @@
+-- name: CreateOrganization :one
+INSERT INTO organizations (name, approval_threshold, created_by)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetOrganization :one
+SELECT *
+FROM organizations
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetOrganizationForUpdate :one
+SELECT *
+FROM organizations
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: CreateOrganizationMember :one
+INSERT INTO organization_members (organization_id, username, role)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetOrganizationMember :one
+SELECT *
+FROM organization_members
+WHERE organization_id = $1
+  AND username = $2
+LIMIT 1;
+
+-- name: ListOrganizationMembers :many
+SELECT *
+FROM organization_members
+WHERE organization_id = $1
+ORDER BY created_at, username;
+
+-- name: DeleteOrganizationMember :exec
+DELETE
+FROM organization_members
+WHERE organization_id = $1
+  AND username = $2;
+
+-- name: CountOrganizationAdmins :one
+SELECT count(*)
+FROM organization_members
+WHERE organization_id = $1
+  AND role = 'admin';
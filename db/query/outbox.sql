@@ -0,0 +1,24 @@
+-- name: CreateOutboxMessage :one
+INSERT INTO outbox (task_type, payload, queue, max_retry, process_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListPendingOutboxMessages :many
+SELECT *
+FROM outbox
+WHERE sent_at IS NULL
+  AND next_attempt_at <= now()
+ORDER BY id
+LIMIT $1 FOR UPDATE SKIP LOCKED;
+
+-- name: MarkOutboxMessageSent :exec
+UPDATE outbox
+SET sent_at = now()
+WHERE id = $1;
+
+-- name: MarkOutboxMessageFailed :exec
+UPDATE outbox
+SET attempts        = attempts + 1,
+    last_error      = $2,
+    next_attempt_at = $3
+WHERE id = $1;
@@ -0,0 +1,50 @@
+-- name: CreatePayee :one
+INSERT INTO payees (owner, nickname, account_id, currency)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetPayee :one
+SELECT *
+FROM payees
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetPayeeForUpdate :one
+SELECT *
+FROM payees
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetPayeeByAccount :one
+SELECT *
+FROM payees
+WHERE owner = $1
+  AND account_id = $2
+LIMIT 1;
+
+-- name: ListPayees :many
+SELECT *
+FROM payees
+WHERE owner = $1
+ORDER BY nickname
+LIMIT $2 OFFSET $3;
+
+-- name: UpdatePayee :one
+UPDATE payees
+SET nickname   = $2,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: VerifyPayee :exec
+UPDATE payees
+SET is_verified = true,
+    updated_at  = now()
+WHERE id = $1
+  AND is_verified = false;
+
+-- name: DeletePayee :exec
+DELETE
+FROM payees
+WHERE id = $1;
@@ -0,0 +1,31 @@
+-- name: CountTransfersBetween :one
+SELECT COUNT(*)
+FROM transfers
+WHERE from_account_id = $1
+  AND to_account_id = $2;
+
+-- name: CountOutgoingTransfersSince :one
+SELECT COUNT(*)
+FROM transfers
+WHERE from_account_id = $1
+  AND created_at >= sqlc.arg(since);
+
+-- name: GetLatestSession :one
+SELECT *
+FROM sessions
+WHERE username = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: CountSessionsFromIP :one
+SELECT COUNT(*)
+FROM sessions
+WHERE username = $1
+  AND client_ip = $2
+  AND created_at < sqlc.arg(before);
+
+-- name: CountSessionsBefore :one
+SELECT COUNT(*)
+FROM sessions
+WHERE username = $1
+  AND created_at < sqlc.arg(before);
@@ -0,0 +1,57 @@
+-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (owner, from_account_id, to_account_id, amount, currency, recurrence, next_run_at, end_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetScheduledTransfer :one
+SELECT *
+FROM scheduled_transfers
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetScheduledTransferForUpdate :one
+SELECT *
+FROM scheduled_transfers
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListScheduledTransfers :many
+SELECT *
+FROM scheduled_transfers
+WHERE owner = $1
+ORDER BY id
+LIMIT $2 OFFSET $3;
+
+-- name: ListDueScheduledTransfers :many
+SELECT *
+FROM scheduled_transfers
+WHERE status = 'active'
+  AND next_run_at <= sqlc.arg(due_at)
+ORDER BY next_run_at
+LIMIT sqlc.arg('limit');
+
+-- name: UpdateScheduledTransferSchedule :one
+UPDATE scheduled_transfers
+SET next_run_at = $2,
+    status      = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateScheduledTransferRun :one
+INSERT INTO scheduled_transfer_runs (scheduled_transfer_id, transfer_id, status, error, scheduled_for)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetScheduledTransferRun :one
+SELECT *
+FROM scheduled_transfer_runs
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListScheduledTransferRuns :many
+SELECT *
+FROM scheduled_transfer_runs
+WHERE scheduled_transfer_id = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
@@ -0,0 +1,34 @@
+-- name: CreateTransferApproval :one
+INSERT INTO transfer_approvals (organization_id, initiator, from_account_id, to_account_id, amount, currency)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetTransferApproval :one
+SELECT *
+FROM transfer_approvals
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetTransferApprovalForUpdate :one
+SELECT *
+FROM transfer_approvals
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListTransferApprovals :many
+SELECT *
+FROM transfer_approvals
+WHERE organization_id = $1
+  AND status = $2
+ORDER BY created_at
+LIMIT $3 OFFSET $4;
+
+-- name: UpdateTransferApproval :one
+UPDATE transfer_approvals
+SET status      = $2,
+    approver    = $3,
+    transfer_id = $4,
+    decided_at  = now()
+WHERE id = $1
+RETURNING *;
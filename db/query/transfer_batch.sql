@@ -0,0 +1,28 @@
+-- name: CreateTransferBatch :one
+INSERT INTO transfer_batches (owner, from_account_id, currency, mode, status, item_count)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetTransferBatch :one
+SELECT *
+FROM transfer_batches
+WHERE id = $1
+LIMIT 1;
+
+-- name: UpdateTransferBatch :one
+UPDATE transfer_batches
+SET status          = $2,
+    succeeded_count = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateTransferBatchItem :one
+INSERT INTO transfer_batch_items (batch_id, position, to_account_id, amount, status, transfer_id, error)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: ListTransferBatchItems :many
+SELECT *
+FROM transfer_batch_items
+WHERE batch_id = $1
+ORDER BY position;
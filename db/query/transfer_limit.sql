@@ -0,0 +1,50 @@
+-- name: GetProductTransferLimit :one
+SELECT *
+FROM product_transfer_limits
+WHERE product = $1
+  AND currency = $2
+LIMIT 1;
+
+-- name: SetProductTransferLimit :one
+INSERT INTO product_transfer_limits (product, currency, max_amount, daily_amount, monthly_amount, daily_count)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (product, currency) DO UPDATE SET max_amount     = excluded.max_amount,
+                                              daily_amount   = excluded.daily_amount,
+                                              monthly_amount = excluded.monthly_amount,
+                                              daily_count    = excluded.daily_count
+RETURNING *;
+
+-- name: DeleteProductTransferLimit :exec
+DELETE
+FROM product_transfer_limits
+WHERE product = $1
+  AND currency = $2;
+
+-- name: GetAccountTransferLimit :one
+SELECT *
+FROM account_transfer_limits
+WHERE account_id = $1
+LIMIT 1;
+
+-- name: SetAccountTransferLimit :one
+INSERT INTO account_transfer_limits (account_id, max_amount, daily_amount, monthly_amount, daily_count)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (account_id) DO UPDATE SET max_amount     = excluded.max_amount,
+                                       daily_amount   = excluded.daily_amount,
+                                       monthly_amount = excluded.monthly_amount,
+                                       daily_count    = excluded.daily_count,
+                                       updated_at     = now()
+RETURNING *;
+
+-- name: DeleteAccountTransferLimit :exec
+DELETE
+FROM account_transfer_limits
+WHERE account_id = $1;
+
+-- name: GetOutgoingTransferTotals :one
+SELECT COALESCE(SUM(amount) FILTER (WHERE created_at >= sqlc.arg(day_start)), 0)::bigint AS daily_amount,
+       COUNT(*) FILTER (WHERE created_at >= sqlc.arg(day_start))                     AS daily_count,
+       COALESCE(SUM(amount), 0)::bigint                                              AS monthly_amount
+FROM transfers
+WHERE from_account_id = sqlc.arg(account_id)
+  AND created_at >= sqlc.arg(month_start);
@@ -0,0 +1,33 @@
+-- name: CreateTransferReview :one
+INSERT INTO transfer_reviews (owner, from_account_id, to_account_id, amount, currency, score, reasons)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetTransferReview :one
+SELECT *
+FROM transfer_reviews
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetTransferReviewForUpdate :one
+SELECT *
+FROM transfer_reviews
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListTransferReviews :many
+SELECT *
+FROM transfer_reviews
+WHERE status = $1
+ORDER BY created_at
+LIMIT $2 OFFSET $3;
+
+-- name: UpdateTransferReview :one
+UPDATE transfer_reviews
+SET status      = $2,
+    reviewer    = $3,
+    transfer_id = $4,
+    reviewed_at = now()
+WHERE id = $1
+RETURNING *;
@@ -9,6 +9,19 @@ FROM users
 WHERE username = $1
 LIMIT 1;
 
+-- name: GetUserByEmail :one
+SELECT *
+FROM users
+WHERE email = $1
+LIMIT 1;
+
+-- name: GetUserForUpdate :one
+SELECT *
+FROM users
+WHERE username = $1
+LIMIT 1
+FOR NO KEY UPDATE;
+
 -- name: UpdateUser :one
 UPDATE users
 SET
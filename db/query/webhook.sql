@@ -0,0 +1,58 @@
+-- name: CreateWebhookEndpoint :one
+INSERT INTO webhook_endpoints (owner, url, secret)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetWebhookEndpoint :one
+SELECT *
+FROM webhook_endpoints
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetWebhookEndpointForUpdate :one
+SELECT *
+FROM webhook_endpoints
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListActiveWebhookEndpoints :many
+SELECT *
+FROM webhook_endpoints
+WHERE owner = $1
+  AND is_active = true
+ORDER BY id;
+
+-- name: DeactivateWebhookEndpoint :one
+UPDATE webhook_endpoints
+SET is_active = false
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (endpoint_id, event_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetWebhookDelivery :one
+SELECT *
+FROM webhook_deliveries
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListWebhookDeliveries :many
+SELECT *
+FROM webhook_deliveries
+WHERE endpoint_id = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: UpdateWebhookDeliveryAttempt :one
+UPDATE webhook_deliveries
+SET status          = $2,
+    attempts        = attempts + 1,
+    response_status = $3,
+    last_error      = $4,
+    updated_at      = now()
+WHERE id = $1
+RETURNING *;
@@ -7,13 +7,14 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 const addAccountBalance = `-- name: AddAccountBalance :one
 UPDATE accounts
 set balance = balance + $1
 WHERE id = $2
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, status, held_balance, available_balance, product, organization_id
 `
 
 type AddAccountBalanceParams struct {
@@ -30,24 +31,65 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Product,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const addAccountHeldBalance = `-- name: AddAccountHeldBalance :one
+UPDATE accounts
+set held_balance = held_balance + $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, status, held_balance, available_balance, product, organization_id
+`
+
+type AddAccountHeldBalanceParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) AddAccountHeldBalance(ctx context.Context, arg AddAccountHeldBalanceParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, addAccountHeldBalance, arg.Amount, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Product,
+		&i.OrganizationID,
 	)
 	return i, err
 }
 
 const createAccount = `-- name: CreateAccount :one
-INSERT INTO accounts (owner, balance, currency)
-VALUES ($1, $2, $3)
-RETURNING id, owner, balance, currency, created_at
+INSERT INTO accounts (owner, balance, currency, product)
+VALUES ($1, $2, $3, $4)
+RETURNING id, owner, balance, currency, created_at, status, held_balance, available_balance, product, organization_id
 `
 
 type CreateAccountParams struct {
 	Owner    string `json:"owner"`
 	Balance  int64  `json:"balance"`
 	Currency string `json:"currency"`
+	Product  string `json:"product"`
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, createAccount, arg.Owner, arg.Balance, arg.Currency)
+	row := q.db.QueryRowContext(ctx, createAccount,
+		arg.Owner,
+		arg.Balance,
+		arg.Currency,
+		arg.Product,
+	)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -55,6 +97,11 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Product,
+		&i.OrganizationID,
 	)
 	return i, err
 }
@@ -69,7 +116,7 @@ func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, created_at
+SELECT id, owner, balance, currency, created_at, status, held_balance, available_balance, product, organization_id
 FROM accounts
 WHERE id = $1
 LIMIT 1
@@ -84,12 +131,17 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Product,
+		&i.OrganizationID,
 	)
 	return i, err
 }
 
 const getAccountByOwner = `-- name: GetAccountByOwner :one
-SELECT id, owner, balance, currency, created_at
+SELECT id, owner, balance, currency, created_at, status, held_balance, available_balance, product, organization_id
 FROM accounts
 WHERE owner = $1
 LIMIT 1
@@ -104,12 +156,17 @@ func (q *Queries) GetAccountByOwner(ctx context.Context, owner string) (Account,
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Product,
+		&i.OrganizationID,
 	)
 	return i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, created_at
+SELECT id, owner, balance, currency, created_at, status, held_balance, available_balance, product, organization_id
 FROM accounts
 WHERE id = $1
 LIMIT 1
@@ -125,14 +182,21 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Product,
+		&i.OrganizationID,
 	)
 	return i, err
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at
+SELECT id, owner, balance, currency, created_at, status, held_balance, available_balance, product, organization_id
 FROM accounts
-WHERE owner = $1
+WHERE (organization_id IS NULL AND
+       (owner = $1 OR id IN (SELECT account_id FROM account_members WHERE username = $1 AND status = 'active')))
+   OR organization_id IN (SELECT organization_id FROM organization_members WHERE username = $1)
 ORDER BY id
 LIMIT $2 OFFSET $3
 `
@@ -158,6 +222,11 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.Status,
+			&i.HeldBalance,
+			&i.AvailableBalance,
+			&i.Product,
+			&i.OrganizationID,
 		); err != nil {
 			return nil, err
 		}
@@ -172,11 +241,41 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 	return items, nil
 }
 
+const setAccountOrganization = `-- name: SetAccountOrganization :one
+UPDATE accounts
+set organization_id = $2
+WHERE id = $1
+RETURNING id, owner, balance, currency, created_at, status, held_balance, available_balance, product, organization_id
+`
+
+type SetAccountOrganizationParams struct {
+	ID             int64         `json:"id"`
+	OrganizationID sql.NullInt64 `json:"organizationID"`
+}
+
+func (q *Queries) SetAccountOrganization(ctx context.Context, arg SetAccountOrganizationParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, setAccountOrganization, arg.ID, arg.OrganizationID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Product,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
 const updateAccount = `-- name: UpdateAccount :one
 UPDATE accounts
 set balance = $2
 WHERE id = $1
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, status, held_balance, available_balance, product, organization_id
 `
 
 type UpdateAccountParams struct {
@@ -193,6 +292,41 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Product,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const updateAccountStatus = `-- name: UpdateAccountStatus :one
+UPDATE accounts
+set status = $2
+WHERE id = $1
+RETURNING id, owner, balance, currency, created_at, status, held_balance, available_balance, product, organization_id
+`
+
+type UpdateAccountStatusParams struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+}
+
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountStatus, arg.ID, arg.Status)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Product,
+		&i.OrganizationID,
 	)
 	return i, err
 }
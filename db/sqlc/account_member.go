@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Roles of the members of an account. The owner of an account is its Owner, the other roles are granted by invitation.
+const (
+	AccountRoleOwner   = "owner"
+	AccountRoleCoOwner = "co_owner"
+	AccountRoleViewer  = "viewer"
+	// AccountRoleSpender can move up to the spend limit of its membership in one operation
+	AccountRoleSpender = "spender"
+)
+
+// Statuses of an account membership
+const (
+	AccountMemberInvited = "invited"
+	AccountMemberActive  = "active"
+)
+
+// Permissions checked against the role of an account member
+const (
+	// AccountPermissionView allows reading an account, its holds and its members
+	AccountPermissionView = "view"
+	// AccountPermissionSpend allows moving money from or into an account
+	AccountPermissionSpend = "spend"
+	// AccountPermissionManage allows managing the members of an account and deleting it
+	AccountPermissionManage = "manage"
+)
+
+var (
+	// ErrNotAccountMember is returned when a user is neither the owner nor an active member of an account
+	ErrNotAccountMember = errors.New("user is not a member of the account")
+	// ErrAccountPermission is returned when the role of a member doesn't allow an operation
+	ErrAccountPermission = errors.New("account role doesn't allow the operation")
+	// ErrAccountMemberNotInvited is returned when accepting a membership that is not pending
+	ErrAccountMemberNotInvited = errors.New("account membership is not pending")
+)
+
+// IsSupportedAccountRole checks if a role can be granted by invitation
+func IsSupportedAccountRole(role string) bool {
+	switch role {
+	case AccountRoleCoOwner, AccountRoleViewer, AccountRoleSpender:
+		return true
+	}
+	return false
+}
+
+// Allows reports whether the role of an active member grants permission, for amount when spending
+func (member AccountMember) Allows(permission string, amount int64) bool {
+	if member.Status != AccountMemberActive {
+		return false
+	}
+
+	switch member.Role {
+	case AccountRoleOwner:
+		return true
+	case AccountRoleCoOwner:
+		return permission != AccountPermissionManage
+	case AccountRoleSpender:
+		switch permission {
+		case AccountPermissionView:
+			return true
+		case AccountPermissionSpend:
+			return member.SpendLimit.Valid && amount <= member.SpendLimit.Int64
+		}
+	case AccountRoleViewer:
+		return permission == AccountPermissionView
+	}
+
+	return false
+}
+
+// AuthorizeAccount returns the membership of username in account when it grants permission, for amount when spending.
+// The owner of the account is a member with the owner role without a membership record.
+// The members of the organization owning an account are its only members, as granted by their organization role.
+// It returns an error wrapping ErrNotAccountMember or ErrAccountPermission when the user is not allowed.
+func AuthorizeAccount(
+	ctx context.Context, q Querier, account Account, username, permission string, amount int64,
+) (AccountMember, error) {
+	if account.OrganizationID.Valid {
+		return authorizeOrganizationAccount(ctx, q, account, username, permission)
+	}
+
+	if account.Owner == username {
+		return AccountMember{
+			AccountID: account.ID,
+			Username:  username,
+			Role:      AccountRoleOwner,
+			Status:    AccountMemberActive,
+			CreatedAt: account.CreatedAt,
+		}, nil
+	}
+
+	member, err := q.GetAccountMember(ctx, GetAccountMemberParams{AccountID: account.ID, Username: username})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return member, fmt.Errorf("account %d: %w", account.ID, ErrNotAccountMember)
+		}
+		return member, err
+	}
+
+	if member.Status != AccountMemberActive {
+		return member, fmt.Errorf("account %d: %w", account.ID, ErrNotAccountMember)
+	}
+
+	if !member.Allows(permission, amount) {
+		return member, fmt.Errorf("%w: %s can't %s account %d", ErrAccountPermission, member.Role, permission, account.ID)
+	}
+
+	return member, nil
+}
+
+// IsAccountAccessError reports whether err is an authorization failure of AuthorizeAccount
+func IsAccountAccessError(err error) bool {
+	return errors.Is(err, ErrNotAccountMember) || errors.Is(err, ErrAccountPermission)
+}
@@ -0,0 +1,162 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: account_member.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const acceptAccountMember = `-- name: AcceptAccountMember :one
+UPDATE account_members
+SET status      = 'active',
+    accepted_at = now()
+WHERE account_id = $1
+  AND username = $2
+RETURNING account_id, username, role, spend_limit, status, invited_by, accepted_at, created_at
+`
+
+type AcceptAccountMemberParams struct {
+	AccountID int64  `json:"accountID"`
+	Username  string `json:"username"`
+}
+
+func (q *Queries) AcceptAccountMember(ctx context.Context, arg AcceptAccountMemberParams) (AccountMember, error) {
+	row := q.db.QueryRowContext(ctx, acceptAccountMember, arg.AccountID, arg.Username)
+	var i AccountMember
+	err := row.Scan(
+		&i.AccountID,
+		&i.Username,
+		&i.Role,
+		&i.SpendLimit,
+		&i.Status,
+		&i.InvitedBy,
+		&i.AcceptedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createAccountMember = `-- name: CreateAccountMember :one
+INSERT INTO account_members (account_id, username, role, spend_limit, invited_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING account_id, username, role, spend_limit, status, invited_by, accepted_at, created_at
+`
+
+type CreateAccountMemberParams struct {
+	AccountID  int64         `json:"accountID"`
+	Username   string        `json:"username"`
+	Role       string        `json:"role"`
+	SpendLimit sql.NullInt64 `json:"spendLimit"`
+	InvitedBy  string        `json:"invitedBy"`
+}
+
+func (q *Queries) CreateAccountMember(ctx context.Context, arg CreateAccountMemberParams) (AccountMember, error) {
+	row := q.db.QueryRowContext(ctx, createAccountMember,
+		arg.AccountID,
+		arg.Username,
+		arg.Role,
+		arg.SpendLimit,
+		arg.InvitedBy,
+	)
+	var i AccountMember
+	err := row.Scan(
+		&i.AccountID,
+		&i.Username,
+		&i.Role,
+		&i.SpendLimit,
+		&i.Status,
+		&i.InvitedBy,
+		&i.AcceptedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteAccountMember = `-- name: DeleteAccountMember :exec
+DELETE
+FROM account_members
+WHERE account_id = $1
+  AND username = $2
+`
+
+type DeleteAccountMemberParams struct {
+	AccountID int64  `json:"accountID"`
+	Username  string `json:"username"`
+}
+
+func (q *Queries) DeleteAccountMember(ctx context.Context, arg DeleteAccountMemberParams) error {
+	_, err := q.db.ExecContext(ctx, deleteAccountMember, arg.AccountID, arg.Username)
+	return err
+}
+
+const getAccountMember = `-- name: GetAccountMember :one
+SELECT account_id, username, role, spend_limit, status, invited_by, accepted_at, created_at
+FROM account_members
+WHERE account_id = $1
+  AND username = $2
+LIMIT 1
+`
+
+type GetAccountMemberParams struct {
+	AccountID int64  `json:"accountID"`
+	Username  string `json:"username"`
+}
+
+func (q *Queries) GetAccountMember(ctx context.Context, arg GetAccountMemberParams) (AccountMember, error) {
+	row := q.db.QueryRowContext(ctx, getAccountMember, arg.AccountID, arg.Username)
+	var i AccountMember
+	err := row.Scan(
+		&i.AccountID,
+		&i.Username,
+		&i.Role,
+		&i.SpendLimit,
+		&i.Status,
+		&i.InvitedBy,
+		&i.AcceptedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAccountMembers = `-- name: ListAccountMembers :many
+SELECT account_id, username, role, spend_limit, status, invited_by, accepted_at, created_at
+FROM account_members
+WHERE account_id = $1
+ORDER BY created_at, username
+`
+
+func (q *Queries) ListAccountMembers(ctx context.Context, accountID int64) ([]AccountMember, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountMembers, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AccountMember{}
+	for rows.Next() {
+		var i AccountMember
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.Username,
+			&i.Role,
+			&i.SpendLimit,
+			&i.Status,
+			&i.InvitedBy,
+			&i.AcceptedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
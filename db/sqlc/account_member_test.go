@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountMember_Allows(t *testing.T) {
+	spender := AccountMember{
+		Role:       AccountRoleSpender,
+		SpendLimit: sql.NullInt64{Int64: 100, Valid: true},
+		Status:     AccountMemberActive,
+	}
+
+	testCases := []struct {
+		name       string
+		member     AccountMember
+		permission string
+		amount     int64
+		allowed    bool
+	}{
+		{"OwnerManage", AccountMember{Role: AccountRoleOwner, Status: AccountMemberActive}, AccountPermissionManage, 0, true},
+		{"CoOwnerSpend", AccountMember{Role: AccountRoleCoOwner, Status: AccountMemberActive}, AccountPermissionSpend, 1_000, true},
+		{"CoOwnerManage", AccountMember{Role: AccountRoleCoOwner, Status: AccountMemberActive}, AccountPermissionManage, 0, false},
+		{"ViewerView", AccountMember{Role: AccountRoleViewer, Status: AccountMemberActive}, AccountPermissionView, 0, true},
+		{"ViewerSpend", AccountMember{Role: AccountRoleViewer, Status: AccountMemberActive}, AccountPermissionSpend, 1, false},
+		{"SpenderWithinLimit", spender, AccountPermissionSpend, 100, true},
+		{"SpenderAboveLimit", spender, AccountPermissionSpend, 101, false},
+		{"SpenderManage", spender, AccountPermissionManage, 0, false},
+		{"Invited", AccountMember{Role: AccountRoleCoOwner, Status: AccountMemberInvited}, AccountPermissionView, 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.allowed, tc.member.Allows(tc.permission, tc.amount))
+		})
+	}
+}
+
+func TestStore_AccountMemberLifecycle(t *testing.T) {
+	store := NewStore(testDB)
+	account := createAccountWithCurrency(t, 1_000, util.BRL)
+	user := createRandomUser(t)
+
+	invited, err := store.InviteAccountMemberTx(context.Background(), InviteAccountMemberTxParams{
+		CreateAccountMemberParams: CreateAccountMemberParams{
+			AccountID:  account.ID,
+			Username:   user.Username,
+			Role:       AccountRoleSpender,
+			SpendLimit: sql.NullInt64{Int64: 100, Valid: true},
+			InvitedBy:  account.Owner,
+		},
+		Audit: AuditParams{Actor: account.Owner},
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountMemberInvited, invited.Member.Status)
+	require.False(t, invited.Member.AcceptedAt.Valid)
+
+	// a pending invitation grants nothing
+	_, err = AuthorizeAccount(context.Background(), store, account, user.Username, AccountPermissionView, 0)
+	require.ErrorIs(t, err, ErrNotAccountMember)
+
+	accepted, err := store.AcceptAccountMemberTx(context.Background(), AcceptAccountMemberTxParams{
+		AccountID: account.ID,
+		Username:  user.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountMemberActive, accepted.Member.Status)
+	require.True(t, accepted.Member.AcceptedAt.Valid)
+
+	_, err = store.AcceptAccountMemberTx(context.Background(), AcceptAccountMemberTxParams{
+		AccountID: account.ID,
+		Username:  user.Username,
+	})
+	require.ErrorIs(t, err, ErrAccountMemberNotInvited)
+
+	_, err = AuthorizeAccount(context.Background(), store, account, user.Username, AccountPermissionSpend, 100)
+	require.NoError(t, err)
+	_, err = AuthorizeAccount(context.Background(), store, account, user.Username, AccountPermissionSpend, 101)
+	require.ErrorIs(t, err, ErrAccountPermission)
+
+	accounts, err := store.ListAccounts(context.Background(), ListAccountsParams{Owner: user.Username, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ID, accounts[0].ID)
+
+	removed, err := store.RemoveAccountMemberTx(context.Background(), RemoveAccountMemberTxParams{
+		AccountID: account.ID,
+		Username:  user.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, accepted.Member, removed.Member)
+
+	_, err = AuthorizeAccount(context.Background(), store, account, user.Username, AccountPermissionView, 0)
+	require.ErrorIs(t, err, ErrNotAccountMember)
+}
+
+func TestQueries_CreateAccountSameCurrency(t *testing.T) {
+	account := createAccountWithCurrency(t, 0, util.BRL)
+
+	_, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    account.Owner,
+		Currency: account.Currency,
+		Product:  account.Product,
+	})
+	require.NoError(t, err)
+}
@@ -11,7 +11,7 @@ import (
 func createRandomAccount(t *testing.T) Account {
 	user := createRandomUser(t)
 
-	arg := CreateAccountParams{user.Username, util.RandomMoney(), util.RandomCurrency()}
+	arg := CreateAccountParams{user.Username, util.RandomMoney(), util.RandomCurrency(), util.CheckingProduct}
 	account, err := testQueries.CreateAccount(context.Background(), arg)
 
 	require.NoError(t, err)
@@ -19,8 +19,10 @@ func createRandomAccount(t *testing.T) Account {
 	require.Equal(t, arg.Owner, account.Owner)
 	require.Equal(t, arg.Balance, account.Balance)
 	require.Equal(t, arg.Currency, account.Currency)
+	require.Equal(t, arg.Product, account.Product)
 	require.NotZero(t, account.ID)
 	require.NotZero(t, account.CreatedAt)
+	require.Equal(t, util.ActiveAccountStatus, account.Status)
 
 	return account
 }
@@ -0,0 +1,182 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Actions recorded in the audit log
+const (
+	AuditActionCreateUser           = "user.create"
+	AuditActionUpdateUser           = "user.update"
+	AuditActionLoginUser            = "user.login"
+	AuditActionCreateAccount        = "account.create"
+	AuditActionDeleteAccount        = "account.delete"
+	AuditActionUpdateAccountBalance = "account.update_balance"
+	AuditActionFreezeAccount        = "account.freeze"
+	AuditActionCreateTransfer       = "transfer.create"
+
+	AuditActionCreateScheduledTransfer = "scheduled_transfer.create"
+	AuditActionPauseScheduledTransfer  = "scheduled_transfer.pause"
+	AuditActionResumeScheduledTransfer = "scheduled_transfer.resume"
+	AuditActionCancelScheduledTransfer = "scheduled_transfer.cancel"
+
+	AuditActionCreateHold  = "hold.create"
+	AuditActionCaptureHold = "hold.capture"
+	AuditActionVoidHold    = "hold.void"
+	AuditActionExpireHold  = "hold.expire"
+
+	AuditActionPostInterest = "interest.post"
+
+	AuditActionSetAccountTransferLimit = "account.set_transfer_limit"
+
+	AuditActionCreateTransferReview  = "transfer_review.create"
+	AuditActionApproveTransferReview = "transfer_review.approve"
+	AuditActionRejectTransferReview  = "transfer_review.reject"
+
+	AuditActionCreateTransferBatch = "transfer_batch.create"
+
+	AuditActionCreatePayee = "payee.create"
+	AuditActionUpdatePayee = "payee.update"
+	AuditActionDeletePayee = "payee.delete"
+
+	AuditActionPostJournal = "journal.post"
+
+	AuditActionCreateWebhookEndpoint     = "webhook_endpoint.create"
+	AuditActionDeactivateWebhookEndpoint = "webhook_endpoint.deactivate"
+
+	AuditActionInviteAccountMember = "account.invite_member"
+	AuditActionAcceptAccountMember = "account.accept_member"
+	AuditActionRemoveAccountMember = "account.remove_member"
+
+	AuditActionCreateOrganization       = "organization.create"
+	AuditActionAddOrganizationMember    = "organization.add_member"
+	AuditActionRemoveOrganizationMember = "organization.remove_member"
+
+	AuditActionCreateTransferApproval  = "transfer_approval.create"
+	AuditActionApproveTransferApproval = "transfer_approval.approve"
+	AuditActionRejectTransferApproval  = "transfer_approval.reject"
+)
+
+// Target types recorded in the audit log
+const (
+	AuditTargetUser     = "user"
+	AuditTargetAccount  = "account"
+	AuditTargetTransfer = "transfer"
+	AuditTargetSession  = "session"
+
+	AuditTargetScheduledTransfer = "scheduled_transfer"
+	AuditTargetHold              = "hold"
+	AuditTargetInterestPosting   = "interest_posting"
+	AuditTargetTransferReview    = "transfer_review"
+	AuditTargetTransferBatch     = "transfer_batch"
+	AuditTargetPayee             = "payee"
+	AuditTargetJournal           = "journal"
+	AuditTargetOrganization      = "organization"
+	AuditTargetTransferApproval  = "transfer_approval"
+	AuditTargetWebhookEndpoint   = "webhook_endpoint"
+)
+
+// AuditParams identifies who performs a state-changing operation and where the request came from.
+// It is recorded in the audit log within the same transaction as the change.
+type AuditParams struct {
+	Actor     string `json:"-"`
+	ClientIP  string `json:"-"`
+	UserAgent string `json:"-"`
+	RequestID string `json:"-"`
+}
+
+// auditUser is the user state recorded in the audit log and in domain events, which must never contain the password hash
+type auditUser struct {
+	Username          string    `json:"username"`
+	FullName          string    `json:"fullName"`
+	Email             string    `json:"email"`
+	Role              string    `json:"role"`
+	PasswordChangedAt time.Time `json:"passwordChangedAt"`
+}
+
+func newAuditUser(user User) auditUser {
+	return auditUser{
+		Username:          user.Username,
+		FullName:          user.FullName,
+		Email:             user.Email,
+		Role:              user.Role,
+		PasswordChangedAt: user.PasswordChangedAt,
+	}
+}
+
+// auditWebhookEndpoint is the webhook endpoint state recorded in the audit log, which must never contain the signing secret
+type auditWebhookEndpoint struct {
+	ID        int64     `json:"id"`
+	Owner     string    `json:"owner"`
+	Url       string    `json:"url"`
+	IsActive  bool      `json:"isActive"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func newAuditWebhookEndpoint(endpoint WebhookEndpoint) auditWebhookEndpoint {
+	return auditWebhookEndpoint{
+		ID:        endpoint.ID,
+		Owner:     endpoint.Owner,
+		Url:       endpoint.Url,
+		IsActive:  endpoint.IsActive,
+		CreatedAt: endpoint.CreatedAt,
+	}
+}
+
+// recordUserCreated records the creation of a user in the audit log and emits the user.created event.
+// A user registering itself, without an actor, is the actor of its creation.
+func recordUserCreated(ctx context.Context, queries *Queries, audit AuditParams, user User) error {
+	if audit.Actor == "" {
+		audit.Actor = user.Username
+	}
+
+	err := recordAudit(ctx, queries, audit, AuditActionCreateUser, AuditTargetUser, user.Username, nil, newAuditUser(user))
+	if err != nil {
+		return err
+	}
+
+	return recordDomainEvent(ctx, queries, EventUserCreated, newAuditUser(user), user.Username)
+}
+
+// recordAudit writes an audit event with the before and after states of the target
+func recordAudit(
+	ctx context.Context, queries *Queries, audit AuditParams, action, targetType, targetID string, before, after interface{},
+) error {
+	beforeJSON, err := auditJSON(before)
+	if err != nil {
+		return err
+	}
+
+	afterJSON, err := auditJSON(after)
+	if err != nil {
+		return err
+	}
+
+	_, err = queries.CreateAuditEvent(ctx, CreateAuditEventParams{
+		Actor:      audit.Actor,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Before:     beforeJSON,
+		After:      afterJSON,
+		ClientIp:   audit.ClientIP,
+		UserAgent:  audit.UserAgent,
+		RequestID:  audit.RequestID,
+	})
+	return err
+}
+
+func auditJSON(state interface{}) (json.RawMessage, error) {
+	if state == nil {
+		return json.RawMessage("{}"), nil
+	}
+
+	return json.Marshal(state)
+}
+
+func auditID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,127 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: audit_event.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+const createAuditEvent = `-- name: CreateAuditEvent :one
+INSERT INTO audit_events (actor, action, target_type, target_id, before, after, client_ip, user_agent, request_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, actor, action, target_type, target_id, before, after, client_ip, user_agent, request_id, created_at
+`
+
+type CreateAuditEventParams struct {
+	Actor      string          `json:"actor"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"targetType"`
+	TargetID   string          `json:"targetID"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	ClientIp   string          `json:"clientIp"`
+	UserAgent  string          `json:"userAgent"`
+	RequestID  string          `json:"requestID"`
+}
+
+func (q *Queries) CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error) {
+	row := q.db.QueryRowContext(ctx, createAuditEvent,
+		arg.Actor,
+		arg.Action,
+		arg.TargetType,
+		arg.TargetID,
+		arg.Before,
+		arg.After,
+		arg.ClientIp,
+		arg.UserAgent,
+		arg.RequestID,
+	)
+	var i AuditEvent
+	err := row.Scan(
+		&i.ID,
+		&i.Actor,
+		&i.Action,
+		&i.TargetType,
+		&i.TargetID,
+		&i.Before,
+		&i.After,
+		&i.ClientIp,
+		&i.UserAgent,
+		&i.RequestID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAuditEvents = `-- name: ListAuditEvents :many
+SELECT id, actor, action, target_type, target_id, before, after, client_ip, user_agent, request_id, created_at
+FROM audit_events
+WHERE ($1::varchar IS NULL OR actor = $1)
+  AND ($2::varchar IS NULL OR action = $2)
+  AND ($3::varchar IS NULL OR target_type = $3)
+  AND ($4::varchar IS NULL OR target_id = $4)
+  AND ($5::timestamptz IS NULL OR created_at >= $5)
+  AND ($6::timestamptz IS NULL OR created_at < $6)
+ORDER BY id DESC
+LIMIT $7 OFFSET $8
+`
+
+type ListAuditEventsParams struct {
+	Actor         sql.NullString `json:"actor"`
+	Action        sql.NullString `json:"action"`
+	TargetType    sql.NullString `json:"targetType"`
+	TargetID      sql.NullString `json:"targetID"`
+	CreatedAfter  sql.NullTime   `json:"createdAfter"`
+	CreatedBefore sql.NullTime   `json:"createdBefore"`
+	Limit         int32          `json:"limit"`
+	Offset        int32          `json:"offset"`
+}
+
+func (q *Queries) ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error) {
+	rows, err := q.db.QueryContext(ctx, listAuditEvents,
+		arg.Actor,
+		arg.Action,
+		arg.TargetType,
+		arg.TargetID,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AuditEvent{}
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Actor,
+			&i.Action,
+			&i.TargetType,
+			&i.TargetID,
+			&i.Before,
+			&i.After,
+			&i.ClientIp,
+			&i.UserAgent,
+			&i.RequestID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func createRandomAuditEvent(t *testing.T, actor string) AuditEvent {
+	arg := CreateAuditEventParams{
+		Actor:      actor,
+		Action:     AuditActionUpdateUser,
+		TargetType: AuditTargetUser,
+		TargetID:   actor,
+		Before:     json.RawMessage(`{"fullName": "before"}`),
+		After:      json.RawMessage(`{"fullName": "after"}`),
+		ClientIp:   "127.0.0.1",
+		UserAgent:  util.RandomString(10),
+		RequestID:  util.RandomString(16),
+	}
+	event, err := testQueries.CreateAuditEvent(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.NotZero(t, event.ID)
+	require.Equal(t, arg.Actor, event.Actor)
+	require.Equal(t, arg.Action, event.Action)
+	require.Equal(t, arg.TargetType, event.TargetType)
+	require.Equal(t, arg.TargetID, event.TargetID)
+	require.JSONEq(t, string(arg.Before), string(event.Before))
+	require.JSONEq(t, string(arg.After), string(event.After))
+	require.Equal(t, arg.ClientIp, event.ClientIp)
+	require.Equal(t, arg.UserAgent, event.UserAgent)
+	require.Equal(t, arg.RequestID, event.RequestID)
+	require.NotZero(t, event.CreatedAt)
+
+	return event
+}
+
+func TestQueries_CreateAuditEvent(t *testing.T) {
+	createRandomAuditEvent(t, util.RandomOwner())
+}
+
+func TestQueries_ListAuditEvents(t *testing.T) {
+	actor := util.RandomOwner()
+	for i := 0; i < 3; i++ {
+		createRandomAuditEvent(t, actor)
+	}
+
+	events, err := testQueries.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		Actor:  sql.NullString{String: actor, Valid: true},
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	for _, event := range events {
+		require.Equal(t, actor, event.Actor)
+	}
+}
+
+func TestStore_UpdateUserTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	result, err := store.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: user.Username,
+			FullName: sql.NullString{String: util.RandomOwner(), Valid: true},
+		},
+		Audit: AuditParams{Actor: user.Username, RequestID: util.RandomString(16)},
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, user.FullName, result.User.FullName)
+
+	events, err := store.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		TargetType: sql.NullString{String: AuditTargetUser, Valid: true},
+		TargetID:   sql.NullString{String: user.Username, Valid: true},
+		Limit:      5,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, AuditActionUpdateUser, events[0].Action)
+	require.Equal(t, user.Username, events[0].Actor)
+
+	var before, after map[string]interface{}
+	require.NoError(t, json.Unmarshal(events[0].Before, &before))
+	require.NoError(t, json.Unmarshal(events[0].After, &after))
+	require.Equal(t, user.FullName, before["fullName"])
+	require.Equal(t, result.User.FullName, after["fullName"])
+	require.NotContains(t, after, "hashedPassword")
+}
+
+func TestStore_CreateUserTxAudit(t *testing.T) {
+	store := NewStore(testDB)
+
+	result, err := store.CreateUserTx(context.Background(), CreateUserTxParams{
+		CreateUserParams: CreateUserParams{
+			Username:       util.RandomOwner(),
+			HashedPassword: util.RandomString(32),
+			FullName:       util.RandomOwner(),
+			Email:          util.RandomEmail(),
+		},
+		AfterCreate: func(*User) ([]CreateOutboxMessageParams, error) { return nil, nil },
+		Audit:       AuditParams{RequestID: util.RandomString(16)},
+	})
+	require.NoError(t, err)
+
+	events, err := store.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		Action:   sql.NullString{String: AuditActionCreateUser, Valid: true},
+		TargetID: sql.NullString{String: result.User.Username, Valid: true},
+		Limit:    5,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	// a user registering itself is the actor
+	require.Equal(t, result.User.Username, events[0].Actor)
+	require.NotContains(t, string(events[0].After), "hashedPassword")
+}
+
+func TestStore_DeleteAccountTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createAccountWithCurrency(t, 0, util.USD)
+
+	result, err := store.DeleteAccountTx(context.Background(), DeleteAccountTxParams{
+		AccountID: account.ID,
+		Audit:     AuditParams{Actor: account.Owner},
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.ID, result.Account.ID)
+
+	_, err = store.GetAccount(context.Background(), account.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	events, err := store.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		Action:   sql.NullString{String: AuditActionDeleteAccount, Valid: true},
+		TargetID: sql.NullString{String: auditID(account.ID), Valid: true},
+		Limit:    5,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, account.Owner, events[0].Actor)
+	require.NotEmpty(t, events[0].Before)
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/MathPeixoto/go-financial-system/requestid"
+	"github.com/MathPeixoto/go-financial-system/tracing"
+)
+
+// Domain events delivered to the webhook endpoints of the users involved
+const (
+	EventUserCreated       = "user.created"
+	EventAccountCreated    = "account.created"
+	EventAccountFrozen     = "account.frozen"
+	EventTransferCompleted = "transfer.completed"
+	EventInterestPosted    = "interest.posted"
+)
+
+// Statuses of a webhook delivery
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// TaskDeliverWebhook is the task that posts a domain event to a webhook endpoint.
+// It is declared here because the store writes it to the outbox along with the event.
+const TaskDeliverWebhook = "task:deliver_webhook"
+
+const (
+	webhookDeliveryQueue    = "default"
+	webhookDeliveryMaxRetry = 8
+)
+
+// PayloadDeliverWebhook is the payload of TaskDeliverWebhook
+type PayloadDeliverWebhook struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+// recordDomainEvent stores an event and schedules its delivery to the active webhook endpoints of the recipients
+func recordDomainEvent(ctx context.Context, queries *Queries, eventType string, data interface{}, recipients ...string) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	event, err := queries.CreateDomainEvent(ctx, CreateDomainEventParams{
+		EventType: eventType,
+		Payload:   payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	notified := make(map[string]bool, len(recipients))
+	for _, recipient := range recipients {
+		if notified[recipient] {
+			continue
+		}
+		notified[recipient] = true
+
+		endpoints, err := queries.ListActiveWebhookEndpoints(ctx, recipient)
+		if err != nil {
+			return err
+		}
+
+		for _, endpoint := range endpoints {
+			if _, err := scheduleWebhookDelivery(ctx, queries, endpoint.ID, event.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// scheduleWebhookDelivery creates a delivery and writes the task that performs it to the outbox
+func scheduleWebhookDelivery(ctx context.Context, queries *Queries, endpointID, eventID int64) (WebhookDelivery, error) {
+	delivery, err := queries.CreateWebhookDelivery(ctx, CreateWebhookDeliveryParams{
+		EndpointID: endpointID,
+		EventID:    eventID,
+	})
+	if err != nil {
+		return delivery, err
+	}
+
+	payload, err := json.Marshal(PayloadDeliverWebhook{DeliveryID: delivery.ID})
+	if err != nil {
+		return delivery, err
+	}
+
+	_, err = writeOutboxMessage(ctx, queries, CreateOutboxMessageParams{
+		TaskType:  TaskDeliverWebhook,
+		Payload:   payload,
+		Queue:     webhookDeliveryQueue,
+		MaxRetry:  webhookDeliveryMaxRetry,
+		ProcessAt: time.Now(),
+	})
+	return delivery, err
+}
+
+// writeOutboxMessage writes a task to the outbox carrying the trace context and the request ID of ctx,
+// so that processing the task continues the trace and the logs of the request
+func writeOutboxMessage(ctx context.Context, queries *Queries, arg CreateOutboxMessageParams) (Outbox, error) {
+	arg.Payload = requestid.InjectPayload(ctx, tracing.InjectPayload(ctx, arg.Payload))
+	return queries.CreateOutboxMessage(ctx, arg)
+}
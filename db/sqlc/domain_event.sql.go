@@ -0,0 +1,53 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: domain_event.sql
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const createDomainEvent = `-- name: CreateDomainEvent :one
+INSERT INTO domain_events (event_type, payload)
+VALUES ($1, $2)
+RETURNING id, event_type, payload, created_at
+`
+
+type CreateDomainEventParams struct {
+	EventType string          `json:"eventType"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+func (q *Queries) CreateDomainEvent(ctx context.Context, arg CreateDomainEventParams) (DomainEvent, error) {
+	row := q.db.QueryRowContext(ctx, createDomainEvent, arg.EventType, arg.Payload)
+	var i DomainEvent
+	err := row.Scan(
+		&i.ID,
+		&i.EventType,
+		&i.Payload,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getDomainEvent = `-- name: GetDomainEvent :one
+SELECT id, event_type, payload, created_at
+FROM domain_events
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetDomainEvent(ctx context.Context, id int64) (DomainEvent, error) {
+	row := q.db.QueryRowContext(ctx, getDomainEvent, id)
+	var i DomainEvent
+	err := row.Scan(
+		&i.ID,
+		&i.EventType,
+		&i.Payload,
+		&i.CreatedAt,
+	)
+	return i, err
+}
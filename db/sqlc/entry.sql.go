@@ -12,7 +12,7 @@ import (
 const createEntry = `-- name: CreateEntry :one
 INSERT INTO entries (account_id, amount)
 VALUES ($1, $2)
-RETURNING id, account_id, amount, created_at
+RETURNING id, account_id, amount, created_at, journal_id
 `
 
 type CreateEntryParams struct {
@@ -28,6 +28,7 @@ func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.JournalID,
 	)
 	return i, err
 }
@@ -42,7 +43,7 @@ func (q *Queries) DeleteEntry(ctx context.Context, id int64) error {
 }
 
 const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at
+SELECT id, account_id, amount, created_at, journal_id
 FROM entries
 WHERE id = $1
 LIMIT 1
@@ -56,12 +57,13 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.JournalID,
 	)
 	return i, err
 }
 
 const listEntries = `-- name: ListEntries :many
-SELECT id, account_id, amount, created_at
+SELECT id, account_id, amount, created_at, journal_id
 FROM entries
 ORDER BY id
 LIMIT $1 OFFSET $2
@@ -86,6 +88,7 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.JournalID,
 		); err != nil {
 			return nil, err
 		}
@@ -104,7 +107,7 @@ const updateEntry = `-- name: UpdateEntry :one
 UPDATE entries
 set amount = $2
 WHERE id = $1
-RETURNING id, account_id, amount, created_at
+RETURNING id, account_id, amount, created_at, journal_id
 `
 
 type UpdateEntryParams struct {
@@ -120,6 +123,7 @@ func (q *Queries) UpdateEntry(ctx context.Context, arg UpdateEntryParams) (Entry
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.JournalID,
 	)
 	return i, err
 }
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// TransferFee is charged to the sender of a transfer on top of the amount and credited to a revenue account
+type TransferFee struct {
+	Amount           int64 `json:"amount"`
+	RevenueAccountID int64 `json:"revenue_account_id"`
+}
+
+// feeFor returns the fee of the schedule for an amount: the flat fee plus the percentage,
+// rounded half up to the smallest currency unit, capped by the maximum fee when set
+func (schedule FeeSchedule) feeFor(amount int64) int64 {
+	fee := schedule.FlatFee + (amount*int64(schedule.PercentageBps)+5_000)/10_000
+	if schedule.MaxFee.Valid && fee > schedule.MaxFee.Int64 {
+		fee = schedule.MaxFee.Int64
+	}
+	return fee
+}
+
+// quoteFee returns the fee of a transfer in the tier of the fee schedule the amount falls into.
+// Transfers in a currency without a fee schedule are free.
+func quoteFee(ctx context.Context, queries *Queries, currency string, amount int64) (TransferFee, error) {
+	schedule, err := queries.GetFeeSchedule(ctx, GetFeeScheduleParams{
+		Currency: currency,
+		Amount:   amount,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return TransferFee{}, nil
+		}
+		return TransferFee{}, err
+	}
+
+	return TransferFee{
+		Amount:           schedule.feeFor(amount),
+		RevenueAccountID: schedule.RevenueAccountID,
+	}, nil
+}
+
+// QuoteTransferFee returns the fee TransferTx would charge for an amount in a currency
+func (store *SQLStore) QuoteTransferFee(ctx context.Context, currency string, amount int64) (TransferFee, error) {
+	return quoteFee(ctx, store.Queries, currency, amount)
+}
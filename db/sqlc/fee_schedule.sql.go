@@ -0,0 +1,90 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: fee_schedule.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createFeeSchedule = `-- name: CreateFeeSchedule :one
+INSERT INTO fee_schedules (currency, min_amount, flat_fee, percentage_bps, max_fee, revenue_account_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, currency, min_amount, flat_fee, percentage_bps, max_fee, revenue_account_id, created_at
+`
+
+type CreateFeeScheduleParams struct {
+	Currency         string        `json:"currency"`
+	MinAmount        int64         `json:"minAmount"`
+	FlatFee          int64         `json:"flatFee"`
+	PercentageBps    int32         `json:"percentageBps"`
+	MaxFee           sql.NullInt64 `json:"maxFee"`
+	RevenueAccountID int64         `json:"revenueAccountID"`
+}
+
+func (q *Queries) CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error) {
+	row := q.db.QueryRowContext(ctx, createFeeSchedule,
+		arg.Currency,
+		arg.MinAmount,
+		arg.FlatFee,
+		arg.PercentageBps,
+		arg.MaxFee,
+		arg.RevenueAccountID,
+	)
+	var i FeeSchedule
+	err := row.Scan(
+		&i.ID,
+		&i.Currency,
+		&i.MinAmount,
+		&i.FlatFee,
+		&i.PercentageBps,
+		&i.MaxFee,
+		&i.RevenueAccountID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteFeeSchedule = `-- name: DeleteFeeSchedule :exec
+DELETE
+FROM fee_schedules
+WHERE id = $1
+`
+
+func (q *Queries) DeleteFeeSchedule(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteFeeSchedule, id)
+	return err
+}
+
+const getFeeSchedule = `-- name: GetFeeSchedule :one
+SELECT id, currency, min_amount, flat_fee, percentage_bps, max_fee, revenue_account_id, created_at
+FROM fee_schedules
+WHERE currency = $1
+  AND min_amount <= $2
+ORDER BY min_amount DESC
+LIMIT 1
+`
+
+type GetFeeScheduleParams struct {
+	Currency string `json:"currency"`
+	Amount   int64  `json:"amount"`
+}
+
+func (q *Queries) GetFeeSchedule(ctx context.Context, arg GetFeeScheduleParams) (FeeSchedule, error) {
+	row := q.db.QueryRowContext(ctx, getFeeSchedule, arg.Currency, arg.Amount)
+	var i FeeSchedule
+	err := row.Scan(
+		&i.ID,
+		&i.Currency,
+		&i.MinAmount,
+		&i.FlatFee,
+		&i.PercentageBps,
+		&i.MaxFee,
+		&i.RevenueAccountID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
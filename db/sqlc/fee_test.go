@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/stretchr/testify/require"
+)
+
+// setFeeScheduleTier creates a fee schedule tier, which is deleted at the end of the test
+// so that it does not charge the transfers of other tests
+func setFeeScheduleTier(t *testing.T, arg CreateFeeScheduleParams) FeeSchedule {
+	schedule, err := testQueries.CreateFeeSchedule(context.Background(), arg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, testQueries.DeleteFeeSchedule(context.Background(), schedule.ID))
+	})
+
+	return schedule
+}
+
+func TestFeeSchedule_FeeFor(t *testing.T) {
+	testCases := []struct {
+		name     string
+		schedule FeeSchedule
+		amount   int64
+		fee      int64
+	}{
+		{
+			name:     "Flat",
+			schedule: FeeSchedule{FlatFee: 25},
+			amount:   1_000,
+			fee:      25,
+		},
+		{
+			name:     "Percentage",
+			schedule: FeeSchedule{PercentageBps: 150},
+			amount:   1_000,
+			fee:      15,
+		},
+		{
+			name:     "PercentageRoundsHalfUp",
+			schedule: FeeSchedule{PercentageBps: 150},
+			amount:   1_010,
+			fee:      15,
+		},
+		{
+			name:     "FlatAndPercentage",
+			schedule: FeeSchedule{FlatFee: 10, PercentageBps: 100},
+			amount:   5_000,
+			fee:      60,
+		},
+		{
+			name:     "Capped",
+			schedule: FeeSchedule{FlatFee: 10, PercentageBps: 100, MaxFee: sql.NullInt64{Int64: 40, Valid: true}},
+			amount:   5_000,
+			fee:      40,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.fee, tc.schedule.feeFor(tc.amount))
+		})
+	}
+}
+
+func TestStore_TransferTxChargesFee(t *testing.T) {
+	store := NewStore(testDB)
+	from := createAccountWithCurrency(t, 10_000, util.BRL)
+	to := createAccountWithCurrency(t, 0, util.BRL)
+	revenue := createAccountWithCurrency(t, 0, util.BRL)
+
+	setFeeScheduleTier(t, CreateFeeScheduleParams{
+		Currency:         util.BRL,
+		FlatFee:          5,
+		RevenueAccountID: revenue.ID,
+	})
+	setFeeScheduleTier(t, CreateFeeScheduleParams{
+		Currency:         util.BRL,
+		MinAmount:        1_000,
+		PercentageBps:    100,
+		MaxFee:           sql.NullInt64{Int64: 30, Valid: true},
+		RevenueAccountID: revenue.ID,
+	})
+
+	// the amount falls into the second tier
+	fee, err := store.QuoteTransferFee(context.Background(), util.BRL, 2_000)
+	require.NoError(t, err)
+	require.Equal(t, TransferFee{Amount: 20, RevenueAccountID: revenue.ID}, fee)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        2_000,
+		Audit:         AuditParams{Actor: from.Owner},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, int64(20), result.Fee)
+	require.Equal(t, from.ID, result.FeeEntry.AccountID)
+	require.Equal(t, int64(-20), result.FeeEntry.Amount)
+	require.Equal(t, revenue.ID, result.RevenueEntry.AccountID)
+	require.Equal(t, int64(20), result.RevenueEntry.Amount)
+
+	require.Equal(t, int64(7_980), result.FromAccount.Balance)
+	require.Equal(t, int64(2_000), result.ToAccount.Balance)
+
+	revenue, err = testQueries.GetAccount(context.Background(), revenue.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(20), revenue.Balance)
+
+	// the amount falls into the first tier
+	fee, err = store.QuoteTransferFee(context.Background(), util.BRL, 999)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), fee.Amount)
+}
+
+func TestStore_QuoteTransferFeeWithoutSchedule(t *testing.T) {
+	store := NewStore(testDB)
+
+	fee, err := store.QuoteTransferFee(context.Background(), util.USD, 1_000)
+	require.NoError(t, err)
+	require.Zero(t, fee.Amount)
+}
@@ -0,0 +1,54 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: funding_account.sql
+
+package db
+
+import (
+	"context"
+)
+
+const deleteFundingAccount = `-- name: DeleteFundingAccount :exec
+DELETE
+FROM funding_accounts
+WHERE currency = $1
+`
+
+func (q *Queries) DeleteFundingAccount(ctx context.Context, currency string) error {
+	_, err := q.db.ExecContext(ctx, deleteFundingAccount, currency)
+	return err
+}
+
+const getFundingAccount = `-- name: GetFundingAccount :one
+SELECT currency, account_id
+FROM funding_accounts
+WHERE currency = $1
+LIMIT 1
+`
+
+func (q *Queries) GetFundingAccount(ctx context.Context, currency string) (FundingAccount, error) {
+	row := q.db.QueryRowContext(ctx, getFundingAccount, currency)
+	var i FundingAccount
+	err := row.Scan(&i.Currency, &i.AccountID)
+	return i, err
+}
+
+const setFundingAccount = `-- name: SetFundingAccount :one
+INSERT INTO funding_accounts (currency, account_id)
+VALUES ($1, $2)
+ON CONFLICT (currency) DO UPDATE SET account_id = excluded.account_id
+RETURNING currency, account_id
+`
+
+type SetFundingAccountParams struct {
+	Currency  string `json:"currency"`
+	AccountID int64  `json:"accountID"`
+}
+
+func (q *Queries) SetFundingAccount(ctx context.Context, arg SetFundingAccountParams) (FundingAccount, error) {
+	row := q.db.QueryRowContext(ctx, setFundingAccount, arg.Currency, arg.AccountID)
+	var i FundingAccount
+	err := row.Scan(&i.Currency, &i.AccountID)
+	return i, err
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/stretchr/testify/require"
+)
+
+// useFundingAccount makes an account fund the deposits of its currency,
+// which is unset at the end of the test so that it does not fund the deposits of other tests
+func useFundingAccount(t *testing.T, account Account) {
+	_, err := testQueries.SetFundingAccount(context.Background(), SetFundingAccountParams{
+		Currency:  account.Currency,
+		AccountID: account.ID,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, testQueries.DeleteFundingAccount(context.Background(), account.Currency))
+	})
+}
+
+func TestStore_AddAccountBalanceTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createAccountWithCurrency(t, 100, util.EUR)
+	funding := createAccountWithCurrency(t, 0, util.EUR)
+	useFundingAccount(t, funding)
+
+	result, err := store.AddAccountBalanceTx(context.Background(), AddAccountBalanceTxParams{
+		AddAccountBalanceParams: AddAccountBalanceParams{ID: account.ID, Amount: 50},
+		Audit:                   AuditParams{Actor: account.Owner},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(150), result.Account.Balance)
+	require.Equal(t, account.ID, result.Entry.AccountID)
+	require.Equal(t, int64(50), result.Entry.Amount)
+	require.Equal(t, funding.ID, result.FundingEntry.AccountID)
+	require.Equal(t, int64(-50), result.FundingEntry.Amount)
+	require.Equal(t, result.Journal.ID, result.Entry.JournalID.Int64)
+	require.Equal(t, result.Journal.ID, result.FundingEntry.JournalID.Int64)
+
+	updatedFunding, err := store.GetAccount(context.Background(), funding.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(-50), updatedFunding.Balance)
+
+	// a frozen account receives no deposits
+	_, err = store.FreezeAccountTx(context.Background(), FreezeAccountTxParams{
+		AccountID: account.ID,
+		Audit:     AuditParams{Actor: "admin"},
+	})
+	require.NoError(t, err)
+
+	_, err = store.AddAccountBalanceTx(context.Background(), AddAccountBalanceTxParams{
+		AddAccountBalanceParams: AddAccountBalanceParams{ID: account.ID, Amount: 50},
+		Audit:                   AuditParams{Actor: account.Owner},
+	})
+	require.ErrorIs(t, err, ErrAccountFrozen)
+
+	frozen, err := store.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(150), frozen.Balance)
+}
+
+func TestStore_AddAccountBalanceTxNoFundingAccount(t *testing.T) {
+	store := NewStore(testDB)
+	account := createAccountWithCurrency(t, 100, util.EUR)
+
+	_, err := store.AddAccountBalanceTx(context.Background(), AddAccountBalanceTxParams{
+		AddAccountBalanceParams: AddAccountBalanceParams{ID: account.ID, Amount: 50},
+		Audit:                   AuditParams{Actor: account.Owner},
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
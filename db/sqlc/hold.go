@@ -0,0 +1,20 @@
+package db
+
+import "errors"
+
+// Statuses of an authorization hold
+const (
+	HoldPending  = "pending"
+	HoldCaptured = "captured"
+	HoldVoided   = "voided"
+	HoldExpired  = "expired"
+)
+
+var (
+	// ErrHoldNotPending is returned when capturing or releasing a hold that was already captured, voided or expired
+	ErrHoldNotPending = errors.New("hold is not pending")
+	// ErrHoldExpired is returned when capturing a hold past its expiry, even if the worker has not expired it yet
+	ErrHoldExpired = errors.New("hold has expired")
+	// ErrCaptureAmount is returned when capturing more than the held amount
+	ErrCaptureAmount = errors.New("capture amount exceeds the held amount")
+)
@@ -0,0 +1,251 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: hold.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createHold = `-- name: CreateHold :one
+INSERT INTO holds (account_id, to_account_id, amount, currency, expires_at, reserved_fee)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, account_id, to_account_id, amount, currency, status, captured_amount, transfer_id, expires_at, updated_at, created_at, reserved_fee
+`
+
+type CreateHoldParams struct {
+	AccountID   int64     `json:"accountID"`
+	ToAccountID int64     `json:"toAccountID"`
+	Amount      int64     `json:"amount"`
+	Currency    string    `json:"currency"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	ReservedFee int64     `json:"reservedFee"`
+}
+
+func (q *Queries) CreateHold(ctx context.Context, arg CreateHoldParams) (Hold, error) {
+	row := q.db.QueryRowContext(ctx, createHold,
+		arg.AccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Currency,
+		arg.ExpiresAt,
+		arg.ReservedFee,
+	)
+	var i Hold
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.CapturedAmount,
+		&i.TransferID,
+		&i.ExpiresAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.ReservedFee,
+	)
+	return i, err
+}
+
+const getHold = `-- name: GetHold :one
+SELECT id, account_id, to_account_id, amount, currency, status, captured_amount, transfer_id, expires_at, updated_at, created_at, reserved_fee
+FROM holds
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetHold(ctx context.Context, id int64) (Hold, error) {
+	row := q.db.QueryRowContext(ctx, getHold, id)
+	var i Hold
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.CapturedAmount,
+		&i.TransferID,
+		&i.ExpiresAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.ReservedFee,
+	)
+	return i, err
+}
+
+const getHoldForUpdate = `-- name: GetHoldForUpdate :one
+SELECT id, account_id, to_account_id, amount, currency, status, captured_amount, transfer_id, expires_at, updated_at, created_at, reserved_fee
+FROM holds
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetHoldForUpdate(ctx context.Context, id int64) (Hold, error) {
+	row := q.db.QueryRowContext(ctx, getHoldForUpdate, id)
+	var i Hold
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.CapturedAmount,
+		&i.TransferID,
+		&i.ExpiresAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.ReservedFee,
+	)
+	return i, err
+}
+
+const listExpiredHolds = `-- name: ListExpiredHolds :many
+SELECT id, account_id, to_account_id, amount, currency, status, captured_amount, transfer_id, expires_at, updated_at, created_at, reserved_fee
+FROM holds
+WHERE status = 'pending'
+  AND expires_at <= $1
+ORDER BY expires_at
+LIMIT $2
+`
+
+type ListExpiredHoldsParams struct {
+	ExpiredAt time.Time `json:"expiredAt"`
+	Limit     int32     `json:"limit"`
+}
+
+func (q *Queries) ListExpiredHolds(ctx context.Context, arg ListExpiredHoldsParams) ([]Hold, error) {
+	rows, err := q.db.QueryContext(ctx, listExpiredHolds, arg.ExpiredAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Hold{}
+	for rows.Next() {
+		var i Hold
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.Status,
+			&i.CapturedAmount,
+			&i.TransferID,
+			&i.ExpiresAt,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+			&i.ReservedFee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHolds = `-- name: ListHolds :many
+SELECT id, account_id, to_account_id, amount, currency, status, captured_amount, transfer_id, expires_at, updated_at, created_at, reserved_fee
+FROM holds
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListHoldsParams struct {
+	AccountID int64 `json:"accountID"`
+	Limit     int32 `json:"limit"`
+	Offset    int32 `json:"offset"`
+}
+
+func (q *Queries) ListHolds(ctx context.Context, arg ListHoldsParams) ([]Hold, error) {
+	rows, err := q.db.QueryContext(ctx, listHolds, arg.AccountID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Hold{}
+	for rows.Next() {
+		var i Hold
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.Status,
+			&i.CapturedAmount,
+			&i.TransferID,
+			&i.ExpiresAt,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+			&i.ReservedFee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateHold = `-- name: UpdateHold :one
+UPDATE holds
+SET status          = $2,
+    captured_amount = $3,
+    transfer_id     = $4,
+    updated_at      = now()
+WHERE id = $1
+RETURNING id, account_id, to_account_id, amount, currency, status, captured_amount, transfer_id, expires_at, updated_at, created_at, reserved_fee
+`
+
+type UpdateHoldParams struct {
+	ID             int64         `json:"id"`
+	Status         string        `json:"status"`
+	CapturedAmount int64         `json:"capturedAmount"`
+	TransferID     sql.NullInt64 `json:"transferID"`
+}
+
+func (q *Queries) UpdateHold(ctx context.Context, arg UpdateHoldParams) (Hold, error) {
+	row := q.db.QueryRowContext(ctx, updateHold,
+		arg.ID,
+		arg.Status,
+		arg.CapturedAmount,
+		arg.TransferID,
+	)
+	var i Hold
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.CapturedAmount,
+		&i.TransferID,
+		&i.ExpiresAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.ReservedFee,
+	)
+	return i, err
+}
@@ -0,0 +1,237 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomHold(t *testing.T, store Store, account, toAccount Account, amount int64, expiresAt time.Time) CreateHoldTxResult {
+	arg := CreateHoldTxParams{
+		CreateHoldParams: CreateHoldParams{
+			AccountID:   account.ID,
+			ToAccountID: toAccount.ID,
+			Amount:      amount,
+			Currency:    account.Currency,
+			ExpiresAt:   expiresAt,
+		},
+		Audit: AuditParams{Actor: account.Owner},
+	}
+	result, err := store.CreateHoldTx(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.NotZero(t, result.Hold.ID)
+	require.Equal(t, arg.AccountID, result.Hold.AccountID)
+	require.Equal(t, arg.ToAccountID, result.Hold.ToAccountID)
+	require.Equal(t, arg.Amount, result.Hold.Amount)
+	require.Equal(t, arg.Currency, result.Hold.Currency)
+	require.Equal(t, HoldPending, result.Hold.Status)
+	require.Zero(t, result.Hold.CapturedAmount)
+	require.False(t, result.Hold.TransferID.Valid)
+	require.WithinDuration(t, arg.ExpiresAt, result.Hold.ExpiresAt, time.Second)
+
+	require.Equal(t, account.Balance, result.Account.Balance)
+	require.Equal(t, account.HeldBalance+amount, result.Account.HeldBalance)
+	require.Equal(t, account.AvailableBalance-amount, result.Account.AvailableBalance)
+
+	return result
+}
+
+func TestStore_CreateHoldTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+	account := createAccountWithCurrency(t, 100, util.USD)
+	toAccount := createAccountWithCurrency(t, 0, util.USD)
+
+	createRandomHold(t, store, account, toAccount, 80, time.Now().Add(time.Hour))
+
+	_, err := store.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		CreateHoldParams: CreateHoldParams{
+			AccountID:   account.ID,
+			ToAccountID: toAccount.ID,
+			Amount:      30,
+			Currency:    account.Currency,
+			ExpiresAt:   time.Now().Add(time.Hour),
+		},
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestStore_CreateHoldTxReservesFee(t *testing.T) {
+	store := NewStore(testDB)
+	account := createAccountWithCurrency(t, 100, util.EUR)
+	toAccount := createAccountWithCurrency(t, 0, util.EUR)
+	revenue := createAccountWithCurrency(t, 0, util.EUR)
+
+	setFeeScheduleTier(t, CreateFeeScheduleParams{
+		Currency:         util.EUR,
+		FlatFee:          5,
+		RevenueAccountID: revenue.ID,
+	})
+
+	arg := CreateHoldTxParams{
+		CreateHoldParams: CreateHoldParams{
+			AccountID:   account.ID,
+			ToAccountID: toAccount.ID,
+			Amount:      96,
+			Currency:    account.Currency,
+			ExpiresAt:   time.Now().Add(time.Hour),
+		},
+		Audit: AuditParams{Actor: account.Owner},
+	}
+
+	// the balance covers the amount but not its fee
+	_, err := store.CreateHoldTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	arg.Amount = 95
+	created, err := store.CreateHoldTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), created.Hold.ReservedFee)
+	require.Equal(t, int64(100), created.Account.HeldBalance)
+	require.Zero(t, created.Account.AvailableBalance)
+
+	// the whole available balance was held, and the capture can pay the fee
+	result, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		ID:    created.Hold.ID,
+		Now:   time.Now(),
+		Audit: AuditParams{Actor: toAccount.Owner},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), result.Transfer.Fee)
+	require.Zero(t, result.Transfer.FromAccount.Balance)
+	require.Zero(t, result.Transfer.FromAccount.HeldBalance)
+}
+
+func TestStore_CreateHoldTxTransferLimit(t *testing.T) {
+	store := NewStore(testDB)
+	account := createSavingsAccount(t, 1_000, util.BRL)
+	toAccount := createAccountWithCurrency(t, 0, util.BRL)
+
+	useProductTransferLimit(t, SetProductTransferLimitParams{
+		Product:   util.SavingsProduct,
+		Currency:  util.BRL,
+		MaxAmount: sql.NullInt64{Int64: 300, Valid: true},
+	})
+
+	_, err := store.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		CreateHoldParams: CreateHoldParams{
+			AccountID:   account.ID,
+			ToAccountID: toAccount.ID,
+			Amount:      400,
+			Currency:    account.Currency,
+			ExpiresAt:   time.Now().Add(time.Hour),
+		},
+	})
+	var limitErr *TransferLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, account.ID, limitErr.AccountID)
+}
+
+func TestStore_CaptureHoldTxPartial(t *testing.T) {
+	store := NewStore(testDB)
+	account := createAccountWithCurrency(t, 100, util.EUR)
+	toAccount := createAccountWithCurrency(t, 0, util.EUR)
+	created := createRandomHold(t, store, account, toAccount, 60, time.Now().Add(time.Hour))
+
+	result, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		ID:     created.Hold.ID,
+		Amount: 40,
+		Now:    time.Now(),
+		Audit:  AuditParams{Actor: toAccount.Owner},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, HoldCaptured, result.Hold.Status)
+	require.Equal(t, int64(40), result.Hold.CapturedAmount)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Hold.TransferID.Int64)
+	require.Equal(t, int64(40), result.Transfer.Transfer.Amount)
+
+	// the remainder of the hold is released
+	require.Equal(t, int64(60), result.Transfer.FromAccount.Balance)
+	require.Zero(t, result.Transfer.FromAccount.HeldBalance)
+	require.Equal(t, int64(60), result.Transfer.FromAccount.AvailableBalance)
+	require.Equal(t, int64(40), result.Transfer.ToAccount.Balance)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		ID:  created.Hold.ID,
+		Now: time.Now(),
+	})
+	require.ErrorIs(t, err, ErrHoldNotPending)
+}
+
+func TestStore_CaptureHoldTxErrors(t *testing.T) {
+	store := NewStore(testDB)
+	account := createAccountWithCurrency(t, 100, util.BRL)
+	toAccount := createAccountWithCurrency(t, 0, util.BRL)
+	created := createRandomHold(t, store, account, toAccount, 50, time.Now().Add(time.Hour))
+
+	_, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		ID:     created.Hold.ID,
+		Amount: 51,
+		Now:    time.Now(),
+	})
+	require.ErrorIs(t, err, ErrCaptureAmount)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		ID:  created.Hold.ID,
+		Now: time.Now().Add(2 * time.Hour),
+	})
+	require.ErrorIs(t, err, ErrHoldExpired)
+}
+
+func TestStore_ReleaseHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createAccountWithCurrency(t, 100, util.USD)
+	toAccount := createAccountWithCurrency(t, 0, util.USD)
+	voided := createRandomHold(t, store, account, toAccount, 30, time.Now().Add(time.Hour))
+	expired := createRandomHold(t, store, voided.Account, toAccount, 20, time.Now().Add(-time.Minute))
+
+	// a hold cannot expire before its expiry
+	_, err := store.ReleaseHoldTx(context.Background(), ReleaseHoldTxParams{
+		ID:     voided.Hold.ID,
+		Status: HoldExpired,
+		Now:    time.Now(),
+	})
+	require.Error(t, err)
+
+	result, err := store.ReleaseHoldTx(context.Background(), ReleaseHoldTxParams{
+		ID:     voided.Hold.ID,
+		Status: HoldVoided,
+		Now:    time.Now(),
+		Audit:  AuditParams{Actor: account.Owner},
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldVoided, result.Hold.Status)
+	require.Equal(t, int64(20), result.Account.HeldBalance)
+	require.Equal(t, int64(80), result.Account.AvailableBalance)
+
+	expiredHolds, err := testQueries.ListExpiredHolds(context.Background(), ListExpiredHoldsParams{
+		ExpiredAt: time.Now(),
+		Limit:     1000,
+	})
+	require.NoError(t, err)
+	require.Contains(t, expiredHolds, expired.Hold)
+
+	result, err = store.ReleaseHoldTx(context.Background(), ReleaseHoldTxParams{
+		ID:     expired.Hold.ID,
+		Status: HoldExpired,
+		Now:    time.Now(),
+		Audit:  AuditParams{Actor: "system"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldExpired, result.Hold.Status)
+	require.Equal(t, account.Balance, result.Account.Balance)
+	require.Zero(t, result.Account.HeldBalance)
+	require.Equal(t, account.Balance, result.Account.AvailableBalance)
+
+	_, err = store.ReleaseHoldTx(context.Background(), ReleaseHoldTxParams{
+		ID:     expired.Hold.ID,
+		Status: HoldVoided,
+		Now:    time.Now(),
+	})
+	require.ErrorIs(t, err, ErrHoldNotPending)
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrInterestAlreadyPosted is returned when posting the interest of a period that was already posted for the account
+var ErrInterestAlreadyPosted = errors.New("interest already posted for the period")
+
+const (
+	// microsPerUnit is the number of millionths of the minor unit accruals are computed in
+	microsPerUnit = 1_000_000
+	// daysPerYear is the day count convention of the annual interest rate (actual/365)
+	daysPerYear = 365
+)
+
+// dailyInterestMicros returns the interest of one day on an end-of-day balance at an annual rate in basis points,
+// in millionths of the minor unit rounded half up. Balances that are not positive earn no interest.
+func dailyInterestMicros(balance int64, annualRateBps int32) int64 {
+	if balance <= 0 || annualRateBps <= 0 {
+		return 0
+	}
+	// balance * rate / 10_000 / 365 * 1_000_000
+	return (balance*int64(annualRateBps)*100 + daysPerYear/2) / daysPerYear
+}
+
+// roundMicros rounds an amount in millionths of the minor unit half up to the minor unit
+func roundMicros(micros int64) int64 {
+	return (micros + microsPerUnit/2) / microsPerUnit
+}
+
+type AccrueInterestParams struct {
+	AccountID             int64     `json:"account_id"`
+	AccrualDate           time.Time `json:"accrual_date"`
+	Balance               int64     `json:"balance"`
+	AnnualInterestRateBps int32     `json:"annual_interest_rate_bps"`
+}
+
+// AccrueInterest records the interest of one day on the end-of-day balance of an account.
+// A day is accrued at most once per account, accruing it again returns sql.ErrNoRows.
+func (store *SQLStore) AccrueInterest(ctx context.Context, arg AccrueInterestParams) (InterestAccrual, error) {
+	return store.CreateInterestAccrual(ctx, CreateInterestAccrualParams{
+		AccountID:             arg.AccountID,
+		AccrualDate:           arg.AccrualDate,
+		Balance:               arg.Balance,
+		AnnualInterestRateBps: arg.AnnualInterestRateBps,
+		AmountMicros:          dailyInterestMicros(arg.Balance, arg.AnnualInterestRateBps),
+	})
+}
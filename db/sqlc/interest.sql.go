@@ -0,0 +1,420 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: interest.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createInterestAccrual = `-- name: CreateInterestAccrual :one
+INSERT INTO interest_accruals (account_id, accrual_date, balance, annual_interest_rate_bps, amount_micros)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (account_id, accrual_date) DO NOTHING
+RETURNING id, account_id, accrual_date, balance, annual_interest_rate_bps, amount_micros, posting_id, created_at
+`
+
+type CreateInterestAccrualParams struct {
+	AccountID             int64     `json:"accountID"`
+	AccrualDate           time.Time `json:"accrualDate"`
+	Balance               int64     `json:"balance"`
+	AnnualInterestRateBps int32     `json:"annualInterestRateBps"`
+	AmountMicros          int64     `json:"amountMicros"`
+}
+
+func (q *Queries) CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (InterestAccrual, error) {
+	row := q.db.QueryRowContext(ctx, createInterestAccrual,
+		arg.AccountID,
+		arg.AccrualDate,
+		arg.Balance,
+		arg.AnnualInterestRateBps,
+		arg.AmountMicros,
+	)
+	var i InterestAccrual
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.AccrualDate,
+		&i.Balance,
+		&i.AnnualInterestRateBps,
+		&i.AmountMicros,
+		&i.PostingID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createInterestPosting = `-- name: CreateInterestPosting :one
+INSERT INTO interest_postings (account_id, period_start, amount)
+VALUES ($1, $2, 0)
+ON CONFLICT (account_id, period_start) DO NOTHING
+RETURNING id, account_id, period_start, amount, entry_id, expense_entry_id, created_at
+`
+
+type CreateInterestPostingParams struct {
+	AccountID   int64     `json:"accountID"`
+	PeriodStart time.Time `json:"periodStart"`
+}
+
+func (q *Queries) CreateInterestPosting(ctx context.Context, arg CreateInterestPostingParams) (InterestPosting, error) {
+	row := q.db.QueryRowContext(ctx, createInterestPosting, arg.AccountID, arg.PeriodStart)
+	var i InterestPosting
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.PeriodStart,
+		&i.Amount,
+		&i.EntryID,
+		&i.ExpenseEntryID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteInterestExpenseAccount = `-- name: DeleteInterestExpenseAccount :exec
+DELETE
+FROM interest_expense_accounts
+WHERE currency = $1
+`
+
+func (q *Queries) DeleteInterestExpenseAccount(ctx context.Context, currency string) error {
+	_, err := q.db.ExecContext(ctx, deleteInterestExpenseAccount, currency)
+	return err
+}
+
+const getAccountProduct = `-- name: GetAccountProduct :one
+SELECT code, annual_interest_rate_bps, created_at
+FROM account_products
+WHERE code = $1
+LIMIT 1
+`
+
+func (q *Queries) GetAccountProduct(ctx context.Context, code string) (AccountProduct, error) {
+	row := q.db.QueryRowContext(ctx, getAccountProduct, code)
+	var i AccountProduct
+	err := row.Scan(&i.Code, &i.AnnualInterestRateBps, &i.CreatedAt)
+	return i, err
+}
+
+const getFirstUnaccruedDay = `-- name: GetFirstUnaccruedDay :one
+SELECT COALESCE(MIN(COALESCE((SELECT MAX(ia.accrual_date) + 1
+                              FROM interest_accruals ia
+                              WHERE ia.account_id = a.id),
+                             (a.created_at AT TIME ZONE 'UTC')::date)),
+                (now() AT TIME ZONE 'UTC')::date)::date AS first_unaccrued_day
+FROM accounts a
+         JOIN account_products p ON p.code = a.product
+WHERE p.annual_interest_rate_bps > 0
+`
+
+func (q *Queries) GetFirstUnaccruedDay(ctx context.Context) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, getFirstUnaccruedDay)
+	var first_unaccrued_day time.Time
+	err := row.Scan(&first_unaccrued_day)
+	return first_unaccrued_day, err
+}
+
+const getInterestExpenseAccount = `-- name: GetInterestExpenseAccount :one
+SELECT currency, account_id
+FROM interest_expense_accounts
+WHERE currency = $1
+LIMIT 1
+`
+
+func (q *Queries) GetInterestExpenseAccount(ctx context.Context, currency string) (InterestExpenseAccount, error) {
+	row := q.db.QueryRowContext(ctx, getInterestExpenseAccount, currency)
+	var i InterestExpenseAccount
+	err := row.Scan(&i.Currency, &i.AccountID)
+	return i, err
+}
+
+const listAccountsWithUnpostedInterest = `-- name: ListAccountsWithUnpostedInterest :many
+SELECT DISTINCT ia.account_id
+FROM interest_accruals ia
+WHERE ia.posting_id IS NULL
+  AND ia.accrual_date < $1
+  AND ia.account_id > $2
+  AND NOT EXISTS(SELECT 1
+                 FROM interest_postings ip
+                 WHERE ip.account_id = ia.account_id
+                   AND ip.period_start = $3)
+ORDER BY ia.account_id
+LIMIT $4
+`
+
+type ListAccountsWithUnpostedInterestParams struct {
+	PeriodEnd      time.Time `json:"periodEnd"`
+	AfterAccountID int64     `json:"afterAccountID"`
+	PeriodStart    time.Time `json:"periodStart"`
+	Limit          int32     `json:"limit"`
+}
+
+func (q *Queries) ListAccountsWithUnpostedInterest(ctx context.Context, arg ListAccountsWithUnpostedInterestParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountsWithUnpostedInterest,
+		arg.PeriodEnd,
+		arg.AfterAccountID,
+		arg.PeriodStart,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var account_id int64
+		if err := rows.Scan(&account_id); err != nil {
+			return nil, err
+		}
+		items = append(items, account_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEndOfDayBalances = `-- name: ListEndOfDayBalances :many
+SELECT a.id                                                          AS account_id,
+       p.annual_interest_rate_bps,
+       (a.balance - COALESCE((SELECT SUM(e.amount)
+                              FROM entries e
+                              WHERE e.account_id = a.id
+                                AND e.created_at >= $1), 0))::bigint AS balance
+FROM accounts a
+         JOIN account_products p ON p.code = a.product
+WHERE p.annual_interest_rate_bps > 0
+  AND a.created_at < $1
+  AND NOT EXISTS(SELECT 1
+                 FROM interest_accruals ia
+                 WHERE ia.account_id = a.id
+                   AND ia.accrual_date = $2)
+ORDER BY a.id
+LIMIT $3
+`
+
+type ListEndOfDayBalancesParams struct {
+	EndOfDay    time.Time `json:"endOfDay"`
+	AccrualDate time.Time `json:"accrualDate"`
+	Limit       int32     `json:"limit"`
+}
+
+type ListEndOfDayBalancesRow struct {
+	AccountID             int64 `json:"accountID"`
+	AnnualInterestRateBps int32 `json:"annualInterestRateBps"`
+	Balance               int64 `json:"balance"`
+}
+
+func (q *Queries) ListEndOfDayBalances(ctx context.Context, arg ListEndOfDayBalancesParams) ([]ListEndOfDayBalancesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEndOfDayBalances, arg.EndOfDay, arg.AccrualDate, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEndOfDayBalancesRow{}
+	for rows.Next() {
+		var i ListEndOfDayBalancesRow
+		if err := rows.Scan(&i.AccountID, &i.AnnualInterestRateBps, &i.Balance); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInterestAccruals = `-- name: ListInterestAccruals :many
+SELECT id, account_id, accrual_date, balance, annual_interest_rate_bps, amount_micros, posting_id, created_at
+FROM interest_accruals
+WHERE account_id = $1
+ORDER BY accrual_date DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListInterestAccrualsParams struct {
+	AccountID int64 `json:"accountID"`
+	Limit     int32 `json:"limit"`
+	Offset    int32 `json:"offset"`
+}
+
+func (q *Queries) ListInterestAccruals(ctx context.Context, arg ListInterestAccrualsParams) ([]InterestAccrual, error) {
+	rows, err := q.db.QueryContext(ctx, listInterestAccruals, arg.AccountID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []InterestAccrual{}
+	for rows.Next() {
+		var i InterestAccrual
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.AccrualDate,
+			&i.Balance,
+			&i.AnnualInterestRateBps,
+			&i.AmountMicros,
+			&i.PostingID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInterestPostings = `-- name: ListInterestPostings :many
+SELECT id, account_id, period_start, amount, entry_id, expense_entry_id, created_at
+FROM interest_postings
+WHERE account_id = $1
+ORDER BY period_start DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListInterestPostingsParams struct {
+	AccountID int64 `json:"accountID"`
+	Limit     int32 `json:"limit"`
+	Offset    int32 `json:"offset"`
+}
+
+func (q *Queries) ListInterestPostings(ctx context.Context, arg ListInterestPostingsParams) ([]InterestPosting, error) {
+	rows, err := q.db.QueryContext(ctx, listInterestPostings, arg.AccountID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []InterestPosting{}
+	for rows.Next() {
+		var i InterestPosting
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.PeriodStart,
+			&i.Amount,
+			&i.EntryID,
+			&i.ExpenseEntryID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markInterestAccrualsPosted = `-- name: MarkInterestAccrualsPosted :many
+UPDATE interest_accruals
+SET posting_id = $1
+WHERE account_id = $2
+  AND posting_id IS NULL
+  AND accrual_date < $3
+RETURNING amount_micros
+`
+
+type MarkInterestAccrualsPostedParams struct {
+	PostingID sql.NullInt64 `json:"postingID"`
+	AccountID int64         `json:"accountID"`
+	PeriodEnd time.Time     `json:"periodEnd"`
+}
+
+func (q *Queries) MarkInterestAccrualsPosted(ctx context.Context, arg MarkInterestAccrualsPostedParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, markInterestAccrualsPosted, arg.PostingID, arg.AccountID, arg.PeriodEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var amount_micros int64
+		if err := rows.Scan(&amount_micros); err != nil {
+			return nil, err
+		}
+		items = append(items, amount_micros)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setInterestExpenseAccount = `-- name: SetInterestExpenseAccount :one
+INSERT INTO interest_expense_accounts (currency, account_id)
+VALUES ($1, $2)
+ON CONFLICT (currency) DO UPDATE SET account_id = excluded.account_id
+RETURNING currency, account_id
+`
+
+type SetInterestExpenseAccountParams struct {
+	Currency  string `json:"currency"`
+	AccountID int64  `json:"accountID"`
+}
+
+func (q *Queries) SetInterestExpenseAccount(ctx context.Context, arg SetInterestExpenseAccountParams) (InterestExpenseAccount, error) {
+	row := q.db.QueryRowContext(ctx, setInterestExpenseAccount, arg.Currency, arg.AccountID)
+	var i InterestExpenseAccount
+	err := row.Scan(&i.Currency, &i.AccountID)
+	return i, err
+}
+
+const updateInterestPosting = `-- name: UpdateInterestPosting :one
+UPDATE interest_postings
+SET amount           = $2,
+    entry_id         = $3,
+    expense_entry_id = $4
+WHERE id = $1
+RETURNING id, account_id, period_start, amount, entry_id, expense_entry_id, created_at
+`
+
+type UpdateInterestPostingParams struct {
+	ID             int64         `json:"id"`
+	Amount         int64         `json:"amount"`
+	EntryID        sql.NullInt64 `json:"entryID"`
+	ExpenseEntryID sql.NullInt64 `json:"expenseEntryID"`
+}
+
+func (q *Queries) UpdateInterestPosting(ctx context.Context, arg UpdateInterestPostingParams) (InterestPosting, error) {
+	row := q.db.QueryRowContext(ctx, updateInterestPosting,
+		arg.ID,
+		arg.Amount,
+		arg.EntryID,
+		arg.ExpenseEntryID,
+	)
+	var i InterestPosting
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.PeriodStart,
+		&i.Amount,
+		&i.EntryID,
+		&i.ExpenseEntryID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
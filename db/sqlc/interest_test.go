@@ -0,0 +1,268 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createSavingsAccount creates a savings account of a new user in the given currency
+func createSavingsAccount(t *testing.T, balance int64, currency string) Account {
+	user := createRandomUser(t)
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  balance,
+		Currency: currency,
+		Product:  util.SavingsProduct,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.SavingsProduct, account.Product)
+	return account
+}
+
+// useInterestExpenseAccount makes an account pay the interest of its currency,
+// which is unset at the end of the test so that it does not pay the interest of other tests
+func useInterestExpenseAccount(t *testing.T, account Account) {
+	_, err := testQueries.SetInterestExpenseAccount(context.Background(), SetInterestExpenseAccountParams{
+		Currency:  account.Currency,
+		AccountID: account.ID,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, testQueries.DeleteInterestExpenseAccount(context.Background(), account.Currency))
+	})
+}
+
+func TestDailyInterestMicros(t *testing.T) {
+	testCases := []struct {
+		name    string
+		balance int64
+		rateBps int32
+		micros  int64
+	}{
+		{
+			name:    "Exact",
+			balance: 365_000,
+			rateBps: 100,
+			micros:  10_000_000,
+		},
+		{
+			name:    "RoundsHalfUp",
+			balance: 100_000,
+			rateBps: 200,
+			micros:  5_479_452,
+		},
+		{
+			name:    "ZeroBalance",
+			balance: 0,
+			rateBps: 200,
+			micros:  0,
+		},
+		{
+			name:    "NegativeBalance",
+			balance: -100_000,
+			rateBps: 200,
+			micros:  0,
+		},
+		{
+			name:    "ZeroRate",
+			balance: 100_000,
+			rateBps: 0,
+			micros:  0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.micros, dailyInterestMicros(tc.balance, tc.rateBps))
+		})
+	}
+}
+
+func TestRoundMicros(t *testing.T) {
+	require.Equal(t, int64(0), roundMicros(499_999))
+	require.Equal(t, int64(1), roundMicros(500_000))
+	require.Equal(t, int64(164), roundMicros(164_383_560))
+}
+
+func TestStore_AccrueInterest(t *testing.T) {
+	store := NewStore(testDB)
+	account := createSavingsAccount(t, 100_000, util.EUR)
+	day := time.Date(2030, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	arg := AccrueInterestParams{
+		AccountID:             account.ID,
+		AccrualDate:           day,
+		Balance:               account.Balance,
+		AnnualInterestRateBps: 200,
+	}
+	accrual, err := store.AccrueInterest(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, account.ID, accrual.AccountID)
+	require.True(t, day.Equal(accrual.AccrualDate))
+	require.Equal(t, account.Balance, accrual.Balance)
+	require.Equal(t, int64(5_479_452), accrual.AmountMicros)
+	require.False(t, accrual.PostingID.Valid)
+
+	// a day is accrued once
+	_, err = store.AccrueInterest(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestQueries_ListEndOfDayBalances(t *testing.T) {
+	store := NewStore(testDB)
+	from := createSavingsAccount(t, 1_000, util.BRL)
+	to := createAccountWithCurrency(t, 0, util.BRL)
+	useFundingAccount(t, createAccountWithCurrency(t, 0, util.BRL))
+	// the balance at the end of a day is rebuilt from the entries made after it
+	endOfDay := time.Now()
+	accrualDate := time.Date(endOfDay.Year(), endOfDay.Month(), endOfDay.Day(), 0, 0, 0, 0, time.UTC)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        400,
+	})
+	require.NoError(t, err)
+
+	// deposits are entries too
+	deposit, err := store.AddAccountBalanceTx(context.Background(), AddAccountBalanceTxParams{
+		AddAccountBalanceParams: AddAccountBalanceParams{ID: from.ID, Amount: 500},
+		Audit:                   AuditParams{Actor: "system"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, from.ID, deposit.Entry.AccountID)
+	require.Equal(t, int64(500), deposit.Entry.Amount)
+	require.Equal(t, int64(1_100), deposit.Account.Balance)
+
+	var found bool
+	for {
+		balances, err := testQueries.ListEndOfDayBalances(context.Background(), ListEndOfDayBalancesParams{
+			EndOfDay:    endOfDay,
+			AccrualDate: accrualDate,
+			Limit:       100,
+		})
+		require.NoError(t, err)
+		if len(balances) == 0 {
+			break
+		}
+
+		for _, balance := range balances {
+			require.NotEqual(t, to.ID, balance.AccountID)
+			if balance.AccountID == from.ID {
+				found = true
+				require.Equal(t, int64(1_000), balance.Balance)
+				require.Equal(t, int32(200), balance.AnnualInterestRateBps)
+			}
+
+			_, err = store.AccrueInterest(context.Background(), AccrueInterestParams{
+				AccountID:             balance.AccountID,
+				AccrualDate:           accrualDate,
+				Balance:               balance.Balance,
+				AnnualInterestRateBps: balance.AnnualInterestRateBps,
+			})
+			require.NoError(t, err)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestQueries_GetFirstUnaccruedDay(t *testing.T) {
+	account := createSavingsAccount(t, 1_000, util.BRL)
+	createdAt := account.CreatedAt.UTC()
+	createdOn := time.Date(createdAt.Year(), createdAt.Month(), createdAt.Day(), 0, 0, 0, 0, time.UTC)
+
+	// the account has not accrued the day it was created on
+	firstDay, err := testQueries.GetFirstUnaccruedDay(context.Background())
+	require.NoError(t, err)
+	require.False(t, firstDay.After(createdOn))
+}
+
+func TestStore_PostInterestTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createSavingsAccount(t, 1_000_000, util.USD)
+	expense := createAccountWithCurrency(t, 0, util.USD)
+	useInterestExpenseAccount(t, expense)
+
+	periodStart := time.Date(2030, time.March, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.AddDate(0, 1, 0)
+
+	// 31 days of 54.794521 accrue 1698.630151, posted as 1699
+	for day := periodStart; day.Before(periodEnd); day = day.AddDate(0, 0, 1) {
+		_, err := store.AccrueInterest(context.Background(), AccrueInterestParams{
+			AccountID:             account.ID,
+			AccrualDate:           day,
+			Balance:               account.Balance,
+			AnnualInterestRateBps: 200,
+		})
+		require.NoError(t, err)
+	}
+
+	arg := PostInterestTxParams{
+		AccountID:   account.ID,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Audit:       AuditParams{Actor: "system"},
+	}
+	result, err := store.PostInterestTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1_699), result.Posting.Amount)
+	require.Equal(t, account.ID, result.Posting.AccountID)
+	require.Equal(t, result.Entry.ID, result.Posting.EntryID.Int64)
+	require.Equal(t, result.ExpenseEntry.ID, result.Posting.ExpenseEntryID.Int64)
+	require.Equal(t, int64(1_699), result.Entry.Amount)
+	require.Equal(t, expense.ID, result.ExpenseEntry.AccountID)
+	require.Equal(t, int64(-1_699), result.ExpenseEntry.Amount)
+	require.Equal(t, result.Journal.ID, result.Entry.JournalID.Int64)
+	require.Equal(t, result.Journal.ID, result.ExpenseEntry.JournalID.Int64)
+	require.Equal(t, account.Balance+1_699, result.Account.Balance)
+
+	updatedExpense, err := store.GetAccount(context.Background(), expense.ID)
+	require.NoError(t, err)
+	require.Equal(t, expense.Balance-1_699, updatedExpense.Balance)
+
+	accruals, err := store.ListInterestAccruals(context.Background(), ListInterestAccrualsParams{
+		AccountID: account.ID,
+		Limit:     100,
+	})
+	require.NoError(t, err)
+	require.Len(t, accruals, 31)
+	for _, accrual := range accruals {
+		require.Equal(t, result.Posting.ID, accrual.PostingID.Int64)
+	}
+
+	// a period is posted once
+	_, err = store.PostInterestTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInterestAlreadyPosted)
+}
+
+func TestStore_PostInterestTxRoundsToZero(t *testing.T) {
+	store := NewStore(testDB)
+	account := createSavingsAccount(t, 1, util.USD)
+	periodStart := time.Date(2030, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := store.AccrueInterest(context.Background(), AccrueInterestParams{
+		AccountID:             account.ID,
+		AccrualDate:           periodStart,
+		Balance:               account.Balance,
+		AnnualInterestRateBps: 200,
+	})
+	require.NoError(t, err)
+
+	// no expense account is needed when there is nothing to post
+	result, err := store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID:   account.ID,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodStart.AddDate(0, 1, 0),
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.Posting.Amount)
+	require.False(t, result.Posting.EntryID.Valid)
+	require.Zero(t, result.Entry.ID)
+	require.Equal(t, account.Balance, result.Account.Balance)
+}
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+)
+
+// ErrUnbalancedJournal is returned when the postings of a journal in a currency don't sum to zero
+var ErrUnbalancedJournal = errors.New("journal postings don't balance")
+
+// Posting is a leg of a journal: an amount added to the balance of an account, negative for a debit
+type Posting struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+}
+
+// lockAccounts locks accounts in ascending ID order, the order addBalances updates them in, and returns them by ID
+func lockAccounts(ctx context.Context, queries *Queries, ids []int64) (map[int64]Account, error) {
+	sorted := make([]int64, len(ids))
+	copy(sorted, ids)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	accounts := make(map[int64]Account, len(sorted))
+	for _, id := range sorted {
+		if _, ok := accounts[id]; ok {
+			continue
+		}
+
+		account, err := queries.GetAccountForUpdate(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("cannot lock account %d: %w", id, err)
+		}
+		accounts[id] = account
+	}
+
+	return accounts, nil
+}
+
+// checkPostings verifies that a journal has at least two non-zero postings to accounts that aren't frozen,
+// and that they sum to zero within each currency of their accounts
+func checkPostings(postings []Posting, accounts map[int64]Account) error {
+	if len(postings) < 2 {
+		return fmt.Errorf("a journal needs at least two postings, got %d", len(postings))
+	}
+
+	sums := make(map[string]int64)
+	for i, posting := range postings {
+		if posting.Amount == 0 {
+			return fmt.Errorf("posting %d to account %d has a zero amount", i, posting.AccountID)
+		}
+		if accounts[posting.AccountID].Status == util.FrozenAccountStatus {
+			return fmt.Errorf("account %d: %w", posting.AccountID, ErrAccountFrozen)
+		}
+		sums[accounts[posting.AccountID].Currency] += posting.Amount
+	}
+
+	currencies := make([]string, 0, len(sums))
+	for currency := range sums {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	for _, currency := range currencies {
+		if sum := sums[currency]; sum != 0 {
+			return fmt.Errorf("%w: %s postings sum to %d", ErrUnbalancedJournal, currency, sum)
+		}
+	}
+
+	return nil
+}
+
+// postJournal records a journal and one entry per posting, and updates the balances of the accounts,
+// within the transaction of queries so that other transactions can embed it.
+// It returns the entries in the order of the postings and the updated accounts by ID.
+func postJournal(
+	ctx context.Context, queries *Queries, description string, postings []Posting,
+) (Journal, []Entry, map[int64]Account, error) {
+	ids := make([]int64, len(postings))
+	for i, posting := range postings {
+		ids[i] = posting.AccountID
+	}
+
+	accounts, err := lockAccounts(ctx, queries, ids)
+	if err != nil {
+		return Journal{}, nil, nil, err
+	}
+
+	if err := checkPostings(postings, accounts); err != nil {
+		return Journal{}, nil, nil, err
+	}
+
+	journal, err := queries.CreateJournal(ctx, description)
+	if err != nil {
+		return journal, nil, nil, err
+	}
+
+	entries := make([]Entry, len(postings))
+	balances := make(map[int64]int64, len(accounts))
+	for i, posting := range postings {
+		entries[i], err = queries.CreateJournalEntry(ctx, CreateJournalEntryParams{
+			AccountID: posting.AccountID,
+			Amount:    posting.Amount,
+			JournalID: sql.NullInt64{Int64: journal.ID, Valid: true},
+		})
+		if err != nil {
+			return journal, nil, nil, err
+		}
+		balances[posting.AccountID] += posting.Amount
+	}
+
+	accounts, err = addBalances(ctx, queries, balances)
+	return journal, entries, accounts, err
+}
+
+// addBalances adds amounts to the balances of accounts and returns them by ID.
+// One good way to avoid deadlock is to update the accounts always in a given order, so they are updated by ascending ID.
+func addBalances(ctx context.Context, queries *Queries, amounts map[int64]int64) (map[int64]Account, error) {
+	ids := make([]int64, 0, len(amounts))
+	for id := range amounts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	accounts := make(map[int64]Account, len(ids))
+	for _, id := range ids {
+		account, err := queries.AddAccountBalance(ctx, AddAccountBalanceParams{
+			Amount: amounts[id],
+			ID:     id,
+		})
+		if err != nil {
+			return nil, err
+		}
+		accounts[id] = account
+	}
+
+	return accounts, nil
+}
@@ -0,0 +1,99 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: journal.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createJournal = `-- name: CreateJournal :one
+INSERT INTO journals (description)
+VALUES ($1)
+RETURNING id, description, created_at
+`
+
+func (q *Queries) CreateJournal(ctx context.Context, description string) (Journal, error) {
+	row := q.db.QueryRowContext(ctx, createJournal, description)
+	var i Journal
+	err := row.Scan(&i.ID, &i.Description, &i.CreatedAt)
+	return i, err
+}
+
+const createJournalEntry = `-- name: CreateJournalEntry :one
+INSERT INTO entries (account_id, amount, journal_id)
+VALUES ($1, $2, $3)
+RETURNING id, account_id, amount, created_at, journal_id
+`
+
+type CreateJournalEntryParams struct {
+	AccountID int64         `json:"accountID"`
+	Amount    int64         `json:"amount"`
+	JournalID sql.NullInt64 `json:"journalID"`
+}
+
+func (q *Queries) CreateJournalEntry(ctx context.Context, arg CreateJournalEntryParams) (Entry, error) {
+	row := q.db.QueryRowContext(ctx, createJournalEntry, arg.AccountID, arg.Amount, arg.JournalID)
+	var i Entry
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.JournalID,
+	)
+	return i, err
+}
+
+const getJournal = `-- name: GetJournal :one
+SELECT id, description, created_at
+FROM journals
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetJournal(ctx context.Context, id int64) (Journal, error) {
+	row := q.db.QueryRowContext(ctx, getJournal, id)
+	var i Journal
+	err := row.Scan(&i.ID, &i.Description, &i.CreatedAt)
+	return i, err
+}
+
+const listJournalEntries = `-- name: ListJournalEntries :many
+SELECT id, account_id, amount, created_at, journal_id
+FROM entries
+WHERE journal_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListJournalEntries(ctx context.Context, journalID sql.NullInt64) ([]Entry, error) {
+	rows, err := q.db.QueryContext(ctx, listJournalEntries, journalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Entry{}
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.JournalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,166 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPostings(t *testing.T) {
+	accounts := map[int64]Account{
+		1: {ID: 1, Currency: util.BRL},
+		2: {ID: 2, Currency: util.BRL},
+		3: {ID: 3, Currency: util.USD},
+		4: {ID: 4, Currency: util.USD},
+		5: {ID: 5, Currency: util.BRL, Status: util.FrozenAccountStatus},
+	}
+
+	testCases := []struct {
+		name     string
+		postings []Posting
+		isErr    error
+		hasErr   bool
+	}{
+		{
+			name:     "Balanced",
+			postings: []Posting{{AccountID: 1, Amount: -30}, {AccountID: 2, Amount: 10}, {AccountID: 2, Amount: 20}},
+		},
+		{
+			name: "BalancedPerCurrency",
+			postings: []Posting{
+				{AccountID: 1, Amount: -500}, {AccountID: 2, Amount: 500},
+				{AccountID: 3, Amount: -100}, {AccountID: 4, Amount: 100},
+			},
+		},
+		{
+			name:     "Unbalanced",
+			postings: []Posting{{AccountID: 1, Amount: -30}, {AccountID: 2, Amount: 20}},
+			isErr:    ErrUnbalancedJournal,
+			hasErr:   true,
+		},
+		{
+			name:     "BalancedAcrossCurrencies",
+			postings: []Posting{{AccountID: 1, Amount: -100}, {AccountID: 3, Amount: 100}},
+			isErr:    ErrUnbalancedJournal,
+			hasErr:   true,
+		},
+		{
+			name:     "FrozenAccount",
+			postings: []Posting{{AccountID: 1, Amount: -30}, {AccountID: 5, Amount: 30}},
+			isErr:    ErrAccountFrozen,
+			hasErr:   true,
+		},
+		{
+			name:     "SinglePosting",
+			postings: []Posting{{AccountID: 1, Amount: 0}},
+			hasErr:   true,
+		},
+		{
+			name:     "ZeroAmount",
+			postings: []Posting{{AccountID: 1, Amount: 0}, {AccountID: 2, Amount: 0}},
+			hasErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkPostings(tc.postings, accounts)
+			if !tc.hasErr {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			if tc.isErr != nil {
+				require.ErrorIs(t, err, tc.isErr)
+			}
+		})
+	}
+}
+
+func TestStore_PostJournalTx(t *testing.T) {
+	store := NewStore(testDB)
+	payer := createAccountWithCurrency(t, 1_000, util.BRL)
+	payeeOne := createAccountWithCurrency(t, 0, util.BRL)
+	payeeTwo := createAccountWithCurrency(t, 0, util.BRL)
+
+	arg := PostJournalTxParams{
+		Description: "split payment",
+		Postings: []Posting{
+			{AccountID: payer.ID, Amount: -300},
+			{AccountID: payeeOne.ID, Amount: 100},
+			{AccountID: payeeTwo.ID, Amount: 200},
+		},
+		Audit: AuditParams{Actor: payer.Owner},
+	}
+	result, err := store.PostJournalTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, result.Journal.ID)
+	require.Equal(t, arg.Description, result.Journal.Description)
+	require.Len(t, result.Entries, len(arg.Postings))
+	for i, entry := range result.Entries {
+		require.Equal(t, arg.Postings[i].AccountID, entry.AccountID)
+		require.Equal(t, arg.Postings[i].Amount, entry.Amount)
+		require.Equal(t, result.Journal.ID, entry.JournalID.Int64)
+	}
+	require.Equal(t, int64(700), result.Accounts[payer.ID].Balance)
+	require.Equal(t, int64(100), result.Accounts[payeeOne.ID].Balance)
+	require.Equal(t, int64(200), result.Accounts[payeeTwo.ID].Balance)
+
+	entries, err := testQueries.ListJournalEntries(context.Background(), sql.NullInt64{Int64: result.Journal.ID, Valid: true})
+	require.NoError(t, err)
+	require.Equal(t, result.Entries, entries)
+}
+
+func TestStore_PostJournalTxUnbalanced(t *testing.T) {
+	store := NewStore(testDB)
+	from := createAccountWithCurrency(t, 1_000, util.BRL)
+	to := createAccountWithCurrency(t, 0, util.USD)
+
+	_, err := store.PostJournalTx(context.Background(), PostJournalTxParams{
+		Postings: []Posting{{AccountID: from.ID, Amount: -100}, {AccountID: to.ID, Amount: 100}},
+	})
+	require.ErrorIs(t, err, ErrUnbalancedJournal)
+
+	account, err := testQueries.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance, account.Balance)
+}
+
+func TestStore_PostJournalTxDeadlock(t *testing.T) {
+	store := NewStore(testDB)
+	accounts := []Account{
+		createAccountWithCurrency(t, 1_000, util.BRL),
+		createAccountWithCurrency(t, 1_000, util.BRL),
+		createAccountWithCurrency(t, 1_000, util.BRL),
+	}
+
+	// concurrent journals list the same accounts in different orders
+	n := 9
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		first, second, third := accounts[i%3], accounts[(i+1)%3], accounts[(i+2)%3]
+		go func() {
+			_, err := store.PostJournalTx(context.Background(), PostJournalTxParams{
+				Postings: []Posting{
+					{AccountID: first.ID, Amount: -20},
+					{AccountID: second.ID, Amount: 10},
+					{AccountID: third.ID, Amount: 10},
+				},
+			})
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	for _, account := range accounts {
+		updated, err := testQueries.GetAccount(context.Background(), account.ID)
+		require.NoError(t, err)
+		require.Equal(t, account.Balance, updated.Balance)
+	}
+}
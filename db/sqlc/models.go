@@ -5,17 +5,73 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Account struct {
-	ID        int64     `json:"id"`
-	Owner     string    `json:"owner"`
-	Balance   int64     `json:"balance"`
-	Currency  string    `json:"currency"`
-	CreatedAt time.Time `json:"createdAt"`
+	ID               int64         `json:"id"`
+	Owner            string        `json:"owner"`
+	Balance          int64         `json:"balance"`
+	Currency         string        `json:"currency"`
+	CreatedAt        time.Time     `json:"createdAt"`
+	Status           string        `json:"status"`
+	HeldBalance      int64         `json:"heldBalance"`
+	AvailableBalance int64         `json:"availableBalance"`
+	Product          string        `json:"product"`
+	OrganizationID   sql.NullInt64 `json:"organizationID"`
+}
+
+type AccountMember struct {
+	AccountID int64  `json:"accountID"`
+	Username  string `json:"username"`
+	// co_owner, viewer or spender, the owner of the account is its accounts.owner
+	Role string `json:"role"`
+	// largest amount a spender can move in one operation
+	SpendLimit sql.NullInt64 `json:"spendLimit"`
+	Status     string        `json:"status"`
+	InvitedBy  string        `json:"invitedBy"`
+	AcceptedAt sql.NullTime  `json:"acceptedAt"`
+	CreatedAt  time.Time     `json:"createdAt"`
+}
+
+type AccountProduct struct {
+	Code                  string    `json:"code"`
+	AnnualInterestRateBps int32     `json:"annualInterestRateBps"`
+	CreatedAt             time.Time `json:"createdAt"`
+}
+
+type AccountTransferLimit struct {
+	AccountID     int64         `json:"accountID"`
+	MaxAmount     sql.NullInt64 `json:"maxAmount"`
+	DailyAmount   sql.NullInt64 `json:"dailyAmount"`
+	MonthlyAmount sql.NullInt64 `json:"monthlyAmount"`
+	DailyCount    sql.NullInt32 `json:"dailyCount"`
+	UpdatedAt     time.Time     `json:"updatedAt"`
+}
+
+type AuditEvent struct {
+	ID         int64           `json:"id"`
+	Actor      string          `json:"actor"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"targetType"`
+	TargetID   string          `json:"targetID"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	ClientIp   string          `json:"clientIp"`
+	UserAgent  string          `json:"userAgent"`
+	RequestID  string          `json:"requestID"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+type DomainEvent struct {
+	ID        int64           `json:"id"`
+	EventType string          `json:"eventType"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"createdAt"`
 }
 
 type Entry struct {
@@ -24,6 +80,160 @@ type Entry struct {
 	// can be negative or positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"createdAt"`
+	// journal posting the entry with the other legs of the same transaction
+	JournalID sql.NullInt64 `json:"journalID"`
+}
+
+type FeeSchedule struct {
+	ID       int64  `json:"id"`
+	Currency string `json:"currency"`
+	// lower bound of the tier, the tier with the highest bound not above the amount applies
+	MinAmount int64 `json:"minAmount"`
+	FlatFee   int64 `json:"flatFee"`
+	// in basis points of the amount
+	PercentageBps int32 `json:"percentageBps"`
+	// caps the fee when set
+	MaxFee           sql.NullInt64 `json:"maxFee"`
+	RevenueAccountID int64         `json:"revenueAccountID"`
+	CreatedAt        time.Time     `json:"createdAt"`
+}
+
+// account debited by the deposits of a currency, so that they are balanced journals
+type FundingAccount struct {
+	Currency  string `json:"currency"`
+	AccountID int64  `json:"accountID"`
+}
+
+type Hold struct {
+	ID          int64 `json:"id"`
+	AccountID   int64 `json:"accountID"`
+	ToAccountID int64 `json:"toAccountID"`
+	// must be positive
+	Amount         int64         `json:"amount"`
+	Currency       string        `json:"currency"`
+	Status         string        `json:"status"`
+	CapturedAmount int64         `json:"capturedAmount"`
+	TransferID     sql.NullInt64 `json:"transferID"`
+	ExpiresAt      time.Time     `json:"expiresAt"`
+	UpdatedAt      time.Time     `json:"updatedAt"`
+	CreatedAt      time.Time     `json:"createdAt"`
+	// fee quoted for the amount, held along with it until the hold is captured or released
+	ReservedFee int64 `json:"reservedFee"`
+}
+
+type InterestAccrual struct {
+	ID          int64     `json:"id"`
+	AccountID   int64     `json:"accountID"`
+	AccrualDate time.Time `json:"accrualDate"`
+	// end-of-day balance
+	Balance               int64 `json:"balance"`
+	AnnualInterestRateBps int32 `json:"annualInterestRateBps"`
+	// in millionths of the minor unit, rounded when posted
+	AmountMicros int64         `json:"amountMicros"`
+	PostingID    sql.NullInt64 `json:"postingID"`
+	CreatedAt    time.Time     `json:"createdAt"`
+}
+
+type InterestExpenseAccount struct {
+	Currency  string `json:"currency"`
+	AccountID int64  `json:"accountID"`
+}
+
+type InterestPosting struct {
+	ID             int64         `json:"id"`
+	AccountID      int64         `json:"accountID"`
+	PeriodStart    time.Time     `json:"periodStart"`
+	Amount         int64         `json:"amount"`
+	EntryID        sql.NullInt64 `json:"entryID"`
+	ExpenseEntryID sql.NullInt64 `json:"expenseEntryID"`
+	CreatedAt      time.Time     `json:"createdAt"`
+}
+
+type Journal struct {
+	ID          int64     `json:"id"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type Organization struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// transfers above it from the accounts of the organization need the approval of another member
+	ApprovalThreshold int64     `json:"approvalThreshold"`
+	CreatedBy         string    `json:"createdBy"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
+
+type OrganizationMember struct {
+	OrganizationID int64  `json:"organizationID"`
+	Username       string `json:"username"`
+	// admin, approver or initiator
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Outbox struct {
+	ID            int64           `json:"id"`
+	TaskType      string          `json:"taskType"`
+	Payload       json.RawMessage `json:"payload"`
+	Queue         string          `json:"queue"`
+	MaxRetry      int32           `json:"maxRetry"`
+	ProcessAt     time.Time       `json:"processAt"`
+	Attempts      int32           `json:"attempts"`
+	LastError     string          `json:"lastError"`
+	NextAttemptAt time.Time       `json:"nextAttemptAt"`
+	SentAt        sql.NullTime    `json:"sentAt"`
+	CreatedAt     time.Time       `json:"createdAt"`
+}
+
+type Payee struct {
+	ID        int64  `json:"id"`
+	Owner     string `json:"owner"`
+	Nickname  string `json:"nickname"`
+	AccountID int64  `json:"accountID"`
+	Currency  string `json:"currency"`
+	// set once a transfer to the payee succeeds, which lifts its cooldown
+	IsVerified bool      `json:"isVerified"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type ProductTransferLimit struct {
+	Product  string `json:"product"`
+	Currency string `json:"currency"`
+	// of a single transfer, unlimited when null
+	MaxAmount sql.NullInt64 `json:"maxAmount"`
+	// outgoing total per UTC day, unlimited when null
+	DailyAmount sql.NullInt64 `json:"dailyAmount"`
+	// outgoing total per UTC month, unlimited when null
+	MonthlyAmount sql.NullInt64 `json:"monthlyAmount"`
+	// outgoing transfers per UTC day, unlimited when null
+	DailyCount sql.NullInt32 `json:"dailyCount"`
+	CreatedAt  time.Time     `json:"createdAt"`
+}
+
+type ScheduledTransfer struct {
+	ID            int64        `json:"id"`
+	Owner         string       `json:"owner"`
+	FromAccountID int64        `json:"fromAccountID"`
+	ToAccountID   int64        `json:"toAccountID"`
+	Amount        int64        `json:"amount"`
+	Currency      string       `json:"currency"`
+	Recurrence    string       `json:"recurrence"`
+	NextRunAt     time.Time    `json:"nextRunAt"`
+	EndAt         sql.NullTime `json:"endAt"`
+	Status        string       `json:"status"`
+	CreatedAt     time.Time    `json:"createdAt"`
+}
+
+type ScheduledTransferRun struct {
+	ID                  int64         `json:"id"`
+	ScheduledTransferID int64         `json:"scheduledTransferID"`
+	TransferID          sql.NullInt64 `json:"transferID"`
+	Status              string        `json:"status"`
+	Error               string        `json:"error"`
+	ScheduledFor        time.Time     `json:"scheduledFor"`
+	CreatedAt           time.Time     `json:"createdAt"`
 }
 
 type Session struct {
@@ -46,6 +256,64 @@ type Transfer struct {
 	CreatedAt time.Time `json:"createdAt"`
 }
 
+type TransferApproval struct {
+	ID             int64          `json:"id"`
+	OrganizationID int64          `json:"organizationID"`
+	Initiator      string         `json:"initiator"`
+	FromAccountID  int64          `json:"fromAccountID"`
+	ToAccountID    int64          `json:"toAccountID"`
+	Amount         int64          `json:"amount"`
+	Currency       string         `json:"currency"`
+	Status         string         `json:"status"`
+	Approver       sql.NullString `json:"approver"`
+	TransferID     sql.NullInt64  `json:"transferID"`
+	DecidedAt      sql.NullTime   `json:"decidedAt"`
+	CreatedAt      time.Time      `json:"createdAt"`
+}
+
+type TransferBatch struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"fromAccountID"`
+	Currency      string `json:"currency"`
+	// atomic or best_effort
+	Mode           string    `json:"mode"`
+	Status         string    `json:"status"`
+	ItemCount      int32     `json:"itemCount"`
+	SucceededCount int32     `json:"succeededCount"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type TransferBatchItem struct {
+	ID       int64 `json:"id"`
+	BatchID  int64 `json:"batchID"`
+	Position int32 `json:"position"`
+	// not a foreign key, so that items to unknown accounts are recorded as failed
+	ToAccountID int64         `json:"toAccountID"`
+	Amount      int64         `json:"amount"`
+	Status      string        `json:"status"`
+	TransferID  sql.NullInt64 `json:"transferID"`
+	Error       string        `json:"error"`
+	CreatedAt   time.Time     `json:"createdAt"`
+}
+
+type TransferReview struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"fromAccountID"`
+	ToAccountID   int64  `json:"toAccountID"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	Status        string `json:"status"`
+	// sum of the scores of the risk rules that flagged the transfer
+	Score      int32          `json:"score"`
+	Reasons    []string       `json:"reasons"`
+	Reviewer   sql.NullString `json:"reviewer"`
+	TransferID sql.NullInt64  `json:"transferID"`
+	ReviewedAt sql.NullTime   `json:"reviewedAt"`
+	CreatedAt  time.Time      `json:"createdAt"`
+}
+
 type User struct {
 	Username          string    `json:"username"`
 	HashedPassword    string    `json:"hashedPassword"`
@@ -53,4 +321,34 @@ type User struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"passwordChangedAt"`
 	CreatedAt         time.Time `json:"createdAt"`
+	Role              string    `json:"role"`
+}
+
+type UserIdentity struct {
+	Issuer    string    `json:"issuer"`
+	Subject   string    `json:"subject"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type WebhookDelivery struct {
+	ID             int64     `json:"id"`
+	EndpointID     int64     `json:"endpointID"`
+	EventID        int64     `json:"eventID"`
+	Status         string    `json:"status"`
+	Attempts       int32     `json:"attempts"`
+	ResponseStatus int32     `json:"responseStatus"`
+	LastError      string    `json:"lastError"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type WebhookEndpoint struct {
+	ID        int64     `json:"id"`
+	Owner     string    `json:"owner"`
+	Url       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	IsActive  bool      `json:"isActive"`
+	CreatedAt time.Time `json:"createdAt"`
 }
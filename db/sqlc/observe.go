@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/MathPeixoto/go-financial-system/metrics"
+	"github.com/MathPeixoto/go-financial-system/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const queryNamePrefix = "-- name: "
+
+// observedDB traces the queries run through it and records their latency, named after their sqlc query name
+type observedDB struct {
+	DBTX
+}
+
+func (db observedDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	name := queryName(query)
+	ctx, span := startQuerySpan(ctx, name)
+	startTime := time.Now()
+
+	result, err := db.DBTX.ExecContext(ctx, query, args...)
+	metrics.ObserveQuery(name, time.Since(startTime), err)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (db observedDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	name := queryName(query)
+	ctx, span := startQuerySpan(ctx, name)
+	startTime := time.Now()
+
+	rows, err := db.DBTX.QueryContext(ctx, query, args...)
+	metrics.ObserveQuery(name, time.Since(startTime), err)
+	tracing.End(span, err)
+	return rows, err
+}
+
+func (db observedDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	name := queryName(query)
+	ctx, span := startQuerySpan(ctx, name)
+	startTime := time.Now()
+
+	row := db.DBTX.QueryRowContext(ctx, query, args...)
+	metrics.ObserveQuery(name, time.Since(startTime), row.Err())
+	tracing.End(span, row.Err())
+	return row
+}
+
+// observedTx is an observedDB running a transaction, whose queries are traced as children of the span of the transaction.
+// It runs its hooks once the transaction commits.
+type observedTx struct {
+	observedDB
+	span        trace.Span
+	afterCommit []func()
+}
+
+func (tx *observedTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return tx.observedDB.ExecContext(trace.ContextWithSpan(ctx, tx.span), query, args...)
+}
+
+func (tx *observedTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.observedDB.QueryContext(trace.ContextWithSpan(ctx, tx.span), query, args...)
+}
+
+func (tx *observedTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return tx.observedDB.QueryRowContext(trace.ContextWithSpan(ctx, tx.span), query, args...)
+}
+
+// afterCommit runs fn once the transaction of the queries commits, or right away when they don't run in a transaction
+func (q *Queries) afterCommit(fn func()) {
+	if tx, ok := q.db.(*observedTx); ok {
+		tx.afterCommit = append(tx.afterCommit, fn)
+		return
+	}
+	fn()
+}
+
+func startQuerySpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
+}
+
+// queryName returns the name of a query generated by sqlc from its leading comment
+func queryName(query string) string {
+	if !strings.HasPrefix(query, queryNamePrefix) {
+		return "unnamed"
+	}
+
+	fields := strings.Fields(strings.TrimPrefix(query, queryNamePrefix))
+	if len(fields) == 0 {
+		return "unnamed"
+	}
+	return fields[0]
+}
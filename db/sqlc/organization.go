@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Roles of the members of an organization
+const (
+	// OrganizationRoleAdmin manages the members and the accounts of an organization, and approves transfers
+	OrganizationRoleAdmin = "admin"
+	// OrganizationRoleApprover initiates transfers and approves the ones initiated by other members
+	OrganizationRoleApprover = "approver"
+	// OrganizationRoleInitiator initiates transfers, which need approval above the threshold of the organization
+	OrganizationRoleInitiator = "initiator"
+)
+
+// Statuses of a transfer waiting for the approval of another member of an organization
+const (
+	TransferApprovalPending  = "pending_approval"
+	TransferApprovalApproved = "approved"
+	TransferApprovalRejected = "rejected"
+)
+
+var (
+	// ErrNotOrganizationMember is returned when a user is not a member of an organization
+	ErrNotOrganizationMember = errors.New("user is not a member of the organization")
+	// ErrOrganizationPermission is returned when the role of a member doesn't allow an operation on its organization
+	ErrOrganizationPermission = errors.New("organization role doesn't allow the operation")
+	// ErrLastOrganizationAdmin is returned when removing the only admin of an organization
+	ErrLastOrganizationAdmin = errors.New("organization must keep at least one admin")
+	// ErrTransferApprovalNotPending is returned when approving or rejecting a transfer that was already decided
+	ErrTransferApprovalNotPending = errors.New("transfer approval is not pending")
+	// ErrSelfApproval is returned when the initiator of a transfer tries to approve it
+	ErrSelfApproval = errors.New("transfer must be approved by another member than its initiator")
+)
+
+// IsSupportedOrganizationRole checks if a role can be granted to a member of an organization
+func IsSupportedOrganizationRole(role string) bool {
+	switch role {
+	case OrganizationRoleAdmin, OrganizationRoleApprover, OrganizationRoleInitiator:
+		return true
+	}
+	return false
+}
+
+// CanApprove reports whether the role of the member allows approving the transfers of other members
+func (member OrganizationMember) CanApprove() bool {
+	return member.Role == OrganizationRoleAdmin || member.Role == OrganizationRoleApprover
+}
+
+// accountMember returns the membership that the member holds in an account of its organization.
+// Admins act as owners of the account and the other members as co-owners.
+func (member OrganizationMember) accountMember(account Account) AccountMember {
+	role := AccountRoleCoOwner
+	if member.Role == OrganizationRoleAdmin {
+		role = AccountRoleOwner
+	}
+
+	return AccountMember{
+		AccountID: account.ID,
+		Username:  member.Username,
+		Role:      role,
+		Status:    AccountMemberActive,
+		CreatedAt: member.CreatedAt,
+	}
+}
+
+// authorizeOrganizationAccount authorizes username on an account owned by an organization from its organization membership
+func authorizeOrganizationAccount(
+	ctx context.Context, q Querier, account Account, username, permission string,
+) (AccountMember, error) {
+	orgMember, err := q.GetOrganizationMember(ctx, GetOrganizationMemberParams{
+		OrganizationID: account.OrganizationID.Int64,
+		Username:       username,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return AccountMember{}, fmt.Errorf("account %d: %w", account.ID, ErrNotAccountMember)
+		}
+		return AccountMember{}, err
+	}
+
+	member := orgMember.accountMember(account)
+	if !member.Allows(permission, 0) {
+		return member, fmt.Errorf("%w: %s can't %s account %d", ErrAccountPermission, orgMember.Role, permission, account.ID)
+	}
+
+	return member, nil
+}
+
+// RequiresApproval reports whether a transfer of amount from account must be approved by another member
+// of the organization owning the account before it is executed
+func RequiresApproval(ctx context.Context, q Querier, account Account, amount int64) (bool, error) {
+	if !account.OrganizationID.Valid {
+		return false, nil
+	}
+
+	organization, err := q.GetOrganization(ctx, account.OrganizationID.Int64)
+	if err != nil {
+		return false, err
+	}
+
+	return amount > organization.ApprovalThreshold, nil
+}
+
+// AuthorizeOrganization returns the membership of username in an organization, checking that it has one of roles when given.
+// It returns an error wrapping ErrNotOrganizationMember or ErrOrganizationPermission when the user is not allowed.
+func AuthorizeOrganization(
+	ctx context.Context, q Querier, organizationID int64, username string, roles ...string,
+) (OrganizationMember, error) {
+	member, err := q.GetOrganizationMember(ctx, GetOrganizationMemberParams{OrganizationID: organizationID, Username: username})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return member, fmt.Errorf("organization %d: %w", organizationID, ErrNotOrganizationMember)
+		}
+		return member, err
+	}
+
+	if len(roles) == 0 {
+		return member, nil
+	}
+	for _, role := range roles {
+		if member.Role == role {
+			return member, nil
+		}
+	}
+
+	return member, fmt.Errorf("%w: %s of organization %d", ErrOrganizationPermission, member.Role, organizationID)
+}
@@ -0,0 +1,191 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: organization.sql
+
+package db
+
+import (
+	"context"
+)
+
+const countOrganizationAdmins = `-- name: CountOrganizationAdmins :one
+SELECT count(*)
+FROM organization_members
+WHERE organization_id = $1
+  AND role = 'admin'
+`
+
+func (q *Queries) CountOrganizationAdmins(ctx context.Context, organizationID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countOrganizationAdmins, organizationID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createOrganization = `-- name: CreateOrganization :one
+INSERT INTO organizations (name, approval_threshold, created_by)
+VALUES ($1, $2, $3)
+RETURNING id, name, approval_threshold, created_by, created_at
+`
+
+type CreateOrganizationParams struct {
+	Name              string `json:"name"`
+	ApprovalThreshold int64  `json:"approvalThreshold"`
+	CreatedBy         string `json:"createdBy"`
+}
+
+func (q *Queries) CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (Organization, error) {
+	row := q.db.QueryRowContext(ctx, createOrganization, arg.Name, arg.ApprovalThreshold, arg.CreatedBy)
+	var i Organization
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ApprovalThreshold,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createOrganizationMember = `-- name: CreateOrganizationMember :one
+INSERT INTO organization_members (organization_id, username, role)
+VALUES ($1, $2, $3)
+RETURNING organization_id, username, role, created_at
+`
+
+type CreateOrganizationMemberParams struct {
+	OrganizationID int64  `json:"organizationID"`
+	Username       string `json:"username"`
+	Role           string `json:"role"`
+}
+
+func (q *Queries) CreateOrganizationMember(ctx context.Context, arg CreateOrganizationMemberParams) (OrganizationMember, error) {
+	row := q.db.QueryRowContext(ctx, createOrganizationMember, arg.OrganizationID, arg.Username, arg.Role)
+	var i OrganizationMember
+	err := row.Scan(
+		&i.OrganizationID,
+		&i.Username,
+		&i.Role,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteOrganizationMember = `-- name: DeleteOrganizationMember :exec
+DELETE
+FROM organization_members
+WHERE organization_id = $1
+  AND username = $2
+`
+
+type DeleteOrganizationMemberParams struct {
+	OrganizationID int64  `json:"organizationID"`
+	Username       string `json:"username"`
+}
+
+func (q *Queries) DeleteOrganizationMember(ctx context.Context, arg DeleteOrganizationMemberParams) error {
+	_, err := q.db.ExecContext(ctx, deleteOrganizationMember, arg.OrganizationID, arg.Username)
+	return err
+}
+
+const getOrganization = `-- name: GetOrganization :one
+SELECT id, name, approval_threshold, created_by, created_at
+FROM organizations
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetOrganization(ctx context.Context, id int64) (Organization, error) {
+	row := q.db.QueryRowContext(ctx, getOrganization, id)
+	var i Organization
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ApprovalThreshold,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getOrganizationForUpdate = `-- name: GetOrganizationForUpdate :one
+SELECT id, name, approval_threshold, created_by, created_at
+FROM organizations
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetOrganizationForUpdate(ctx context.Context, id int64) (Organization, error) {
+	row := q.db.QueryRowContext(ctx, getOrganizationForUpdate, id)
+	var i Organization
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ApprovalThreshold,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getOrganizationMember = `-- name: GetOrganizationMember :one
+SELECT organization_id, username, role, created_at
+FROM organization_members
+WHERE organization_id = $1
+  AND username = $2
+LIMIT 1
+`
+
+type GetOrganizationMemberParams struct {
+	OrganizationID int64  `json:"organizationID"`
+	Username       string `json:"username"`
+}
+
+func (q *Queries) GetOrganizationMember(ctx context.Context, arg GetOrganizationMemberParams) (OrganizationMember, error) {
+	row := q.db.QueryRowContext(ctx, getOrganizationMember, arg.OrganizationID, arg.Username)
+	var i OrganizationMember
+	err := row.Scan(
+		&i.OrganizationID,
+		&i.Username,
+		&i.Role,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listOrganizationMembers = `-- name: ListOrganizationMembers :many
+SELECT organization_id, username, role, created_at
+FROM organization_members
+WHERE organization_id = $1
+ORDER BY created_at, username
+`
+
+func (q *Queries) ListOrganizationMembers(ctx context.Context, organizationID int64) ([]OrganizationMember, error) {
+	rows, err := q.db.QueryContext(ctx, listOrganizationMembers, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OrganizationMember{}
+	for rows.Next() {
+		var i OrganizationMember
+		if err := rows.Scan(
+			&i.OrganizationID,
+			&i.Username,
+			&i.Role,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
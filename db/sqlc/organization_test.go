@@ -0,0 +1,171 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationMember_accountMember(t *testing.T) {
+	account := Account{ID: 1}
+
+	admin := OrganizationMember{Role: OrganizationRoleAdmin}.accountMember(account)
+	require.True(t, admin.Allows(AccountPermissionManage, 0))
+
+	for _, role := range []string{OrganizationRoleApprover, OrganizationRoleInitiator} {
+		member := OrganizationMember{Role: role}.accountMember(account)
+		require.True(t, member.Allows(AccountPermissionSpend, 1_000))
+		require.False(t, member.Allows(AccountPermissionManage, 0))
+	}
+}
+
+func createRandomOrganization(t *testing.T, approvalThreshold int64) (Organization, OrganizationMember) {
+	store := NewStore(testDB)
+	creator := createRandomUser(t)
+
+	result, err := store.CreateOrganizationTx(context.Background(), CreateOrganizationTxParams{
+		CreateOrganizationParams: CreateOrganizationParams{
+			Name:              util.RandomString(10),
+			ApprovalThreshold: approvalThreshold,
+			CreatedBy:         creator.Username,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, approvalThreshold, result.Organization.ApprovalThreshold)
+	require.Equal(t, creator.Username, result.Admin.Username)
+	require.Equal(t, OrganizationRoleAdmin, result.Admin.Role)
+
+	return result.Organization, result.Admin
+}
+
+func addOrganizationMember(t *testing.T, organization Organization, role string) OrganizationMember {
+	user := createRandomUser(t)
+	result, err := NewStore(testDB).AddOrganizationMemberTx(context.Background(), AddOrganizationMemberTxParams{
+		CreateOrganizationMemberParams: CreateOrganizationMemberParams{
+			OrganizationID: organization.ID,
+			Username:       user.Username,
+			Role:           role,
+		},
+	})
+	require.NoError(t, err)
+	return result.Member
+}
+
+func createOrganizationAccount(t *testing.T, organization Organization, balance int64) Account {
+	result, err := NewStore(testDB).CreateAccountTx(context.Background(), CreateAccountTxParams{
+		CreateAccountParams: CreateAccountParams{
+			Owner:    organization.CreatedBy,
+			Balance:  balance,
+			Currency: util.BRL,
+			Product:  util.CheckingProduct,
+		},
+		OrganizationID: sql.NullInt64{Int64: organization.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, organization.ID, result.Account.OrganizationID.Int64)
+	return result.Account
+}
+
+func TestAuthorizeAccount_Organization(t *testing.T) {
+	organization, admin := createRandomOrganization(t, 100)
+	initiator := addOrganizationMember(t, organization, OrganizationRoleInitiator)
+	account := createOrganizationAccount(t, organization, 0)
+
+	member, err := AuthorizeAccount(context.Background(), testQueries, account, admin.Username, AccountPermissionManage, 0)
+	require.NoError(t, err)
+	require.Equal(t, AccountRoleOwner, member.Role)
+
+	_, err = AuthorizeAccount(context.Background(), testQueries, account, initiator.Username, AccountPermissionSpend, 1_000)
+	require.NoError(t, err)
+	_, err = AuthorizeAccount(context.Background(), testQueries, account, initiator.Username, AccountPermissionManage, 0)
+	require.ErrorIs(t, err, ErrAccountPermission)
+
+	outsider := createRandomUser(t)
+	_, err = AuthorizeAccount(context.Background(), testQueries, account, outsider.Username, AccountPermissionView, 0)
+	require.ErrorIs(t, err, ErrNotAccountMember)
+
+	required, err := RequiresApproval(context.Background(), testQueries, account, 100)
+	require.NoError(t, err)
+	require.False(t, required)
+	required, err = RequiresApproval(context.Background(), testQueries, account, 101)
+	require.NoError(t, err)
+	require.True(t, required)
+
+	accounts, err := testQueries.ListAccounts(context.Background(), ListAccountsParams{Owner: initiator.Username, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ID, accounts[0].ID)
+}
+
+func TestStore_DecideTransferApprovalTx(t *testing.T) {
+	store := NewStore(testDB)
+	organization, _ := createRandomOrganization(t, 100)
+	initiator := addOrganizationMember(t, organization, OrganizationRoleInitiator)
+	otherInitiator := addOrganizationMember(t, organization, OrganizationRoleInitiator)
+	approver := addOrganizationMember(t, organization, OrganizationRoleApprover)
+	from := createOrganizationAccount(t, organization, 1_000)
+	to := createAccountWithCurrency(t, 0, util.BRL)
+
+	created, err := store.CreateTransferApprovalTx(context.Background(), CreateTransferApprovalTxParams{
+		CreateTransferApprovalParams: CreateTransferApprovalParams{
+			OrganizationID: organization.ID,
+			Initiator:      initiator.Username,
+			FromAccountID:  from.ID,
+			ToAccountID:    to.ID,
+			Amount:         500,
+			Currency:       util.BRL,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferApprovalPending, created.TransferApproval.Status)
+
+	decide := func(username string, approve bool) (DecideTransferApprovalTxResult, error) {
+		return store.DecideTransferApprovalTx(context.Background(), DecideTransferApprovalTxParams{
+			ID:       created.TransferApproval.ID,
+			Approve:  approve,
+			Approver: username,
+		})
+	}
+
+	_, err = decide(initiator.Username, true)
+	require.ErrorIs(t, err, ErrSelfApproval)
+
+	_, err = decide(otherInitiator.Username, true)
+	require.ErrorIs(t, err, ErrOrganizationPermission)
+
+	_, err = decide(createRandomUser(t).Username, true)
+	require.ErrorIs(t, err, ErrNotOrganizationMember)
+
+	result, err := decide(approver.Username, true)
+	require.NoError(t, err)
+	require.Equal(t, TransferApprovalApproved, result.TransferApproval.Status)
+	require.Equal(t, approver.Username, result.TransferApproval.Approver.String)
+	require.Equal(t, result.Transfer.Transfer.ID, result.TransferApproval.TransferID.Int64)
+	require.True(t, result.TransferApproval.DecidedAt.Valid)
+	require.Equal(t, int64(500), result.Transfer.ToAccount.Balance)
+
+	_, err = decide(approver.Username, false)
+	require.ErrorIs(t, err, ErrTransferApprovalNotPending)
+}
+
+func TestStore_RemoveOrganizationMemberTxLastAdmin(t *testing.T) {
+	store := NewStore(testDB)
+	organization, admin := createRandomOrganization(t, 0)
+
+	_, err := store.RemoveOrganizationMemberTx(context.Background(), RemoveOrganizationMemberTxParams{
+		OrganizationID: organization.ID,
+		Username:       admin.Username,
+	})
+	require.ErrorIs(t, err, ErrLastOrganizationAdmin)
+
+	addOrganizationMember(t, organization, OrganizationRoleAdmin)
+	result, err := store.RemoveOrganizationMemberTx(context.Background(), RemoveOrganizationMemberTxParams{
+		OrganizationID: organization.ID,
+		Username:       admin.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, admin, result.Member)
+}
@@ -0,0 +1,125 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: outbox.sql
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const createOutboxMessage = `-- name: CreateOutboxMessage :one
+INSERT INTO outbox (task_type, payload, queue, max_retry, process_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, task_type, payload, queue, max_retry, process_at, attempts, last_error, next_attempt_at, sent_at, created_at
+`
+
+type CreateOutboxMessageParams struct {
+	TaskType  string          `json:"taskType"`
+	Payload   json.RawMessage `json:"payload"`
+	Queue     string          `json:"queue"`
+	MaxRetry  int32           `json:"maxRetry"`
+	ProcessAt time.Time       `json:"processAt"`
+}
+
+func (q *Queries) CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) (Outbox, error) {
+	row := q.db.QueryRowContext(ctx, createOutboxMessage,
+		arg.TaskType,
+		arg.Payload,
+		arg.Queue,
+		arg.MaxRetry,
+		arg.ProcessAt,
+	)
+	var i Outbox
+	err := row.Scan(
+		&i.ID,
+		&i.TaskType,
+		&i.Payload,
+		&i.Queue,
+		&i.MaxRetry,
+		&i.ProcessAt,
+		&i.Attempts,
+		&i.LastError,
+		&i.NextAttemptAt,
+		&i.SentAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listPendingOutboxMessages = `-- name: ListPendingOutboxMessages :many
+SELECT id, task_type, payload, queue, max_retry, process_at, attempts, last_error, next_attempt_at, sent_at, created_at
+FROM outbox
+WHERE sent_at IS NULL
+  AND next_attempt_at <= now()
+ORDER BY id
+LIMIT $1 FOR UPDATE SKIP LOCKED
+`
+
+func (q *Queries) ListPendingOutboxMessages(ctx context.Context, limit int32) ([]Outbox, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingOutboxMessages, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Outbox{}
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskType,
+			&i.Payload,
+			&i.Queue,
+			&i.MaxRetry,
+			&i.ProcessAt,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.SentAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOutboxMessageFailed = `-- name: MarkOutboxMessageFailed :exec
+UPDATE outbox
+SET attempts        = attempts + 1,
+    last_error      = $2,
+    next_attempt_at = $3
+WHERE id = $1
+`
+
+type MarkOutboxMessageFailedParams struct {
+	ID            int64     `json:"id"`
+	LastError     string    `json:"lastError"`
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+}
+
+func (q *Queries) MarkOutboxMessageFailed(ctx context.Context, arg MarkOutboxMessageFailedParams) error {
+	_, err := q.db.ExecContext(ctx, markOutboxMessageFailed, arg.ID, arg.LastError, arg.NextAttemptAt)
+	return err
+}
+
+const markOutboxMessageSent = `-- name: MarkOutboxMessageSent :exec
+UPDATE outbox
+SET sent_at = now()
+WHERE id = $1
+`
+
+func (q *Queries) MarkOutboxMessageSent(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, markOutboxMessageSent, id)
+	return err
+}
@@ -0,0 +1,138 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createRandomOutboxMessage(t *testing.T) Outbox {
+	arg := CreateOutboxMessageParams{
+		TaskType:  "task:" + util.RandomString(8),
+		Payload:   json.RawMessage(fmt.Sprintf(`{"username": %q}`, util.RandomOwner())),
+		Queue:     "default",
+		MaxRetry:  3,
+		ProcessAt: time.Now(),
+	}
+	message, err := testQueries.CreateOutboxMessage(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.NotZero(t, message.ID)
+	require.Equal(t, arg.TaskType, message.TaskType)
+	require.JSONEq(t, string(arg.Payload), string(message.Payload))
+	require.Equal(t, arg.Queue, message.Queue)
+	require.Equal(t, arg.MaxRetry, message.MaxRetry)
+	require.WithinDuration(t, arg.ProcessAt, message.ProcessAt, time.Second)
+	require.Zero(t, message.Attempts)
+	require.False(t, message.SentAt.Valid)
+
+	return message
+}
+
+func TestStore_CreateUserTxWritesOutbox(t *testing.T) {
+	store := NewStore(testDB)
+	taskType := "task:" + util.RandomString(8)
+
+	result, err := store.CreateUserTx(context.Background(), CreateUserTxParams{
+		CreateUserParams: CreateUserParams{
+			Username:       util.RandomOwner(),
+			HashedPassword: util.RandomString(10),
+			FullName:       util.RandomOwner(),
+			Email:          util.RandomEmail(),
+		},
+		AfterCreate: func(user *User) ([]CreateOutboxMessageParams, error) {
+			return []CreateOutboxMessageParams{{
+				TaskType:  taskType,
+				Payload:   json.RawMessage(fmt.Sprintf(`{"username": %q}`, user.Username)),
+				Queue:     "default",
+				MaxRetry:  3,
+				ProcessAt: time.Now(),
+			}}, nil
+		},
+	})
+	require.NoError(t, err)
+
+	var published []Outbox
+	_, err = store.RelayOutboxTx(context.Background(), RelayOutboxTxParams{
+		Limit: 1000,
+		Publish: func(message Outbox) error {
+			if message.TaskType == taskType {
+				published = append(published, message)
+			}
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, published, 1)
+	require.JSONEq(t, fmt.Sprintf(`{"username": %q}`, result.User.Username), string(published[0].Payload))
+}
+
+func TestStore_CreateUserTxRollsBackOutbox(t *testing.T) {
+	store := NewStore(testDB)
+	existingUser := createRandomUser(t)
+	taskType := "task:" + util.RandomString(8)
+
+	_, err := store.CreateUserTx(context.Background(), CreateUserTxParams{
+		CreateUserParams: CreateUserParams{
+			Username:       existingUser.Username,
+			HashedPassword: util.RandomString(10),
+			FullName:       util.RandomOwner(),
+			Email:          util.RandomEmail(),
+		},
+		AfterCreate: func(user *User) ([]CreateOutboxMessageParams, error) {
+			return []CreateOutboxMessageParams{{TaskType: taskType, Payload: json.RawMessage(`{}`)}}, nil
+		},
+	})
+	require.Error(t, err)
+
+	_, err = store.RelayOutboxTx(context.Background(), RelayOutboxTxParams{
+		Limit: 1000,
+		Publish: func(message Outbox) error {
+			require.NotEqual(t, taskType, message.TaskType)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestStore_RelayOutboxTx(t *testing.T) {
+	store := NewStore(testDB)
+	failing := createRandomOutboxMessage(t)
+	succeeding := createRandomOutboxMessage(t)
+
+	publishAll := func(publish func(message Outbox) error) {
+		_, err := store.RelayOutboxTx(context.Background(), RelayOutboxTxParams{Limit: 1000, Publish: publish})
+		require.NoError(t, err)
+	}
+
+	attempts := map[int64]int{}
+	publishAll(func(message Outbox) error {
+		attempts[message.ID]++
+		if message.ID == failing.ID {
+			return fmt.Errorf("redis is down")
+		}
+		return nil
+	})
+	require.Equal(t, 1, attempts[failing.ID])
+	require.Equal(t, 1, attempts[succeeding.ID])
+
+	// neither the sent message nor the failed one, which backs off, is published again right away
+	publishAll(func(message Outbox) error {
+		attempts[message.ID]++
+		return nil
+	})
+	require.Equal(t, 1, attempts[failing.ID])
+	require.Equal(t, 1, attempts[succeeding.ID])
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	require.Equal(t, time.Second, outboxBackoff(0))
+	require.Equal(t, 2*time.Second, outboxBackoff(1))
+	require.Equal(t, 8*time.Second, outboxBackoff(3))
+	require.Equal(t, outboxMaxBackoff, outboxBackoff(20))
+	require.Equal(t, outboxMaxBackoff, outboxBackoff(1000))
+}
@@ -0,0 +1,154 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPayee(t *testing.T, owner string, account Account) Payee {
+	arg := CreatePayeeParams{
+		Owner:     owner,
+		Nickname:  util.RandomString(8),
+		AccountID: account.ID,
+		Currency:  account.Currency,
+	}
+	payee, err := testQueries.CreatePayee(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.NotZero(t, payee.ID)
+	require.Equal(t, arg.Owner, payee.Owner)
+	require.Equal(t, arg.Nickname, payee.Nickname)
+	require.Equal(t, arg.AccountID, payee.AccountID)
+	require.Equal(t, arg.Currency, payee.Currency)
+	require.False(t, payee.IsVerified)
+	require.NotZero(t, payee.CreatedAt)
+
+	return payee
+}
+
+func TestQueries_CreatePayeeDuplicate(t *testing.T) {
+	from := createRandomAccount(t)
+	to := createRandomAccount(t)
+	payee := createRandomPayee(t, from.Owner, to)
+
+	_, err := testQueries.CreatePayee(context.Background(), CreatePayeeParams{
+		Owner:     from.Owner,
+		Nickname:  util.RandomString(8),
+		AccountID: to.ID,
+		Currency:  to.Currency,
+	})
+	require.Error(t, err)
+
+	_, err = testQueries.CreatePayee(context.Background(), CreatePayeeParams{
+		Owner:     from.Owner,
+		Nickname:  payee.Nickname,
+		AccountID: createRandomAccount(t).ID,
+		Currency:  to.Currency,
+	})
+	require.Error(t, err)
+}
+
+func TestQueries_ListPayees(t *testing.T) {
+	owner := createRandomUser(t)
+	for i := 0; i < 3; i++ {
+		createRandomPayee(t, owner.Username, createRandomAccount(t))
+	}
+	createRandomPayee(t, createRandomUser(t).Username, createRandomAccount(t))
+
+	payees, err := testQueries.ListPayees(context.Background(), ListPayeesParams{
+		Owner: owner.Username,
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, payees, 3)
+	for i, payee := range payees {
+		require.Equal(t, owner.Username, payee.Owner)
+		if i > 0 {
+			require.LessOrEqual(t, payees[i-1].Nickname, payee.Nickname)
+		}
+	}
+}
+
+func TestQueries_UpdateAndDeletePayee(t *testing.T) {
+	owner := createRandomUser(t)
+	payee := createRandomPayee(t, owner.Username, createRandomAccount(t))
+
+	updated, err := testQueries.UpdatePayee(context.Background(), UpdatePayeeParams{
+		ID:       payee.ID,
+		Nickname: "landlord",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "landlord", updated.Nickname)
+	require.Equal(t, payee.AccountID, updated.AccountID)
+
+	require.NoError(t, testQueries.DeletePayee(context.Background(), payee.ID))
+
+	_, err = testQueries.GetPayee(context.Background(), payee.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestStore_PayeeTxAudit(t *testing.T) {
+	store := NewStore(testDB)
+	owner := createRandomUser(t)
+	account := createRandomAccount(t)
+	audit := AuditParams{Actor: owner.Username}
+
+	created, err := store.CreatePayeeTx(context.Background(), CreatePayeeTxParams{
+		CreatePayeeParams: CreatePayeeParams{
+			Owner:     owner.Username,
+			Nickname:  util.RandomOwner(),
+			AccountID: account.ID,
+			Currency:  account.Currency,
+		},
+		Audit: audit,
+	})
+	require.NoError(t, err)
+
+	updated, err := store.UpdatePayeeTx(context.Background(), UpdatePayeeTxParams{
+		UpdatePayeeParams: UpdatePayeeParams{ID: created.Payee.ID, Nickname: "landlord"},
+		Audit:             audit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "landlord", updated.Payee.Nickname)
+
+	deleted, err := store.DeletePayeeTx(context.Background(), DeletePayeeTxParams{PayeeID: created.Payee.ID, Audit: audit})
+	require.NoError(t, err)
+	require.Equal(t, "landlord", deleted.Payee.Nickname)
+
+	events, err := store.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		TargetType: sql.NullString{String: AuditTargetPayee, Valid: true},
+		TargetID:   sql.NullString{String: auditID(created.Payee.ID), Valid: true},
+		Limit:      5,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	actions := make([]string, len(events))
+	for i, event := range events {
+		require.Equal(t, owner.Username, event.Actor)
+		actions[i] = event.Action
+	}
+	require.ElementsMatch(t, []string{AuditActionCreatePayee, AuditActionUpdatePayee, AuditActionDeletePayee}, actions)
+}
+
+func TestStore_TransferTxVerifiesPayee(t *testing.T) {
+	store := NewStore(testDB)
+	from := createAccountWithCurrency(t, 1_000, util.BRL)
+	to := createAccountWithCurrency(t, 0, util.BRL)
+	payee := createRandomPayee(t, from.Owner, to)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        10,
+		PayeeID:       payee.ID,
+	})
+	require.NoError(t, err)
+
+	payee, err = testQueries.GetPayee(context.Background(), payee.ID)
+	require.NoError(t, err)
+	require.True(t, payee.IsVerified)
+}
@@ -6,34 +6,146 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AcceptAccountMember(ctx context.Context, arg AcceptAccountMemberParams) (AccountMember, error)
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	AddAccountHeldBalance(ctx context.Context, arg AddAccountHeldBalanceParams) (Account, error)
+	CountOrganizationAdmins(ctx context.Context, organizationID int64) (int64, error)
+	CountOutgoingTransfersSince(ctx context.Context, arg CountOutgoingTransfersSinceParams) (int64, error)
+	CountSessionsBefore(ctx context.Context, arg CountSessionsBeforeParams) (int64, error)
+	CountSessionsFromIP(ctx context.Context, arg CountSessionsFromIPParams) (int64, error)
+	CountTransfersBetween(ctx context.Context, arg CountTransfersBetweenParams) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAccountMember(ctx context.Context, arg CreateAccountMemberParams) (AccountMember, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error)
+	CreateDomainEvent(ctx context.Context, arg CreateDomainEventParams) (DomainEvent, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error)
+	CreateHold(ctx context.Context, arg CreateHoldParams) (Hold, error)
+	CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (InterestAccrual, error)
+	CreateInterestPosting(ctx context.Context, arg CreateInterestPostingParams) (InterestPosting, error)
+	CreateJournal(ctx context.Context, description string) (Journal, error)
+	CreateJournalEntry(ctx context.Context, arg CreateJournalEntryParams) (Entry, error)
+	CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (Organization, error)
+	CreateOrganizationMember(ctx context.Context, arg CreateOrganizationMemberParams) (OrganizationMember, error)
+	CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) (Outbox, error)
+	CreatePayee(ctx context.Context, arg CreatePayeeParams) (Payee, error)
+	CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error)
+	CreateScheduledTransferRun(ctx context.Context, arg CreateScheduledTransferRunParams) (ScheduledTransferRun, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (TransferApproval, error)
+	CreateTransferBatch(ctx context.Context, arg CreateTransferBatchParams) (TransferBatch, error)
+	CreateTransferBatchItem(ctx context.Context, arg CreateTransferBatchItemParams) (TransferBatchItem, error)
+	CreateTransferReview(ctx context.Context, arg CreateTransferReviewParams) (TransferReview, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) (UserIdentity, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	CreateWebhookEndpoint(ctx context.Context, arg CreateWebhookEndpointParams) (WebhookEndpoint, error)
+	DeactivateWebhookEndpoint(ctx context.Context, id int64) (WebhookEndpoint, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteAccountMember(ctx context.Context, arg DeleteAccountMemberParams) error
+	DeleteAccountTransferLimit(ctx context.Context, accountID int64) error
 	DeleteEntry(ctx context.Context, id int64) error
+	DeleteFeeSchedule(ctx context.Context, id int64) error
+	DeleteFundingAccount(ctx context.Context, currency string) error
+	DeleteInterestExpenseAccount(ctx context.Context, currency string) error
+	DeleteOrganizationMember(ctx context.Context, arg DeleteOrganizationMemberParams) error
+	DeletePayee(ctx context.Context, id int64) error
+	DeleteProductTransferLimit(ctx context.Context, arg DeleteProductTransferLimitParams) error
 	DeleteTransfer(ctx context.Context, id int64) error
 	GetAccount(ctx context.Context, id int64) (Account, error)
 	GetAccountByOwner(ctx context.Context, owner string) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetAccountMember(ctx context.Context, arg GetAccountMemberParams) (AccountMember, error)
+	GetAccountProduct(ctx context.Context, code string) (AccountProduct, error)
+	GetAccountTransferLimit(ctx context.Context, accountID int64) (AccountTransferLimit, error)
+	GetDomainEvent(ctx context.Context, id int64) (DomainEvent, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetFeeSchedule(ctx context.Context, arg GetFeeScheduleParams) (FeeSchedule, error)
+	GetFirstUnaccruedDay(ctx context.Context) (time.Time, error)
+	GetFundingAccount(ctx context.Context, currency string) (FundingAccount, error)
+	GetHold(ctx context.Context, id int64) (Hold, error)
+	GetHoldForUpdate(ctx context.Context, id int64) (Hold, error)
+	GetInterestExpenseAccount(ctx context.Context, currency string) (InterestExpenseAccount, error)
+	GetJournal(ctx context.Context, id int64) (Journal, error)
+	GetLatestSession(ctx context.Context, username string) (Session, error)
+	GetOrganization(ctx context.Context, id int64) (Organization, error)
+	GetOrganizationForUpdate(ctx context.Context, id int64) (Organization, error)
+	GetOrganizationMember(ctx context.Context, arg GetOrganizationMemberParams) (OrganizationMember, error)
+	GetOutgoingTransferTotals(ctx context.Context, arg GetOutgoingTransferTotalsParams) (GetOutgoingTransferTotalsRow, error)
+	GetPayee(ctx context.Context, id int64) (Payee, error)
+	GetPayeeByAccount(ctx context.Context, arg GetPayeeByAccountParams) (Payee, error)
+	GetPayeeForUpdate(ctx context.Context, id int64) (Payee, error)
+	GetProductTransferLimit(ctx context.Context, arg GetProductTransferLimitParams) (ProductTransferLimit, error)
+	GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
+	GetScheduledTransferForUpdate(ctx context.Context, id int64) (ScheduledTransfer, error)
+	GetScheduledTransferRun(ctx context.Context, id int64) (ScheduledTransferRun, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferApproval(ctx context.Context, id int64) (TransferApproval, error)
+	GetTransferApprovalForUpdate(ctx context.Context, id int64) (TransferApproval, error)
+	GetTransferBatch(ctx context.Context, id int64) (TransferBatch, error)
+	GetTransferReview(ctx context.Context, id int64) (TransferReview, error)
+	GetTransferReviewForUpdate(ctx context.Context, id int64) (TransferReview, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetUserForUpdate(ctx context.Context, username string) (User, error)
+	GetUserIdentity(ctx context.Context, arg GetUserIdentityParams) (UserIdentity, error)
+	GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error)
+	GetWebhookEndpoint(ctx context.Context, id int64) (WebhookEndpoint, error)
+	GetWebhookEndpointForUpdate(ctx context.Context, id int64) (WebhookEndpoint, error)
+	ListAccountMembers(ctx context.Context, accountID int64) ([]AccountMember, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListAccountsWithUnpostedInterest(ctx context.Context, arg ListAccountsWithUnpostedInterestParams) ([]int64, error)
+	ListActiveWebhookEndpoints(ctx context.Context, owner string) ([]WebhookEndpoint, error)
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	ListDueScheduledTransfers(ctx context.Context, arg ListDueScheduledTransfersParams) ([]ScheduledTransfer, error)
+	ListEndOfDayBalances(ctx context.Context, arg ListEndOfDayBalancesParams) ([]ListEndOfDayBalancesRow, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListExpiredHolds(ctx context.Context, arg ListExpiredHoldsParams) ([]Hold, error)
+	ListHolds(ctx context.Context, arg ListHoldsParams) ([]Hold, error)
+	ListInterestAccruals(ctx context.Context, arg ListInterestAccrualsParams) ([]InterestAccrual, error)
+	ListInterestPostings(ctx context.Context, arg ListInterestPostingsParams) ([]InterestPosting, error)
+	ListJournalEntries(ctx context.Context, journalID sql.NullInt64) ([]Entry, error)
+	ListOrganizationMembers(ctx context.Context, organizationID int64) ([]OrganizationMember, error)
+	ListPayees(ctx context.Context, arg ListPayeesParams) ([]Payee, error)
+	ListPendingOutboxMessages(ctx context.Context, limit int32) ([]Outbox, error)
+	ListScheduledTransferRuns(ctx context.Context, arg ListScheduledTransferRunsParams) ([]ScheduledTransferRun, error)
+	ListScheduledTransfers(ctx context.Context, arg ListScheduledTransfersParams) ([]ScheduledTransfer, error)
+	ListTransferApprovals(ctx context.Context, arg ListTransferApprovalsParams) ([]TransferApproval, error)
+	ListTransferBatchItems(ctx context.Context, batchID int64) ([]TransferBatchItem, error)
+	ListTransferReviews(ctx context.Context, arg ListTransferReviewsParams) ([]TransferReview, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	MarkInterestAccrualsPosted(ctx context.Context, arg MarkInterestAccrualsPostedParams) ([]int64, error)
+	MarkOutboxMessageFailed(ctx context.Context, arg MarkOutboxMessageFailedParams) error
+	MarkOutboxMessageSent(ctx context.Context, id int64) error
+	SetAccountOrganization(ctx context.Context, arg SetAccountOrganizationParams) (Account, error)
+	SetAccountTransferLimit(ctx context.Context, arg SetAccountTransferLimitParams) (AccountTransferLimit, error)
+	SetFundingAccount(ctx context.Context, arg SetFundingAccountParams) (FundingAccount, error)
+	SetInterestExpenseAccount(ctx context.Context, arg SetInterestExpenseAccountParams) (InterestExpenseAccount, error)
+	SetProductTransferLimit(ctx context.Context, arg SetProductTransferLimitParams) (ProductTransferLimit, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
 	UpdateEntry(ctx context.Context, arg UpdateEntryParams) (Entry, error)
+	UpdateHold(ctx context.Context, arg UpdateHoldParams) (Hold, error)
+	UpdateInterestPosting(ctx context.Context, arg UpdateInterestPostingParams) (InterestPosting, error)
+	UpdatePayee(ctx context.Context, arg UpdatePayeeParams) (Payee, error)
+	UpdateScheduledTransferSchedule(ctx context.Context, arg UpdateScheduledTransferScheduleParams) (ScheduledTransfer, error)
 	UpdateTransfer(ctx context.Context, arg UpdateTransferParams) (Transfer, error)
+	UpdateTransferApproval(ctx context.Context, arg UpdateTransferApprovalParams) (TransferApproval, error)
+	UpdateTransferBatch(ctx context.Context, arg UpdateTransferBatchParams) (TransferBatch, error)
+	UpdateTransferReview(ctx context.Context, arg UpdateTransferReviewParams) (TransferReview, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateWebhookDeliveryAttempt(ctx context.Context, arg UpdateWebhookDeliveryAttemptParams) (WebhookDelivery, error)
+	VerifyPayee(ctx context.Context, id int64) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,113 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: risk.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const countOutgoingTransfersSince = `-- name: CountOutgoingTransfersSince :one
+SELECT COUNT(*)
+FROM transfers
+WHERE from_account_id = $1
+  AND created_at >= $2
+`
+
+type CountOutgoingTransfersSinceParams struct {
+	FromAccountID int64     `json:"fromAccountID"`
+	Since         time.Time `json:"since"`
+}
+
+func (q *Queries) CountOutgoingTransfersSince(ctx context.Context, arg CountOutgoingTransfersSinceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countOutgoingTransfersSince, arg.FromAccountID, arg.Since)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countSessionsBefore = `-- name: CountSessionsBefore :one
+SELECT COUNT(*)
+FROM sessions
+WHERE username = $1
+  AND created_at < $2
+`
+
+type CountSessionsBeforeParams struct {
+	Username string    `json:"username"`
+	Before   time.Time `json:"before"`
+}
+
+func (q *Queries) CountSessionsBefore(ctx context.Context, arg CountSessionsBeforeParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSessionsBefore, arg.Username, arg.Before)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countSessionsFromIP = `-- name: CountSessionsFromIP :one
+SELECT COUNT(*)
+FROM sessions
+WHERE username = $1
+  AND client_ip = $2
+  AND created_at < $3
+`
+
+type CountSessionsFromIPParams struct {
+	Username string    `json:"username"`
+	ClientIp string    `json:"clientIp"`
+	Before   time.Time `json:"before"`
+}
+
+func (q *Queries) CountSessionsFromIP(ctx context.Context, arg CountSessionsFromIPParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSessionsFromIP, arg.Username, arg.ClientIp, arg.Before)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTransfersBetween = `-- name: CountTransfersBetween :one
+SELECT COUNT(*)
+FROM transfers
+WHERE from_account_id = $1
+  AND to_account_id = $2
+`
+
+type CountTransfersBetweenParams struct {
+	FromAccountID int64 `json:"fromAccountID"`
+	ToAccountID   int64 `json:"toAccountID"`
+}
+
+func (q *Queries) CountTransfersBetween(ctx context.Context, arg CountTransfersBetweenParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countTransfersBetween, arg.FromAccountID, arg.ToAccountID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getLatestSession = `-- name: GetLatestSession :one
+SELECT id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at
+FROM sessions
+WHERE username = $1
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestSession(ctx context.Context, username string) (Session, error) {
+	row := q.db.QueryRowContext(ctx, getLatestSession, username)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.RefreshToken,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.IsBlocked,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
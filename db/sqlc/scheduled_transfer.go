@@ -0,0 +1,81 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+)
+
+// Statuses of a scheduled transfer
+const (
+	ScheduledTransferActive    = "active"
+	ScheduledTransferPaused    = "paused"
+	ScheduledTransferCancelled = "cancelled"
+	ScheduledTransferCompleted = "completed"
+	ScheduledTransferFailed    = "failed"
+)
+
+// Statuses of a scheduled transfer run
+const (
+	ScheduledTransferRunSucceeded = "succeeded"
+	ScheduledTransferRunFailed    = "failed"
+)
+
+// EventScheduledTransferFailed is emitted when a scheduled transfer could not be executed
+const EventScheduledTransferFailed = "scheduled_transfer.failed"
+
+// TaskSendScheduledTransferFailed is the task that notifies the owner of a failed scheduled transfer run.
+// It is declared here because the store writes it to the outbox along with the run.
+const TaskSendScheduledTransferFailed = "task:send_scheduled_transfer_failed"
+
+const (
+	scheduledTransferNotificationQueue    = "critical"
+	scheduledTransferNotificationMaxRetry = 10
+)
+
+// PayloadSendScheduledTransferFailed is the payload of TaskSendScheduledTransferFailed
+type PayloadSendScheduledTransferFailed struct {
+	RunID int64 `json:"run_id"`
+}
+
+// checkScheduledTransfer verifies that the accounts of a scheduled transfer can still execute it, including its fee
+func checkScheduledTransfer(scheduled ScheduledTransfer, from, to Account, fee int64) error {
+	for _, account := range []Account{from, to} {
+		if account.Currency != scheduled.Currency {
+			return fmt.Errorf("account %d has currency %s, but transfer currency is %s",
+				account.ID, account.Currency, scheduled.Currency)
+		}
+		if account.Status == util.FrozenAccountStatus {
+			return fmt.Errorf("account %d is frozen", account.ID)
+		}
+	}
+
+	if from.AvailableBalance < scheduled.Amount+fee {
+		return ErrInsufficientFunds
+	}
+
+	return nil
+}
+
+// nextSchedule returns when a scheduled transfer runs next after the run due at scheduled.NextRunAt,
+// and its status. Runs missed while the worker was down are skipped rather than executed in a burst.
+func nextSchedule(scheduled ScheduledTransfer, succeeded bool, now time.Time) (time.Time, string) {
+	if scheduled.Recurrence == util.OnceRecurrence {
+		if succeeded {
+			return scheduled.NextRunAt, ScheduledTransferCompleted
+		}
+		return scheduled.NextRunAt, ScheduledTransferFailed
+	}
+
+	next := util.NextOccurrence(scheduled.Recurrence, scheduled.NextRunAt)
+	for !next.After(now) {
+		next = util.NextOccurrence(scheduled.Recurrence, next)
+	}
+
+	if scheduled.EndAt.Valid && next.After(scheduled.EndAt.Time) {
+		return next, ScheduledTransferCompleted
+	}
+
+	return next, ScheduledTransferActive
+}
@@ -0,0 +1,343 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: scheduled_transfer.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createScheduledTransfer = `-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (owner, from_account_id, to_account_id, amount, currency, recurrence, next_run_at, end_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, owner, from_account_id, to_account_id, amount, currency, recurrence, next_run_at, end_at, status, created_at
+`
+
+type CreateScheduledTransferParams struct {
+	Owner         string       `json:"owner"`
+	FromAccountID int64        `json:"fromAccountID"`
+	ToAccountID   int64        `json:"toAccountID"`
+	Amount        int64        `json:"amount"`
+	Currency      string       `json:"currency"`
+	Recurrence    string       `json:"recurrence"`
+	NextRunAt     time.Time    `json:"nextRunAt"`
+	EndAt         sql.NullTime `json:"endAt"`
+}
+
+func (q *Queries) CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error) {
+	row := q.db.QueryRowContext(ctx, createScheduledTransfer,
+		arg.Owner,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Currency,
+		arg.Recurrence,
+		arg.NextRunAt,
+		arg.EndAt,
+	)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Recurrence,
+		&i.NextRunAt,
+		&i.EndAt,
+		&i.Status,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createScheduledTransferRun = `-- name: CreateScheduledTransferRun :one
+INSERT INTO scheduled_transfer_runs (scheduled_transfer_id, transfer_id, status, error, scheduled_for)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, scheduled_transfer_id, transfer_id, status, error, scheduled_for, created_at
+`
+
+type CreateScheduledTransferRunParams struct {
+	ScheduledTransferID int64         `json:"scheduledTransferID"`
+	TransferID          sql.NullInt64 `json:"transferID"`
+	Status              string        `json:"status"`
+	Error               string        `json:"error"`
+	ScheduledFor        time.Time     `json:"scheduledFor"`
+}
+
+func (q *Queries) CreateScheduledTransferRun(ctx context.Context, arg CreateScheduledTransferRunParams) (ScheduledTransferRun, error) {
+	row := q.db.QueryRowContext(ctx, createScheduledTransferRun,
+		arg.ScheduledTransferID,
+		arg.TransferID,
+		arg.Status,
+		arg.Error,
+		arg.ScheduledFor,
+	)
+	var i ScheduledTransferRun
+	err := row.Scan(
+		&i.ID,
+		&i.ScheduledTransferID,
+		&i.TransferID,
+		&i.Status,
+		&i.Error,
+		&i.ScheduledFor,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getScheduledTransfer = `-- name: GetScheduledTransfer :one
+SELECT id, owner, from_account_id, to_account_id, amount, currency, recurrence, next_run_at, end_at, status, created_at
+FROM scheduled_transfers
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error) {
+	row := q.db.QueryRowContext(ctx, getScheduledTransfer, id)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Recurrence,
+		&i.NextRunAt,
+		&i.EndAt,
+		&i.Status,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getScheduledTransferForUpdate = `-- name: GetScheduledTransferForUpdate :one
+SELECT id, owner, from_account_id, to_account_id, amount, currency, recurrence, next_run_at, end_at, status, created_at
+FROM scheduled_transfers
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetScheduledTransferForUpdate(ctx context.Context, id int64) (ScheduledTransfer, error) {
+	row := q.db.QueryRowContext(ctx, getScheduledTransferForUpdate, id)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Recurrence,
+		&i.NextRunAt,
+		&i.EndAt,
+		&i.Status,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getScheduledTransferRun = `-- name: GetScheduledTransferRun :one
+SELECT id, scheduled_transfer_id, transfer_id, status, error, scheduled_for, created_at
+FROM scheduled_transfer_runs
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetScheduledTransferRun(ctx context.Context, id int64) (ScheduledTransferRun, error) {
+	row := q.db.QueryRowContext(ctx, getScheduledTransferRun, id)
+	var i ScheduledTransferRun
+	err := row.Scan(
+		&i.ID,
+		&i.ScheduledTransferID,
+		&i.TransferID,
+		&i.Status,
+		&i.Error,
+		&i.ScheduledFor,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listDueScheduledTransfers = `-- name: ListDueScheduledTransfers :many
+SELECT id, owner, from_account_id, to_account_id, amount, currency, recurrence, next_run_at, end_at, status, created_at
+FROM scheduled_transfers
+WHERE status = 'active'
+  AND next_run_at <= $1
+ORDER BY next_run_at
+LIMIT $2
+`
+
+type ListDueScheduledTransfersParams struct {
+	DueAt time.Time `json:"dueAt"`
+	Limit int32     `json:"limit"`
+}
+
+func (q *Queries) ListDueScheduledTransfers(ctx context.Context, arg ListDueScheduledTransfersParams) ([]ScheduledTransfer, error) {
+	rows, err := q.db.QueryContext(ctx, listDueScheduledTransfers, arg.DueAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScheduledTransfer{}
+	for rows.Next() {
+		var i ScheduledTransfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.Recurrence,
+			&i.NextRunAt,
+			&i.EndAt,
+			&i.Status,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScheduledTransferRuns = `-- name: ListScheduledTransferRuns :many
+SELECT id, scheduled_transfer_id, transfer_id, status, error, scheduled_for, created_at
+FROM scheduled_transfer_runs
+WHERE scheduled_transfer_id = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListScheduledTransferRunsParams struct {
+	ScheduledTransferID int64 `json:"scheduledTransferID"`
+	Limit               int32 `json:"limit"`
+	Offset              int32 `json:"offset"`
+}
+
+func (q *Queries) ListScheduledTransferRuns(ctx context.Context, arg ListScheduledTransferRunsParams) ([]ScheduledTransferRun, error) {
+	rows, err := q.db.QueryContext(ctx, listScheduledTransferRuns, arg.ScheduledTransferID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScheduledTransferRun{}
+	for rows.Next() {
+		var i ScheduledTransferRun
+		if err := rows.Scan(
+			&i.ID,
+			&i.ScheduledTransferID,
+			&i.TransferID,
+			&i.Status,
+			&i.Error,
+			&i.ScheduledFor,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScheduledTransfers = `-- name: ListScheduledTransfers :many
+SELECT id, owner, from_account_id, to_account_id, amount, currency, recurrence, next_run_at, end_at, status, created_at
+FROM scheduled_transfers
+WHERE owner = $1
+ORDER BY id
+LIMIT $2 OFFSET $3
+`
+
+type ListScheduledTransfersParams struct {
+	Owner  string `json:"owner"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+func (q *Queries) ListScheduledTransfers(ctx context.Context, arg ListScheduledTransfersParams) ([]ScheduledTransfer, error) {
+	rows, err := q.db.QueryContext(ctx, listScheduledTransfers, arg.Owner, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScheduledTransfer{}
+	for rows.Next() {
+		var i ScheduledTransfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.Recurrence,
+			&i.NextRunAt,
+			&i.EndAt,
+			&i.Status,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateScheduledTransferSchedule = `-- name: UpdateScheduledTransferSchedule :one
+UPDATE scheduled_transfers
+SET next_run_at = $2,
+    status      = $3
+WHERE id = $1
+RETURNING id, owner, from_account_id, to_account_id, amount, currency, recurrence, next_run_at, end_at, status, created_at
+`
+
+type UpdateScheduledTransferScheduleParams struct {
+	ID        int64     `json:"id"`
+	NextRunAt time.Time `json:"nextRunAt"`
+	Status    string    `json:"status"`
+}
+
+func (q *Queries) UpdateScheduledTransferSchedule(ctx context.Context, arg UpdateScheduledTransferScheduleParams) (ScheduledTransfer, error) {
+	row := q.db.QueryRowContext(ctx, updateScheduledTransferSchedule, arg.ID, arg.NextRunAt, arg.Status)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Recurrence,
+		&i.NextRunAt,
+		&i.EndAt,
+		&i.Status,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,176 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createAccountWithCurrency creates an account of a new user in the given currency
+func createAccountWithCurrency(t *testing.T, balance int64, currency string) Account {
+	user := createRandomUser(t)
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  balance,
+		Currency: currency,
+		Product:  util.CheckingProduct,
+	})
+	require.NoError(t, err)
+	return account
+}
+
+func createRandomScheduledTransfer(t *testing.T, from, to Account, amount int64, recurrence string, nextRunAt time.Time) ScheduledTransfer {
+	arg := CreateScheduledTransferParams{
+		Owner:         from.Owner,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        amount,
+		Currency:      from.Currency,
+		Recurrence:    recurrence,
+		NextRunAt:     nextRunAt,
+	}
+	scheduled, err := testQueries.CreateScheduledTransfer(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.NotZero(t, scheduled.ID)
+	require.Equal(t, arg.Owner, scheduled.Owner)
+	require.Equal(t, arg.FromAccountID, scheduled.FromAccountID)
+	require.Equal(t, arg.ToAccountID, scheduled.ToAccountID)
+	require.Equal(t, arg.Amount, scheduled.Amount)
+	require.Equal(t, arg.Currency, scheduled.Currency)
+	require.Equal(t, arg.Recurrence, scheduled.Recurrence)
+	require.WithinDuration(t, arg.NextRunAt, scheduled.NextRunAt, time.Second)
+	require.False(t, scheduled.EndAt.Valid)
+	require.Equal(t, ScheduledTransferActive, scheduled.Status)
+	require.NotZero(t, scheduled.CreatedAt)
+
+	return scheduled
+}
+
+func TestStore_ExecuteScheduledTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+	from := createAccountWithCurrency(t, 100, util.USD)
+	to := createAccountWithCurrency(t, 0, util.USD)
+	now := time.Now()
+	dueAt := now.Add(-time.Hour)
+	scheduled := createRandomScheduledTransfer(t, from, to, 30, util.MonthlyRecurrence, dueAt)
+
+	result, err := store.ExecuteScheduledTransferTx(context.Background(), ExecuteScheduledTransferTxParams{
+		ID:  scheduled.ID,
+		Now: now,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, ScheduledTransferRunSucceeded, result.Run.Status)
+	require.Empty(t, result.Run.Error)
+	require.Equal(t, sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}, result.Run.TransferID)
+	require.Equal(t, int64(70), result.Transfer.FromAccount.Balance)
+	require.Equal(t, int64(30), result.Transfer.ToAccount.Balance)
+
+	require.Equal(t, ScheduledTransferActive, result.ScheduledTransfer.Status)
+	require.WithinDuration(t, util.NextOccurrence(util.MonthlyRecurrence, dueAt), result.ScheduledTransfer.NextRunAt, time.Second)
+
+	// the next run is not due yet, so executing it again is a no-op
+	result, err = store.ExecuteScheduledTransferTx(context.Background(), ExecuteScheduledTransferTxParams{
+		ID:  scheduled.ID,
+		Now: now,
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.Run.ID)
+
+	runs, err := testQueries.ListScheduledTransferRuns(context.Background(), ListScheduledTransferRunsParams{
+		ScheduledTransferID: scheduled.ID,
+		Limit:               10,
+	})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+}
+
+func TestStore_ExecuteScheduledTransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+	from := createAccountWithCurrency(t, 10, util.EUR)
+	to := createAccountWithCurrency(t, 0, util.EUR)
+	now := time.Now()
+	scheduled := createRandomScheduledTransfer(t, from, to, 30, util.OnceRecurrence, now.Add(-time.Minute))
+
+	result, err := store.ExecuteScheduledTransferTx(context.Background(), ExecuteScheduledTransferTxParams{
+		ID:  scheduled.ID,
+		Now: now,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, ScheduledTransferRunFailed, result.Run.Status)
+	require.Equal(t, ErrInsufficientFunds.Error(), result.Run.Error)
+	require.False(t, result.Run.TransferID.Valid)
+	require.Equal(t, ScheduledTransferFailed, result.ScheduledTransfer.Status)
+
+	account, err := testQueries.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance, account.Balance)
+
+	messages, err := testQueries.ListPendingOutboxMessages(context.Background(), 1000)
+	require.NoError(t, err)
+
+	var notified bool
+	for _, message := range messages {
+		if message.TaskType != TaskSendScheduledTransferFailed {
+			continue
+		}
+		var payload PayloadSendScheduledTransferFailed
+		require.NoError(t, json.Unmarshal(message.Payload, &payload))
+		notified = notified || payload.RunID == result.Run.ID
+	}
+	require.True(t, notified)
+}
+
+func TestStore_UpdateScheduledTransferStatusTx(t *testing.T) {
+	store := NewStore(testDB)
+	from := createAccountWithCurrency(t, 100, util.BRL)
+	to := createAccountWithCurrency(t, 0, util.BRL)
+	now := time.Now()
+	scheduled := createRandomScheduledTransfer(t, from, to, 10, util.DailyRecurrence, now.Add(time.Hour))
+	audit := AuditParams{Actor: from.Owner}
+
+	result, err := store.UpdateScheduledTransferStatusTx(context.Background(), UpdateScheduledTransferStatusTxParams{
+		ID:     scheduled.ID,
+		Status: ScheduledTransferPaused,
+		Now:    now,
+		Audit:  audit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferPaused, result.ScheduledTransfer.Status)
+
+	// resuming two days later skips the runs missed while paused
+	later := now.Add(48 * time.Hour)
+	result, err = store.UpdateScheduledTransferStatusTx(context.Background(), UpdateScheduledTransferStatusTxParams{
+		ID:     scheduled.ID,
+		Status: ScheduledTransferActive,
+		Now:    later,
+		Audit:  audit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferActive, result.ScheduledTransfer.Status)
+	require.WithinDuration(t, scheduled.NextRunAt.AddDate(0, 0, 2), result.ScheduledTransfer.NextRunAt, time.Second)
+
+	result, err = store.UpdateScheduledTransferStatusTx(context.Background(), UpdateScheduledTransferStatusTxParams{
+		ID:     scheduled.ID,
+		Status: ScheduledTransferCancelled,
+		Now:    later,
+		Audit:  audit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferCancelled, result.ScheduledTransfer.Status)
+
+	_, err = store.UpdateScheduledTransferStatusTx(context.Background(), UpdateScheduledTransferStatusTxParams{
+		ID:     scheduled.ID,
+		Status: ScheduledTransferActive,
+		Now:    later,
+		Audit:  audit,
+	})
+	require.ErrorIs(t, err, ErrScheduledTransferStatus)
+}
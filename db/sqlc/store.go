@@ -4,12 +4,51 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+
+	"github.com/MathPeixoto/go-financial-system/tracing"
+	"github.com/rs/zerolog/log"
 )
 
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	QuoteTransferFee(ctx context.Context, currency string, amount int64) (TransferFee, error)
 	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
+	LinkOIDCUserTx(ctx context.Context, arg LinkOIDCUserTxParams) (LinkOIDCUserTxResult, error)
+	UpdateUserTx(ctx context.Context, arg UpdateUserTxParams) (UpdateUserTxResult, error)
+	AddAccountBalanceTx(ctx context.Context, arg AddAccountBalanceTxParams) (AddAccountBalanceTxResult, error)
+	CreateSessionTx(ctx context.Context, arg CreateSessionTxParams) (CreateSessionTxResult, error)
+	CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (CreateAccountTxResult, error)
+	FreezeAccountTx(ctx context.Context, arg FreezeAccountTxParams) (FreezeAccountTxResult, error)
+	DeleteAccountTx(ctx context.Context, arg DeleteAccountTxParams) (DeleteAccountTxResult, error)
+	ReplayWebhookDeliveryTx(ctx context.Context, arg ReplayWebhookDeliveryTxParams) (ReplayWebhookDeliveryTxResult, error)
+	CreateHoldTx(ctx context.Context, arg CreateHoldTxParams) (CreateHoldTxResult, error)
+	CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error)
+	ReleaseHoldTx(ctx context.Context, arg ReleaseHoldTxParams) (ReleaseHoldTxResult, error)
+	CreateScheduledTransferTx(ctx context.Context, arg CreateScheduledTransferTxParams) (CreateScheduledTransferTxResult, error)
+	ExecuteScheduledTransferTx(ctx context.Context, arg ExecuteScheduledTransferTxParams) (ExecuteScheduledTransferTxResult, error)
+	RelayOutboxTx(ctx context.Context, arg RelayOutboxTxParams) (RelayOutboxTxResult, error)
+	UpdateScheduledTransferStatusTx(ctx context.Context, arg UpdateScheduledTransferStatusTxParams) (UpdateScheduledTransferStatusTxResult, error)
+	AccrueInterest(ctx context.Context, arg AccrueInterestParams) (InterestAccrual, error)
+	PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error)
+	SetAccountTransferLimitTx(ctx context.Context, arg SetAccountTransferLimitTxParams) (SetAccountTransferLimitTxResult, error)
+	CreateTransferReviewTx(ctx context.Context, arg CreateTransferReviewTxParams) (CreateTransferReviewTxResult, error)
+	ReviewTransferTx(ctx context.Context, arg ReviewTransferTxParams) (ReviewTransferTxResult, error)
+	CreateTransferBatchTx(ctx context.Context, arg CreateTransferBatchTxParams) (CreateTransferBatchTxResult, error)
+	CreatePayeeTx(ctx context.Context, arg CreatePayeeTxParams) (CreatePayeeTxResult, error)
+	UpdatePayeeTx(ctx context.Context, arg UpdatePayeeTxParams) (UpdatePayeeTxResult, error)
+	DeletePayeeTx(ctx context.Context, arg DeletePayeeTxParams) (DeletePayeeTxResult, error)
+	CreateWebhookEndpointTx(ctx context.Context, arg CreateWebhookEndpointTxParams) (CreateWebhookEndpointTxResult, error)
+	DeactivateWebhookEndpointTx(ctx context.Context, arg DeactivateWebhookEndpointTxParams) (DeactivateWebhookEndpointTxResult, error)
+	PostJournalTx(ctx context.Context, arg PostJournalTxParams) (PostJournalTxResult, error)
+	InviteAccountMemberTx(ctx context.Context, arg InviteAccountMemberTxParams) (InviteAccountMemberTxResult, error)
+	AcceptAccountMemberTx(ctx context.Context, arg AcceptAccountMemberTxParams) (AcceptAccountMemberTxResult, error)
+	RemoveAccountMemberTx(ctx context.Context, arg RemoveAccountMemberTxParams) (RemoveAccountMemberTxResult, error)
+	CreateOrganizationTx(ctx context.Context, arg CreateOrganizationTxParams) (CreateOrganizationTxResult, error)
+	AddOrganizationMemberTx(ctx context.Context, arg AddOrganizationMemberTxParams) (AddOrganizationMemberTxResult, error)
+	RemoveOrganizationMemberTx(ctx context.Context, arg RemoveOrganizationMemberTxParams) (RemoveOrganizationMemberTxResult, error)
+	CreateTransferApprovalTx(ctx context.Context, arg CreateTransferApprovalTxParams) (CreateTransferApprovalTxResult, error)
+	DecideTransferApprovalTx(ctx context.Context, arg DecideTransferApprovalTxParams) (DecideTransferApprovalTxResult, error)
 }
 
 // SQLStore provides all functions to execute SQL queries and transactions
@@ -23,26 +62,40 @@ type SQLStore struct {
 func NewStore(db *sql.DB) Store {
 	return &SQLStore{
 		db:      db,
-		Queries: New(db),
+		Queries: New(observedDB{db}),
 	}
 }
 
-// execTx executes a function within a database transaction
-func (store *SQLStore) execTx(ctx context.Context, fn func(queries *Queries) error) error {
+// execTx executes a function within a database transaction, traced by a span parenting the spans of its queries
+func (store *SQLStore) execTx(ctx context.Context, fn func(queries *Queries) error) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "execTx")
+	defer func() { tracing.End(span, err) }()
+
 	tx, err := store.db.BeginTx(ctx, nil)
 
 	if err != nil {
 		return err
 	}
 
-	q := New(tx)
+	observed := &observedTx{observedDB: observedDB{tx}, span: span}
+	q := New(observed)
 	err = fn(q)
 	if err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
+			log.Ctx(ctx).Error().Err(rbErr).AnErr("tx_error", err).Msg("cannot rollback transaction")
 			return fmt.Errorf("tx err: %w, rb error: %v", err, rbErr)
 		}
+		log.Ctx(ctx).Debug().Err(err).Msg("transaction rolled back")
 		return err
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range observed.afterCommit {
+		hook()
+	}
+	return nil
 }
@@ -2,14 +2,15 @@ package db
 
 import (
 	"context"
+	"github.com/MathPeixoto/go-financial-system/util"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
 
 func TestStore_TransferTx(t *testing.T) {
 	store := NewStore(testDB)
-	accountOne := createRandomAccount(t)
-	accountTwo := createRandomAccount(t)
+	accountOne := createAccountWithCurrency(t, 1_000, util.BRL)
+	accountTwo := createAccountWithCurrency(t, 1_000, util.BRL)
 
 	// run n concurrent result transactions
 	n := 5
@@ -21,7 +22,10 @@ func TestStore_TransferTx(t *testing.T) {
 	for i := 0; i < n; i++ {
 		go func() {
 			result, err := store.TransferTx(context.Background(), TransferTxParams{
-				accountOne.ID, accountTwo.ID, amount,
+				FromAccountID: accountOne.ID,
+				ToAccountID:   accountTwo.ID,
+				Amount:        amount,
+				Audit:         AuditParams{Actor: accountOne.Owner},
 			})
 
 			errs <- err
@@ -69,6 +73,10 @@ func TestStore_TransferTx(t *testing.T) {
 		_, err = store.GetEntry(context.Background(), toEntry.ID)
 		require.NoError(t, err)
 
+		// both entries are posted in the journal of the transfer
+		require.True(t, fromEntry.JournalID.Valid)
+		require.Equal(t, fromEntry.JournalID, toEntry.JournalID)
+
 		// check accounts
 		fromAccount := result.FromAccount
 		require.NotEmpty(t, fromAccount)
@@ -102,10 +110,27 @@ func TestStore_TransferTx(t *testing.T) {
 	require.Equal(t, accountTwo.Balance+int64(n)*amount, updateAccountTwo.Balance)
 }
 
+func TestStore_TransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+	from := createAccountWithCurrency(t, 100, util.BRL)
+	to := createAccountWithCurrency(t, 0, util.BRL)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        101,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	account, err := testQueries.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance, account.Balance)
+}
+
 func TestStore_TransferTxDeadlock(t *testing.T) {
 	store := NewStore(testDB)
-	accountOne := createRandomAccount(t)
-	accountTwo := createRandomAccount(t)
+	accountOne := createAccountWithCurrency(t, 1_000, util.BRL)
+	accountTwo := createAccountWithCurrency(t, 1_000, util.BRL)
 
 	// run n concurrent result transactions
 	n := 10
@@ -123,7 +148,10 @@ func TestStore_TransferTxDeadlock(t *testing.T) {
 
 		go func() {
 			_, err := store.TransferTx(context.Background(), TransferTxParams{
-				fromAccountID, toAccountID, amount,
+				FromAccountID: fromAccountID,
+				ToAccountID:   toAccountID,
+				Amount:        amount,
+				Audit:         AuditParams{Actor: accountOne.Owner},
 			})
 
 			errs <- err
@@ -144,3 +172,37 @@ func TestStore_TransferTxDeadlock(t *testing.T) {
 	require.Equal(t, accountOne.Balance, updateAccountOne.Balance)
 	require.Equal(t, accountTwo.Balance, updateAccountTwo.Balance)
 }
+
+func TestStore_LinkOIDCUserTx(t *testing.T) {
+	store := NewStore(testDB)
+	existingUser := createRandomUser(t)
+
+	arg := LinkOIDCUserTxParams{
+		Issuer:         "https://" + util.RandomString(8) + ".example.com",
+		Subject:        util.RandomString(12),
+		Email:          existingUser.Email,
+		FullName:       existingUser.FullName,
+		Username:       util.RandomOwner(),
+		HashedPassword: existingUser.HashedPassword,
+	}
+
+	// an unknown identity with the email of an existing user is not linked to that user, whose email is unverified
+	_, err := store.LinkOIDCUserTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrOIDCEmailInUse)
+
+	// an unknown identity with an unknown email provisions a new user, even if the username is taken
+	arg.Email = util.RandomEmail()
+	arg.Username = existingUser.Username
+	result, err := store.LinkOIDCUserTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, result.Created)
+	require.NotEqual(t, existingUser.Username, result.User.Username)
+	require.Equal(t, arg.Email, result.User.Email)
+	require.Equal(t, result.User.Username, result.Identity.Username)
+
+	// an identity that was linked before resolves to the same user
+	linked, err := store.LinkOIDCUserTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, linked.Created)
+	require.Equal(t, result.User.Username, linked.User.Username)
+}
@@ -0,0 +1,207 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: transfer_approval.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createTransferApproval = `-- name: CreateTransferApproval :one
+INSERT INTO transfer_approvals (organization_id, initiator, from_account_id, to_account_id, amount, currency)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, organization_id, initiator, from_account_id, to_account_id, amount, currency, status, approver, transfer_id, decided_at, created_at
+`
+
+type CreateTransferApprovalParams struct {
+	OrganizationID int64  `json:"organizationID"`
+	Initiator      string `json:"initiator"`
+	FromAccountID  int64  `json:"fromAccountID"`
+	ToAccountID    int64  `json:"toAccountID"`
+	Amount         int64  `json:"amount"`
+	Currency       string `json:"currency"`
+}
+
+func (q *Queries) CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (TransferApproval, error) {
+	row := q.db.QueryRowContext(ctx, createTransferApproval,
+		arg.OrganizationID,
+		arg.Initiator,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Currency,
+	)
+	var i TransferApproval
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.Initiator,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.Approver,
+		&i.TransferID,
+		&i.DecidedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTransferApproval = `-- name: GetTransferApproval :one
+SELECT id, organization_id, initiator, from_account_id, to_account_id, amount, currency, status, approver, transfer_id, decided_at, created_at
+FROM transfer_approvals
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetTransferApproval(ctx context.Context, id int64) (TransferApproval, error) {
+	row := q.db.QueryRowContext(ctx, getTransferApproval, id)
+	var i TransferApproval
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.Initiator,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.Approver,
+		&i.TransferID,
+		&i.DecidedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTransferApprovalForUpdate = `-- name: GetTransferApprovalForUpdate :one
+SELECT id, organization_id, initiator, from_account_id, to_account_id, amount, currency, status, approver, transfer_id, decided_at, created_at
+FROM transfer_approvals
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetTransferApprovalForUpdate(ctx context.Context, id int64) (TransferApproval, error) {
+	row := q.db.QueryRowContext(ctx, getTransferApprovalForUpdate, id)
+	var i TransferApproval
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.Initiator,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.Approver,
+		&i.TransferID,
+		&i.DecidedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listTransferApprovals = `-- name: ListTransferApprovals :many
+SELECT id, organization_id, initiator, from_account_id, to_account_id, amount, currency, status, approver, transfer_id, decided_at, created_at
+FROM transfer_approvals
+WHERE organization_id = $1
+  AND status = $2
+ORDER BY created_at
+LIMIT $3 OFFSET $4
+`
+
+type ListTransferApprovalsParams struct {
+	OrganizationID int64  `json:"organizationID"`
+	Status         string `json:"status"`
+	Limit          int32  `json:"limit"`
+	Offset         int32  `json:"offset"`
+}
+
+func (q *Queries) ListTransferApprovals(ctx context.Context, arg ListTransferApprovalsParams) ([]TransferApproval, error) {
+	rows, err := q.db.QueryContext(ctx, listTransferApprovals,
+		arg.OrganizationID,
+		arg.Status,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransferApproval{}
+	for rows.Next() {
+		var i TransferApproval
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.Initiator,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.Status,
+			&i.Approver,
+			&i.TransferID,
+			&i.DecidedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateTransferApproval = `-- name: UpdateTransferApproval :one
+UPDATE transfer_approvals
+SET status      = $2,
+    approver    = $3,
+    transfer_id = $4,
+    decided_at  = now()
+WHERE id = $1
+RETURNING id, organization_id, initiator, from_account_id, to_account_id, amount, currency, status, approver, transfer_id, decided_at, created_at
+`
+
+type UpdateTransferApprovalParams struct {
+	ID         int64          `json:"id"`
+	Status     string         `json:"status"`
+	Approver   sql.NullString `json:"approver"`
+	TransferID sql.NullInt64  `json:"transferID"`
+}
+
+func (q *Queries) UpdateTransferApproval(ctx context.Context, arg UpdateTransferApprovalParams) (TransferApproval, error) {
+	row := q.db.QueryRowContext(ctx, updateTransferApproval,
+		arg.ID,
+		arg.Status,
+		arg.Approver,
+		arg.TransferID,
+	)
+	var i TransferApproval
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.Initiator,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.Approver,
+		&i.TransferID,
+		&i.DecidedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+)
+
+// Modes of a transfer batch
+const (
+	// TransferBatchAtomic executes all the transfers of a batch or none of them
+	TransferBatchAtomic = "atomic"
+	// TransferBatchBestEffort executes every transfer of a batch on its own, so that failed ones don't stop the others
+	TransferBatchBestEffort = "best_effort"
+)
+
+// Statuses of a transfer batch
+const (
+	TransferBatchProcessing         = "processing"
+	TransferBatchCompleted          = "completed"
+	TransferBatchPartiallyCompleted = "partially_completed"
+	TransferBatchFailed             = "failed"
+)
+
+// Statuses of a transfer batch item
+const (
+	TransferBatchItemSucceeded = "succeeded"
+	TransferBatchItemFailed    = "failed"
+	// TransferBatchItemCanceled marks the other items of an atomic batch when one of them failed
+	TransferBatchItemCanceled = "canceled"
+)
+
+// errTransferBatchItem wraps the failure of an item that aborts an atomic batch
+type errTransferBatchItem struct {
+	position int
+	err      error
+}
+
+func (e *errTransferBatchItem) Error() string {
+	return fmt.Sprintf("item %d: %s", e.position, e.err)
+}
+
+// transferBatchStatus returns the status of a processed batch from the number of its items that succeeded
+func transferBatchStatus(itemCount, succeededCount int) string {
+	switch succeededCount {
+	case itemCount:
+		return TransferBatchCompleted
+	case 0:
+		return TransferBatchFailed
+	default:
+		return TransferBatchPartiallyCompleted
+	}
+}
+
+// checkTransferBatchItem verifies that the accounts of a batch item can execute it, including its fee
+func checkTransferBatchItem(batch TransferBatch, from, to Account, amount, fee int64) error {
+	if from.ID == to.ID {
+		return fmt.Errorf("account %d can't transfer to itself", from.ID)
+	}
+
+	for _, account := range []Account{from, to} {
+		if account.Currency != batch.Currency {
+			return fmt.Errorf("account %d has currency %s, but transfer currency is %s",
+				account.ID, account.Currency, batch.Currency)
+		}
+		if account.Status == util.FrozenAccountStatus {
+			return fmt.Errorf("account %d: %w", account.ID, ErrAccountFrozen)
+		}
+	}
+
+	if from.AvailableBalance < amount+fee {
+		return ErrInsufficientFunds
+	}
+
+	return nil
+}
+
+// executeTransferBatchItem performs the transfer of a batch item within the transaction of queries.
+// It returns the reason of the item failure in itemErr, and in err the errors that must abort the transaction.
+func executeTransferBatchItem(
+	ctx context.Context, queries *Queries, batch TransferBatch, item TransferBatchItemParams, audit AuditParams,
+) (result TransferTxResult, itemErr error, err error) {
+	from, to, err := lockTransferAccounts(ctx, queries, batch.FromAccountID, item.ToAccountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return result, fmt.Errorf("account %d not found", item.ToAccountID), nil
+		}
+		return result, nil, err
+	}
+
+	if _, itemErr = AuthorizeAccount(ctx, queries, from, batch.Owner, AccountPermissionSpend, item.Amount); itemErr != nil {
+		if IsAccountAccessError(itemErr) {
+			return result, itemErr, nil
+		}
+		return result, nil, itemErr
+	}
+
+	fee, err := quoteFee(ctx, queries, batch.Currency, item.Amount)
+	if err != nil {
+		return result, nil, err
+	}
+
+	if itemErr = checkTransferBatchItem(batch, from, to, item.Amount, fee.Amount); itemErr != nil {
+		return result, itemErr, nil
+	}
+
+	// transfer checks the transfer limits before writing anything, so exceeding them fails the item alone
+	result, err = transfer(ctx, queries, TransferTxParams{
+		FromAccountID: batch.FromAccountID,
+		ToAccountID:   item.ToAccountID,
+		Amount:        item.Amount,
+		Audit:         audit,
+	})
+	if errors.Is(err, ErrTransferLimitExceeded) {
+		return result, err, nil
+	}
+	return result, nil, err
+}
@@ -0,0 +1,186 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: transfer_batch.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createTransferBatch = `-- name: CreateTransferBatch :one
+INSERT INTO transfer_batches (owner, from_account_id, currency, mode, status, item_count)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, owner, from_account_id, currency, mode, status, item_count, succeeded_count, created_at
+`
+
+type CreateTransferBatchParams struct {
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"fromAccountID"`
+	Currency      string `json:"currency"`
+	Mode          string `json:"mode"`
+	Status        string `json:"status"`
+	ItemCount     int32  `json:"itemCount"`
+}
+
+func (q *Queries) CreateTransferBatch(ctx context.Context, arg CreateTransferBatchParams) (TransferBatch, error) {
+	row := q.db.QueryRowContext(ctx, createTransferBatch,
+		arg.Owner,
+		arg.FromAccountID,
+		arg.Currency,
+		arg.Mode,
+		arg.Status,
+		arg.ItemCount,
+	)
+	var i TransferBatch
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.Currency,
+		&i.Mode,
+		&i.Status,
+		&i.ItemCount,
+		&i.SucceededCount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createTransferBatchItem = `-- name: CreateTransferBatchItem :one
+INSERT INTO transfer_batch_items (batch_id, position, to_account_id, amount, status, transfer_id, error)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, batch_id, position, to_account_id, amount, status, transfer_id, error, created_at
+`
+
+type CreateTransferBatchItemParams struct {
+	BatchID     int64         `json:"batchID"`
+	Position    int32         `json:"position"`
+	ToAccountID int64         `json:"toAccountID"`
+	Amount      int64         `json:"amount"`
+	Status      string        `json:"status"`
+	TransferID  sql.NullInt64 `json:"transferID"`
+	Error       string        `json:"error"`
+}
+
+func (q *Queries) CreateTransferBatchItem(ctx context.Context, arg CreateTransferBatchItemParams) (TransferBatchItem, error) {
+	row := q.db.QueryRowContext(ctx, createTransferBatchItem,
+		arg.BatchID,
+		arg.Position,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Status,
+		arg.TransferID,
+		arg.Error,
+	)
+	var i TransferBatchItem
+	err := row.Scan(
+		&i.ID,
+		&i.BatchID,
+		&i.Position,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Status,
+		&i.TransferID,
+		&i.Error,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTransferBatch = `-- name: GetTransferBatch :one
+SELECT id, owner, from_account_id, currency, mode, status, item_count, succeeded_count, created_at
+FROM transfer_batches
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetTransferBatch(ctx context.Context, id int64) (TransferBatch, error) {
+	row := q.db.QueryRowContext(ctx, getTransferBatch, id)
+	var i TransferBatch
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.Currency,
+		&i.Mode,
+		&i.Status,
+		&i.ItemCount,
+		&i.SucceededCount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listTransferBatchItems = `-- name: ListTransferBatchItems :many
+SELECT id, batch_id, position, to_account_id, amount, status, transfer_id, error, created_at
+FROM transfer_batch_items
+WHERE batch_id = $1
+ORDER BY position
+`
+
+func (q *Queries) ListTransferBatchItems(ctx context.Context, batchID int64) ([]TransferBatchItem, error) {
+	rows, err := q.db.QueryContext(ctx, listTransferBatchItems, batchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransferBatchItem{}
+	for rows.Next() {
+		var i TransferBatchItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.BatchID,
+			&i.Position,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Status,
+			&i.TransferID,
+			&i.Error,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateTransferBatch = `-- name: UpdateTransferBatch :one
+UPDATE transfer_batches
+SET status          = $2,
+    succeeded_count = $3
+WHERE id = $1
+RETURNING id, owner, from_account_id, currency, mode, status, item_count, succeeded_count, created_at
+`
+
+type UpdateTransferBatchParams struct {
+	ID             int64  `json:"id"`
+	Status         string `json:"status"`
+	SucceededCount int32  `json:"succeededCount"`
+}
+
+func (q *Queries) UpdateTransferBatch(ctx context.Context, arg UpdateTransferBatchParams) (TransferBatch, error) {
+	row := q.db.QueryRowContext(ctx, updateTransferBatch, arg.ID, arg.Status, arg.SucceededCount)
+	var i TransferBatch
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.Currency,
+		&i.Mode,
+		&i.Status,
+		&i.ItemCount,
+		&i.SucceededCount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferBatchStatus(t *testing.T) {
+	require.Equal(t, TransferBatchCompleted, transferBatchStatus(3, 3))
+	require.Equal(t, TransferBatchPartiallyCompleted, transferBatchStatus(3, 1))
+	require.Equal(t, TransferBatchFailed, transferBatchStatus(3, 0))
+}
+
+func TestStore_CreateTransferBatchTxAtomic(t *testing.T) {
+	store := NewStore(testDB)
+	from := createAccountWithCurrency(t, 1_000, util.BRL)
+	to1 := createAccountWithCurrency(t, 0, util.BRL)
+	to2 := createAccountWithCurrency(t, 0, util.BRL)
+
+	result, err := store.CreateTransferBatchTx(context.Background(), CreateTransferBatchTxParams{
+		Owner:         from.Owner,
+		FromAccountID: from.ID,
+		Currency:      util.BRL,
+		Mode:          TransferBatchAtomic,
+		Items:         []TransferBatchItemParams{{ToAccountID: to1.ID, Amount: 100}, {ToAccountID: to2.ID, Amount: 200}},
+		Audit:         AuditParams{Actor: from.Owner},
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferBatchCompleted, result.TransferBatch.Status)
+	require.Equal(t, int32(2), result.TransferBatch.ItemCount)
+	require.Equal(t, int32(2), result.TransferBatch.SucceededCount)
+	require.Len(t, result.Items, 2)
+	for i, item := range result.Items {
+		require.Equal(t, int32(i), item.Position)
+		require.Equal(t, TransferBatchItemSucceeded, item.Status)
+		require.True(t, item.TransferID.Valid)
+	}
+
+	account, err := testQueries.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(700), account.Balance)
+}
+
+func TestStore_CreateTransferBatchTxAtomicRollsBack(t *testing.T) {
+	store := NewStore(testDB)
+	from := createAccountWithCurrency(t, 1_000, util.BRL)
+	to := createAccountWithCurrency(t, 0, util.BRL)
+	otherCurrency := createAccountWithCurrency(t, 0, util.USD)
+
+	result, err := store.CreateTransferBatchTx(context.Background(), CreateTransferBatchTxParams{
+		Owner:         from.Owner,
+		FromAccountID: from.ID,
+		Currency:      util.BRL,
+		Mode:          TransferBatchAtomic,
+		Items:         []TransferBatchItemParams{{ToAccountID: to.ID, Amount: 100}, {ToAccountID: otherCurrency.ID, Amount: 200}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferBatchFailed, result.TransferBatch.Status)
+	require.Zero(t, result.TransferBatch.SucceededCount)
+	require.Len(t, result.Items, 2)
+	require.Equal(t, TransferBatchItemCanceled, result.Items[0].Status)
+	require.Equal(t, TransferBatchItemFailed, result.Items[1].Status)
+	require.Contains(t, result.Items[1].Error, "currency")
+	require.False(t, result.Items[0].TransferID.Valid)
+
+	account, err := testQueries.GetAccount(context.Background(), to.ID)
+	require.NoError(t, err)
+	require.Zero(t, account.Balance)
+}
+
+func TestStore_CreateTransferBatchTxAtomicMissingAccount(t *testing.T) {
+	store := NewStore(testDB)
+	from := createAccountWithCurrency(t, 1_000, util.BRL)
+	to := createAccountWithCurrency(t, 0, util.BRL)
+	missingID := to.ID + 1_000_000
+
+	result, err := store.CreateTransferBatchTx(context.Background(), CreateTransferBatchTxParams{
+		Owner:         from.Owner,
+		FromAccountID: from.ID,
+		Currency:      util.BRL,
+		Mode:          TransferBatchAtomic,
+		Items:         []TransferBatchItemParams{{ToAccountID: to.ID, Amount: 100}, {ToAccountID: missingID, Amount: 200}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferBatchFailed, result.TransferBatch.Status)
+	require.Equal(t, TransferBatchItemCanceled, result.Items[0].Status)
+	require.Equal(t, TransferBatchItemFailed, result.Items[1].Status)
+	require.Contains(t, result.Items[1].Error, "not found")
+
+	account, err := testQueries.GetAccount(context.Background(), to.ID)
+	require.NoError(t, err)
+	require.Zero(t, account.Balance)
+}
+
+func TestStore_CreateTransferBatchTxBestEffort(t *testing.T) {
+	store := NewStore(testDB)
+	from := createAccountWithCurrency(t, 250, util.BRL)
+	to := createAccountWithCurrency(t, 0, util.BRL)
+
+	result, err := store.CreateTransferBatchTx(context.Background(), CreateTransferBatchTxParams{
+		Owner:         from.Owner,
+		FromAccountID: from.ID,
+		Currency:      util.BRL,
+		Mode:          TransferBatchBestEffort,
+		Items: []TransferBatchItemParams{
+			{ToAccountID: to.ID, Amount: 100},
+			{ToAccountID: to.ID, Amount: 200},
+			{ToAccountID: to.ID, Amount: 150},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferBatchPartiallyCompleted, result.TransferBatch.Status)
+	require.Equal(t, int32(2), result.TransferBatch.SucceededCount)
+	require.Equal(t, TransferBatchItemSucceeded, result.Items[0].Status)
+	require.Equal(t, TransferBatchItemFailed, result.Items[1].Status)
+	require.Equal(t, ErrInsufficientFunds.Error(), result.Items[1].Error)
+	require.Equal(t, TransferBatchItemSucceeded, result.Items[2].Status)
+
+	items, err := testQueries.ListTransferBatchItems(context.Background(), result.TransferBatch.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.Items, items)
+
+	account, err := testQueries.GetAccount(context.Background(), to.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(250), account.Balance)
+}
+
+func TestStore_CreateTransferBatchTxBestEffortTransferLimit(t *testing.T) {
+	store := NewStore(testDB)
+	from := createAccountWithCurrency(t, 1_000, util.BRL)
+	to := createAccountWithCurrency(t, 0, util.BRL)
+	useProductTransferLimit(t, SetProductTransferLimitParams{
+		Product:   from.Product,
+		Currency:  util.BRL,
+		MaxAmount: sql.NullInt64{Int64: 150, Valid: true},
+	})
+
+	result, err := store.CreateTransferBatchTx(context.Background(), CreateTransferBatchTxParams{
+		Owner:         from.Owner,
+		FromAccountID: from.ID,
+		Currency:      util.BRL,
+		Mode:          TransferBatchBestEffort,
+		Items: []TransferBatchItemParams{
+			{ToAccountID: to.ID, Amount: 100},
+			{ToAccountID: to.ID, Amount: 200},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferBatchPartiallyCompleted, result.TransferBatch.Status)
+	require.Equal(t, TransferBatchItemSucceeded, result.Items[0].Status)
+	require.Equal(t, TransferBatchItemFailed, result.Items[1].Status)
+	require.Contains(t, result.Items[1].Error, "limit")
+
+	account, err := testQueries.GetAccount(context.Background(), to.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), account.Balance)
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Kinds of transfer limits
+const (
+	TransferLimitMaxAmount     = "max_amount"
+	TransferLimitDailyAmount   = "daily_amount"
+	TransferLimitMonthlyAmount = "monthly_amount"
+	TransferLimitDailyCount    = "daily_count"
+)
+
+// ErrTransferLimitExceeded matches every TransferLimitError with errors.Is
+var ErrTransferLimitExceeded = errors.New("transfer limit exceeded")
+
+// TransferLimitError is returned when a transfer exceeds a limit of the account it is sent from
+type TransferLimitError struct {
+	AccountID int64
+	Limit     string
+	// Allowed is the value of the limit and Remaining what is left of it before the transfer
+	Allowed   int64
+	Remaining int64
+}
+
+func (e *TransferLimitError) Error() string {
+	return fmt.Sprintf("transfer exceeds the %s limit of account %d: %d remaining of %d",
+		e.Limit, e.AccountID, e.Remaining, e.Allowed)
+}
+
+func (e *TransferLimitError) Is(target error) bool {
+	return target == ErrTransferLimitExceeded
+}
+
+// TransferLimits are the limits applying to the outgoing transfers of an account, each unlimited when not valid
+type TransferLimits struct {
+	MaxAmount     sql.NullInt64 `json:"max_amount"`
+	DailyAmount   sql.NullInt64 `json:"daily_amount"`
+	MonthlyAmount sql.NullInt64 `json:"monthly_amount"`
+	DailyCount    sql.NullInt32 `json:"daily_count"`
+}
+
+// effectiveTransferLimits applies the limits set on an account over the limits of its product
+func effectiveTransferLimits(product ProductTransferLimit, account AccountTransferLimit) TransferLimits {
+	limits := TransferLimits{
+		MaxAmount:     product.MaxAmount,
+		DailyAmount:   product.DailyAmount,
+		MonthlyAmount: product.MonthlyAmount,
+		DailyCount:    product.DailyCount,
+	}
+	if account.MaxAmount.Valid {
+		limits.MaxAmount = account.MaxAmount
+	}
+	if account.DailyAmount.Valid {
+		limits.DailyAmount = account.DailyAmount
+	}
+	if account.MonthlyAmount.Valid {
+		limits.MonthlyAmount = account.MonthlyAmount
+	}
+	if account.DailyCount.Valid {
+		limits.DailyCount = account.DailyCount
+	}
+	return limits
+}
+
+// check returns a TransferLimitError when a transfer of amount, on top of the outgoing totals of the day
+// and the month, exceeds one of the limits
+func (limits TransferLimits) check(accountID, amount int64, totals GetOutgoingTransferTotalsRow) error {
+	exceeded := func(limit string, allowed, used, requested int64) error {
+		if used+requested <= allowed {
+			return nil
+		}
+		remaining := allowed - used
+		if remaining < 0 {
+			remaining = 0
+		}
+		return &TransferLimitError{AccountID: accountID, Limit: limit, Allowed: allowed, Remaining: remaining}
+	}
+
+	if limits.MaxAmount.Valid {
+		if err := exceeded(TransferLimitMaxAmount, limits.MaxAmount.Int64, 0, amount); err != nil {
+			return err
+		}
+	}
+	if limits.DailyCount.Valid {
+		if err := exceeded(TransferLimitDailyCount, int64(limits.DailyCount.Int32), totals.DailyCount, 1); err != nil {
+			return err
+		}
+	}
+	if limits.DailyAmount.Valid {
+		if err := exceeded(TransferLimitDailyAmount, limits.DailyAmount.Int64, totals.DailyAmount, amount); err != nil {
+			return err
+		}
+	}
+	if limits.MonthlyAmount.Valid {
+		if err := exceeded(TransferLimitMonthlyAmount, limits.MonthlyAmount.Int64, totals.MonthlyAmount, amount); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getTransferLimits returns the limits of an account, from its product and currency and its own overrides
+func getTransferLimits(ctx context.Context, queries *Queries, account Account) (TransferLimits, error) {
+	product, err := queries.GetProductTransferLimit(ctx, GetProductTransferLimitParams{
+		Product:  account.Product,
+		Currency: account.Currency,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return TransferLimits{}, err
+	}
+
+	override, err := queries.GetAccountTransferLimit(ctx, account.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return TransferLimits{}, err
+	}
+
+	return effectiveTransferLimits(product, override), nil
+}
+
+// checkTransferLimits verifies a transfer of amount against the limits of the from account and its transfers
+// of the current UTC day and month. The from account must be locked by the transaction of queries,
+// so that concurrent transfers from it are checked one after the other.
+func checkTransferLimits(ctx context.Context, queries *Queries, from Account, amount int64, now time.Time) error {
+	limits, err := getTransferLimits(ctx, queries, from)
+	if err != nil {
+		return err
+	}
+
+	if limits == (TransferLimits{}) {
+		return nil
+	}
+
+	now = now.UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	totals, err := queries.GetOutgoingTransferTotals(ctx, GetOutgoingTransferTotalsParams{
+		DayStart:   dayStart,
+		AccountID:  from.ID,
+		MonthStart: dayStart.AddDate(0, 0, 1-dayStart.Day()),
+	})
+	if err != nil {
+		return err
+	}
+
+	return limits.check(from.ID, amount, totals)
+}
@@ -0,0 +1,197 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: transfer_limit.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const deleteAccountTransferLimit = `-- name: DeleteAccountTransferLimit :exec
+DELETE
+FROM account_transfer_limits
+WHERE account_id = $1
+`
+
+func (q *Queries) DeleteAccountTransferLimit(ctx context.Context, accountID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteAccountTransferLimit, accountID)
+	return err
+}
+
+const deleteProductTransferLimit = `-- name: DeleteProductTransferLimit :exec
+DELETE
+FROM product_transfer_limits
+WHERE product = $1
+  AND currency = $2
+`
+
+type DeleteProductTransferLimitParams struct {
+	Product  string `json:"product"`
+	Currency string `json:"currency"`
+}
+
+func (q *Queries) DeleteProductTransferLimit(ctx context.Context, arg DeleteProductTransferLimitParams) error {
+	_, err := q.db.ExecContext(ctx, deleteProductTransferLimit, arg.Product, arg.Currency)
+	return err
+}
+
+const getAccountTransferLimit = `-- name: GetAccountTransferLimit :one
+SELECT account_id, max_amount, daily_amount, monthly_amount, daily_count, updated_at
+FROM account_transfer_limits
+WHERE account_id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetAccountTransferLimit(ctx context.Context, accountID int64) (AccountTransferLimit, error) {
+	row := q.db.QueryRowContext(ctx, getAccountTransferLimit, accountID)
+	var i AccountTransferLimit
+	err := row.Scan(
+		&i.AccountID,
+		&i.MaxAmount,
+		&i.DailyAmount,
+		&i.MonthlyAmount,
+		&i.DailyCount,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getOutgoingTransferTotals = `-- name: GetOutgoingTransferTotals :one
+SELECT COALESCE(SUM(amount) FILTER (WHERE created_at >= $1), 0)::bigint AS daily_amount,
+       COUNT(*) FILTER (WHERE created_at >= $1)                     AS daily_count,
+       COALESCE(SUM(amount), 0)::bigint                                              AS monthly_amount
+FROM transfers
+WHERE from_account_id = $2
+  AND created_at >= $3
+`
+
+type GetOutgoingTransferTotalsParams struct {
+	DayStart   time.Time `json:"dayStart"`
+	AccountID  int64     `json:"accountID"`
+	MonthStart time.Time `json:"monthStart"`
+}
+
+type GetOutgoingTransferTotalsRow struct {
+	DailyAmount   int64 `json:"dailyAmount"`
+	DailyCount    int64 `json:"dailyCount"`
+	MonthlyAmount int64 `json:"monthlyAmount"`
+}
+
+func (q *Queries) GetOutgoingTransferTotals(ctx context.Context, arg GetOutgoingTransferTotalsParams) (GetOutgoingTransferTotalsRow, error) {
+	row := q.db.QueryRowContext(ctx, getOutgoingTransferTotals, arg.DayStart, arg.AccountID, arg.MonthStart)
+	var i GetOutgoingTransferTotalsRow
+	err := row.Scan(&i.DailyAmount, &i.DailyCount, &i.MonthlyAmount)
+	return i, err
+}
+
+const getProductTransferLimit = `-- name: GetProductTransferLimit :one
+SELECT product, currency, max_amount, daily_amount, monthly_amount, daily_count, created_at
+FROM product_transfer_limits
+WHERE product = $1
+  AND currency = $2
+LIMIT 1
+`
+
+type GetProductTransferLimitParams struct {
+	Product  string `json:"product"`
+	Currency string `json:"currency"`
+}
+
+func (q *Queries) GetProductTransferLimit(ctx context.Context, arg GetProductTransferLimitParams) (ProductTransferLimit, error) {
+	row := q.db.QueryRowContext(ctx, getProductTransferLimit, arg.Product, arg.Currency)
+	var i ProductTransferLimit
+	err := row.Scan(
+		&i.Product,
+		&i.Currency,
+		&i.MaxAmount,
+		&i.DailyAmount,
+		&i.MonthlyAmount,
+		&i.DailyCount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const setAccountTransferLimit = `-- name: SetAccountTransferLimit :one
+INSERT INTO account_transfer_limits (account_id, max_amount, daily_amount, monthly_amount, daily_count)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (account_id) DO UPDATE SET max_amount     = excluded.max_amount,
+                                       daily_amount   = excluded.daily_amount,
+                                       monthly_amount = excluded.monthly_amount,
+                                       daily_count    = excluded.daily_count,
+                                       updated_at     = now()
+RETURNING account_id, max_amount, daily_amount, monthly_amount, daily_count, updated_at
+`
+
+type SetAccountTransferLimitParams struct {
+	AccountID     int64         `json:"accountID"`
+	MaxAmount     sql.NullInt64 `json:"maxAmount"`
+	DailyAmount   sql.NullInt64 `json:"dailyAmount"`
+	MonthlyAmount sql.NullInt64 `json:"monthlyAmount"`
+	DailyCount    sql.NullInt32 `json:"dailyCount"`
+}
+
+func (q *Queries) SetAccountTransferLimit(ctx context.Context, arg SetAccountTransferLimitParams) (AccountTransferLimit, error) {
+	row := q.db.QueryRowContext(ctx, setAccountTransferLimit,
+		arg.AccountID,
+		arg.MaxAmount,
+		arg.DailyAmount,
+		arg.MonthlyAmount,
+		arg.DailyCount,
+	)
+	var i AccountTransferLimit
+	err := row.Scan(
+		&i.AccountID,
+		&i.MaxAmount,
+		&i.DailyAmount,
+		&i.MonthlyAmount,
+		&i.DailyCount,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const setProductTransferLimit = `-- name: SetProductTransferLimit :one
+INSERT INTO product_transfer_limits (product, currency, max_amount, daily_amount, monthly_amount, daily_count)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (product, currency) DO UPDATE SET max_amount     = excluded.max_amount,
+                                              daily_amount   = excluded.daily_amount,
+                                              monthly_amount = excluded.monthly_amount,
+                                              daily_count    = excluded.daily_count
+RETURNING product, currency, max_amount, daily_amount, monthly_amount, daily_count, created_at
+`
+
+type SetProductTransferLimitParams struct {
+	Product       string        `json:"product"`
+	Currency      string        `json:"currency"`
+	MaxAmount     sql.NullInt64 `json:"maxAmount"`
+	DailyAmount   sql.NullInt64 `json:"dailyAmount"`
+	MonthlyAmount sql.NullInt64 `json:"monthlyAmount"`
+	DailyCount    sql.NullInt32 `json:"dailyCount"`
+}
+
+func (q *Queries) SetProductTransferLimit(ctx context.Context, arg SetProductTransferLimitParams) (ProductTransferLimit, error) {
+	row := q.db.QueryRowContext(ctx, setProductTransferLimit,
+		arg.Product,
+		arg.Currency,
+		arg.MaxAmount,
+		arg.DailyAmount,
+		arg.MonthlyAmount,
+		arg.DailyCount,
+	)
+	var i ProductTransferLimit
+	err := row.Scan(
+		&i.Product,
+		&i.Currency,
+		&i.MaxAmount,
+		&i.DailyAmount,
+		&i.MonthlyAmount,
+		&i.DailyCount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
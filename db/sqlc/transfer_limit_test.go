@@ -0,0 +1,194 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/stretchr/testify/require"
+)
+
+// useProductTransferLimit sets the transfer limits of a product and currency, which are deleted at the end
+// of the test so that they do not limit the transfers of other tests
+func useProductTransferLimit(t *testing.T, arg SetProductTransferLimitParams) {
+	_, err := testQueries.SetProductTransferLimit(context.Background(), arg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, testQueries.DeleteProductTransferLimit(context.Background(), DeleteProductTransferLimitParams{
+			Product:  arg.Product,
+			Currency: arg.Currency,
+		}))
+	})
+}
+
+func TestEffectiveTransferLimits(t *testing.T) {
+	product := ProductTransferLimit{
+		MaxAmount:   sql.NullInt64{Int64: 100, Valid: true},
+		DailyAmount: sql.NullInt64{Int64: 500, Valid: true},
+		DailyCount:  sql.NullInt32{Int32: 3, Valid: true},
+	}
+	account := AccountTransferLimit{
+		MaxAmount:     sql.NullInt64{Int64: 200, Valid: true},
+		MonthlyAmount: sql.NullInt64{Int64: 1_000, Valid: true},
+	}
+
+	require.Equal(t, TransferLimits{
+		MaxAmount:     sql.NullInt64{Int64: 200, Valid: true},
+		DailyAmount:   sql.NullInt64{Int64: 500, Valid: true},
+		MonthlyAmount: sql.NullInt64{Int64: 1_000, Valid: true},
+		DailyCount:    sql.NullInt32{Int32: 3, Valid: true},
+	}, effectiveTransferLimits(product, account))
+}
+
+func TestTransferLimits_Check(t *testing.T) {
+	limits := TransferLimits{
+		MaxAmount:     sql.NullInt64{Int64: 100, Valid: true},
+		DailyAmount:   sql.NullInt64{Int64: 250, Valid: true},
+		MonthlyAmount: sql.NullInt64{Int64: 1_000, Valid: true},
+		DailyCount:    sql.NullInt32{Int32: 3, Valid: true},
+	}
+
+	testCases := []struct {
+		name      string
+		amount    int64
+		totals    GetOutgoingTransferTotalsRow
+		limit     string
+		remaining int64
+	}{
+		{
+			name:   "WithinLimits",
+			amount: 100,
+			totals: GetOutgoingTransferTotalsRow{DailyAmount: 150, DailyCount: 2, MonthlyAmount: 900},
+		},
+		{
+			name:      "MaxAmount",
+			amount:    101,
+			limit:     TransferLimitMaxAmount,
+			remaining: 100,
+		},
+		{
+			name:      "DailyCount",
+			amount:    10,
+			totals:    GetOutgoingTransferTotalsRow{DailyAmount: 30, DailyCount: 3, MonthlyAmount: 30},
+			limit:     TransferLimitDailyCount,
+			remaining: 0,
+		},
+		{
+			name:      "DailyAmount",
+			amount:    100,
+			totals:    GetOutgoingTransferTotalsRow{DailyAmount: 200, DailyCount: 1, MonthlyAmount: 200},
+			limit:     TransferLimitDailyAmount,
+			remaining: 50,
+		},
+		{
+			name:      "MonthlyAmount",
+			amount:    100,
+			totals:    GetOutgoingTransferTotalsRow{DailyAmount: 0, DailyCount: 0, MonthlyAmount: 950},
+			limit:     TransferLimitMonthlyAmount,
+			remaining: 50,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := limits.check(1, tc.amount, tc.totals)
+			if tc.limit == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, ErrTransferLimitExceeded)
+			var limitErr *TransferLimitError
+			require.True(t, errors.As(err, &limitErr))
+			require.Equal(t, tc.limit, limitErr.Limit)
+			require.Equal(t, tc.remaining, limitErr.Remaining)
+		})
+	}
+
+	require.NoError(t, TransferLimits{}.check(1, 1_000_000, GetOutgoingTransferTotalsRow{DailyCount: 1_000}))
+}
+
+func TestStore_TransferTxProductLimit(t *testing.T) {
+	store := NewStore(testDB)
+	from := createSavingsAccount(t, 1_000, util.BRL)
+	to := createAccountWithCurrency(t, 0, util.BRL)
+
+	useProductTransferLimit(t, SetProductTransferLimitParams{
+		Product:     util.SavingsProduct,
+		Currency:    util.BRL,
+		DailyAmount: sql.NullInt64{Int64: 300, Valid: true},
+	})
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{FromAccountID: from.ID, ToAccountID: to.ID, Amount: 200})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{FromAccountID: from.ID, ToAccountID: to.ID, Amount: 200})
+	var limitErr *TransferLimitError
+	require.True(t, errors.As(err, &limitErr))
+	require.Equal(t, from.ID, limitErr.AccountID)
+	require.Equal(t, TransferLimitDailyAmount, limitErr.Limit)
+	require.Equal(t, int64(300), limitErr.Allowed)
+	require.Equal(t, int64(100), limitErr.Remaining)
+
+	// the rejected transfer is rolled back
+	account, err := testQueries.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(800), account.Balance)
+}
+
+func TestStore_SetAccountTransferLimitTx(t *testing.T) {
+	store := NewStore(testDB)
+	from := createSavingsAccount(t, 1_000, util.BRL)
+	to := createAccountWithCurrency(t, 0, util.BRL)
+
+	useProductTransferLimit(t, SetProductTransferLimitParams{
+		Product:   util.SavingsProduct,
+		Currency:  util.BRL,
+		MaxAmount: sql.NullInt64{Int64: 50, Valid: true},
+	})
+
+	result, err := store.SetAccountTransferLimitTx(context.Background(), SetAccountTransferLimitTxParams{
+		SetAccountTransferLimitParams: SetAccountTransferLimitParams{
+			AccountID:  from.ID,
+			MaxAmount:  sql.NullInt64{Int64: 500, Valid: true},
+			DailyCount: sql.NullInt32{Int32: 1, Valid: true},
+		},
+		Audit: AuditParams{Actor: from.Owner},
+	})
+	require.NoError(t, err)
+	require.Equal(t, from.ID, result.Limit.AccountID)
+	require.Equal(t, TransferLimits{
+		MaxAmount:  sql.NullInt64{Int64: 500, Valid: true},
+		DailyCount: sql.NullInt32{Int32: 1, Valid: true},
+	}, result.Effective)
+
+	events, err := testQueries.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		Action:   sql.NullString{String: AuditActionSetAccountTransferLimit, Valid: true},
+		TargetID: sql.NullString{String: auditID(from.ID), Valid: true},
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, from.Owner, events[0].Actor)
+
+	// the override lifts the max amount of the product but allows a single transfer a day
+	_, err = store.TransferTx(context.Background(), TransferTxParams{FromAccountID: from.ID, ToAccountID: to.ID, Amount: 400})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{FromAccountID: from.ID, ToAccountID: to.ID, Amount: 10})
+	var limitErr *TransferLimitError
+	require.True(t, errors.As(err, &limitErr))
+	require.Equal(t, TransferLimitDailyCount, limitErr.Limit)
+	require.Zero(t, limitErr.Remaining)
+}
+
+func TestStore_SetAccountTransferLimitTxAccountNotFound(t *testing.T) {
+	store := NewStore(testDB)
+
+	_, err := store.SetAccountTransferLimitTx(context.Background(), SetAccountTransferLimitTxParams{
+		SetAccountTransferLimitParams: SetAccountTransferLimitParams{AccountID: -1},
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,17 @@
+package db
+
+import "errors"
+
+// Statuses of a transfer parked for review by the risk screening
+const (
+	TransferReviewPending  = "pending_review"
+	TransferReviewApproved = "approved"
+	TransferReviewRejected = "rejected"
+)
+
+var (
+	// ErrTransferReviewNotPending is returned when approving or rejecting a transfer that was already reviewed
+	ErrTransferReviewNotPending = errors.New("transfer review is not pending")
+	// ErrAccountFrozen is returned when executing a reviewed transfer after one of its accounts was frozen
+	ErrAccountFrozen = errors.New("account is frozen")
+)
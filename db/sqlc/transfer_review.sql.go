@@ -0,0 +1,209 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.17.2
+// source: transfer_review.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+const createTransferReview = `-- name: CreateTransferReview :one
+INSERT INTO transfer_reviews (owner, from_account_id, to_account_id, amount, currency, score, reasons)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, owner, from_account_id, to_account_id, amount, currency, status, score, reasons, reviewer, transfer_id, reviewed_at, created_at
+`
+
+type CreateTransferReviewParams struct {
+	Owner         string   `json:"owner"`
+	FromAccountID int64    `json:"fromAccountID"`
+	ToAccountID   int64    `json:"toAccountID"`
+	Amount        int64    `json:"amount"`
+	Currency      string   `json:"currency"`
+	Score         int32    `json:"score"`
+	Reasons       []string `json:"reasons"`
+}
+
+func (q *Queries) CreateTransferReview(ctx context.Context, arg CreateTransferReviewParams) (TransferReview, error) {
+	row := q.db.QueryRowContext(ctx, createTransferReview,
+		arg.Owner,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Currency,
+		arg.Score,
+		pq.Array(arg.Reasons),
+	)
+	var i TransferReview
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.Score,
+		pq.Array(&i.Reasons),
+		&i.Reviewer,
+		&i.TransferID,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTransferReview = `-- name: GetTransferReview :one
+SELECT id, owner, from_account_id, to_account_id, amount, currency, status, score, reasons, reviewer, transfer_id, reviewed_at, created_at
+FROM transfer_reviews
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetTransferReview(ctx context.Context, id int64) (TransferReview, error) {
+	row := q.db.QueryRowContext(ctx, getTransferReview, id)
+	var i TransferReview
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.Score,
+		pq.Array(&i.Reasons),
+		&i.Reviewer,
+		&i.TransferID,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTransferReviewForUpdate = `-- name: GetTransferReviewForUpdate :one
+SELECT id, owner, from_account_id, to_account_id, amount, currency, status, score, reasons, reviewer, transfer_id, reviewed_at, created_at
+FROM transfer_reviews
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetTransferReviewForUpdate(ctx context.Context, id int64) (TransferReview, error) {
+	row := q.db.QueryRowContext(ctx, getTransferReviewForUpdate, id)
+	var i TransferReview
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.Score,
+		pq.Array(&i.Reasons),
+		&i.Reviewer,
+		&i.TransferID,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listTransferReviews = `-- name: ListTransferReviews :many
+SELECT id, owner, from_account_id, to_account_id, amount, currency, status, score, reasons, reviewer, transfer_id, reviewed_at, created_at
+FROM transfer_reviews
+WHERE status = $1
+ORDER BY created_at
+LIMIT $2 OFFSET $3
+`
+
+type ListTransferReviewsParams struct {
+	Status string `json:"status"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+func (q *Queries) ListTransferReviews(ctx context.Context, arg ListTransferReviewsParams) ([]TransferReview, error) {
+	rows, err := q.db.QueryContext(ctx, listTransferReviews, arg.Status, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransferReview{}
+	for rows.Next() {
+		var i TransferReview
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.Status,
+			&i.Score,
+			pq.Array(&i.Reasons),
+			&i.Reviewer,
+			&i.TransferID,
+			&i.ReviewedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateTransferReview = `-- name: UpdateTransferReview :one
+UPDATE transfer_reviews
+SET status      = $2,
+    reviewer    = $3,
+    transfer_id = $4,
+    reviewed_at = now()
+WHERE id = $1
+RETURNING id, owner, from_account_id, to_account_id, amount, currency, status, score, reasons, reviewer, transfer_id, reviewed_at, created_at
+`
+
+type UpdateTransferReviewParams struct {
+	ID         int64          `json:"id"`
+	Status     string         `json:"status"`
+	Reviewer   sql.NullString `json:"reviewer"`
+	TransferID sql.NullInt64  `json:"transferID"`
+}
+
+func (q *Queries) UpdateTransferReview(ctx context.Context, arg UpdateTransferReviewParams) (TransferReview, error) {
+	row := q.db.QueryRowContext(ctx, updateTransferReview,
+		arg.ID,
+		arg.Status,
+		arg.Reviewer,
+		arg.TransferID,
+	)
+	var i TransferReview
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.Score,
+		pq.Array(&i.Reasons),
+		&i.Reviewer,
+		&i.TransferID,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,136 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomTransferReview(t *testing.T, store Store, from, to Account, amount int64) TransferReview {
+	arg := CreateTransferReviewTxParams{
+		CreateTransferReviewParams: CreateTransferReviewParams{
+			Owner:         from.Owner,
+			FromAccountID: from.ID,
+			ToAccountID:   to.ID,
+			Amount:        amount,
+			Currency:      from.Currency,
+			Score:         40,
+			Reasons:       []string{"new_payee: first transfer"},
+		},
+		Audit: AuditParams{Actor: from.Owner},
+	}
+	result, err := store.CreateTransferReviewTx(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.NotZero(t, result.TransferReview.ID)
+	require.Equal(t, arg.Owner, result.TransferReview.Owner)
+	require.Equal(t, arg.Amount, result.TransferReview.Amount)
+	require.Equal(t, arg.Reasons, result.TransferReview.Reasons)
+	require.Equal(t, TransferReviewPending, result.TransferReview.Status)
+	require.False(t, result.TransferReview.Reviewer.Valid)
+	require.False(t, result.TransferReview.TransferID.Valid)
+
+	return result.TransferReview
+}
+
+func TestStore_ReviewTransferTxApprove(t *testing.T) {
+	store := NewStore(testDB)
+	admin := createRandomUser(t)
+	from := createAccountWithCurrency(t, 1_000, util.BRL)
+	to := createAccountWithCurrency(t, 0, util.BRL)
+	review := createRandomTransferReview(t, store, from, to, 300)
+
+	result, err := store.ReviewTransferTx(context.Background(), ReviewTransferTxParams{
+		ID:       review.ID,
+		Approve:  true,
+		Reviewer: admin.Username,
+		Audit:    AuditParams{Actor: admin.Username},
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferReviewApproved, result.TransferReview.Status)
+	require.Equal(t, admin.Username, result.TransferReview.Reviewer.String)
+	require.Equal(t, result.Transfer.Transfer.ID, result.TransferReview.TransferID.Int64)
+	require.WithinDuration(t, time.Now(), result.TransferReview.ReviewedAt.Time, time.Minute)
+	require.Equal(t, int64(700), result.Transfer.FromAccount.Balance)
+	require.Equal(t, int64(300), result.Transfer.ToAccount.Balance)
+
+	_, err = store.ReviewTransferTx(context.Background(), ReviewTransferTxParams{ID: review.ID, Reviewer: admin.Username})
+	require.ErrorIs(t, err, ErrTransferReviewNotPending)
+}
+
+func TestStore_ReviewTransferTxReject(t *testing.T) {
+	store := NewStore(testDB)
+	admin := createRandomUser(t)
+	from := createAccountWithCurrency(t, 1_000, util.BRL)
+	to := createAccountWithCurrency(t, 0, util.BRL)
+	review := createRandomTransferReview(t, store, from, to, 300)
+
+	result, err := store.ReviewTransferTx(context.Background(), ReviewTransferTxParams{
+		ID:       review.ID,
+		Reviewer: admin.Username,
+		Audit:    AuditParams{Actor: admin.Username},
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferReviewRejected, result.TransferReview.Status)
+	require.False(t, result.TransferReview.TransferID.Valid)
+	require.Zero(t, result.Transfer.Transfer.ID)
+
+	account, err := testQueries.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance, account.Balance)
+}
+
+func TestStore_ReviewTransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+	admin := createRandomUser(t)
+	from := createAccountWithCurrency(t, 100, util.BRL)
+	to := createAccountWithCurrency(t, 0, util.BRL)
+	review := createRandomTransferReview(t, store, from, to, 300)
+
+	_, err := store.ReviewTransferTx(context.Background(), ReviewTransferTxParams{
+		ID:       review.ID,
+		Approve:  true,
+		Reviewer: admin.Username,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// the review stays pending so that it can still be rejected
+	review, err = testQueries.GetTransferReview(context.Background(), review.ID)
+	require.NoError(t, err)
+	require.Equal(t, TransferReviewPending, review.Status)
+}
+
+func TestQueries_CountTransfersBetween(t *testing.T) {
+	from := createAccountWithCurrency(t, 1_000, util.BRL)
+	to := createAccountWithCurrency(t, 0, util.BRL)
+	arg := CountTransfersBetweenParams{FromAccountID: from.ID, ToAccountID: to.ID}
+
+	count, err := testQueries.CountTransfersBetween(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	_, err = NewStore(testDB).TransferTx(context.Background(), TransferTxParams{FromAccountID: from.ID, ToAccountID: to.ID, Amount: 10})
+	require.NoError(t, err)
+
+	count, err = testQueries.CountTransfersBetween(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	count, err = testQueries.CountOutgoingTransfersSince(context.Background(), CountOutgoingTransfersSinceParams{
+		FromAccountID: from.ID,
+		Since:         time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
+
+func TestQueries_GetLatestSessionNotFound(t *testing.T) {
+	user := createRandomUser(t)
+
+	_, err := testQueries.GetLatestSession(context.Background(), user.Username)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,41 @@
+package db
+
+import "context"
+
+type AcceptAccountMemberTxParams struct {
+	AccountID int64
+	Username  string
+	Audit     AuditParams
+}
+
+type AcceptAccountMemberTxResult struct {
+	Member AccountMember
+}
+
+// AcceptAccountMemberTx activates the pending membership of a user in an account
+// and records it in the audit log within a single database transaction
+func (store *SQLStore) AcceptAccountMemberTx(ctx context.Context, arg AcceptAccountMemberTxParams) (AcceptAccountMemberTxResult, error) {
+	var result AcceptAccountMemberTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		member := GetAccountMemberParams{AccountID: arg.AccountID, Username: arg.Username}
+		before, err := queries.GetAccountMember(ctx, member)
+		if err != nil {
+			return err
+		}
+
+		if before.Status != AccountMemberInvited {
+			return ErrAccountMemberNotInvited
+		}
+
+		result.Member, err = queries.AcceptAccountMember(ctx, AcceptAccountMemberParams(member))
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionAcceptAccountMember, AuditTargetAccount,
+			auditID(result.Member.AccountID), before, result.Member)
+	})
+
+	return result, err
+}
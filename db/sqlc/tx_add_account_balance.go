@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+type AddAccountBalanceTxParams struct {
+	AddAccountBalanceParams
+	Audit AuditParams
+}
+
+type AddAccountBalanceTxResult struct {
+	Account Account
+	Journal Journal
+	Entry   Entry
+	// FundingEntry is the entry debiting the funding account of the currency of the account
+	FundingEntry Entry
+}
+
+// AddAccountBalanceTx adds an amount to an account balance as a journal debiting the funding account of its currency,
+// so that the past balances can be rebuilt from the entries and the ledger stays balanced,
+// and records the change in the audit log within a single database transaction.
+// Adding to a frozen account, or from a frozen funding account, returns an error wrapping ErrAccountFrozen.
+func (store *SQLStore) AddAccountBalanceTx(ctx context.Context, arg AddAccountBalanceTxParams) (AddAccountBalanceTxResult, error) {
+	var result AddAccountBalanceTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		account, err := queries.GetAccount(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		funding, err := queries.GetFundingAccount(ctx, account.Currency)
+		if err != nil {
+			return fmt.Errorf("cannot get funding account for %s: %w", account.Currency, err)
+		}
+
+		locked, err := lockAccounts(ctx, queries, []int64{arg.ID, funding.AccountID})
+		if err != nil {
+			return err
+		}
+		before := locked[arg.ID]
+
+		var entries []Entry
+		var accounts map[int64]Account
+		result.Journal, entries, accounts, err = postJournal(ctx, queries, fmt.Sprintf("deposit into account %d", arg.ID),
+			[]Posting{
+				{AccountID: arg.ID, Amount: arg.Amount},
+				{AccountID: funding.AccountID, Amount: -arg.Amount},
+			})
+		if err != nil {
+			return err
+		}
+		result.Entry, result.FundingEntry = entries[0], entries[1]
+		result.Account = accounts[arg.ID]
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionUpdateAccountBalance, AuditTargetAccount,
+			auditID(result.Account.ID), before, result.Account)
+	})
+
+	return result, err
+}
@@ -0,0 +1,31 @@
+package db
+
+import "context"
+
+type AddOrganizationMemberTxParams struct {
+	CreateOrganizationMemberParams
+	Audit AuditParams
+}
+
+type AddOrganizationMemberTxResult struct {
+	Member OrganizationMember
+}
+
+// AddOrganizationMemberTx adds a user to an organization with a role
+// and records it in the audit log within a single database transaction
+func (store *SQLStore) AddOrganizationMemberTx(ctx context.Context, arg AddOrganizationMemberTxParams) (AddOrganizationMemberTxResult, error) {
+	var result AddOrganizationMemberTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		var err error
+		result.Member, err = queries.CreateOrganizationMember(ctx, arg.CreateOrganizationMemberParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionAddOrganizationMember, AuditTargetOrganization,
+			auditID(result.Member.OrganizationID), nil, result.Member)
+	})
+
+	return result, err
+}
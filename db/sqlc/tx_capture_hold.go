@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type CaptureHoldTxParams struct {
+	ID int64
+	// Amount to capture, the whole hold when zero. The remainder of a partial capture is released.
+	Amount int64
+	Now    time.Time
+	Audit  AuditParams
+}
+
+type CaptureHoldTxResult struct {
+	Hold     Hold
+	Transfer TransferTxResult
+}
+
+// CaptureHoldTx captures a pending hold into a transfer to the account it was authorized for,
+// releasing the held amount, within a single database transaction
+func (store *SQLStore) CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error) {
+	var result CaptureHoldTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		before, err := queries.GetHoldForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if before.Status != HoldPending {
+			return ErrHoldNotPending
+		}
+
+		if !arg.Now.Before(before.ExpiresAt) {
+			return ErrHoldExpired
+		}
+
+		amount := arg.Amount
+		if amount == 0 {
+			amount = before.Amount
+		}
+		if amount > before.Amount {
+			return ErrCaptureAmount
+		}
+
+		if _, _, err := lockTransferAccounts(ctx, queries, before.AccountID, before.ToAccountID); err != nil {
+			return err
+		}
+
+		_, err = queries.AddAccountHeldBalance(ctx, AddAccountHeldBalanceParams{
+			Amount: -(before.Amount + before.ReservedFee),
+			ID:     before.AccountID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Transfer, err = transfer(ctx, queries, TransferTxParams{
+			FromAccountID: before.AccountID,
+			ToAccountID:   before.ToAccountID,
+			Amount:        amount,
+			Audit:         arg.Audit,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = queries.UpdateHold(ctx, UpdateHoldParams{
+			ID:             before.ID,
+			Status:         HoldCaptured,
+			CapturedAmount: amount,
+			TransferID:     sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionCaptureHold, AuditTargetHold,
+			auditID(result.Hold.ID), before, result.Hold)
+	})
+
+	return result, err
+}
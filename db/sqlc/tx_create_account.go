@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+type CreateAccountTxParams struct {
+	CreateAccountParams
+	// OrganizationID is set when the account is owned by an organization rather than by its Owner
+	OrganizationID sql.NullInt64
+	Audit          AuditParams
+}
+
+type CreateAccountTxResult struct {
+	Account Account
+}
+
+// CreateAccountTx creates an account, records it in the audit log and emits the account.created event
+// within a single database transaction
+func (store *SQLStore) CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (CreateAccountTxResult, error) {
+	var result CreateAccountTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		var err error
+
+		result.Account, err = queries.CreateAccount(ctx, arg.CreateAccountParams)
+		if err != nil {
+			return err
+		}
+
+		if arg.OrganizationID.Valid {
+			result.Account, err = queries.SetAccountOrganization(ctx, SetAccountOrganizationParams{
+				ID:             result.Account.ID,
+				OrganizationID: arg.OrganizationID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		err = recordAudit(ctx, queries, arg.Audit, AuditActionCreateAccount, AuditTargetAccount,
+			auditID(result.Account.ID), nil, result.Account)
+		if err != nil {
+			return err
+		}
+
+		return recordDomainEvent(ctx, queries, EventAccountCreated, result.Account, result.Account.Owner)
+	})
+
+	return result, err
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+type CreateHoldTxParams struct {
+	// CreateHoldParams of the hold, whose ReservedFee is quoted by the transaction
+	CreateHoldParams
+	Audit AuditParams
+}
+
+type CreateHoldTxResult struct {
+	Hold    Hold
+	Account Account
+}
+
+// CreateHoldTx authorizes an amount on an account, which reduces its available balance without moving money,
+// and records it in the audit log within a single database transaction.
+// The fee of the transfer the hold is captured into is reserved along with the amount, and the hold is subject
+// to the transfer limits of the account, so that a hold that is granted can be captured. It fails with
+// ErrInsufficientFunds when the available balance does not cover the amount and its fee.
+func (store *SQLStore) CreateHoldTx(ctx context.Context, arg CreateHoldTxParams) (CreateHoldTxResult, error) {
+	var result CreateHoldTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		account, err := queries.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		err = checkTransferLimits(ctx, queries, account, arg.Amount, time.Now())
+		if err != nil {
+			return err
+		}
+
+		fee, err := quoteFee(ctx, queries, account.Currency, arg.Amount)
+		if err != nil {
+			return err
+		}
+
+		if account.AvailableBalance < arg.Amount+fee.Amount {
+			return ErrInsufficientFunds
+		}
+
+		params := arg.CreateHoldParams
+		params.ReservedFee = fee.Amount
+		result.Hold, err = queries.CreateHold(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = queries.AddAccountHeldBalance(ctx, AddAccountHeldBalanceParams{
+			Amount: arg.Amount + fee.Amount,
+			ID:     arg.AccountID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionCreateHold, AuditTargetHold,
+			auditID(result.Hold.ID), nil, result.Hold)
+	})
+
+	return result, err
+}
@@ -0,0 +1,42 @@
+package db
+
+import "context"
+
+type CreateOrganizationTxParams struct {
+	CreateOrganizationParams
+	Audit AuditParams
+}
+
+type CreateOrganizationTxResult struct {
+	Organization Organization
+	// Admin is the membership of the user creating the organization
+	Admin OrganizationMember
+}
+
+// CreateOrganizationTx creates an organization with its creator as admin
+// and records it in the audit log within a single database transaction
+func (store *SQLStore) CreateOrganizationTx(ctx context.Context, arg CreateOrganizationTxParams) (CreateOrganizationTxResult, error) {
+	var result CreateOrganizationTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		var err error
+		result.Organization, err = queries.CreateOrganization(ctx, arg.CreateOrganizationParams)
+		if err != nil {
+			return err
+		}
+
+		result.Admin, err = queries.CreateOrganizationMember(ctx, CreateOrganizationMemberParams{
+			OrganizationID: result.Organization.ID,
+			Username:       result.Organization.CreatedBy,
+			Role:           OrganizationRoleAdmin,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionCreateOrganization, AuditTargetOrganization,
+			auditID(result.Organization.ID), nil, result.Organization)
+	})
+
+	return result, err
+}
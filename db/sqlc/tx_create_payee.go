@@ -0,0 +1,32 @@
+package db
+
+import "context"
+
+type CreatePayeeTxParams struct {
+	CreatePayeeParams
+	Audit AuditParams
+}
+
+type CreatePayeeTxResult struct {
+	Payee Payee
+}
+
+// CreatePayeeTx adds a payee to the address book of its owner and records it in the audit log
+// within a single database transaction
+func (store *SQLStore) CreatePayeeTx(ctx context.Context, arg CreatePayeeTxParams) (CreatePayeeTxResult, error) {
+	var result CreatePayeeTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		var err error
+
+		result.Payee, err = queries.CreatePayee(ctx, arg.CreatePayeeParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionCreatePayee, AuditTargetPayee,
+			auditID(result.Payee.ID), nil, result.Payee)
+	})
+
+	return result, err
+}
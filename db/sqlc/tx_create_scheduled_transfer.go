@@ -0,0 +1,33 @@
+package db
+
+import "context"
+
+type CreateScheduledTransferTxParams struct {
+	CreateScheduledTransferParams
+	Audit AuditParams
+}
+
+type CreateScheduledTransferTxResult struct {
+	ScheduledTransfer ScheduledTransfer
+}
+
+// CreateScheduledTransferTx creates a scheduled transfer and records it in the audit log within a single database transaction
+func (store *SQLStore) CreateScheduledTransferTx(
+	ctx context.Context, arg CreateScheduledTransferTxParams,
+) (CreateScheduledTransferTxResult, error) {
+	var result CreateScheduledTransferTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		var err error
+
+		result.ScheduledTransfer, err = queries.CreateScheduledTransfer(ctx, arg.CreateScheduledTransferParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionCreateScheduledTransfer, AuditTargetScheduledTransfer,
+			auditID(result.ScheduledTransfer.ID), nil, result.ScheduledTransfer)
+	})
+
+	return result, err
+}
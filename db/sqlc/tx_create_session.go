@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateSessionTxParams struct {
+	CreateSessionParams
+	Audit AuditParams
+}
+
+type CreateSessionTxResult struct {
+	Session Session
+}
+
+// auditSession is the session state recorded in the audit log, which must never contain the refresh token
+type auditSession struct {
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	UserAgent string    `json:"userAgent"`
+	ClientIp  string    `json:"clientIp"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// CreateSessionTx creates the session of a user login and records the login in the audit log
+// within a single database transaction
+func (store *SQLStore) CreateSessionTx(ctx context.Context, arg CreateSessionTxParams) (CreateSessionTxResult, error) {
+	var result CreateSessionTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		var err error
+
+		result.Session, err = queries.CreateSession(ctx, arg.CreateSessionParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionLoginUser, AuditTargetSession, result.Session.ID.String(),
+			nil, auditSession{
+				ID:        result.Session.ID,
+				Username:  result.Session.Username,
+				UserAgent: result.Session.UserAgent,
+				ClientIp:  result.Session.ClientIp,
+				ExpiresAt: result.Session.ExpiresAt,
+			})
+	})
+
+	return result, err
+}
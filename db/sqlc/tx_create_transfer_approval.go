@@ -0,0 +1,31 @@
+package db
+
+import "context"
+
+type CreateTransferApprovalTxParams struct {
+	CreateTransferApprovalParams
+	Audit AuditParams
+}
+
+type CreateTransferApprovalTxResult struct {
+	TransferApproval TransferApproval
+}
+
+// CreateTransferApprovalTx parks a transfer from an account of an organization until another member approves it,
+// and records it in the audit log within a single database transaction
+func (store *SQLStore) CreateTransferApprovalTx(ctx context.Context, arg CreateTransferApprovalTxParams) (CreateTransferApprovalTxResult, error) {
+	var result CreateTransferApprovalTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		var err error
+		result.TransferApproval, err = queries.CreateTransferApproval(ctx, arg.CreateTransferApprovalParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionCreateTransferApproval, AuditTargetTransferApproval,
+			auditID(result.TransferApproval.ID), nil, result.TransferApproval)
+	})
+
+	return result, err
+}
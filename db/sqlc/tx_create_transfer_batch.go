@@ -0,0 +1,263 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// failTransferBatchTimeout bounds recording a best effort batch as failed
+const failTransferBatchTimeout = 5 * time.Second
+
+type TransferBatchItemParams struct {
+	ToAccountID int64
+	Amount      int64
+}
+
+type CreateTransferBatchTxParams struct {
+	Owner         string
+	FromAccountID int64
+	Currency      string
+	Mode          string
+	Items         []TransferBatchItemParams
+	Audit         AuditParams
+}
+
+type CreateTransferBatchTxResult struct {
+	TransferBatch TransferBatch
+	// Items are in the order of the request
+	Items []TransferBatchItem
+}
+
+// CreateTransferBatchTx executes the transfers of a batch from a single account and records the batch and
+// the status of each of its items. An atomic batch runs in a single database transaction: when an item fails,
+// none of the transfers are kept and the batch is recorded as failed, with the other items canceled.
+// A best effort batch executes every item in its own database transaction and records the failed ones,
+// the batch being recorded as failed when an unexpected error stops it.
+// The batch is recorded in the audit log once processed.
+func (store *SQLStore) CreateTransferBatchTx(ctx context.Context, arg CreateTransferBatchTxParams) (CreateTransferBatchTxResult, error) {
+	if arg.Mode == TransferBatchAtomic {
+		return store.createAtomicTransferBatch(ctx, arg)
+	}
+	return store.createBestEffortTransferBatch(ctx, arg)
+}
+
+func (store *SQLStore) createAtomicTransferBatch(ctx context.Context, arg CreateTransferBatchTxParams) (CreateTransferBatchTxResult, error) {
+	var result CreateTransferBatchTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		batch, err := newTransferBatch(ctx, queries, arg)
+		if err != nil {
+			return err
+		}
+
+		if err := lockTransferBatchAccounts(ctx, queries, arg); err != nil {
+			return err
+		}
+
+		result.Items = make([]TransferBatchItem, 0, len(arg.Items))
+		for i, item := range arg.Items {
+			transferred, itemErr, err := executeTransferBatchItem(ctx, queries, batch, item, arg.Audit)
+			if err != nil {
+				return err
+			}
+			if itemErr != nil {
+				return &errTransferBatchItem{position: i, err: itemErr}
+			}
+
+			batchItem, err := recordTransferBatchItem(ctx, queries, batch.ID, i, item, transferred, nil)
+			if err != nil {
+				return err
+			}
+			result.Items = append(result.Items, batchItem)
+		}
+
+		result.TransferBatch, err = finishTransferBatch(ctx, queries, batch, len(result.Items), arg.Audit)
+		return err
+	})
+
+	var itemErr *errTransferBatchItem
+	if !errors.As(err, &itemErr) {
+		return result, err
+	}
+
+	// the transfers were rolled back, record the batch as failed on its own
+	result = CreateTransferBatchTxResult{}
+	err = store.execTx(ctx, func(queries *Queries) error {
+		batch, err := newTransferBatch(ctx, queries, arg)
+		if err != nil {
+			return err
+		}
+
+		result.Items = make([]TransferBatchItem, 0, len(arg.Items))
+		for i, item := range arg.Items {
+			itemArg := transferBatchItemParams(batch.ID, i, item)
+			itemArg.Status = TransferBatchItemCanceled
+			itemArg.Error = fmt.Sprintf("canceled by the failure of item %d", itemErr.position)
+			if i == itemErr.position {
+				itemArg.Status = TransferBatchItemFailed
+				itemArg.Error = itemErr.err.Error()
+			}
+
+			batchItem, err := queries.CreateTransferBatchItem(ctx, itemArg)
+			if err != nil {
+				return err
+			}
+			result.Items = append(result.Items, batchItem)
+		}
+
+		result.TransferBatch, err = finishTransferBatch(ctx, queries, batch, 0, arg.Audit)
+		return err
+	})
+
+	return result, err
+}
+
+func (store *SQLStore) createBestEffortTransferBatch(ctx context.Context, arg CreateTransferBatchTxParams) (CreateTransferBatchTxResult, error) {
+	var result CreateTransferBatchTxResult
+
+	var batch TransferBatch
+	err := store.execTx(ctx, func(queries *Queries) error {
+		var err error
+		batch, err = newTransferBatch(ctx, queries, arg)
+		return err
+	})
+	if err != nil {
+		return result, err
+	}
+
+	succeeded := 0
+	result.Items = make([]TransferBatchItem, 0, len(arg.Items))
+	for i, item := range arg.Items {
+		err = store.execTx(ctx, func(queries *Queries) error {
+			transferred, itemErr, err := executeTransferBatchItem(ctx, queries, batch, item, arg.Audit)
+			if err != nil {
+				return err
+			}
+
+			batchItem, err := recordTransferBatchItem(ctx, queries, batch.ID, i, item, transferred, itemErr)
+			if err != nil {
+				return err
+			}
+			result.Items = append(result.Items, batchItem)
+			if itemErr == nil {
+				succeeded++
+			}
+			return nil
+		})
+		if err != nil {
+			return result, store.failTransferBatch(batch, succeeded, err)
+		}
+	}
+
+	err = store.execTx(ctx, func(queries *Queries) error {
+		var err error
+		result.TransferBatch, err = finishTransferBatch(ctx, queries, batch, succeeded, arg.Audit)
+		return err
+	})
+	if err != nil {
+		return result, store.failTransferBatch(batch, succeeded, err)
+	}
+
+	return result, nil
+}
+
+// failTransferBatch records as failed a best effort batch stopped by batchErr, rather than leaving it processing,
+// and returns batchErr. It runs on its own context, as batchErr may be the cancellation of the request.
+func (store *SQLStore) failTransferBatch(batch TransferBatch, succeeded int, batchErr error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), failTransferBatchTimeout)
+	defer cancel()
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		_, err := queries.UpdateTransferBatch(ctx, UpdateTransferBatchParams{
+			ID:             batch.ID,
+			Status:         TransferBatchFailed,
+			SucceededCount: int32(succeeded),
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%w, and cannot record batch %d as failed: %v", batchErr, batch.ID, err)
+	}
+
+	return batchErr
+}
+
+// lockTransferBatchAccounts locks the from account and every to account of a batch up front, in ascending ID order,
+// so that batches and transfers sharing accounts can't deadlock. An item to a missing account fails the batch.
+func lockTransferBatchAccounts(ctx context.Context, queries *Queries, arg CreateTransferBatchTxParams) error {
+	ids := make([]int64, 0, len(arg.Items)+1)
+	ids = append(ids, arg.FromAccountID)
+	for _, item := range arg.Items {
+		ids = append(ids, item.ToAccountID)
+	}
+
+	_, err := lockAccounts(ctx, queries, ids)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	for i, item := range arg.Items {
+		if _, err := queries.GetAccount(ctx, item.ToAccountID); errors.Is(err, sql.ErrNoRows) {
+			return &errTransferBatchItem{position: i, err: fmt.Errorf("account %d not found", item.ToAccountID)}
+		}
+	}
+
+	return err
+}
+
+// newTransferBatch records a batch being processed
+func newTransferBatch(ctx context.Context, queries *Queries, arg CreateTransferBatchTxParams) (TransferBatch, error) {
+	return queries.CreateTransferBatch(ctx, CreateTransferBatchParams{
+		Owner:         arg.Owner,
+		FromAccountID: arg.FromAccountID,
+		Currency:      arg.Currency,
+		Mode:          arg.Mode,
+		Status:        TransferBatchProcessing,
+		ItemCount:     int32(len(arg.Items)),
+	})
+}
+
+// recordTransferBatchItem records the transfer of an item, or its failure when itemErr is not nil
+func recordTransferBatchItem(
+	ctx context.Context, queries *Queries, batchID int64, position int, item TransferBatchItemParams,
+	transferred TransferTxResult, itemErr error,
+) (TransferBatchItem, error) {
+	arg := transferBatchItemParams(batchID, position, item)
+	if itemErr != nil {
+		arg.Status = TransferBatchItemFailed
+		arg.Error = itemErr.Error()
+	} else {
+		arg.Status = TransferBatchItemSucceeded
+		arg.TransferID = sql.NullInt64{Int64: transferred.Transfer.ID, Valid: true}
+	}
+
+	return queries.CreateTransferBatchItem(ctx, arg)
+}
+
+func transferBatchItemParams(batchID int64, position int, item TransferBatchItemParams) CreateTransferBatchItemParams {
+	return CreateTransferBatchItemParams{
+		BatchID:     batchID,
+		Position:    int32(position),
+		ToAccountID: item.ToAccountID,
+		Amount:      item.Amount,
+	}
+}
+
+// finishTransferBatch sets the status of a processed batch and records it in the audit log
+func finishTransferBatch(ctx context.Context, queries *Queries, batch TransferBatch, succeeded int, audit AuditParams) (TransferBatch, error) {
+	processed, err := queries.UpdateTransferBatch(ctx, UpdateTransferBatchParams{
+		ID:             batch.ID,
+		Status:         transferBatchStatus(int(batch.ItemCount), succeeded),
+		SucceededCount: int32(succeeded),
+	})
+	if err != nil {
+		return processed, err
+	}
+
+	err = recordAudit(ctx, queries, audit, AuditActionCreateTransferBatch, AuditTargetTransferBatch,
+		auditID(processed.ID), nil, processed)
+	return processed, err
+}
@@ -0,0 +1,31 @@
+package db
+
+import "context"
+
+type CreateTransferReviewTxParams struct {
+	CreateTransferReviewParams
+	Audit AuditParams
+}
+
+type CreateTransferReviewTxResult struct {
+	TransferReview TransferReview
+}
+
+// CreateTransferReviewTx parks a transfer flagged by the risk screening until an admin reviews it,
+// and records it in the audit log within a single database transaction
+func (store *SQLStore) CreateTransferReviewTx(ctx context.Context, arg CreateTransferReviewTxParams) (CreateTransferReviewTxResult, error) {
+	var result CreateTransferReviewTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		var err error
+		result.TransferReview, err = queries.CreateTransferReview(ctx, arg.CreateTransferReviewParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionCreateTransferReview, AuditTargetTransferReview,
+			auditID(result.TransferReview.ID), nil, result.TransferReview)
+	})
+
+	return result, err
+}
@@ -4,15 +4,18 @@ import "context"
 
 type CreateUserTxParams struct {
 	CreateUserParams
-	AfterCreate func(user *User) error
+	// AfterCreate returns the messages written to the outbox along with the user,
+	// so that their tasks are only published once the user is committed
+	AfterCreate func(user *User) ([]CreateOutboxMessageParams, error)
+	Audit       AuditParams
 }
 
 type CreateUserTxResult struct {
 	User User
 }
 
-// CreateUserTx performs a money transfer from one account to the other
-// It creates a transfer record, add account entries, and update accounts' balance within a single database transaction
+// CreateUserTx creates a user, the outbox messages of its follow-up tasks, records it in the audit log
+// and emits the user.created event within a single database transaction
 func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error) {
 	var result CreateUserTxResult
 
@@ -23,7 +26,19 @@ func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams)
 		if err != nil {
 			return err
 		}
-		return arg.AfterCreate(&result.User)
+
+		messages, err := arg.AfterCreate(&result.User)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			if _, err := writeOutboxMessage(ctx, queries, message); err != nil {
+				return err
+			}
+		}
+
+		return recordUserCreated(ctx, queries, arg.Audit, result.User)
 	})
 	return result, err
 }
@@ -0,0 +1,33 @@
+package db
+
+import "context"
+
+type CreateWebhookEndpointTxParams struct {
+	CreateWebhookEndpointParams
+	Audit AuditParams
+}
+
+type CreateWebhookEndpointTxResult struct {
+	Endpoint WebhookEndpoint
+}
+
+// CreateWebhookEndpointTx registers a webhook endpoint and records it in the audit log within a single database transaction
+func (store *SQLStore) CreateWebhookEndpointTx(
+	ctx context.Context, arg CreateWebhookEndpointTxParams,
+) (CreateWebhookEndpointTxResult, error) {
+	var result CreateWebhookEndpointTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		var err error
+
+		result.Endpoint, err = queries.CreateWebhookEndpoint(ctx, arg.CreateWebhookEndpointParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionCreateWebhookEndpoint, AuditTargetWebhookEndpoint,
+			auditID(result.Endpoint.ID), nil, newAuditWebhookEndpoint(result.Endpoint))
+	})
+
+	return result, err
+}
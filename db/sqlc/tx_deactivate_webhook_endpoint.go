@@ -0,0 +1,37 @@
+package db
+
+import "context"
+
+type DeactivateWebhookEndpointTxParams struct {
+	EndpointID int64
+	Audit      AuditParams
+}
+
+type DeactivateWebhookEndpointTxResult struct {
+	Endpoint WebhookEndpoint
+}
+
+// DeactivateWebhookEndpointTx stops the deliveries to a webhook endpoint and records it in the audit log
+// within a single database transaction. The endpoint is kept, so that its delivery log is kept as well.
+func (store *SQLStore) DeactivateWebhookEndpointTx(
+	ctx context.Context, arg DeactivateWebhookEndpointTxParams,
+) (DeactivateWebhookEndpointTxResult, error) {
+	var result DeactivateWebhookEndpointTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		before, err := queries.GetWebhookEndpointForUpdate(ctx, arg.EndpointID)
+		if err != nil {
+			return err
+		}
+
+		result.Endpoint, err = queries.DeactivateWebhookEndpoint(ctx, arg.EndpointID)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionDeactivateWebhookEndpoint, AuditTargetWebhookEndpoint,
+			auditID(result.Endpoint.ID), newAuditWebhookEndpoint(before), newAuditWebhookEndpoint(result.Endpoint))
+	})
+
+	return result, err
+}
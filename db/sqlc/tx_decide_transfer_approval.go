@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type DecideTransferApprovalTxParams struct {
+	ID int64
+	// Approve executes the transfer, otherwise it is rejected
+	Approve  bool
+	Approver string
+	Audit    AuditParams
+}
+
+type DecideTransferApprovalTxResult struct {
+	TransferApproval TransferApproval
+	// Transfer is empty when the transfer is rejected
+	Transfer TransferTxResult
+}
+
+// DecideTransferApprovalTx approves or rejects a transfer waiting for approval and records it in the audit log
+// within a single database transaction. Only admins and approvers of the organization other than the initiator
+// can approve a transfer, while the initiator can also reject it. An approved transfer is executed as its initiator,
+// so that it is still subject to the state of its accounts and their transfer limits.
+func (store *SQLStore) DecideTransferApprovalTx(ctx context.Context, arg DecideTransferApprovalTxParams) (DecideTransferApprovalTxResult, error) {
+	var result DecideTransferApprovalTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		before, err := queries.GetTransferApprovalForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if before.Status != TransferApprovalPending {
+			return ErrTransferApprovalNotPending
+		}
+
+		approver, err := AuthorizeOrganization(ctx, queries, before.OrganizationID, arg.Approver)
+		if err != nil {
+			return err
+		}
+
+		update := UpdateTransferApprovalParams{
+			ID:       before.ID,
+			Status:   TransferApprovalRejected,
+			Approver: sql.NullString{String: arg.Approver, Valid: true},
+		}
+		action := AuditActionRejectTransferApproval
+
+		if arg.Approve {
+			if approver.Username == before.Initiator {
+				return ErrSelfApproval
+			}
+			if !approver.CanApprove() {
+				return fmt.Errorf("%w: %s can't approve transfers", ErrOrganizationPermission, approver.Role)
+			}
+
+			result.Transfer, err = executePendingTransfer(ctx, queries, pendingTransfer{
+				Initiator:     before.Initiator,
+				FromAccountID: before.FromAccountID,
+				ToAccountID:   before.ToAccountID,
+				Amount:        before.Amount,
+				Currency:      before.Currency,
+				RequestID:     fmt.Sprintf("transfer_approval:%d", before.ID),
+			})
+			if err != nil {
+				return err
+			}
+
+			update.Status = TransferApprovalApproved
+			update.TransferID = sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}
+			action = AuditActionApproveTransferApproval
+		} else if approver.Username != before.Initiator && !approver.CanApprove() {
+			return fmt.Errorf("%w: %s can't reject transfers", ErrOrganizationPermission, approver.Role)
+		}
+
+		result.TransferApproval, err = queries.UpdateTransferApproval(ctx, update)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, action, AuditTargetTransferApproval,
+			auditID(result.TransferApproval.ID), before, result.TransferApproval)
+	})
+
+	return result, err
+}
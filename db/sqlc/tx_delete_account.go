@@ -0,0 +1,36 @@
+package db
+
+import "context"
+
+type DeleteAccountTxParams struct {
+	AccountID int64
+	Audit     AuditParams
+}
+
+type DeleteAccountTxResult struct {
+	// Account is the state of the account before it was deleted
+	Account Account
+}
+
+// DeleteAccountTx deletes an account and records it in the audit log within a single database transaction
+func (store *SQLStore) DeleteAccountTx(ctx context.Context, arg DeleteAccountTxParams) (DeleteAccountTxResult, error) {
+	var result DeleteAccountTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		var err error
+
+		result.Account, err = queries.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		if err := queries.DeleteAccount(ctx, arg.AccountID); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionDeleteAccount, AuditTargetAccount,
+			auditID(result.Account.ID), result.Account, nil)
+	})
+
+	return result, err
+}
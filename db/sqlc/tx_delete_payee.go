@@ -0,0 +1,37 @@
+package db
+
+import "context"
+
+type DeletePayeeTxParams struct {
+	PayeeID int64
+	Audit   AuditParams
+}
+
+type DeletePayeeTxResult struct {
+	// Payee is the state of the payee before it was deleted
+	Payee Payee
+}
+
+// DeletePayeeTx removes a payee from the address book of its owner and records it in the audit log
+// within a single database transaction
+func (store *SQLStore) DeletePayeeTx(ctx context.Context, arg DeletePayeeTxParams) (DeletePayeeTxResult, error) {
+	var result DeletePayeeTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		var err error
+
+		result.Payee, err = queries.GetPayeeForUpdate(ctx, arg.PayeeID)
+		if err != nil {
+			return err
+		}
+
+		if err := queries.DeletePayee(ctx, arg.PayeeID); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionDeletePayee, AuditTargetPayee,
+			auditID(result.Payee.ID), result.Payee, nil)
+	})
+
+	return result, err
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type ExecuteScheduledTransferTxParams struct {
+	ID  int64
+	Now time.Time
+}
+
+type ExecuteScheduledTransferTxResult struct {
+	ScheduledTransfer ScheduledTransfer
+	// Run is empty when the scheduled transfer was not due anymore, e.g. because another worker executed it
+	Run      ScheduledTransferRun
+	Transfer TransferTxResult
+}
+
+// ExecuteScheduledTransferTx executes a due scheduled transfer, records the outcome of the run and schedules
+// the next one within a single database transaction.
+// A run that cannot be executed, e.g. for insufficient funds or exceeding a transfer limit,
+// is recorded as failed and notifies the owner.
+func (store *SQLStore) ExecuteScheduledTransferTx(
+	ctx context.Context, arg ExecuteScheduledTransferTxParams,
+) (ExecuteScheduledTransferTxResult, error) {
+	var result ExecuteScheduledTransferTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		scheduled, err := queries.GetScheduledTransferForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result.ScheduledTransfer = scheduled
+		if scheduled.Status != ScheduledTransferActive || scheduled.NextRunAt.After(arg.Now) {
+			return nil
+		}
+
+		from, to, err := lockTransferAccounts(ctx, queries, scheduled.FromAccountID, scheduled.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		fee, err := quoteFee(ctx, queries, scheduled.Currency, scheduled.Amount)
+		if err != nil {
+			return err
+		}
+
+		// the owner of the scheduled transfer may have left the account or lost the right to spend from it
+		_, runErr := AuthorizeAccount(ctx, queries, from, scheduled.Owner, AccountPermissionSpend, scheduled.Amount)
+		if runErr != nil && !IsAccountAccessError(runErr) {
+			return runErr
+		}
+		if runErr == nil {
+			runErr = checkScheduledTransfer(scheduled, from, to, fee.Amount)
+		}
+		if runErr == nil {
+			runErr = checkTransferLimits(ctx, queries, from, scheduled.Amount, arg.Now)
+			if runErr != nil && !errors.Is(runErr, ErrTransferLimitExceeded) {
+				return runErr
+			}
+		}
+		if runErr == nil {
+			result.Transfer, err = transfer(ctx, queries, TransferTxParams{
+				FromAccountID: scheduled.FromAccountID,
+				ToAccountID:   scheduled.ToAccountID,
+				Amount:        scheduled.Amount,
+				Audit: AuditParams{
+					Actor:     scheduled.Owner,
+					RequestID: fmt.Sprintf("scheduled_transfer:%d", scheduled.ID),
+				},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		runArg := CreateScheduledTransferRunParams{
+			ScheduledTransferID: scheduled.ID,
+			Status:              ScheduledTransferRunSucceeded,
+			ScheduledFor:        scheduled.NextRunAt,
+		}
+		if runErr != nil {
+			runArg.Status = ScheduledTransferRunFailed
+			runArg.Error = runErr.Error()
+		} else {
+			runArg.TransferID = sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}
+		}
+
+		result.Run, err = queries.CreateScheduledTransferRun(ctx, runArg)
+		if err != nil {
+			return err
+		}
+
+		if runErr != nil {
+			if err := notifyScheduledTransferFailed(ctx, queries, scheduled, result.Run); err != nil {
+				return err
+			}
+		}
+
+		nextRunAt, status := nextSchedule(scheduled, runErr == nil, arg.Now)
+		result.ScheduledTransfer, err = queries.UpdateScheduledTransferSchedule(ctx, UpdateScheduledTransferScheduleParams{
+			ID:        scheduled.ID,
+			NextRunAt: nextRunAt,
+			Status:    status,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// notifyScheduledTransferFailed emails the owner through the outbox and emits the scheduled_transfer.failed event
+func notifyScheduledTransferFailed(ctx context.Context, queries *Queries, scheduled ScheduledTransfer, run ScheduledTransferRun) error {
+	payload, err := json.Marshal(PayloadSendScheduledTransferFailed{RunID: run.ID})
+	if err != nil {
+		return err
+	}
+
+	_, err = writeOutboxMessage(ctx, queries, CreateOutboxMessageParams{
+		TaskType:  TaskSendScheduledTransferFailed,
+		Payload:   payload,
+		Queue:     scheduledTransferNotificationQueue,
+		MaxRetry:  scheduledTransferNotificationMaxRetry,
+		ProcessAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return recordDomainEvent(ctx, queries, EventScheduledTransferFailed, run, scheduled.Owner)
+}
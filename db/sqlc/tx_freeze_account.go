@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+)
+
+type FreezeAccountTxParams struct {
+	AccountID int64
+	Audit     AuditParams
+}
+
+type FreezeAccountTxResult struct {
+	Account Account
+}
+
+// FreezeAccountTx freezes an account, records it in the audit log and emits the account.frozen event
+// within a single database transaction
+func (store *SQLStore) FreezeAccountTx(ctx context.Context, arg FreezeAccountTxParams) (FreezeAccountTxResult, error) {
+	var result FreezeAccountTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		before, err := queries.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = queries.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+			ID:     arg.AccountID,
+			Status: util.FrozenAccountStatus,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = recordAudit(ctx, queries, arg.Audit, AuditActionFreezeAccount, AuditTargetAccount,
+			auditID(result.Account.ID), before, result.Account)
+		if err != nil {
+			return err
+		}
+
+		return recordDomainEvent(ctx, queries, EventAccountFrozen, result.Account, result.Account.Owner)
+	})
+
+	return result, err
+}
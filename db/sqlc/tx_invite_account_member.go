@@ -0,0 +1,31 @@
+package db
+
+import "context"
+
+type InviteAccountMemberTxParams struct {
+	CreateAccountMemberParams
+	Audit AuditParams
+}
+
+type InviteAccountMemberTxResult struct {
+	Member AccountMember
+}
+
+// InviteAccountMemberTx invites a user to an account with a role, which applies once the user accepts it,
+// and records the invitation in the audit log within a single database transaction
+func (store *SQLStore) InviteAccountMemberTx(ctx context.Context, arg InviteAccountMemberTxParams) (InviteAccountMemberTxResult, error) {
+	var result InviteAccountMemberTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		var err error
+		result.Member, err = queries.CreateAccountMember(ctx, arg.CreateAccountMemberParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionInviteAccountMember, AuditTargetAccount,
+			auditID(result.Member.AccountID), nil, result.Member)
+	})
+
+	return result, err
+}
@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+)
+
+// ErrOIDCEmailInUse is returned when an unknown identity has the email of an existing local user.
+// The local emails are not verified, so the identity is not linked to that user, who could have been
+// registered with the email of the identity's owner by someone else.
+var ErrOIDCEmailInUse = errors.New("email is already used by a local user")
+
+type LinkOIDCUserTxParams struct {
+	Issuer         string
+	Subject        string
+	Email          string
+	FullName       string
+	Username       string
+	HashedPassword string
+	Audit          AuditParams
+}
+
+type LinkOIDCUserTxResult struct {
+	User     User
+	Identity UserIdentity
+	Created  bool
+}
+
+// LinkOIDCUserTx resolves the local user behind an external identity.
+// An identity that was seen before returns its linked user, otherwise a new user is provisioned and linked,
+// unless a local user already owns the same email, all within a single database transaction
+func (store *SQLStore) LinkOIDCUserTx(ctx context.Context, arg LinkOIDCUserTxParams) (LinkOIDCUserTxResult, error) {
+	var result LinkOIDCUserTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		var err error
+
+		result.Identity, err = queries.GetUserIdentity(ctx, GetUserIdentityParams{
+			Issuer:  arg.Issuer,
+			Subject: arg.Subject,
+		})
+		if err == nil {
+			result.User, err = queries.GetUser(ctx, result.Identity.Username)
+			return err
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		_, err = queries.GetUserByEmail(ctx, arg.Email)
+		if err == nil {
+			return ErrOIDCEmailInUse
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		username, err := availableUsername(ctx, queries, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		result.User, err = queries.CreateUser(ctx, CreateUserParams{
+			Username:       username,
+			HashedPassword: arg.HashedPassword,
+			FullName:       arg.FullName,
+			Email:          arg.Email,
+		})
+		if err != nil {
+			return err
+		}
+		result.Created = true
+
+		err = recordUserCreated(ctx, queries, arg.Audit, result.User)
+		if err != nil {
+			return err
+		}
+
+		result.Identity, err = queries.CreateUserIdentity(ctx, CreateUserIdentityParams{
+			Issuer:   arg.Issuer,
+			Subject:  arg.Subject,
+			Username: result.User.Username,
+			Email:    arg.Email,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// availableUsername returns the candidate username, or the candidate with a random suffix when it is taken
+func availableUsername(ctx context.Context, queries *Queries, candidate string) (string, error) {
+	username := candidate
+	for i := 0; i < 5; i++ {
+		_, err := queries.GetUser(ctx, username)
+		if errors.Is(err, sql.ErrNoRows) {
+			return username, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		username = fmt.Sprintf("%s_%s", candidate, util.RandomString(4))
+	}
+
+	return "", fmt.Errorf("cannot find an available username for %s", candidate)
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type PostInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// PeriodStart identifies the posting, at most one is made per account and period
+	PeriodStart time.Time `json:"period_start"`
+	// PeriodEnd is exclusive, unposted accruals before it are posted, including late accruals of earlier periods
+	PeriodEnd time.Time   `json:"period_end"`
+	Audit     AuditParams `json:"-"`
+}
+
+type PostInterestTxResult struct {
+	Posting InterestPosting `json:"posting"`
+	Account Account         `json:"account"`
+	// Journal, Entry and ExpenseEntry are empty when the accrued interest rounds to zero
+	Journal      Journal `json:"journal"`
+	Entry        Entry   `json:"entry"`
+	ExpenseEntry Entry   `json:"expense_entry"`
+}
+
+// PostInterestTx posts the interest accrued by an account in a period, rounded to the minor unit.
+// It credits the account from the interest expense account of its currency in a journal, marks the accruals as posted,
+// and records it in the audit log within a single database transaction.
+// Posting a period again returns ErrInterestAlreadyPosted.
+func (store *SQLStore) PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error) {
+	var result PostInterestTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		posting, err := queries.CreateInterestPosting(ctx, CreateInterestPostingParams{
+			AccountID:   arg.AccountID,
+			PeriodStart: arg.PeriodStart,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInterestAlreadyPosted
+			}
+			return err
+		}
+
+		accruals, err := queries.MarkInterestAccrualsPosted(ctx, MarkInterestAccrualsPostedParams{
+			PostingID: sql.NullInt64{Int64: posting.ID, Valid: true},
+			AccountID: arg.AccountID,
+			PeriodEnd: arg.PeriodEnd,
+		})
+		if err != nil {
+			return err
+		}
+
+		var micros int64
+		for _, amountMicros := range accruals {
+			micros += amountMicros
+		}
+		amount := roundMicros(micros)
+
+		result.Account, err = queries.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		updateArg := UpdateInterestPostingParams{
+			ID:     posting.ID,
+			Amount: amount,
+		}
+
+		if amount > 0 {
+			expense, err := queries.GetInterestExpenseAccount(ctx, result.Account.Currency)
+			if err != nil {
+				return fmt.Errorf("cannot get interest expense account for %s: %w", result.Account.Currency, err)
+			}
+
+			var entries []Entry
+			var accounts map[int64]Account
+			result.Journal, entries, accounts, err = postJournal(ctx, queries,
+				fmt.Sprintf("interest posting %d", posting.ID), []Posting{
+					{AccountID: arg.AccountID, Amount: amount},
+					{AccountID: expense.AccountID, Amount: -amount},
+				})
+			if err != nil {
+				return err
+			}
+			result.Entry, result.ExpenseEntry = entries[0], entries[1]
+			result.Account = accounts[arg.AccountID]
+
+			updateArg.EntryID = sql.NullInt64{Int64: result.Entry.ID, Valid: true}
+			updateArg.ExpenseEntryID = sql.NullInt64{Int64: result.ExpenseEntry.ID, Valid: true}
+		}
+
+		result.Posting, err = queries.UpdateInterestPosting(ctx, updateArg)
+		if err != nil {
+			return err
+		}
+
+		err = recordAudit(ctx, queries, arg.Audit, AuditActionPostInterest, AuditTargetInterestPosting,
+			auditID(result.Posting.ID), nil, result.Posting)
+		if err != nil {
+			return err
+		}
+
+		if amount == 0 {
+			return nil
+		}
+		return recordDomainEvent(ctx, queries, EventInterestPosted, result.Posting, result.Account.Owner)
+	})
+
+	return result, err
+}
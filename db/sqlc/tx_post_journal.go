@@ -0,0 +1,38 @@
+package db
+
+import "context"
+
+type PostJournalTxParams struct {
+	Description string
+	Postings    []Posting
+	Audit       AuditParams
+}
+
+type PostJournalTxResult struct {
+	Journal Journal `json:"journal"`
+	// Entries are in the order of the postings
+	Entries []Entry `json:"entries"`
+	// Accounts are the posted accounts by ID, with their updated balances
+	Accounts map[int64]Account `json:"-"`
+}
+
+// PostJournalTx posts a balanced set of entries across any number of accounts
+// It locks the accounts in ascending ID order, checks that the postings sum to zero within each currency,
+// records the journal linking the entries, and updates the accounts' balance within a single database transaction.
+// Unbalanced postings return an error wrapping ErrUnbalancedJournal, and postings to a frozen account ErrAccountFrozen.
+func (store *SQLStore) PostJournalTx(ctx context.Context, arg PostJournalTxParams) (PostJournalTxResult, error) {
+	var result PostJournalTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		var err error
+		result.Journal, result.Entries, result.Accounts, err = postJournal(ctx, queries, arg.Description, arg.Postings)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionPostJournal, AuditTargetJournal,
+			auditID(result.Journal.ID), nil, result)
+	})
+
+	return result, err
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	outboxBaseBackoff = time.Second
+	outboxMaxBackoff  = 5 * time.Minute
+)
+
+type RelayOutboxTxParams struct {
+	Limit int32
+	// Publish hands a pending message over to the task queue
+	Publish func(message Outbox) error
+}
+
+type RelayOutboxTxResult struct {
+	Sent   int
+	Failed int
+}
+
+// RelayOutboxTx locks a batch of pending outbox messages and publishes them.
+// Published messages are marked as sent, while failed ones are retried later with an exponential backoff.
+// Messages locked by another relay are skipped, so several relays can run concurrently.
+func (store *SQLStore) RelayOutboxTx(ctx context.Context, arg RelayOutboxTxParams) (RelayOutboxTxResult, error) {
+	var result RelayOutboxTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		messages, err := queries.ListPendingOutboxMessages(ctx, arg.Limit)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			if publishErr := arg.Publish(message); publishErr != nil {
+				result.Failed++
+				err = queries.MarkOutboxMessageFailed(ctx, MarkOutboxMessageFailedParams{
+					ID:            message.ID,
+					LastError:     publishErr.Error(),
+					NextAttemptAt: time.Now().Add(outboxBackoff(message.Attempts)),
+				})
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			result.Sent++
+			if err := queries.MarkOutboxMessageSent(ctx, message.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// outboxBackoff doubles the delay before the next attempt of a message on every failure
+func outboxBackoff(attempts int32) time.Duration {
+	backoff := outboxBaseBackoff
+	for i := int32(0); i < attempts && backoff < outboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > outboxMaxBackoff {
+		return outboxMaxBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type ReleaseHoldTxParams struct {
+	ID int64
+	// Status is HoldVoided when the hold is cancelled, or HoldExpired when it is released past its expiry
+	Status string
+	Now    time.Time
+	Audit  AuditParams
+}
+
+type ReleaseHoldTxResult struct {
+	Hold    Hold
+	Account Account
+}
+
+// ReleaseHoldTx voids or expires a pending hold, restoring the available balance of its account,
+// and records it in the audit log within a single database transaction
+func (store *SQLStore) ReleaseHoldTx(ctx context.Context, arg ReleaseHoldTxParams) (ReleaseHoldTxResult, error) {
+	var result ReleaseHoldTxResult
+
+	var action string
+	switch arg.Status {
+	case HoldVoided:
+		action = AuditActionVoidHold
+	case HoldExpired:
+		action = AuditActionExpireHold
+	default:
+		return result, fmt.Errorf("cannot release hold with status %s", arg.Status)
+	}
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		before, err := queries.GetHoldForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if before.Status != HoldPending {
+			return ErrHoldNotPending
+		}
+
+		if arg.Status == HoldExpired && arg.Now.Before(before.ExpiresAt) {
+			return fmt.Errorf("hold %d expires at %s", before.ID, before.ExpiresAt)
+		}
+
+		result.Account, err = queries.AddAccountHeldBalance(ctx, AddAccountHeldBalanceParams{
+			Amount: -(before.Amount + before.ReservedFee),
+			ID:     before.AccountID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = queries.UpdateHold(ctx, UpdateHoldParams{
+			ID:     before.ID,
+			Status: arg.Status,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, action, AuditTargetHold,
+			auditID(result.Hold.ID), before, result.Hold)
+	})
+
+	return result, err
+}
@@ -0,0 +1,38 @@
+package db
+
+import "context"
+
+type RemoveAccountMemberTxParams struct {
+	AccountID int64
+	Username  string
+	Audit     AuditParams
+}
+
+type RemoveAccountMemberTxResult struct {
+	// Member is the membership as it was before its removal
+	Member AccountMember
+}
+
+// RemoveAccountMemberTx revokes the membership or the invitation of a user in an account
+// and records it in the audit log within a single database transaction
+func (store *SQLStore) RemoveAccountMemberTx(ctx context.Context, arg RemoveAccountMemberTxParams) (RemoveAccountMemberTxResult, error) {
+	var result RemoveAccountMemberTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		var err error
+		result.Member, err = queries.GetAccountMember(ctx, GetAccountMemberParams{AccountID: arg.AccountID, Username: arg.Username})
+		if err != nil {
+			return err
+		}
+
+		err = queries.DeleteAccountMember(ctx, DeleteAccountMemberParams{AccountID: arg.AccountID, Username: arg.Username})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionRemoveAccountMember, AuditTargetAccount,
+			auditID(result.Member.AccountID), result.Member, nil)
+	})
+
+	return result, err
+}
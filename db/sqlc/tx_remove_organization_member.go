@@ -0,0 +1,54 @@
+package db
+
+import "context"
+
+type RemoveOrganizationMemberTxParams struct {
+	OrganizationID int64
+	Username       string
+	Audit          AuditParams
+}
+
+type RemoveOrganizationMemberTxResult struct {
+	// Member is the membership as it was before its removal
+	Member OrganizationMember
+}
+
+// RemoveOrganizationMemberTx removes a user from an organization and records it in the audit log
+// within a single database transaction. The last admin of an organization can't be removed.
+func (store *SQLStore) RemoveOrganizationMemberTx(ctx context.Context, arg RemoveOrganizationMemberTxParams) (RemoveOrganizationMemberTxResult, error) {
+	var result RemoveOrganizationMemberTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		// locking the organization serializes the removals of its admins
+		_, err := queries.GetOrganizationForUpdate(ctx, arg.OrganizationID)
+		if err != nil {
+			return err
+		}
+
+		member := GetOrganizationMemberParams{OrganizationID: arg.OrganizationID, Username: arg.Username}
+		result.Member, err = queries.GetOrganizationMember(ctx, member)
+		if err != nil {
+			return err
+		}
+
+		if result.Member.Role == OrganizationRoleAdmin {
+			admins, err := queries.CountOrganizationAdmins(ctx, arg.OrganizationID)
+			if err != nil {
+				return err
+			}
+			if admins <= 1 {
+				return ErrLastOrganizationAdmin
+			}
+		}
+
+		err = queries.DeleteOrganizationMember(ctx, DeleteOrganizationMemberParams(member))
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionRemoveOrganizationMember, AuditTargetOrganization,
+			auditID(result.Member.OrganizationID), result.Member, nil)
+	})
+
+	return result, err
+}
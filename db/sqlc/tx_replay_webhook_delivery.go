@@ -0,0 +1,30 @@
+package db
+
+import "context"
+
+type ReplayWebhookDeliveryTxParams struct {
+	DeliveryID int64
+}
+
+type ReplayWebhookDeliveryTxResult struct {
+	Delivery WebhookDelivery
+}
+
+// ReplayWebhookDeliveryTx schedules a new delivery of the event of a previous delivery to the same endpoint
+func (store *SQLStore) ReplayWebhookDeliveryTx(
+	ctx context.Context, arg ReplayWebhookDeliveryTxParams,
+) (ReplayWebhookDeliveryTxResult, error) {
+	var result ReplayWebhookDeliveryTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		previous, err := queries.GetWebhookDelivery(ctx, arg.DeliveryID)
+		if err != nil {
+			return err
+		}
+
+		result.Delivery, err = scheduleWebhookDelivery(ctx, queries, previous.EndpointID, previous.EventID)
+		return err
+	})
+
+	return result, err
+}
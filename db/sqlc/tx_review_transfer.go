@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+)
+
+type ReviewTransferTxParams struct {
+	ID int64
+	// Approve executes the transfer, otherwise it is rejected
+	Approve  bool
+	Reviewer string
+	Audit    AuditParams
+}
+
+type ReviewTransferTxResult struct {
+	TransferReview TransferReview
+	// Transfer is empty when the transfer is rejected
+	Transfer TransferTxResult
+}
+
+// ReviewTransferTx approves or rejects a transfer pending review and records it in the audit log
+// within a single database transaction. An approved transfer is executed as the owner who requested it,
+// so that it is still subject to the state of its accounts and their transfer limits.
+func (store *SQLStore) ReviewTransferTx(ctx context.Context, arg ReviewTransferTxParams) (ReviewTransferTxResult, error) {
+	var result ReviewTransferTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		before, err := queries.GetTransferReviewForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if before.Status != TransferReviewPending {
+			return ErrTransferReviewNotPending
+		}
+
+		update := UpdateTransferReviewParams{
+			ID:       before.ID,
+			Status:   TransferReviewRejected,
+			Reviewer: sql.NullString{String: arg.Reviewer, Valid: true},
+		}
+		action := AuditActionRejectTransferReview
+
+		if arg.Approve {
+			result.Transfer, err = executeReviewedTransfer(ctx, queries, before)
+			if err != nil {
+				return err
+			}
+
+			update.Status = TransferReviewApproved
+			update.TransferID = sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}
+			action = AuditActionApproveTransferReview
+		}
+
+		result.TransferReview, err = queries.UpdateTransferReview(ctx, update)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, action, AuditTargetTransferReview,
+			auditID(result.TransferReview.ID), before, result.TransferReview)
+	})
+
+	return result, err
+}
+
+// executeReviewedTransfer performs an approved transfer once its accounts are verified to still allow it
+func executeReviewedTransfer(ctx context.Context, queries *Queries, review TransferReview) (TransferTxResult, error) {
+	return executePendingTransfer(ctx, queries, pendingTransfer{
+		Initiator:     review.Owner,
+		FromAccountID: review.FromAccountID,
+		ToAccountID:   review.ToAccountID,
+		Amount:        review.Amount,
+		Currency:      review.Currency,
+		RequestID:     fmt.Sprintf("transfer_review:%d", review.ID),
+	})
+}
+
+// pendingTransfer is a transfer requested by Initiator that is executed once someone else approves it
+type pendingTransfer struct {
+	Initiator     string
+	FromAccountID int64
+	ToAccountID   int64
+	Amount        int64
+	Currency      string
+	// RequestID identifies the approval in the audit log of the transfer
+	RequestID string
+}
+
+// executePendingTransfer performs an approved transfer as its initiator, once its accounts are verified to still allow it
+func executePendingTransfer(ctx context.Context, queries *Queries, pending pendingTransfer) (TransferTxResult, error) {
+	from, to, err := lockTransferAccounts(ctx, queries, pending.FromAccountID, pending.ToAccountID)
+	if err != nil {
+		return TransferTxResult{}, err
+	}
+
+	// the initiator may have left the account or lost the right to spend from it while the transfer was pending
+	if _, err := AuthorizeAccount(ctx, queries, from, pending.Initiator, AccountPermissionSpend, pending.Amount); err != nil {
+		return TransferTxResult{}, err
+	}
+
+	for _, account := range []Account{from, to} {
+		if account.Status == util.FrozenAccountStatus {
+			return TransferTxResult{}, fmt.Errorf("account %d: %w", account.ID, ErrAccountFrozen)
+		}
+	}
+
+	return transfer(ctx, queries, TransferTxParams{
+		FromAccountID: pending.FromAccountID,
+		ToAccountID:   pending.ToAccountID,
+		Amount:        pending.Amount,
+		Audit: AuditParams{
+			Actor:     pending.Initiator,
+			RequestID: pending.RequestID,
+		},
+	})
+}
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type SetAccountTransferLimitTxParams struct {
+	SetAccountTransferLimitParams
+	Audit AuditParams
+}
+
+type SetAccountTransferLimitTxResult struct {
+	Limit AccountTransferLimit
+	// Effective are the limits applying to the account once its overrides are applied over its product limits
+	Effective TransferLimits
+}
+
+// SetAccountTransferLimitTx overrides the transfer limits of the product of an account for that account
+// and records the change in the audit log within a single database transaction
+func (store *SQLStore) SetAccountTransferLimitTx(
+	ctx context.Context, arg SetAccountTransferLimitTxParams,
+) (SetAccountTransferLimitTxResult, error) {
+	var result SetAccountTransferLimitTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		account, err := queries.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		var before interface{}
+		previous, err := queries.GetAccountTransferLimit(ctx, arg.AccountID)
+		switch {
+		case err == nil:
+			before = previous
+		case !errors.Is(err, sql.ErrNoRows):
+			return err
+		}
+
+		result.Limit, err = queries.SetAccountTransferLimit(ctx, arg.SetAccountTransferLimitParams)
+		if err != nil {
+			return err
+		}
+
+		result.Effective, err = getTransferLimits(ctx, queries, account)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionSetAccountTransferLimit, AuditTargetAccount,
+			auditID(account.ID), before, result.Limit)
+	})
+
+	return result, err
+}
@@ -1,11 +1,23 @@
 package db
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MathPeixoto/go-financial-system/metrics"
+)
+
+// ErrInsufficientFunds is returned when the available balance of an account does not cover an amount
+var ErrInsufficientFunds = errors.New("insufficient funds")
 
 type TransferTxParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID int64       `json:"from_account_id"`
+	ToAccountID   int64       `json:"to_account_id"`
+	Amount        int64       `json:"amount"`
+	PayeeID       int64       `json:"payee_id,omitempty"`
+	Audit         AuditParams `json:"-"`
 }
 
 type TransferTxResult struct {
@@ -14,70 +26,126 @@ type TransferTxResult struct {
 	ToAccount   Account  `json:"to_account"`
 	FromEntry   Entry    `json:"from_entry"`
 	ToEntry     Entry    `json:"to_entry"`
+	// Fee is charged to the from account on top of the amount, FeeEntry and RevenueEntry are empty when it is zero
+	Fee          int64 `json:"fee"`
+	FeeEntry     Entry `json:"fee_entry"`
+	RevenueEntry Entry `json:"revenue_entry"`
 }
 
 // TransferTx performs a money transfer from one account to the other
-// It creates a transfer record, add account entries, and update accounts' balance within a single database transaction
+// It checks the transfer limits of the from account, creates a transfer record, posts a journal of the account entries,
+// charging the fee of the fee schedule to the revenue account, and update accounts' balance
+// within a single database transaction. A transfer exceeding a limit returns a TransferLimitError,
+// and one whose amount and fee exceed the available balance of the from account returns ErrInsufficientFunds.
+// A transfer sent to a payee of the address book, when PayeeID is set, marks the payee as verified.
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
 	err := store.execTx(ctx, func(queries *Queries) error {
 		var err error
+		result, err = transfer(ctx, queries, arg)
+		return err
+	})
 
-		result.Transfer, err = queries.CreateTransfer(ctx, CreateTransferParams(arg))
-		if err != nil {
-			return err
-		}
+	return result, err
+}
 
-		result.FromEntry, err = queries.CreateEntry(ctx, CreateEntryParams{
-			arg.FromAccountID, -arg.Amount,
-		})
+// transfer moves money within the transaction of queries, so that other transactions can embed a transfer
+func transfer(ctx context.Context, queries *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
 
-		if err != nil {
-			return err
-		}
+	// locking the accounts up front serializes the transfers from the same account while checking its limits
+	fromAccount, _, err := lockTransferAccounts(ctx, queries, arg.FromAccountID, arg.ToAccountID)
+	if err != nil {
+		return result, err
+	}
 
-		result.ToEntry, err = queries.CreateEntry(ctx, CreateEntryParams{
-			arg.ToAccountID, arg.Amount,
-		})
+	err = checkTransferLimits(ctx, queries, fromAccount, arg.Amount, time.Now())
+	if err != nil {
+		return result, err
+	}
 
-		if err != nil {
-			return err
-		}
+	fee, err := quoteFee(ctx, queries, fromAccount.Currency, arg.Amount)
+	if err != nil {
+		return result, err
+	}
+	result.Fee = fee.Amount
 
-		// One good way to avoid deadlock is to update the account always in a given order.
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, _ = addMoney(
-				ctx, queries, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount,
-			)
-		} else {
-			result.ToAccount, result.FromAccount, _ = addMoney(
-				ctx, queries, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount,
-			)
-		}
+	if fromAccount.AvailableBalance < arg.Amount+result.Fee {
+		return result, ErrInsufficientFunds
+	}
 
-		return nil
+	result.Transfer, err = queries.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
 	})
+	if err != nil {
+		return result, err
+	}
 
-	return result, err
-}
+	// the legs of the transfer, and of its fee, are posted as a single journal
+	postings := []Posting{
+		{AccountID: arg.FromAccountID, Amount: -arg.Amount},
+		{AccountID: arg.ToAccountID, Amount: arg.Amount},
+	}
+	if result.Fee > 0 {
+		postings = append(postings,
+			Posting{AccountID: arg.FromAccountID, Amount: -result.Fee},
+			Posting{AccountID: fee.RevenueAccountID, Amount: result.Fee},
+		)
+	}
 
-func addMoney(
-	ctx context.Context, queries *Queries, accountIDOne int64, amountOne int64, accountIDTwo int64, amountTwo int64,
-) (accountOne Account, accountTwo Account, err error) {
-	accountOne, err = queries.AddAccountBalance(ctx, AddAccountBalanceParams{
-		amountOne, accountIDOne,
-	})
+	_, entries, accounts, err := postJournal(ctx, queries, fmt.Sprintf("transfer %d", result.Transfer.ID), postings)
 	if err != nil {
-		return
+		return result, err
+	}
+	result.FromEntry = entries[0]
+	result.ToEntry = entries[1]
+	if result.Fee > 0 {
+		result.FeeEntry = entries[2]
+		result.RevenueEntry = entries[3]
 	}
+	result.FromAccount = accounts[arg.FromAccountID]
+	result.ToAccount = accounts[arg.ToAccountID]
 
-	accountTwo, err = queries.AddAccountBalance(ctx, AddAccountBalanceParams{
-		amountTwo, accountIDTwo,
-	})
+	err = recordAudit(ctx, queries, arg.Audit, AuditActionCreateTransfer, AuditTargetTransfer,
+		auditID(result.Transfer.ID), nil, result.Transfer)
+	if err != nil {
+		return result, err
+	}
+
+	if arg.PayeeID != 0 {
+		if err := queries.VerifyPayee(ctx, arg.PayeeID); err != nil {
+			return result, err
+		}
+	}
+
+	err = recordDomainEvent(ctx, queries, EventTransferCompleted, result.Transfer,
+		result.FromAccount.Owner, result.ToAccount.Owner)
 	if err != nil {
+		return result, err
+	}
+
+	queries.afterCommit(func() {
+		metrics.ObserveTransfer(fromAccount.Currency, arg.Amount)
+	})
+	return result, nil
+}
+
+// lockTransferAccounts locks both accounts of a transfer in ascending ID order, the order transfer updates them in
+func lockTransferAccounts(ctx context.Context, queries *Queries, fromAccountID, toAccountID int64) (from, to Account, err error) {
+	if fromAccountID < toAccountID {
+		if from, err = queries.GetAccountForUpdate(ctx, fromAccountID); err != nil {
+			return
+		}
+		to, err = queries.GetAccountForUpdate(ctx, toAccountID)
 		return
 	}
 
+	if to, err = queries.GetAccountForUpdate(ctx, toAccountID); err != nil {
+		return
+	}
+	from, err = queries.GetAccountForUpdate(ctx, fromAccountID)
 	return
 }
@@ -0,0 +1,34 @@
+package db
+
+import "context"
+
+type UpdatePayeeTxParams struct {
+	UpdatePayeeParams
+	Audit AuditParams
+}
+
+type UpdatePayeeTxResult struct {
+	Payee Payee
+}
+
+// UpdatePayeeTx renames a payee and records the change in the audit log within a single database transaction
+func (store *SQLStore) UpdatePayeeTx(ctx context.Context, arg UpdatePayeeTxParams) (UpdatePayeeTxResult, error) {
+	var result UpdatePayeeTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		before, err := queries.GetPayeeForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result.Payee, err = queries.UpdatePayee(ctx, arg.UpdatePayeeParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionUpdatePayee, AuditTargetPayee,
+			auditID(result.Payee.ID), before, result.Payee)
+	})
+
+	return result, err
+}
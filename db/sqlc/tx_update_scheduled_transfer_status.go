@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+)
+
+// ErrScheduledTransferStatus is returned when a scheduled transfer cannot move to the requested status,
+// e.g. when resuming a cancelled one
+var ErrScheduledTransferStatus = errors.New("invalid scheduled transfer status transition")
+
+type UpdateScheduledTransferStatusTxParams struct {
+	ID     int64
+	Status string
+	Now    time.Time
+	Audit  AuditParams
+}
+
+type UpdateScheduledTransferStatusTxResult struct {
+	ScheduledTransfer ScheduledTransfer
+}
+
+// UpdateScheduledTransferStatusTx pauses, resumes or cancels a scheduled transfer and records it in the audit log
+// within a single database transaction.
+// Resuming a recurring transfer skips the runs missed while it was paused.
+func (store *SQLStore) UpdateScheduledTransferStatusTx(
+	ctx context.Context, arg UpdateScheduledTransferStatusTxParams,
+) (UpdateScheduledTransferStatusTxResult, error) {
+	var result UpdateScheduledTransferStatusTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		before, err := queries.GetScheduledTransferForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		action, err := scheduledTransferStatusAction(before.Status, arg.Status)
+		if err != nil {
+			return err
+		}
+
+		nextRunAt := before.NextRunAt
+		if arg.Status == ScheduledTransferActive && before.Recurrence != util.OnceRecurrence {
+			for !nextRunAt.After(arg.Now) {
+				nextRunAt = util.NextOccurrence(before.Recurrence, nextRunAt)
+			}
+		}
+
+		result.ScheduledTransfer, err = queries.UpdateScheduledTransferSchedule(ctx, UpdateScheduledTransferScheduleParams{
+			ID:        arg.ID,
+			NextRunAt: nextRunAt,
+			Status:    arg.Status,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, action, AuditTargetScheduledTransfer,
+			auditID(result.ScheduledTransfer.ID), before, result.ScheduledTransfer)
+	})
+
+	return result, err
+}
+
+// scheduledTransferStatusAction returns the audit action of a status transition, or an error if it is not allowed
+func scheduledTransferStatusAction(from, to string) (string, error) {
+	switch {
+	case from == ScheduledTransferActive && to == ScheduledTransferPaused:
+		return AuditActionPauseScheduledTransfer, nil
+	case from == ScheduledTransferPaused && to == ScheduledTransferActive:
+		return AuditActionResumeScheduledTransfer, nil
+	case (from == ScheduledTransferActive || from == ScheduledTransferPaused) && to == ScheduledTransferCancelled:
+		return AuditActionCancelScheduledTransfer, nil
+	}
+
+	return "", fmt.Errorf("%w: cannot change status from %s to %s", ErrScheduledTransferStatus, from, to)
+}
@@ -0,0 +1,34 @@
+package db
+
+import "context"
+
+type UpdateUserTxParams struct {
+	UpdateUserParams
+	Audit AuditParams
+}
+
+type UpdateUserTxResult struct {
+	User User
+}
+
+// UpdateUserTx updates a user and records the change in the audit log within a single database transaction
+func (store *SQLStore) UpdateUserTx(ctx context.Context, arg UpdateUserTxParams) (UpdateUserTxResult, error) {
+	var result UpdateUserTxResult
+
+	err := store.execTx(ctx, func(queries *Queries) error {
+		before, err := queries.GetUserForUpdate(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		result.User, err = queries.UpdateUser(ctx, arg.UpdateUserParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, queries, arg.Audit, AuditActionUpdateUser, AuditTargetUser, result.User.Username,
+			newAuditUser(before), newAuditUser(result.User))
+	})
+
+	return result, err
+}
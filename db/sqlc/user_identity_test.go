@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func createRandomUserIdentity(t *testing.T) UserIdentity {
+	user := createRandomUser(t)
+
+	arg := CreateUserIdentityParams{
+		Issuer:   "https://" + util.RandomString(8) + ".example.com",
+		Subject:  util.RandomString(12),
+		Username: user.Username,
+		Email:    user.Email,
+	}
+	identity, err := testQueries.CreateUserIdentity(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.Equal(t, arg.Issuer, identity.Issuer)
+	require.Equal(t, arg.Subject, identity.Subject)
+	require.Equal(t, arg.Username, identity.Username)
+	require.Equal(t, arg.Email, identity.Email)
+	require.NotZero(t, identity.CreatedAt)
+
+	return identity
+}
+
+func TestQueries_CreateUserIdentity(t *testing.T) {
+	createRandomUserIdentity(t)
+}
+
+func TestQueries_GetUserIdentity(t *testing.T) {
+	identityOne := createRandomUserIdentity(t)
+
+	identityTwo, err := testQueries.GetUserIdentity(context.Background(), GetUserIdentityParams{
+		Issuer:  identityOne.Issuer,
+		Subject: identityOne.Subject,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, identityOne, identityTwo)
+}
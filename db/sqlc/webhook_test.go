@@ -0,0 +1,172 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func createRandomWebhookEndpoint(t *testing.T, owner string) WebhookEndpoint {
+	arg := CreateWebhookEndpointParams{
+		Owner:  owner,
+		Url:    "https://" + util.RandomString(8) + ".example.com/webhook",
+		Secret: util.RandomString(32),
+	}
+	endpoint, err := testQueries.CreateWebhookEndpoint(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.NotZero(t, endpoint.ID)
+	require.Equal(t, arg.Owner, endpoint.Owner)
+	require.Equal(t, arg.Url, endpoint.Url)
+	require.Equal(t, arg.Secret, endpoint.Secret)
+	require.True(t, endpoint.IsActive)
+	require.NotZero(t, endpoint.CreatedAt)
+
+	return endpoint
+}
+
+func requireWebhookDeliveries(t *testing.T, endpointID int64) []WebhookDelivery {
+	deliveries, err := testQueries.ListWebhookDeliveries(context.Background(), ListWebhookDeliveriesParams{
+		EndpointID: endpointID,
+		Limit:      10,
+		Offset:     0,
+	})
+	require.NoError(t, err)
+	return deliveries
+}
+
+func TestQueries_DeactivateWebhookEndpoint(t *testing.T) {
+	user := createRandomUser(t)
+	endpoint := createRandomWebhookEndpoint(t, user.Username)
+
+	deactivated, err := testQueries.DeactivateWebhookEndpoint(context.Background(), endpoint.ID)
+	require.NoError(t, err)
+	require.False(t, deactivated.IsActive)
+
+	endpoints, err := testQueries.ListActiveWebhookEndpoints(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Empty(t, endpoints)
+}
+
+func TestStore_WebhookEndpointTxAudit(t *testing.T) {
+	store := NewStore(testDB)
+	owner := createRandomUser(t)
+	audit := AuditParams{Actor: owner.Username}
+
+	created, err := store.CreateWebhookEndpointTx(context.Background(), CreateWebhookEndpointTxParams{
+		CreateWebhookEndpointParams: CreateWebhookEndpointParams{
+			Owner:  owner.Username,
+			Url:    "https://" + util.RandomString(8) + ".example.com/webhook",
+			Secret: util.RandomString(32),
+		},
+		Audit: audit,
+	})
+	require.NoError(t, err)
+	require.True(t, created.Endpoint.IsActive)
+
+	deactivated, err := store.DeactivateWebhookEndpointTx(context.Background(), DeactivateWebhookEndpointTxParams{
+		EndpointID: created.Endpoint.ID,
+		Audit:      audit,
+	})
+	require.NoError(t, err)
+	require.False(t, deactivated.Endpoint.IsActive)
+
+	events, err := store.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		TargetType: sql.NullString{String: AuditTargetWebhookEndpoint, Valid: true},
+		TargetID:   sql.NullString{String: auditID(created.Endpoint.ID), Valid: true},
+		Limit:      5,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	actions := make([]string, len(events))
+	for i, event := range events {
+		require.Equal(t, owner.Username, event.Actor)
+		require.NotContains(t, string(event.Before), created.Endpoint.Secret)
+		require.NotContains(t, string(event.After), created.Endpoint.Secret)
+		actions[i] = event.Action
+	}
+	require.ElementsMatch(t, []string{AuditActionCreateWebhookEndpoint, AuditActionDeactivateWebhookEndpoint}, actions)
+}
+
+func TestStore_TransferTxSchedulesWebhookDeliveries(t *testing.T) {
+	store := NewStore(testDB)
+	accountOne := createAccountWithCurrency(t, 1_000, util.BRL)
+	accountTwo := createAccountWithCurrency(t, 0, util.BRL)
+	endpointOne := createRandomWebhookEndpoint(t, accountOne.Owner)
+	endpointTwo := createRandomWebhookEndpoint(t, accountTwo.Owner)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: accountOne.ID,
+		ToAccountID:   accountTwo.ID,
+		Amount:        10,
+		Audit:         AuditParams{Actor: accountOne.Owner},
+	})
+	require.NoError(t, err)
+
+	for _, endpoint := range []WebhookEndpoint{endpointOne, endpointTwo} {
+		deliveries := requireWebhookDeliveries(t, endpoint.ID)
+		require.Len(t, deliveries, 1)
+		require.Equal(t, WebhookDeliveryPending, deliveries[0].Status)
+
+		event, err := store.GetDomainEvent(context.Background(), deliveries[0].EventID)
+		require.NoError(t, err)
+		require.Equal(t, EventTransferCompleted, event.EventType)
+
+		var transfer Transfer
+		require.NoError(t, json.Unmarshal(event.Payload, &transfer))
+		require.Equal(t, result.Transfer.ID, transfer.ID)
+	}
+}
+
+func TestStore_ReplayWebhookDeliveryTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	endpoint := createRandomWebhookEndpoint(t, user.Username)
+
+	account, err := store.CreateAccountTx(context.Background(), CreateAccountTxParams{
+		CreateAccountParams: CreateAccountParams{
+			Owner:    user.Username,
+			Balance:  0,
+			Currency: util.RandomCurrency(),
+			Product:  util.CheckingProduct,
+		},
+		Audit: AuditParams{Actor: user.Username},
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.Username, account.Account.Owner)
+
+	deliveries := requireWebhookDeliveries(t, endpoint.ID)
+	require.Len(t, deliveries, 1)
+
+	result, err := store.ReplayWebhookDeliveryTx(context.Background(), ReplayWebhookDeliveryTxParams{
+		DeliveryID: deliveries[0].ID,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, deliveries[0].ID, result.Delivery.ID)
+	require.Equal(t, deliveries[0].EventID, result.Delivery.EventID)
+	require.Equal(t, endpoint.ID, result.Delivery.EndpointID)
+	require.Equal(t, WebhookDeliveryPending, result.Delivery.Status)
+}
+
+func TestStore_FreezeAccountTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := createRandomAccount(t)
+	endpoint := createRandomWebhookEndpoint(t, account.Owner)
+
+	result, err := store.FreezeAccountTx(context.Background(), FreezeAccountTxParams{
+		AccountID: account.ID,
+		Audit:     AuditParams{Actor: util.RandomOwner()},
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.FrozenAccountStatus, result.Account.Status)
+
+	deliveries := requireWebhookDeliveries(t, endpoint.ID)
+	require.Len(t, deliveries, 1)
+
+	event, err := store.GetDomainEvent(context.Background(), deliveries[0].EventID)
+	require.NoError(t, err)
+	require.Equal(t, EventAccountFrozen, event.EventType)
+}
@@ -7,6 +7,7 @@ CREATE TABLE "users" (
   "hashed_password" varchar NOT NULL,
   "full_name" varchar NOT NULL,
   "email" varchar UNIQUE NOT NULL,
+  "role" varchar NOT NULL DEFAULT 'depositor',
   "password_changed_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00+00',
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
@@ -16,13 +17,25 @@ CREATE TABLE "accounts" (
   "owner" varchar NOT NULL,
   "balance" bigint NOT NULL,
   "currency" varchar NOT NULL,
-  "created_at" timestamptz NOT NULL DEFAULT (now())
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "status" varchar NOT NULL DEFAULT 'active',
+  "held_balance" bigint NOT NULL DEFAULT 0,
+  "available_balance" bigint GENERATED ALWAYS AS ("balance" - "held_balance") STORED,
+  "product" varchar NOT NULL DEFAULT 'checking',
+  "organization_id" bigint
 );
 
 CREATE TABLE "entries" (
   "id" bigserial PRIMARY KEY,
   "account_id" bigint,
   "amount" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "journal_id" bigint
+);
+
+CREATE TABLE "journals" (
+  "id" bigserial PRIMARY KEY,
+  "description" varchar NOT NULL,
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
 
@@ -45,28 +58,490 @@ CREATE TABLE "sessions" (
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
 
+CREATE TABLE "user_identities" (
+  "issuer" varchar NOT NULL,
+  "subject" varchar NOT NULL,
+  "username" varchar NOT NULL,
+  "email" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("issuer", "subject")
+);
+
+CREATE TABLE "audit_events" (
+  "id" bigserial PRIMARY KEY,
+  "actor" varchar NOT NULL,
+  "action" varchar NOT NULL,
+  "target_type" varchar NOT NULL,
+  "target_id" varchar NOT NULL,
+  "before" jsonb NOT NULL DEFAULT '{}',
+  "after" jsonb NOT NULL DEFAULT '{}',
+  "client_ip" varchar NOT NULL,
+  "user_agent" varchar NOT NULL,
+  "request_id" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
 CREATE INDEX ON "accounts" ("owner");
 
-CREATE UNIQUE INDEX ON "accounts" ("owner", "currency");
+CREATE INDEX ON "accounts" ("organization_id");
 
 CREATE INDEX ON "entries" ("account_id");
 
+CREATE INDEX ON "entries" ("journal_id");
+
 CREATE INDEX ON "transfers" ("from_account_id");
 
 CREATE INDEX ON "transfers" ("to_account_id");
 
 CREATE INDEX ON "transfers" ("from_account_id", "to_account_id");
 
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+CREATE TABLE "outbox" (
+  "id" bigserial PRIMARY KEY,
+  "task_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "queue" varchar NOT NULL,
+  "max_retry" int NOT NULL,
+  "process_at" timestamptz NOT NULL DEFAULT (now()),
+  "attempts" int NOT NULL DEFAULT 0,
+  "last_error" varchar NOT NULL DEFAULT '',
+  "next_attempt_at" timestamptz NOT NULL DEFAULT (now()),
+  "sent_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "domain_events" (
+  "id" bigserial PRIMARY KEY,
+  "event_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "webhook_endpoints" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "url" varchar NOT NULL,
+  "secret" varchar NOT NULL,
+  "is_active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "webhook_deliveries" (
+  "id" bigserial PRIMARY KEY,
+  "endpoint_id" bigint NOT NULL,
+  "event_id" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "response_status" int NOT NULL DEFAULT 0,
+  "last_error" varchar NOT NULL DEFAULT '',
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "scheduled_transfers" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "recurrence" varchar NOT NULL,
+  "next_run_at" timestamptz NOT NULL,
+  "end_at" timestamptz,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "scheduled_transfer_runs" (
+  "id" bigserial PRIMARY KEY,
+  "scheduled_transfer_id" bigint NOT NULL,
+  "transfer_id" bigint,
+  "status" varchar NOT NULL,
+  "error" varchar NOT NULL DEFAULT '',
+  "scheduled_for" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "holds" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "captured_amount" bigint NOT NULL DEFAULT 0,
+  "transfer_id" bigint,
+  "expires_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "reserved_fee" bigint NOT NULL DEFAULT 0
+);
+
+CREATE TABLE "fee_schedules" (
+  "id" bigserial PRIMARY KEY,
+  "currency" varchar NOT NULL,
+  "min_amount" bigint NOT NULL DEFAULT 0,
+  "flat_fee" bigint NOT NULL DEFAULT 0,
+  "percentage_bps" int NOT NULL DEFAULT 0,
+  "max_fee" bigint,
+  "revenue_account_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "account_products" (
+  "code" varchar PRIMARY KEY,
+  "annual_interest_rate_bps" int NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "interest_expense_accounts" (
+  "currency" varchar PRIMARY KEY,
+  "account_id" bigint NOT NULL
+);
+
+CREATE TABLE "funding_accounts" (
+  "currency" varchar PRIMARY KEY,
+  "account_id" bigint NOT NULL
+);
+
+CREATE TABLE "interest_postings" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "period_start" date NOT NULL,
+  "amount" bigint NOT NULL,
+  "entry_id" bigint,
+  "expense_entry_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "interest_accruals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "accrual_date" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "annual_interest_rate_bps" int NOT NULL,
+  "amount_micros" bigint NOT NULL,
+  "posting_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "product_transfer_limits" (
+  "product" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "max_amount" bigint,
+  "daily_amount" bigint,
+  "monthly_amount" bigint,
+  "daily_count" int,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("product", "currency")
+);
+
+CREATE TABLE "account_transfer_limits" (
+  "account_id" bigint PRIMARY KEY,
+  "max_amount" bigint,
+  "daily_amount" bigint,
+  "monthly_amount" bigint,
+  "daily_count" int,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "transfer_reviews" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending_review',
+  "score" int NOT NULL,
+  "reasons" text[] NOT NULL,
+  "reviewer" varchar,
+  "transfer_id" bigint,
+  "reviewed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "payees" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "nickname" varchar NOT NULL,
+  "account_id" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "is_verified" boolean NOT NULL DEFAULT false,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "transfer_batches" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "mode" varchar NOT NULL,
+  "status" varchar NOT NULL,
+  "item_count" int NOT NULL,
+  "succeeded_count" int NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "transfer_batch_items" (
+  "id" bigserial PRIMARY KEY,
+  "batch_id" bigint NOT NULL,
+  "position" int NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "status" varchar NOT NULL,
+  "transfer_id" bigint,
+  "error" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "account_members" (
+  "account_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "role" varchar NOT NULL,
+  "spend_limit" bigint,
+  "status" varchar NOT NULL DEFAULT 'invited',
+  "invited_by" varchar NOT NULL,
+  "accepted_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "username")
+);
+
+CREATE TABLE "organizations" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "approval_threshold" bigint NOT NULL,
+  "created_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "organization_members" (
+  "organization_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "role" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("organization_id", "username")
+);
+
+CREATE TABLE "transfer_approvals" (
+  "id" bigserial PRIMARY KEY,
+  "organization_id" bigint NOT NULL,
+  "initiator" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending_approval',
+  "approver" varchar,
+  "transfer_id" bigint,
+  "decided_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "sessions" ("username", "created_at");
+
+CREATE INDEX ON "user_identities" ("username");
+
+CREATE INDEX ON "audit_events" ("actor");
+
+CREATE INDEX ON "audit_events" ("target_type", "target_id");
+
+CREATE INDEX ON "audit_events" ("created_at");
+
+CREATE INDEX ON "outbox" ("next_attempt_at");
+
+CREATE INDEX ON "webhook_endpoints" ("owner");
+
+CREATE INDEX ON "webhook_deliveries" ("endpoint_id");
+
+CREATE INDEX ON "scheduled_transfers" ("owner");
+
+CREATE INDEX ON "scheduled_transfers" ("next_run_at");
+
+CREATE INDEX ON "scheduled_transfer_runs" ("scheduled_transfer_id");
+
+CREATE INDEX ON "holds" ("account_id");
+
+CREATE INDEX ON "holds" ("expires_at");
+
+CREATE UNIQUE INDEX ON "fee_schedules" ("currency", "min_amount");
+
+CREATE UNIQUE INDEX ON "interest_postings" ("account_id", "period_start");
+
+CREATE UNIQUE INDEX ON "interest_accruals" ("account_id", "accrual_date");
+
+CREATE INDEX ON "interest_accruals" ("accrual_date");
+
+CREATE INDEX ON "transfer_reviews" ("owner");
+
+CREATE INDEX ON "transfer_reviews" ("created_at");
+
+CREATE UNIQUE INDEX ON "payees" ("owner", "account_id");
+
+CREATE UNIQUE INDEX ON "payees" ("owner", "nickname");
+
+CREATE INDEX ON "transfer_batches" ("owner");
+
+CREATE UNIQUE INDEX ON "transfer_batch_items" ("batch_id", "position");
+
+CREATE INDEX ON "account_members" ("username");
+
+CREATE INDEX ON "organization_members" ("username");
+
+CREATE INDEX ON "transfer_approvals" ("organization_id", "created_at");
+
+COMMENT ON COLUMN "holds"."amount" IS 'must be positive';
+
+COMMENT ON COLUMN "holds"."reserved_fee" IS 'fee quoted for the amount, held along with it until the hold is captured or released';
+
+COMMENT ON COLUMN "scheduled_transfers"."recurrence" IS 'once, daily, weekly or monthly';
+
+COMMENT ON COLUMN "fee_schedules"."min_amount" IS 'lower bound of the tier, the tier with the highest bound not above the amount applies';
+
+COMMENT ON COLUMN "fee_schedules"."percentage_bps" IS 'in basis points of the amount';
+
+COMMENT ON COLUMN "account_members"."role" IS 'co_owner, viewer or spender, the owner of the account is its accounts.owner';
+
+COMMENT ON COLUMN "account_members"."spend_limit" IS 'largest amount a spender can move in one operation';
+
+COMMENT ON COLUMN "organizations"."approval_threshold" IS 'transfers above it from the accounts of the organization need the approval of another member';
+
+COMMENT ON COLUMN "organization_members"."role" IS 'admin, approver or initiator';
+
+COMMENT ON COLUMN "fee_schedules"."max_fee" IS 'caps the fee when set';
+
+COMMENT ON COLUMN "interest_accruals"."balance" IS 'end-of-day balance';
+
+COMMENT ON COLUMN "interest_accruals"."amount_micros" IS 'in millionths of the minor unit, rounded when posted';
+
+COMMENT ON COLUMN "product_transfer_limits"."max_amount" IS 'of a single transfer, unlimited when null';
+
+COMMENT ON COLUMN "product_transfer_limits"."daily_amount" IS 'outgoing total per UTC day, unlimited when null';
+
+COMMENT ON COLUMN "product_transfer_limits"."monthly_amount" IS 'outgoing total per UTC month, unlimited when null';
+
+COMMENT ON COLUMN "product_transfer_limits"."daily_count" IS 'outgoing transfers per UTC day, unlimited when null';
+
+COMMENT ON COLUMN "transfer_reviews"."score" IS 'sum of the scores of the risk rules that flagged the transfer';
+
+COMMENT ON COLUMN "payees"."is_verified" IS 'set once a transfer to the payee succeeds, which lifts its cooldown';
+
+COMMENT ON COLUMN "transfer_batches"."mode" IS 'atomic or best_effort';
+
+COMMENT ON COLUMN "transfer_batch_items"."to_account_id" IS 'not a foreign key, so that items to unknown accounts are recorded as failed';
+
+COMMENT ON TABLE "funding_accounts" IS 'account debited by the deposits of a currency, so that they are balanced journals';
+
 COMMENT ON COLUMN "entries"."amount" IS 'can be negative or positive';
 
+COMMENT ON COLUMN "entries"."journal_id" IS 'journal posting the entry with the other legs of the same transaction';
+
 COMMENT ON COLUMN "transfers"."amount" IS 'must be positive';
 
 ALTER TABLE "accounts" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
 
 ALTER TABLE "entries" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
 
+ALTER TABLE "entries" ADD FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
+
 ALTER TABLE "transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
 
 ALTER TABLE "transfers" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
 
 ALTER TABLE "sessions" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "user_identities" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "webhook_endpoints" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("endpoint_id") REFERENCES "webhook_endpoints" ("id");
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("event_id") REFERENCES "domain_events" ("id");
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "scheduled_transfer_runs" ADD FOREIGN KEY ("scheduled_transfer_id") REFERENCES "scheduled_transfers" ("id");
+
+ALTER TABLE "scheduled_transfer_runs" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "fee_schedules" ADD FOREIGN KEY ("revenue_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("product") REFERENCES "account_products" ("code");
+
+ALTER TABLE "interest_expense_accounts" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "funding_accounts" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("expense_entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("posting_id") REFERENCES "interest_postings" ("id");
+
+ALTER TABLE "product_transfer_limits" ADD FOREIGN KEY ("product") REFERENCES "account_products" ("code");
+
+ALTER TABLE "account_transfer_limits" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_reviews" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_reviews" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_reviews" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_reviews" ADD FOREIGN KEY ("reviewer") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_reviews" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_batches" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_batches" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_batch_items" ADD FOREIGN KEY ("batch_id") REFERENCES "transfer_batches" ("id");
+
+ALTER TABLE "transfer_batch_items" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("invited_by") REFERENCES "users" ("username");
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("organization_id") REFERENCES "organizations" ("id");
+
+ALTER TABLE "organizations" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("username");
+
+ALTER TABLE "organization_members" ADD FOREIGN KEY ("organization_id") REFERENCES "organizations" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "organization_members" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("organization_id") REFERENCES "organizations" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("initiator") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("approver") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
@@ -0,0 +1,29 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+)
+
+// authorizeAccount checks that the user is a member of an account whose role grants permission, for amount when spending
+func (server *Server) authorizeAccount(
+	ctx context.Context, account db.Account, username, permission string, amount int64,
+) (db.AccountMember, error) {
+	member, err := server.service.AuthorizeAccount(ctx, account, username, permission, amount)
+	if err != nil {
+		return member, serviceError(err)
+	}
+
+	return member, nil
+}
+
+// getMemberAccount returns an account when the user is a member whose role grants permission on it
+func (server *Server) getMemberAccount(ctx context.Context, accountID int64, username, permission string) (db.Account, error) {
+	account, err := server.service.GetMemberAccount(ctx, accountID, username, permission)
+	if err != nil {
+		return account, serviceError(err)
+	}
+
+	return account, nil
+}
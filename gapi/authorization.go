@@ -2,9 +2,15 @@ package gapi
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"github.com/MathPeixoto/go-financial-system/requestid"
 	"github.com/MathPeixoto/go-financial-system/token"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"strings"
 )
 
@@ -15,30 +21,69 @@ const (
 
 func (server *Server) authorizeUser(ctx context.Context) (*token.Payload, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		// the service callers authenticate with their client certificate rather than a token
+		if payload := server.certificatePrincipal(ctx); payload != nil {
+			requestid.SetUsername(ctx, payload.Username)
+			return payload, nil
+		}
+	}
+
 	if !ok {
 		return nil, fmt.Errorf("no metadata found")
 	}
 
-	values := md.Get(authorizationHeader)
 	if len(values) == 0 {
 		return nil, fmt.Errorf("no authorization header found")
 	}
 
-	authHeader := values[0]
+	accessToken, err := bearerToken(values[0])
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := server.tokenMaker.VerifyToken(accessToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid access token: %w", err)
+	}
+
+	requestid.SetUsername(ctx, payload.Username)
+	return payload, nil
+}
+
+// bearerToken returns the access token of a bearer authorization header
+func bearerToken(authHeader string) (string, error) {
 	fields := strings.Fields(authHeader)
 	if len(fields) < 2 {
-		return nil, fmt.Errorf("invalid authorization header")
+		return "", fmt.Errorf("invalid authorization header")
 	}
 
 	authType := strings.ToLower(fields[0])
 	if authType != authorizationBearer {
-		return nil, fmt.Errorf("unsupported authorization type")
+		return "", fmt.Errorf("unsupported authorization type")
 	}
 
-	accessToken := fields[1]
-	payload, err := server.tokenMaker.VerifyToken(accessToken)
+	return fields[1], nil
+}
+
+// authorizeAdmin authorizes the user and checks that the user currently holds the admin role
+func (server *Server) authorizeAdmin(ctx context.Context) (*token.Payload, error) {
+	payload, err := server.authorizeUser(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("invalid access token: %w", err)
+		return nil, unauthenticatedError(err)
+	}
+
+	user, err := server.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, unauthenticatedError(fmt.Errorf("user %s not found", payload.Username))
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get the user")
+	}
+
+	if user.Role != util.AdminRole {
+		return nil, status.Errorf(codes.PermissionDenied, "user %s does not have the %s role", user.Username, util.AdminRole)
 	}
 
 	return payload, nil
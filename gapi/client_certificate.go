@@ -0,0 +1,68 @@
+package gapi
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+
+	"github.com/MathPeixoto/go-financial-system/tlsconfig"
+	"github.com/MathPeixoto/go-financial-system/token"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+type clientCertificateKey struct{}
+
+// HTTPClientCertificate carries the client certificate of a gateway request through the context,
+// where the gRPC requests find it in their peer
+func HTTPClientCertificate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+			r = r.WithContext(context.WithValue(r.Context(), clientCertificateKey{}, r.TLS.PeerCertificates[0]))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientCertificate returns the certificate the client of a request presented, verified against the client CA bundle
+// during the handshake, or nil
+func clientCertificate(ctx context.Context) *x509.Certificate {
+	if certificate, ok := ctx.Value(clientCertificateKey{}).(*x509.Certificate); ok {
+		return certificate
+	}
+
+	// the peer of the proxied requests is the gateway itself
+	if fromGateway(ctx) {
+		return gatewayClientCertificate(ctx)
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) > 0 {
+			return tlsInfo.State.PeerCertificates[0]
+		}
+	}
+
+	return nil
+}
+
+// certificatePrincipal authenticates a service caller as the user its client certificate is mapped to.
+// It returns nil for the callers without certificate, or with one that isn't mapped.
+func (server *Server) certificatePrincipal(ctx context.Context) *token.Payload {
+	certificate := clientCertificate(ctx)
+	if certificate == nil {
+		return nil
+	}
+
+	username, ok := server.clientPrincipals[tlsconfig.Identity(certificate)]
+	if !ok {
+		return nil
+	}
+
+	// the caller is authenticated for as long as its certificate is valid, without token
+	return &token.Payload{
+		Username:  username,
+		IssuedAt:  certificate.NotBefore,
+		ExpiresAt: certificate.NotAfter,
+	}
+}
@@ -0,0 +1,27 @@
+package gapi
+
+import (
+	"net/http"
+
+	"github.com/MathPeixoto/go-financial-system/clientip"
+)
+
+// HTTPClientIP resolves the IP of the client of a gateway request, reading X-Forwarded-For only behind the trusted
+// proxies, and carries it through the context for the rate limiter, the gateway metadata and the audit log
+func HTTPClientIP(proxies clientip.TrustedProxies) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := proxies.Resolve(r.RemoteAddr, r.Header.Get(xForwardedForHeader))
+			next.ServeHTTP(w, r.WithContext(clientip.NewContext(r.Context(), ip)))
+		})
+	}
+}
+
+// httpClientIP returns the IP of the client of a gateway request resolved by HTTPClientIP, or else its peer
+func httpClientIP(r *http.Request) string {
+	if ip := clientip.FromContext(r.Context()); ip != "" {
+		return ip
+	}
+
+	return hostIP(r.RemoteAddr)
+}
@@ -15,3 +15,258 @@ func converter(dbUser db.User) *pb.User {
 		CreatedAt:         timestamppb.New(dbUser.CreatedAt),
 	}
 }
+
+func convertAccount(account db.Account) *pb.Account {
+	pbAccount := &pb.Account{
+		Id:               account.ID,
+		Owner:            account.Owner,
+		Balance:          account.Balance,
+		Currency:         account.Currency,
+		Status:           account.Status,
+		CreatedAt:        timestamppb.New(account.CreatedAt),
+		HeldBalance:      account.HeldBalance,
+		AvailableBalance: account.AvailableBalance,
+		Product:          account.Product,
+	}
+	if account.OrganizationID.Valid {
+		pbAccount.OrganizationId = &account.OrganizationID.Int64
+	}
+
+	return pbAccount
+}
+
+func convertWebhookEndpoint(endpoint db.WebhookEndpoint) *pb.WebhookEndpoint {
+	return &pb.WebhookEndpoint{
+		Id:        endpoint.ID,
+		Url:       endpoint.Url,
+		IsActive:  endpoint.IsActive,
+		CreatedAt: timestamppb.New(endpoint.CreatedAt),
+	}
+}
+
+func convertWebhookDelivery(delivery db.WebhookDelivery) *pb.WebhookDelivery {
+	return &pb.WebhookDelivery{
+		Id:             delivery.ID,
+		EndpointId:     delivery.EndpointID,
+		EventId:        delivery.EventID,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		UpdatedAt:      timestamppb.New(delivery.UpdatedAt),
+		CreatedAt:      timestamppb.New(delivery.CreatedAt),
+	}
+}
+
+func convertScheduledTransfer(scheduled db.ScheduledTransfer) *pb.ScheduledTransfer {
+	pbScheduled := &pb.ScheduledTransfer{
+		Id:            scheduled.ID,
+		FromAccountId: scheduled.FromAccountID,
+		ToAccountId:   scheduled.ToAccountID,
+		Amount:        scheduled.Amount,
+		Currency:      scheduled.Currency,
+		Recurrence:    scheduled.Recurrence,
+		NextRunAt:     timestamppb.New(scheduled.NextRunAt),
+		Status:        scheduled.Status,
+		CreatedAt:     timestamppb.New(scheduled.CreatedAt),
+	}
+	if scheduled.EndAt.Valid {
+		pbScheduled.EndAt = timestamppb.New(scheduled.EndAt.Time)
+	}
+
+	return pbScheduled
+}
+
+func convertScheduledTransferRun(run db.ScheduledTransferRun) *pb.ScheduledTransferRun {
+	pbRun := &pb.ScheduledTransferRun{
+		Id:                  run.ID,
+		ScheduledTransferId: run.ScheduledTransferID,
+		Status:              run.Status,
+		Error:               run.Error,
+		ScheduledFor:        timestamppb.New(run.ScheduledFor),
+		CreatedAt:           timestamppb.New(run.CreatedAt),
+	}
+	if run.TransferID.Valid {
+		pbRun.TransferId = &run.TransferID.Int64
+	}
+
+	return pbRun
+}
+
+func convertHold(hold db.Hold) *pb.Hold {
+	pbHold := &pb.Hold{
+		Id:             hold.ID,
+		AccountId:      hold.AccountID,
+		ToAccountId:    hold.ToAccountID,
+		Amount:         hold.Amount,
+		Currency:       hold.Currency,
+		Status:         hold.Status,
+		CapturedAmount: hold.CapturedAmount,
+		ExpiresAt:      timestamppb.New(hold.ExpiresAt),
+		UpdatedAt:      timestamppb.New(hold.UpdatedAt),
+		CreatedAt:      timestamppb.New(hold.CreatedAt),
+	}
+	if hold.TransferID.Valid {
+		pbHold.TransferId = &hold.TransferID.Int64
+	}
+
+	return pbHold
+}
+
+func convertTransfer(transfer db.Transfer) *pb.Transfer {
+	return &pb.Transfer{
+		Id:            transfer.ID,
+		FromAccountId: transfer.FromAccountID,
+		ToAccountId:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+		CreatedAt:     timestamppb.New(transfer.CreatedAt),
+	}
+}
+
+func convertTransferLimits(limits db.TransferLimits) *pb.TransferLimits {
+	pbLimits := &pb.TransferLimits{}
+	if limits.MaxAmount.Valid {
+		pbLimits.MaxAmount = &limits.MaxAmount.Int64
+	}
+	if limits.DailyAmount.Valid {
+		pbLimits.DailyAmount = &limits.DailyAmount.Int64
+	}
+	if limits.MonthlyAmount.Valid {
+		pbLimits.MonthlyAmount = &limits.MonthlyAmount.Int64
+	}
+	if limits.DailyCount.Valid {
+		pbLimits.DailyCount = &limits.DailyCount.Int32
+	}
+
+	return pbLimits
+}
+
+func convertTransferReview(review db.TransferReview) *pb.TransferReview {
+	pbReview := &pb.TransferReview{
+		Id:            review.ID,
+		Owner:         review.Owner,
+		FromAccountId: review.FromAccountID,
+		ToAccountId:   review.ToAccountID,
+		Amount:        review.Amount,
+		Currency:      review.Currency,
+		Status:        review.Status,
+		Score:         review.Score,
+		Reasons:       review.Reasons,
+		CreatedAt:     timestamppb.New(review.CreatedAt),
+	}
+	if review.Reviewer.Valid {
+		pbReview.Reviewer = &review.Reviewer.String
+	}
+	if review.TransferID.Valid {
+		pbReview.TransferId = &review.TransferID.Int64
+	}
+	if review.ReviewedAt.Valid {
+		pbReview.ReviewedAt = timestamppb.New(review.ReviewedAt.Time)
+	}
+
+	return pbReview
+}
+
+func convertPayee(payee db.Payee) *pb.Payee {
+	return &pb.Payee{
+		Id:         payee.ID,
+		Nickname:   payee.Nickname,
+		AccountId:  payee.AccountID,
+		Currency:   payee.Currency,
+		IsVerified: payee.IsVerified,
+		UpdatedAt:  timestamppb.New(payee.UpdatedAt),
+		CreatedAt:  timestamppb.New(payee.CreatedAt),
+	}
+}
+
+func convertTransferBatch(batch db.TransferBatch, items []db.TransferBatchItem) *pb.TransferBatch {
+	pbBatch := &pb.TransferBatch{
+		Id:             batch.ID,
+		Owner:          batch.Owner,
+		FromAccountId:  batch.FromAccountID,
+		Currency:       batch.Currency,
+		Mode:           batch.Mode,
+		Status:         batch.Status,
+		ItemCount:      batch.ItemCount,
+		SucceededCount: batch.SucceededCount,
+		Items:          make([]*pb.TransferBatchItem, len(items)),
+		CreatedAt:      timestamppb.New(batch.CreatedAt),
+	}
+	for i, item := range items {
+		pbBatch.Items[i] = &pb.TransferBatchItem{
+			Position:    item.Position,
+			ToAccountId: item.ToAccountID,
+			Amount:      item.Amount,
+			Status:      item.Status,
+			Error:       item.Error,
+		}
+		if item.TransferID.Valid {
+			pbBatch.Items[i].TransferId = &items[i].TransferID.Int64
+		}
+	}
+
+	return pbBatch
+}
+
+func convertAccountMember(member db.AccountMember) *pb.AccountMember {
+	pbMember := &pb.AccountMember{
+		AccountId: member.AccountID,
+		Username:  member.Username,
+		Role:      member.Role,
+		Status:    member.Status,
+		InvitedBy: member.InvitedBy,
+		CreatedAt: timestamppb.New(member.CreatedAt),
+	}
+	if member.SpendLimit.Valid {
+		pbMember.SpendLimit = &member.SpendLimit.Int64
+	}
+	if member.AcceptedAt.Valid {
+		pbMember.AcceptedAt = timestamppb.New(member.AcceptedAt.Time)
+	}
+
+	return pbMember
+}
+
+func convertOrganization(organization db.Organization) *pb.Organization {
+	return &pb.Organization{
+		Id:                organization.ID,
+		Name:              organization.Name,
+		ApprovalThreshold: organization.ApprovalThreshold,
+		CreatedBy:         organization.CreatedBy,
+		CreatedAt:         timestamppb.New(organization.CreatedAt),
+	}
+}
+
+func convertOrganizationMember(member db.OrganizationMember) *pb.OrganizationMember {
+	return &pb.OrganizationMember{
+		OrganizationId: member.OrganizationID,
+		Username:       member.Username,
+		Role:           member.Role,
+		CreatedAt:      timestamppb.New(member.CreatedAt),
+	}
+}
+
+func convertTransferApproval(approval db.TransferApproval) *pb.TransferApproval {
+	pbApproval := &pb.TransferApproval{
+		Id:             approval.ID,
+		OrganizationId: approval.OrganizationID,
+		Initiator:      approval.Initiator,
+		FromAccountId:  approval.FromAccountID,
+		ToAccountId:    approval.ToAccountID,
+		Amount:         approval.Amount,
+		Currency:       approval.Currency,
+		Status:         approval.Status,
+		CreatedAt:      timestamppb.New(approval.CreatedAt),
+	}
+	if approval.Approver.Valid {
+		pbApproval.Approver = &approval.Approver.String
+	}
+	if approval.TransferID.Valid {
+		pbApproval.TransferId = &approval.TransferID.Int64
+	}
+	if approval.DecidedAt.Valid {
+		pbApproval.DecidedAt = timestamppb.New(approval.DecidedAt.Time)
+	}
+
+	return pbApproval
+}
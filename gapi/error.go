@@ -1,6 +1,11 @@
 package gapi
 
 import (
+	"errors"
+	"strconv"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/service"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -27,3 +32,63 @@ func invalidArgumentError(violations []*errdetails.BadRequest_FieldViolation) er
 func unauthenticatedError(err error) error {
 	return status.Errorf(codes.Unauthenticated, "unauthenticated: %s", err.Error())
 }
+
+// transferLimitError reports a transfer exceeding a limit of its account, with the remaining allowance in the details
+func transferLimitError(limitErr *db.TransferLimitError) error {
+	statusExhausted := status.New(codes.ResourceExhausted, limitErr.Error())
+	statusDetails, err := statusExhausted.WithDetails(&errdetails.ErrorInfo{
+		Reason: "TRANSFER_LIMIT_EXCEEDED",
+		Metadata: map[string]string{
+			"account_id": strconv.FormatInt(limitErr.AccountID, 10),
+			"limit":      limitErr.Limit,
+			"allowed":    strconv.FormatInt(limitErr.Allowed, 10),
+			"remaining":  strconv.FormatInt(limitErr.Remaining, 10),
+		},
+	})
+	if err != nil {
+		return statusExhausted.Err()
+	}
+
+	return statusDetails.Err()
+}
+
+// serviceError converts an error of the service layer to the status of its kind
+func serviceError(err error) error {
+	var serviceErr *service.Error
+	if !errors.As(err, &serviceErr) {
+		return status.Errorf(codes.Internal, "%s", err)
+	}
+
+	switch serviceErr.Kind {
+	case service.InvalidArgument:
+		if len(serviceErr.Violations) > 0 {
+			violations := make([]*errdetails.BadRequest_FieldViolation, len(serviceErr.Violations))
+			for i, violation := range serviceErr.Violations {
+				violations[i] = &errdetails.BadRequest_FieldViolation{
+					Field:       violation.Field,
+					Description: violation.Description,
+				}
+			}
+			return invalidArgumentError(violations)
+		}
+		return status.Error(codes.InvalidArgument, serviceErr.Message)
+	case service.NotFound:
+		return status.Error(codes.NotFound, serviceErr.Message)
+	case service.AlreadyExists:
+		return status.Error(codes.AlreadyExists, serviceErr.Message)
+	case service.Unauthenticated:
+		return status.Error(codes.Unauthenticated, serviceErr.Message)
+	case service.PermissionDenied:
+		return status.Error(codes.PermissionDenied, serviceErr.Message)
+	case service.FailedPrecondition:
+		return status.Error(codes.FailedPrecondition, serviceErr.Message)
+	case service.LimitExceeded:
+		var limitErr *db.TransferLimitError
+		if errors.As(serviceErr, &limitErr) {
+			return transferLimitError(limitErr)
+		}
+		return status.Error(codes.ResourceExhausted, serviceErr.Message)
+	}
+
+	return status.Error(codes.Internal, serviceErr.Message)
+}
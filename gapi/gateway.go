@@ -0,0 +1,131 @@
+package gapi
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/MathPeixoto/go-financial-system/requestid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+)
+
+// Modes of the HTTP gateway
+const (
+	// GatewayModeLocal calls the server in-process, without the gRPC interceptors
+	GatewayModeLocal = "local"
+	// GatewayModeEndpoint proxies the requests to the gRPC listener
+	GatewayModeEndpoint = "endpoint"
+	// GatewayModeBufconn proxies the requests to the gRPC server over an in-memory connection
+	GatewayModeBufconn = "bufconn"
+)
+
+// Metadata the gateway forwards to the gRPC server in the proxy modes, trusted only along with the gateway token
+const (
+	gatewayTokenHeader             = "x-gateway-token"
+	gatewayClientIPHeader          = "x-gateway-client-ip"
+	gatewayClientCertificateHeader = "x-gateway-client-certificate-bin"
+)
+
+// gatewayToken proves that a gRPC request comes from the gateway of this process, which is the only one knowing it
+var gatewayToken = newGatewayToken()
+
+func newGatewayToken() string {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		panic("cannot generate gateway token: " + err.Error())
+	}
+
+	return hex.EncodeToString(token)
+}
+
+// GatewayMetadataAnnotator is a gateway metadata annotator forwarding to the gRPC server what the HTTP middlewares
+// resolved about a request: its ID, the IP of its client and its client certificate
+func GatewayMetadataAnnotator(ctx context.Context, r *http.Request) metadata.MD {
+	md := metadata.Pairs(
+		gatewayTokenHeader, gatewayToken,
+		gatewayClientIPHeader, httpClientIP(r),
+	)
+
+	if id := requestid.FromContext(r.Context()); id != "" {
+		md.Set(requestid.MetadataKey, id)
+	}
+
+	if certificate, ok := r.Context().Value(clientCertificateKey{}).(*x509.Certificate); ok {
+		md.Set(gatewayClientCertificateHeader, string(certificate.Raw))
+	}
+
+	return md
+}
+
+// gatewayRequestMetadata returns the metadata the gateway sends to the gRPC server for a request it transcodes
+func gatewayRequestMetadata(r *http.Request) metadata.MD {
+	md := GatewayMetadataAnnotator(r.Context(), r)
+	md.Set(grpcGatewayUserAgentHeader, r.UserAgent())
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		md.Set(authorizationHeader, authorization)
+	}
+
+	return md
+}
+
+// GatewayHeaderMatcher forwards the HTTP headers to the gRPC server like the default matcher of the gateway,
+// except the ones that would pass for the metadata the gateway forwards itself
+func GatewayHeaderMatcher(key string) (string, bool) {
+	name, ok := runtime.DefaultHeaderMatcher(key)
+	if !ok {
+		return "", false
+	}
+
+	switch strings.ToLower(name) {
+	case gatewayTokenHeader, gatewayClientIPHeader, gatewayClientCertificateHeader, requestid.MetadataKey:
+		return "", false
+	}
+
+	return name, true
+}
+
+// fromGateway reports whether a gRPC request was proxied by the gateway of this process,
+// whose forwarded metadata are trusted
+func fromGateway(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	values := md.Get(gatewayTokenHeader)
+	return len(values) == 1 && subtle.ConstantTimeCompare([]byte(values[0]), []byte(gatewayToken)) == 1
+}
+
+// gatewayMetadata returns a metadata forwarded by the gateway, or an empty string
+func gatewayMetadata(ctx context.Context, key string) string {
+	if !fromGateway(ctx) {
+		return ""
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// gatewayClientCertificate returns the client certificate the gateway verified and forwarded, or nil
+func gatewayClientCertificate(ctx context.Context) *x509.Certificate {
+	raw := gatewayMetadata(ctx, gatewayClientCertificateHeader)
+	if raw == "" {
+		return nil
+	}
+
+	certificate, err := x509.ParseCertificate([]byte(raw))
+	if err != nil {
+		return nil
+	}
+
+	return certificate
+}
@@ -0,0 +1,68 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getParticipatingHold returns a hold along with its accounts when the user is a member of the held or the payee account
+// whose role grants permission. Holds of other users are reported as not found, so that their IDs cannot be probed.
+func (server *Server) getParticipatingHold(
+	ctx context.Context, holdID int64, username, permission string,
+) (hold db.Hold, account, toAccount db.Account, err error) {
+	hold, err = server.store.GetHold(ctx, holdID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = status.Errorf(codes.NotFound, "hold %d not found", holdID)
+			return
+		}
+		err = status.Errorf(codes.Internal, "failed to get hold: %s", err)
+		return
+	}
+
+	account, err = server.store.GetAccount(ctx, hold.AccountID)
+	if err != nil {
+		err = status.Errorf(codes.Internal, "failed to get account: %s", err)
+		return
+	}
+
+	toAccount, err = server.store.GetAccount(ctx, hold.ToAccountID)
+	if err != nil {
+		err = status.Errorf(codes.Internal, "failed to get account: %s", err)
+		return
+	}
+
+	for _, participant := range []db.Account{account, toAccount} {
+		_, authErr := db.AuthorizeAccount(ctx, server.store, participant, username, permission, 0)
+		if authErr == nil {
+			return
+		}
+		if !db.IsAccountAccessError(authErr) {
+			err = status.Errorf(codes.Internal, "failed to get account member: %s", authErr)
+			return
+		}
+	}
+
+	err = status.Errorf(codes.NotFound, "hold %d not found", holdID)
+	return
+}
+
+// holdError converts the errors of the hold transactions to status errors
+func holdError(err error, action string) error {
+	var limitErr *db.TransferLimitError
+	switch {
+	case errors.As(err, &limitErr):
+		return transferLimitError(limitErr)
+	case errors.Is(err, db.ErrInsufficientFunds), errors.Is(err, db.ErrHoldNotPending), errors.Is(err, db.ErrHoldExpired):
+		return status.Errorf(codes.FailedPrecondition, "%s", err)
+	case errors.Is(err, db.ErrCaptureAmount):
+		return status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+
+	return status.Errorf(codes.Internal, "failed to %s hold: %s", action, err)
+}
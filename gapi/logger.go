@@ -2,14 +2,18 @@ package gapi
 
 import (
 	"context"
+	"github.com/MathPeixoto/go-financial-system/redact"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"net/http"
 	"time"
 )
 
+// GrpcLogger logs the gRPC requests with the logger of the request carried by the context, set by GrpcRequestID
 func GrpcLogger(
 	ctx context.Context,
 	req interface{},
@@ -25,9 +29,14 @@ func GrpcLogger(
 		statusCode = st.Code()
 	}
 
-	logger := log.Info()
+	logger := log.Ctx(ctx).Info()
 	if err != nil {
-		logger = log.Error()
+		logger = log.Ctx(ctx).Error().Err(err)
+	}
+
+	logger = logMessage(logger, "request", req)
+	if err == nil {
+		logger = logMessage(logger, "response", result)
 	}
 
 	logger.
@@ -40,6 +49,20 @@ func GrpcLogger(
 	return result, err
 }
 
+// logMessage adds the JSON encoding of a request or response message, with its sensitive fields masked, to a log event
+func logMessage(event *zerolog.Event, key string, message interface{}) *zerolog.Event {
+	protoMessage, ok := message.(proto.Message)
+	if !ok {
+		return event
+	}
+
+	encoded, err := redact.Message(protoMessage)
+	if err != nil {
+		return event
+	}
+	return event.RawJSON(key, encoded)
+}
+
 type ResponseRecorder struct {
 	http.ResponseWriter
 	statusCode int
@@ -56,7 +79,8 @@ func (r *ResponseRecorder) Write(b []byte) (int, error) {
 	return r.ResponseWriter.Write(b)
 }
 
-// HTTPLogger HttpLogger Middleware responsible for logging http request and http response
+// HTTPLogger HttpLogger Middleware responsible for logging http request and http response,
+// with the logger of the request carried by the context, set by HTTPRequestID
 func HTTPLogger(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		startTime := time.Now()
@@ -64,9 +88,9 @@ func HTTPLogger(next http.Handler) http.Handler {
 		next.ServeHTTP(recorder, r)
 		duration := time.Since(startTime)
 
-		logger := log.Info()
+		logger := log.Ctx(r.Context()).Info()
 		if recorder.statusCode != http.StatusOK {
-			logger = log.Error().Bytes("response_body", recorder.body)
+			logger = log.Ctx(r.Context()).Error().RawJSON("response_body", redact.JSON(recorder.body))
 		}
 
 		logger.
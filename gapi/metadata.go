@@ -2,6 +2,11 @@ package gapi
 
 import (
 	"context"
+	"net/http"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/requestid"
+	"github.com/MathPeixoto/go-financial-system/service"
 
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
@@ -16,29 +21,59 @@ const (
 type Metadata struct {
 	UserAgent string
 	ClientIP  string
+	RequestID string
 }
 
-func (server *Server) extractMedatada(ctx context.Context) *Metadata {
-	mtdt := new(Metadata)
+func (server *Server) extractMetadata(ctx context.Context) *Metadata {
+	// the request ID is resolved by the interceptor, or by the HTTP middleware in the local gateway mode
+	mtdt := &Metadata{RequestID: requestid.FromContext(ctx)}
 
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		if userAgents := md.Get(grpcGatewayUserAgentHeader); len(userAgents) > 0 {
-			mtdt.UserAgent = userAgents[0]
-		}
-
 		if userAgents := md.Get(userAgentHeader); len(userAgents) > 0 {
 			mtdt.UserAgent = userAgents[0]
 		}
 
-		if clientIPs := md.Get(xForwardedForHeader); len(clientIPs) > 0 {
-			mtdt.ClientIP = clientIPs[0]
+		// the user agent of the proxied requests is the one of the gateway client
+		if userAgents := md.Get(grpcGatewayUserAgentHeader); len(userAgents) > 0 {
+			mtdt.UserAgent = userAgents[0]
 		}
 	}
 
-	// get ip address from peer
-	if peer, ok := peer.FromContext(ctx); ok {
-		mtdt.ClientIP = peer.Addr.String()
+	// the client of the requests proxied by the gateway is the one it resolved, else it is the peer
+	if fromGateway(ctx) {
+		mtdt.ClientIP = gatewayMetadata(ctx, gatewayClientIPHeader)
+	} else if peer, ok := peer.FromContext(ctx); ok {
+		mtdt.ClientIP = hostIP(peer.Addr.String())
 	}
 
 	return mtdt
 }
+
+// extractHTTPMetadata reads the client metadata of requests served directly by the HTTP gateway
+func extractHTTPMetadata(r *http.Request) *Metadata {
+	return &Metadata{
+		UserAgent: r.UserAgent(),
+		ClientIP:  httpClientIP(r),
+		RequestID: requestid.FromContext(r.Context()),
+	}
+}
+
+// audit returns the audit log parameters of an operation performed by actor
+func (mtdt *Metadata) audit(actor string) db.AuditParams {
+	return db.AuditParams{
+		Actor:     actor,
+		ClientIP:  mtdt.ClientIP,
+		UserAgent: mtdt.UserAgent,
+		RequestID: mtdt.RequestID,
+	}
+}
+
+// caller returns the caller of an operation performed by username, empty when unauthenticated
+func (mtdt *Metadata) caller(username string) service.Caller {
+	return service.Caller{
+		Username:  username,
+		ClientIP:  mtdt.ClientIP,
+		UserAgent: mtdt.UserAgent,
+		RequestID: mtdt.RequestID,
+	}
+}
@@ -0,0 +1,70 @@
+package gapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/MathPeixoto/go-financial-system/metrics"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// unmatchedRoute labels the HTTP requests that no route handled
+const unmatchedRoute = "unmatched"
+
+type httpRouteKey struct{}
+
+// GrpcMetrics records the count and the latency of the gRPC requests
+func GrpcMetrics(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp interface{}, err error) {
+	startTime := time.Now()
+	result, err := handler(ctx, req)
+	metrics.ObserveGRPCRequest(info.FullMethod, status.Code(err).String(), time.Since(startTime))
+	return result, err
+}
+
+// HTTPMetrics records the count and the latency of the HTTP requests by route.
+// The route of the gateway requests is their path pattern, recorded by HTTPRouteAnnotator.
+func HTTPMetrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		var route string
+		recorder := &ResponseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), httpRouteKey{}, &route)))
+
+		if route == "" {
+			route = r.URL.Path
+			if recorder.statusCode == http.StatusNotFound {
+				route = unmatchedRoute
+			}
+		}
+		metrics.ObserveHTTPRequest(r.Method, route, recorder.statusCode, time.Since(startTime))
+	})
+}
+
+// HTTPRouteAnnotator is a gateway metadata annotator recording the path pattern matched by a request for HTTPMetrics,
+// and naming the span of the request after it
+func HTTPRouteAnnotator(ctx context.Context, r *http.Request) metadata.MD {
+	pattern, ok := runtime.HTTPPathPattern(ctx)
+	if !ok {
+		return nil
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.SetName(r.Method + " " + pattern)
+	span.SetAttributes(semconv.HTTPRoute(pattern))
+
+	if route, ok := r.Context().Value(httpRouteKey{}).(*string); ok {
+		*route = pattern
+	}
+	return nil
+}
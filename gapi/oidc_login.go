@@ -0,0 +1,252 @@
+package gapi
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/oidc"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	oidcStateCookie   = "oidc_state"
+	oidcStateDuration = 10 * time.Minute
+)
+
+var invalidUsernameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// oidcState is kept in a signed cookie between the redirect to the issuer and the callback,
+// so that any gateway replica can complete the flow
+type oidcState struct {
+	State        string    `json:"state"`
+	Nonce        string    `json:"nonce"`
+	CodeVerifier string    `json:"code_verifier"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// OIDCLogin starts the authorization code flow by redirecting the browser to the identity provider
+func (server *Server) OIDCLogin(w http.ResponseWriter, r *http.Request) {
+	if server.oidcProvider == nil {
+		writeHTTPError(w, status.Errorf(codes.Unimplemented, "oidc login is not configured"))
+		return
+	}
+
+	state := oidcState{ExpiresAt: time.Now().Add(oidcStateDuration)}
+	for _, value := range []*string{&state.State, &state.Nonce, &state.CodeVerifier} {
+		random, err := oidc.RandomValue()
+		if err != nil {
+			writeHTTPError(w, status.Errorf(codes.Internal, "failed to generate login state"))
+			return
+		}
+		*value = random
+	}
+
+	cookie, err := server.signOIDCState(state)
+	if err != nil {
+		writeHTTPError(w, status.Errorf(codes.Internal, "failed to sign login state"))
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    cookie,
+		Path:     "/v1/oidc",
+		Expires:  state.ExpiresAt,
+		HttpOnly: true,
+		Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	authURL := server.oidcProvider.AuthCodeURL(state.State, state.Nonce, oidc.CodeChallenge(state.CodeVerifier))
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// OIDCCallback completes the authorization code flow: it redeems the code, validates the ID token,
+// links or provisions the local user and issues our own access and refresh tokens
+func (server *Server) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	if server.oidcProvider == nil {
+		writeHTTPError(w, status.Errorf(codes.Unimplemented, "oidc login is not configured"))
+		return
+	}
+
+	query := r.URL.Query()
+	if providerErr := query.Get("error"); providerErr != "" {
+		writeHTTPError(w, status.Errorf(codes.Unauthenticated, "identity provider returned %s", providerErr))
+		return
+	}
+
+	cookie, err := r.Cookie(oidcStateCookie)
+	if err != nil {
+		writeHTTPError(w, status.Errorf(codes.Unauthenticated, "login state not found"))
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{Name: oidcStateCookie, Path: "/v1/oidc", MaxAge: -1})
+
+	state, err := server.verifyOIDCState(cookie.Value)
+	if err != nil {
+		writeHTTPError(w, unauthenticatedError(err))
+		return
+	}
+
+	if !hmac.Equal([]byte(state.State), []byte(query.Get("state"))) {
+		writeHTTPError(w, status.Errorf(codes.Unauthenticated, "login state does not match"))
+		return
+	}
+
+	token, err := server.oidcProvider.Exchange(r.Context(), query.Get("code"), state.CodeVerifier)
+	if err != nil {
+		log.Ctx(r.Context()).Error().Err(err).Msg("cannot exchange authorization code")
+		writeHTTPError(w, status.Errorf(codes.Unauthenticated, "failed to exchange authorization code"))
+		return
+	}
+
+	claims, err := server.oidcProvider.VerifyIDToken(r.Context(), token.IDToken, state.Nonce)
+	if err != nil {
+		writeHTTPError(w, unauthenticatedError(err))
+		return
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		writeHTTPError(w, status.Errorf(codes.PermissionDenied, "identity provider did not assert a verified email"))
+		return
+	}
+
+	randomPassword, err := oidc.RandomValue()
+	if err != nil {
+		writeHTTPError(w, status.Errorf(codes.Internal, "failed to generate password"))
+		return
+	}
+
+	hashedPassword, err := util.HashPassword(randomPassword)
+	if err != nil {
+		writeHTTPError(w, status.Errorf(codes.Internal, "failed to hash the password: %s", err))
+		return
+	}
+
+	mtdt := extractHTTPMetadata(r)
+	result, err := server.store.LinkOIDCUserTx(r.Context(), db.LinkOIDCUserTxParams{
+		Issuer:         server.oidcProvider.Issuer(),
+		Subject:        claims.Subject,
+		Email:          claims.Email,
+		FullName:       oidcFullName(claims),
+		Username:       oidcUsername(claims),
+		HashedPassword: hashedPassword,
+		Audit:          mtdt.audit(""),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrOIDCEmailInUse) {
+			writeHTTPError(w, status.Errorf(codes.AlreadyExists, "%s, sign in with its password", err))
+			return
+		}
+		writeHTTPError(w, status.Errorf(codes.Internal, "failed to link user: %s", err))
+		return
+	}
+
+	response, err := server.createLoginSession(r.Context(), result.User, mtdt)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+
+	body, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(response)
+	if err != nil {
+		writeHTTPError(w, status.Errorf(codes.Internal, "failed to marshal response"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+func (server *Server) signOIDCState(state oidcState) (string, error) {
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + server.oidcStateSignature(encoded), nil
+}
+
+func (server *Server) verifyOIDCState(value string) (*oidcState, error) {
+	encoded, signature, found := strings.Cut(value, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(server.oidcStateSignature(encoded))) {
+		return nil, fmt.Errorf("invalid login state")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid login state: %w", err)
+	}
+
+	state := &oidcState{}
+	if err := json.Unmarshal(payload, state); err != nil {
+		return nil, fmt.Errorf("invalid login state: %w", err)
+	}
+
+	if state.ExpiresAt.Before(time.Now()) {
+		return nil, fmt.Errorf("login state has expired")
+	}
+
+	return state, nil
+}
+
+func (server *Server) oidcStateSignature(encoded string) string {
+	mac := hmac.New(sha256.New, []byte(server.config.TokenSymmetricKey))
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// oidcUsername derives a username candidate that passes val.ValidateUsername from the identity claims
+func oidcUsername(claims *oidc.Claims) string {
+	candidate := claims.PreferredUsername
+	if candidate == "" {
+		candidate, _, _ = strings.Cut(claims.Email, "@")
+	}
+
+	username := strings.Trim(invalidUsernameChars.ReplaceAllString(strings.ToLower(candidate), "_"), "_")
+	if len(username) > 90 {
+		username = username[:90]
+	}
+	for len(username) < 3 {
+		username += "_"
+	}
+
+	return username
+}
+
+func oidcFullName(claims *oidc.Claims) string {
+	if claims.Name != "" {
+		return claims.Name
+	}
+
+	return claims.Email
+}
+
+// writeHTTPError writes a gRPC status the same way the gateway does for transcoded calls
+func writeHTTPError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	body, marshalErr := protojson.Marshal(st.Proto())
+	if marshalErr != nil {
+		body = []byte(`{"code": 13, "message": "failed to marshal error message"}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(runtime.HTTPStatusFromCode(st.Code()))
+	_, _ = w.Write(body)
+}
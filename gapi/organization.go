@@ -0,0 +1,65 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// authorizeOrganization checks that the user is a member of an organization, with one of roles when given
+func (server *Server) authorizeOrganization(
+	ctx context.Context, organizationID int64, username string, roles ...string,
+) (db.OrganizationMember, error) {
+	member, err := db.AuthorizeOrganization(ctx, server.store, organizationID, username, roles...)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrNotOrganizationMember):
+			return member, status.Errorf(codes.PermissionDenied, "organization %d doesn't have the authenticated user as a member", organizationID)
+		case errors.Is(err, db.ErrOrganizationPermission):
+			return member, status.Errorf(codes.PermissionDenied, "%s", err)
+		}
+		return member, status.Errorf(codes.Internal, "failed to get organization member: %s", err)
+	}
+
+	return member, nil
+}
+
+// requiresApproval reports whether a transfer of amount from account must wait for the approval of another member
+// of the organization owning the account
+func (server *Server) requiresApproval(ctx context.Context, account db.Account, amount int64) (bool, error) {
+	required, err := server.service.RequiresApproval(ctx, account, amount)
+	if err != nil {
+		return false, serviceError(err)
+	}
+
+	return required, nil
+}
+
+// checkNoApprovalRequired rejects moving amount from account other than by a transfer,
+// when the transfer would need the approval of another member of the organization owning the account
+func (server *Server) checkNoApprovalRequired(ctx context.Context, account db.Account, amount int64) error {
+	required, err := server.requiresApproval(ctx, account, amount)
+	if err != nil {
+		return err
+	}
+
+	if required {
+		return status.Errorf(codes.FailedPrecondition,
+			"amount is above the approval threshold of the organization of account %d, create a transfer to request its approval", account.ID)
+	}
+
+	return nil
+}
+
+// checkPersonalAccount rejects managing the members of an account owned by an organization, which are the members of the organization
+func checkPersonalAccount(account db.Account) error {
+	if account.OrganizationID.Valid {
+		return status.Errorf(codes.FailedPrecondition,
+			"account %d is owned by organization %d, whose members are the members of the account", account.ID, account.OrganizationID.Int64)
+	}
+
+	return nil
+}
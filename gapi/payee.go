@@ -0,0 +1,17 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+)
+
+// getOwnedPayee returns a payee of the address book of the user, reporting the payees of other users as not found
+func (server *Server) getOwnedPayee(ctx context.Context, payeeID int64, username string) (db.Payee, error) {
+	payee, err := server.service.GetOwnedPayee(ctx, payeeID, username)
+	if err != nil {
+		return payee, serviceError(err)
+	}
+
+	return payee, nil
+}
@@ -0,0 +1,301 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MathPeixoto/go-financial-system/metrics"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/ratelimit"
+	"github.com/MathPeixoto/go-financial-system/token"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const retryAfterHeader = "Retry-After"
+
+// RateLimiter throttles the requests to the bank service by client IP, user and method,
+// over gRPC with an interceptor and over the gateway with a middleware
+type RateLimiter struct {
+	limiter    ratelimit.Limiter
+	policies   ratelimit.Policies
+	tokenMaker token.Maker
+	routes     []gatewayRoute
+}
+
+// NewRateLimiter creates a RateLimiter enforcing the policies of the config with limiter,
+// which identifies the users by the access tokens of tokenMaker
+func NewRateLimiter(config util.Config, tokenMaker token.Maker, limiter ratelimit.Limiter) (*RateLimiter, error) {
+	policies, err := ratelimit.NewPolicies(config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read rate limit policies: %w", err)
+	}
+
+	methods := pb.File_service_bank_proto.Services().ByName(protoreflect.Name("Bank")).Methods()
+	for _, name := range policies.MethodNames() {
+		if methods.ByName(protoreflect.Name(name)) == nil {
+			return nil, fmt.Errorf("cannot limit unknown method %s", name)
+		}
+	}
+
+	return &RateLimiter{
+		limiter:    limiter,
+		policies:   policies,
+		tokenMaker: tokenMaker,
+		routes:     gatewayRoutes(methods),
+	}, nil
+}
+
+// GrpcRateLimit rejects the requests to the bank service exceeding a rate limit with ResourceExhausted
+func (rateLimiter *RateLimiter) GrpcRateLimit(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp interface{}, err error) {
+	// the health checks and the reflection are not limited
+	service, method, found := strings.Cut(strings.TrimPrefix(info.FullMethod, "/"), "/")
+	if !found || service != pb.Bank_ServiceDesc.ServiceName {
+		return handler(ctx, req)
+	}
+
+	caller := ratelimit.Caller{Method: method}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(authorizationHeader); len(values) > 0 {
+			caller.Username = rateLimiter.username(values[0])
+		}
+	}
+	if fromGateway(ctx) {
+		caller.ClientIP = gatewayMetadata(ctx, gatewayClientIPHeader)
+	} else if p, ok := peer.FromContext(ctx); ok {
+		caller.ClientIP = hostIP(p.Addr.String())
+	}
+
+	if st := rateLimiter.check(ctx, caller); st != nil {
+		return nil, st.Err()
+	}
+
+	return handler(ctx, req)
+}
+
+// HTTPRateLimit rejects the gateway requests exceeding a rate limit with 429 Too Many Requests,
+// telling the client when to retry in the Retry-After header
+func (rateLimiter *RateLimiter) HTTPRateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller := ratelimit.Caller{
+			Method:   rateLimiter.gatewayMethod(r),
+			Username: rateLimiter.username(r.Header.Get(authorizationHeader)),
+			ClientIP: httpClientIP(r),
+		}
+
+		if st := rateLimiter.check(r.Context(), caller); st != nil {
+			w.Header().Set(retryAfterHeader, retryAfterSeconds(st))
+			writeHTTPError(w, st.Err())
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// check returns the status rejecting the caller, or nil when it is allowed.
+// The requests are allowed when the limiter fails, so that an outage of the limiter doesn't take the service down.
+func (rateLimiter *RateLimiter) check(ctx context.Context, caller ratelimit.Caller) *status.Status {
+	rejection, err := rateLimiter.policies.Allow(ctx, rateLimiter.limiter, caller)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("cannot check rate limits")
+		return nil
+	}
+	if rejection == nil {
+		return nil
+	}
+
+	metrics.ObserveRateLimited(rejection.Policy)
+	log.Ctx(ctx).Warn().
+		Str("policy", rejection.Policy).
+		Str("method", caller.Method).
+		Str("client_ip", caller.ClientIP).
+		Dur("retry_after", rejection.Result.RetryAfter).
+		Msg("rate limit exceeded")
+
+	return rateLimitStatus(rejection)
+}
+
+// username returns the user of a valid bearer authorization header, or an empty string.
+// The invalid tokens are rejected by the handlers, the limiter only counts their requests by IP.
+func (rateLimiter *RateLimiter) username(authHeader string) string {
+	if authHeader == "" {
+		return ""
+	}
+
+	accessToken, err := bearerToken(authHeader)
+	if err != nil {
+		return ""
+	}
+
+	payload, err := rateLimiter.tokenMaker.VerifyToken(accessToken)
+	if err != nil {
+		return ""
+	}
+
+	return payload.Username
+}
+
+// rateLimitStatus reports a rejected request, with the time to wait before retrying in the details
+func rateLimitStatus(rejection *ratelimit.Rejection) *status.Status {
+	statusExhausted := status.Newf(codes.ResourceExhausted, "%s rate limit exceeded, retry in %s",
+		rejection.Policy, rejection.Result.RetryAfter.Round(time.Millisecond))
+	statusDetails, err := statusExhausted.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(rejection.Result.RetryAfter),
+	})
+	if err != nil {
+		return statusExhausted
+	}
+
+	return statusDetails
+}
+
+// retryAfterSeconds returns the retry delay of a status in whole seconds, rounded up as the header has no fraction
+func retryAfterSeconds(st *status.Status) string {
+	seconds := 1
+	for _, detail := range st.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			seconds = int(math.Max(1, math.Ceil(retryInfo.GetRetryDelay().AsDuration().Seconds())))
+		}
+	}
+
+	return strconv.Itoa(seconds)
+}
+
+// hostIP strips the port from an address
+func hostIP(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+
+	return host
+}
+
+// gatewayRoute is an HTTP route of the gateway and the RPC method it calls
+type gatewayRoute struct {
+	httpMethod string
+	segments   []string
+	rpcMethod  string
+}
+
+// gatewayRoutes reads the HTTP routes of the methods from their google.api.http options,
+// since the gateway middlewares run before the gateway resolves the method of a request
+func gatewayRoutes(methods protoreflect.MethodDescriptors) []gatewayRoute {
+	routes := []gatewayRoute{
+		// the CSV upload is served outside the transcoder
+		{httpMethod: http.MethodPost, segments: pathSegments("/v1/transfer_batches/csv"), rpcMethod: "CreateTransferBatch"},
+	}
+
+	for i := 0; i < methods.Len(); i++ {
+		method := methods.Get(i)
+		rule, ok := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+		if !ok || rule == nil {
+			continue
+		}
+
+		for _, binding := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+			httpMethod, path := httpRulePattern(binding)
+			if path == "" {
+				continue
+			}
+
+			routes = append(routes, gatewayRoute{
+				httpMethod: httpMethod,
+				segments:   pathSegments(path),
+				rpcMethod:  string(method.Name()),
+			})
+		}
+	}
+
+	return routes
+}
+
+func httpRulePattern(rule *annotations.HttpRule) (string, string) {
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return http.MethodGet, pattern.Get
+	case *annotations.HttpRule_Put:
+		return http.MethodPut, pattern.Put
+	case *annotations.HttpRule_Post:
+		return http.MethodPost, pattern.Post
+	case *annotations.HttpRule_Delete:
+		return http.MethodDelete, pattern.Delete
+	case *annotations.HttpRule_Patch:
+		return http.MethodPatch, pattern.Patch
+	case *annotations.HttpRule_Custom:
+		return pattern.Custom.GetKind(), pattern.Custom.GetPath()
+	}
+
+	return "", ""
+}
+
+// gatewayMethod returns the RPC method called by a gateway request, or an empty string for the other routes.
+// A literal segment of a route takes precedence over a variable one, as in the gateway.
+func (rateLimiter *RateLimiter) gatewayMethod(r *http.Request) string {
+	segments := pathSegments(r.URL.Path)
+
+	var best *gatewayRoute
+	bestLiterals := -1
+	for i := range rateLimiter.routes {
+		route := &rateLimiter.routes[i]
+		if route.httpMethod != r.Method || len(route.segments) != len(segments) {
+			continue
+		}
+
+		literals, ok := route.match(segments)
+		if ok && literals > bestLiterals {
+			best, bestLiterals = route, literals
+		}
+	}
+
+	if best == nil {
+		return ""
+	}
+	return best.rpcMethod
+}
+
+// match reports whether the path segments match the route, and how many of them are literal in the route
+func (route *gatewayRoute) match(segments []string) (int, bool) {
+	literals := 0
+	for i, segment := range route.segments {
+		if strings.HasPrefix(segment, "{") {
+			if segments[i] == "" {
+				return 0, false
+			}
+			continue
+		}
+
+		if segment != segments[i] {
+			return 0, false
+		}
+		literals++
+	}
+
+	return literals, true
+}
+
+func pathSegments(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
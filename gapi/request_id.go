@@ -0,0 +1,45 @@
+package gapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/MathPeixoto/go-financial-system/requestid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// GrpcRequestID identifies a gRPC request by the ID in its metadata or a new one,
+// carries it with its logger through the context and returns it in the response trailer
+func GrpcRequestID(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp interface{}, err error) {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestid.MetadataKey); len(values) > 0 {
+			id = values[0]
+		}
+	}
+
+	ctx = requestid.NewContext(ctx, requestid.Resolve(id))
+	if err := grpc.SetTrailer(ctx, metadata.Pairs(requestid.MetadataKey, requestid.FromContext(ctx))); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("cannot set request id trailer")
+	}
+
+	return handler(ctx, req)
+}
+
+// HTTPRequestID identifies an HTTP request by its X-Request-Id header or a new ID,
+// carries it with its logger through the context and returns it in the response header
+func HTTPRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := requestid.Resolve(r.Header.Get(requestid.Header))
+		w.Header().Set(requestid.Header, id)
+
+		next.ServeHTTP(w, r.WithContext(requestid.NewContext(r.Context(), id)))
+	})
+}
@@ -0,0 +1,52 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) AcceptAccountMember(ctx context.Context, req *pb.AcceptAccountMemberRequest) (*pb.AcceptAccountMemberResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateAcceptAccountMemberRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.AcceptAccountMemberTx(ctx, db.AcceptAccountMemberTxParams{
+		AccountID: req.GetAccountId(),
+		Username:  authPayload.Username,
+		Audit:     server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, status.Errorf(codes.NotFound, "no invitation to account %d found", req.GetAccountId())
+		case errors.Is(err, db.ErrAccountMemberNotInvited):
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to accept account member: %s", err)
+	}
+
+	return &pb.AcceptAccountMemberResponse{
+		Member: convertAccountMember(result.Member),
+	}, nil
+}
+
+func validateAcceptAccountMemberRequest(req *pb.AcceptAccountMemberRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	return
+}
@@ -0,0 +1,71 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) AddOrganizationMember(ctx context.Context, req *pb.AddOrganizationMemberRequest) (*pb.AddOrganizationMemberResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateAddOrganizationMemberRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err = server.authorizeOrganization(ctx, req.GetOrganizationId(), authPayload.Username, db.OrganizationRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.AddOrganizationMemberTx(ctx, db.AddOrganizationMemberTxParams{
+		CreateOrganizationMemberParams: db.CreateOrganizationMemberParams{
+			OrganizationID: req.GetOrganizationId(),
+			Username:       req.GetUsername(),
+			Role:           req.GetRole(),
+		},
+		Audit: server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok { //nolint: errorlint
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				return nil, status.Errorf(codes.AlreadyExists, "%s is already a member of organization %d", req.GetUsername(), req.GetOrganizationId())
+			case "foreign_key_violation":
+				return nil, status.Errorf(codes.NotFound, "user %s not found", req.GetUsername())
+			}
+		}
+		return nil, status.Errorf(codes.Internal, "failed to add organization member: %s", err)
+	}
+
+	return &pb.AddOrganizationMemberResponse{
+		Member: convertOrganizationMember(result.Member),
+	}, nil
+}
+
+func validateAddOrganizationMemberRequest(req *pb.AddOrganizationMemberRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetOrganizationId()); err != nil {
+		violations = append(violations, fieldViolation("organization_id", err))
+	}
+
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	if !db.IsSupportedOrganizationRole(req.GetRole()) {
+		violations = append(violations, fieldViolation("role",
+			fmt.Errorf("unsupported role %q, must be one of admin, approver or initiator", req.GetRole())))
+	}
+
+	return
+}
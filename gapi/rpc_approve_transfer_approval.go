@@ -0,0 +1,20 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/MathPeixoto/go-financial-system/pb"
+)
+
+func (server *Server) ApproveTransferApproval(
+	ctx context.Context, req *pb.ApproveTransferApprovalRequest,
+) (*pb.ApproveTransferApprovalResponse, error) {
+	approval, err := server.decideTransferApproval(ctx, req.GetTransferApprovalId(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ApproveTransferApprovalResponse{
+		TransferApproval: approval,
+	}, nil
+}
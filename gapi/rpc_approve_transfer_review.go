@@ -0,0 +1,20 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/MathPeixoto/go-financial-system/pb"
+)
+
+func (server *Server) ApproveTransferReview(
+	ctx context.Context, req *pb.ApproveTransferReviewRequest,
+) (*pb.ApproveTransferReviewResponse, error) {
+	review, err := server.reviewTransfer(ctx, req.GetTransferReviewId(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ApproveTransferReviewResponse{
+		TransferReview: review,
+	}, nil
+}
@@ -0,0 +1,21 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+)
+
+func (server *Server) CancelScheduledTransfer(
+	ctx context.Context, req *pb.CancelScheduledTransferRequest,
+) (*pb.CancelScheduledTransferResponse, error) {
+	scheduled, err := server.updateScheduledTransferStatus(ctx, req.GetScheduledTransferId(), db.ScheduledTransferCancelled)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CancelScheduledTransferResponse{
+		ScheduledTransfer: scheduled,
+	}, nil
+}
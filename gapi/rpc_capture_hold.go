@@ -0,0 +1,62 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func (server *Server) CaptureHold(ctx context.Context, req *pb.CaptureHoldRequest) (*pb.CaptureHoldResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateCaptureHoldRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	hold, _, toAccount, err := server.getParticipatingHold(ctx, req.GetHoldId(), authPayload.Username, db.AccountPermissionSpend)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the payee side captures a hold
+	_, err = server.authorizeAccount(ctx, toAccount, authPayload.Username, db.AccountPermissionSpend, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.CaptureHoldTx(ctx, db.CaptureHoldTxParams{
+		ID:     hold.ID,
+		Amount: req.GetAmount(),
+		Now:    time.Now(),
+		Audit:  server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		return nil, holdError(err, "capture")
+	}
+
+	return &pb.CaptureHoldResponse{
+		Hold:       convertHold(result.Hold),
+		TransferId: result.Transfer.Transfer.ID,
+	}, nil
+}
+
+func validateCaptureHoldRequest(req *pb.CaptureHoldRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetHoldId()); err != nil {
+		violations = append(violations, fieldViolation("hold_id", err))
+	}
+
+	if req.Amount != nil {
+		if err := val.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	}
+
+	return
+}
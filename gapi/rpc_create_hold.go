@@ -0,0 +1,106 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+const (
+	defaultHoldDuration = 7 * 24 * time.Hour
+	maxHoldDuration     = 30 * 24 * time.Hour
+)
+
+func (server *Server) CreateHold(ctx context.Context, req *pb.CreateHoldRequest) (*pb.CreateHoldResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateCreateHoldRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.validTransferAccount(ctx, req.GetAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = server.authorizeAccount(ctx, account, authPayload.Username, db.AccountPermissionSpend, req.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := server.checkNoApprovalRequired(ctx, account, req.GetAmount()); err != nil {
+		return nil, err
+	}
+
+	toAccount, err := server.validTransferAccount(ctx, req.GetToAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	caller := server.extractMetadata(ctx).caller(authPayload.Username)
+	if err := server.service.ScreenHold(ctx, caller, account, toAccount, req.GetAmount()); err != nil {
+		return nil, serviceError(err)
+	}
+
+	expiresIn := defaultHoldDuration
+	if req.ExpiresIn != nil {
+		expiresIn = req.GetExpiresIn().AsDuration()
+	}
+
+	result, err := server.store.CreateHoldTx(ctx, db.CreateHoldTxParams{
+		CreateHoldParams: db.CreateHoldParams{
+			AccountID:   req.GetAccountId(),
+			ToAccountID: req.GetToAccountId(),
+			Amount:      req.GetAmount(),
+			Currency:    req.GetCurrency(),
+			ExpiresAt:   time.Now().Add(expiresIn),
+		},
+		Audit: server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		return nil, holdError(err, "create")
+	}
+
+	return &pb.CreateHoldResponse{
+		Hold:    convertHold(result.Hold),
+		Account: convertAccount(result.Account),
+	}, nil
+}
+
+func validateCreateHoldRequest(req *pb.CreateHoldRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := val.ValidateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	} else if req.GetToAccountId() == req.GetAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("must differ from account_id")))
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if req.ExpiresIn != nil {
+		if err := req.GetExpiresIn().CheckValid(); err != nil {
+			violations = append(violations, fieldViolation("expires_in", err))
+		} else if expiresIn := req.GetExpiresIn().AsDuration(); expiresIn <= 0 || expiresIn > maxHoldDuration {
+			violations = append(violations, fieldViolation("expires_in", fmt.Errorf("must be positive and at most %s", maxHoldDuration)))
+		}
+	}
+
+	return
+}
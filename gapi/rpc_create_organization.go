@@ -0,0 +1,53 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateOrganization(ctx context.Context, req *pb.CreateOrganizationRequest) (*pb.CreateOrganizationResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateCreateOrganizationRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.CreateOrganizationTx(ctx, db.CreateOrganizationTxParams{
+		CreateOrganizationParams: db.CreateOrganizationParams{
+			Name:              req.GetName(),
+			ApprovalThreshold: req.GetApprovalThreshold(),
+			CreatedBy:         authPayload.Username,
+		},
+		Audit: server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create organization: %s", err)
+	}
+
+	return &pb.CreateOrganizationResponse{
+		Organization: convertOrganization(result.Organization),
+		Admin:        convertOrganizationMember(result.Admin),
+	}, nil
+}
+
+func validateCreateOrganizationRequest(req *pb.CreateOrganizationRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateString(req.GetName(), 1, 100); err != nil {
+		violations = append(violations, fieldViolation("name", err))
+	}
+
+	if req.GetApprovalThreshold() < 0 {
+		violations = append(violations, fieldViolation("approval_threshold", fmt.Errorf("must not be negative")))
+	}
+
+	return
+}
@@ -0,0 +1,74 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateOrganizationAccount(
+	ctx context.Context, req *pb.CreateOrganizationAccountRequest,
+) (*pb.CreateOrganizationAccountResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateCreateOrganizationAccountRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err = server.authorizeOrganization(ctx, req.GetOrganizationId(), authPayload.Username, db.OrganizationRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	product := req.GetProduct()
+	if product == "" {
+		product = util.CheckingProduct
+	}
+
+	// the admin opening the account is recorded as its owner, while its members are the members of the organization
+	result, err := server.store.CreateAccountTx(ctx, db.CreateAccountTxParams{
+		CreateAccountParams: db.CreateAccountParams{
+			Owner:    authPayload.Username,
+			Currency: req.GetCurrency(),
+			Product:  product,
+		},
+		OrganizationID: sql.NullInt64{Int64: req.GetOrganizationId(), Valid: true},
+		Audit:          server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create organization account: %s", err)
+	}
+
+	return &pb.CreateOrganizationAccountResponse{
+		Account: convertAccount(result.Account),
+	}, nil
+}
+
+func validateCreateOrganizationAccountRequest(
+	req *pb.CreateOrganizationAccountRequest,
+) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetOrganizationId()); err != nil {
+		violations = append(violations, fieldViolation("organization_id", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if req.GetProduct() != "" && !util.IsSupportedAccountProduct(req.GetProduct()) {
+		violations = append(violations, fieldViolation("product", fmt.Errorf("unsupported account product %q", req.GetProduct())))
+	}
+
+	return
+}
@@ -0,0 +1,68 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreatePayee(ctx context.Context, req *pb.CreatePayeeRequest) (*pb.CreatePayeeResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateCreatePayeeRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.store.GetAccount(ctx, req.GetAccountId())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "account %d not found", req.GetAccountId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	result, err := server.store.CreatePayeeTx(ctx, db.CreatePayeeTxParams{
+		CreatePayeeParams: db.CreatePayeeParams{
+			Owner:     authPayload.Username,
+			Nickname:  req.GetNickname(),
+			AccountID: account.ID,
+			Currency:  account.Currency,
+		},
+		Audit: server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok { //nolint: errorlint
+			if pqErr.Code.Name() == "unique_violation" {
+				return nil, status.Errorf(codes.AlreadyExists, "payee already exists: %s", err)
+			}
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create payee: %s", err)
+	}
+
+	return &pb.CreatePayeeResponse{
+		Payee: convertPayee(result.Payee),
+	}, nil
+}
+
+func validateCreatePayeeRequest(req *pb.CreatePayeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateNickname(req.GetNickname()); err != nil {
+		violations = append(violations, fieldViolation("nickname", err))
+	}
+
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	return
+}
@@ -0,0 +1,118 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateScheduledTransfer(
+	ctx context.Context, req *pb.CreateScheduledTransferRequest,
+) (*pb.CreateScheduledTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateCreateScheduledTransferRequest(req, time.Now()); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validTransferAccount(ctx, req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = server.authorizeAccount(ctx, fromAccount, authPayload.Username, db.AccountPermissionSpend, req.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := server.checkNoApprovalRequired(ctx, fromAccount, req.GetAmount()); err != nil {
+		return nil, err
+	}
+
+	toAccount, err := server.validTransferAccount(ctx, req.GetToAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	// the runs of a scheduled transfer can't be held for review, so it is screened once when it is created
+	caller := server.extractMetadata(ctx).caller(authPayload.Username)
+	if err := server.service.ScreenTransfer(ctx, caller, fromAccount, toAccount, req.GetAmount()); err != nil {
+		return nil, serviceError(err)
+	}
+
+	result, err := server.store.CreateScheduledTransferTx(ctx, db.CreateScheduledTransferTxParams{
+		CreateScheduledTransferParams: db.CreateScheduledTransferParams{
+			Owner:         authPayload.Username,
+			FromAccountID: req.GetFromAccountId(),
+			ToAccountID:   req.GetToAccountId(),
+			Amount:        req.GetAmount(),
+			Currency:      req.GetCurrency(),
+			Recurrence:    req.GetRecurrence(),
+			NextRunAt:     req.GetFirstRunAt().AsTime(),
+			EndAt: sql.NullTime{
+				Time:  req.GetEndAt().AsTime(),
+				Valid: req.EndAt != nil,
+			},
+		},
+		Audit: server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create scheduled transfer: %s", err)
+	}
+
+	return &pb.CreateScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(result.ScheduledTransfer),
+	}, nil
+}
+
+func validateCreateScheduledTransferRequest(
+	req *pb.CreateScheduledTransferRequest, now time.Time,
+) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := val.ValidateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	} else if req.GetToAccountId() == req.GetFromAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("must differ from from_account_id")))
+	}
+
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if err := val.ValidateRecurrence(req.GetRecurrence()); err != nil {
+		violations = append(violations, fieldViolation("recurrence", err))
+	}
+
+	if req.FirstRunAt == nil || !req.GetFirstRunAt().AsTime().After(now) {
+		violations = append(violations, fieldViolation("first_run_at", fmt.Errorf("must be in the future")))
+	}
+
+	if req.EndAt != nil {
+		if req.GetRecurrence() == util.OnceRecurrence {
+			violations = append(violations, fieldViolation("end_at", fmt.Errorf("must not be set for a one-off transfer")))
+		} else if !req.GetEndAt().AsTime().After(req.GetFirstRunAt().AsTime()) {
+			violations = append(violations, fieldViolation("end_at", fmt.Errorf("must be after first_run_at")))
+		}
+	}
+
+	return
+}
@@ -0,0 +1,44 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/service"
+)
+
+func (server *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	result, err := server.service.CreateTransfer(ctx, server.extractMetadata(ctx).caller(authPayload.Username),
+		service.CreateTransferParams{
+			FromAccountID: req.GetFromAccountId(),
+			ToAccountID:   req.GetToAccountId(),
+			PayeeID:       req.GetPayeeId(),
+			Amount:        req.GetAmount(),
+			Currency:      req.GetCurrency(),
+		})
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	switch {
+	case result.TransferApprovalID != 0:
+		return &pb.CreateTransferResponse{
+			TransferApprovalId: &result.TransferApprovalID,
+		}, nil
+	case result.TransferReviewID != 0:
+		return &pb.CreateTransferResponse{
+			TransferReviewId: &result.TransferReviewID,
+		}, nil
+	}
+
+	return &pb.CreateTransferResponse{
+		Transfer:    convertTransfer(result.Transfer.Transfer),
+		FromAccount: convertAccount(result.Transfer.FromAccount),
+		Fee:         result.Transfer.Fee,
+	}, nil
+}
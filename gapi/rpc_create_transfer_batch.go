@@ -0,0 +1,107 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxTransferBatchItems bounds the transfers of a batch, which an atomic batch holds locks for in a single transaction
+const maxTransferBatchItems = 500
+
+func (server *Server) CreateTransferBatch(ctx context.Context, req *pb.CreateTransferBatchRequest) (*pb.CreateTransferBatchResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateCreateTransferBatchRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validTransferAccount(ctx, req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	// the spend limit of a spender applies to every transfer of the batch
+	var largestAmount int64
+	items := make([]db.TransferBatchItemParams, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		items[i] = db.TransferBatchItemParams{ToAccountID: item.GetToAccountId(), Amount: item.GetAmount()}
+		if item.GetAmount() > largestAmount {
+			largestAmount = item.GetAmount()
+		}
+	}
+
+	_, err = server.authorizeAccount(ctx, fromAccount, authPayload.Username, db.AccountPermissionSpend, largestAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := server.checkNoApprovalRequired(ctx, fromAccount, largestAmount); err != nil {
+		return nil, err
+	}
+
+	caller := server.extractMetadata(ctx).caller(authPayload.Username)
+	if err := server.service.ScreenTransferBatch(ctx, caller, fromAccount, items); err != nil {
+		return nil, serviceError(err)
+	}
+
+	result, err := server.store.CreateTransferBatchTx(ctx, db.CreateTransferBatchTxParams{
+		Owner:         authPayload.Username,
+		FromAccountID: fromAccount.ID,
+		Currency:      req.GetCurrency(),
+		Mode:          req.GetMode(),
+		Items:         items,
+		Audit:         server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create transfer batch: %s", err)
+	}
+
+	return &pb.CreateTransferBatchResponse{
+		TransferBatch: convertTransferBatch(result.TransferBatch, result.Items),
+	}, nil
+}
+
+func validateCreateTransferBatchRequest(req *pb.CreateTransferBatchRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if mode := req.GetMode(); mode != db.TransferBatchAtomic && mode != db.TransferBatchBestEffort {
+		violations = append(violations, fieldViolation("mode",
+			fmt.Errorf("unsupported mode %q, must be one of %s or %s", mode, db.TransferBatchAtomic, db.TransferBatchBestEffort)))
+	}
+
+	if count := len(req.GetItems()); count < 1 || count > maxTransferBatchItems {
+		violations = append(violations, fieldViolation("items", fmt.Errorf("must contain from 1-%d transfers", maxTransferBatchItems)))
+		return
+	}
+
+	for i, item := range req.GetItems() {
+		if err := val.ValidateID(item.GetToAccountId()); err != nil {
+			violations = append(violations, fieldViolation(fmt.Sprintf("items[%d].to_account_id", i), err))
+		} else if item.GetToAccountId() == req.GetFromAccountId() {
+			violations = append(violations, fieldViolation(fmt.Sprintf("items[%d].to_account_id", i),
+				fmt.Errorf("must differ from from_account_id")))
+		}
+
+		if err := val.ValidateAmount(item.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation(fmt.Sprintf("items[%d].amount", i), err))
+		}
+	}
+
+	return
+}
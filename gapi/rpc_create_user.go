@@ -2,84 +2,25 @@ package gapi
 
 import (
 	"context"
-	"github.com/MathPeixoto/go-financial-system/worker"
-	"github.com/hibiken/asynq"
-	"time"
 
-	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
 	"github.com/MathPeixoto/go-financial-system/pb"
-	"github.com/MathPeixoto/go-financial-system/util"
-	"github.com/MathPeixoto/go-financial-system/val"
-	"github.com/lib/pq"
-	"google.golang.org/genproto/googleapis/rpc/errdetails"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"github.com/MathPeixoto/go-financial-system/service"
 )
 
 func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
-	if violations := validateCreateUserRequest(req); violations != nil {
-		return nil, invalidArgumentError(violations)
-	}
-
-	hashedPassword, err := util.HashPassword(req.GetPassword())
+	user, err := server.service.CreateUser(ctx, server.extractMetadata(ctx).caller(""), service.CreateUserParams{
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+		FullName: req.GetFullName(),
+		Email:    req.GetEmail(),
+	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to hash the password: %s", err)
-	}
-
-	arg := db.CreateUserTxParams{
-		CreateUserParams: db.CreateUserParams{
-			Username:       req.GetUsername(),
-			HashedPassword: hashedPassword,
-			FullName:       req.GetFullName(),
-			Email:          req.GetEmail(),
-		},
-		AfterCreate: func(user *db.User) error {
-			payload := &worker.PayloadSendVerifyEmail{
-				Username: user.Username,
-			}
-			option := []asynq.Option{
-				asynq.MaxRetry(10),
-				asynq.ProcessIn(10 * time.Second),
-				asynq.Queue(worker.QueueCritical),
-			}
-			return server.distributor.DistributeTaskSendVerifyEmail(ctx, payload, option...)
-		},
-	}
-
-	user, err := server.store.CreateUserTx(ctx, arg)
-	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok { //nolint: errorlint
-			if pqErr.Code.Name() == "unique_violation" {
-				return nil, status.Errorf(codes.AlreadyExists, "user already exists: %s", err)
-			}
-		}
-
-		return nil, status.Errorf(codes.Internal, "failed to create user: %s", err)
+		return nil, serviceError(err)
 	}
 
 	userResponse := &pb.CreateUserResponse{
-		User: converter(user.User),
+		User: converter(user),
 	}
 
 	return userResponse, nil
 }
-
-func validateCreateUserRequest(req *pb.CreateUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
-	if err := val.ValidateUsername(req.GetUsername()); err != nil {
-		violations = append(violations, fieldViolation("username", err))
-	}
-
-	if err := val.ValidatePassword(req.GetPassword()); err != nil {
-		violations = append(violations, fieldViolation("password", err))
-	}
-
-	if err := val.ValidateFullName(req.GetFullName()); err != nil {
-		violations = append(violations, fieldViolation("full_name", err))
-	}
-
-	if err := val.ValidateEmail(req.GetEmail()); err != nil {
-		violations = append(violations, fieldViolation("email", err))
-	}
-
-	return
-}
@@ -0,0 +1,58 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"github.com/MathPeixoto/go-financial-system/webhook"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateWebhookEndpoint(
+	ctx context.Context, req *pb.CreateWebhookEndpointRequest,
+) (*pb.CreateWebhookEndpointResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := server.validateCreateWebhookEndpointRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate webhook secret: %s", err)
+	}
+
+	result, err := server.store.CreateWebhookEndpointTx(ctx, db.CreateWebhookEndpointTxParams{
+		CreateWebhookEndpointParams: db.CreateWebhookEndpointParams{
+			Owner:  authPayload.Username,
+			Url:    req.GetUrl(),
+			Secret: secret,
+		},
+		Audit: server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create webhook endpoint: %s", err)
+	}
+
+	return &pb.CreateWebhookEndpointResponse{
+		Endpoint: convertWebhookEndpoint(result.Endpoint),
+		Secret:   result.Endpoint.Secret,
+	}, nil
+}
+
+func (server *Server) validateCreateWebhookEndpointRequest(
+	req *pb.CreateWebhookEndpointRequest,
+) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateWebhookURL(req.GetUrl(), server.config.Environment == "development"); err != nil {
+		violations = append(violations, fieldViolation("url", err))
+	}
+
+	return
+}
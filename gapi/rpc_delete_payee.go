@@ -0,0 +1,48 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DeletePayee(ctx context.Context, req *pb.DeletePayeeRequest) (*pb.DeletePayeeResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateDeletePayeeRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	payee, err := server.getOwnedPayee(ctx, req.GetPayeeId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = server.store.DeletePayeeTx(ctx, db.DeletePayeeTxParams{
+		PayeeID: payee.ID,
+		Audit:   server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete payee: %s", err)
+	}
+
+	return &pb.DeletePayeeResponse{
+		Payee: convertPayee(payee),
+	}, nil
+}
+
+func validateDeletePayeeRequest(req *pb.DeletePayeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetPayeeId()); err != nil {
+		violations = append(violations, fieldViolation("payee_id", err))
+	}
+
+	return
+}
@@ -0,0 +1,52 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DeleteWebhookEndpoint(
+	ctx context.Context, req *pb.DeleteWebhookEndpointRequest,
+) (*pb.DeleteWebhookEndpointResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateDeleteWebhookEndpointRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if _, err := server.getOwnedWebhookEndpoint(ctx, req.GetEndpointId(), authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	// the endpoint is deactivated rather than deleted, so that its delivery log is kept
+	result, err := server.store.DeactivateWebhookEndpointTx(ctx, db.DeactivateWebhookEndpointTxParams{
+		EndpointID: req.GetEndpointId(),
+		Audit:      server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete webhook endpoint: %s", err)
+	}
+
+	return &pb.DeleteWebhookEndpointResponse{
+		Endpoint: convertWebhookEndpoint(result.Endpoint),
+	}, nil
+}
+
+func validateDeleteWebhookEndpointRequest(
+	req *pb.DeleteWebhookEndpointRequest,
+) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetEndpointId()); err != nil {
+		violations = append(violations, fieldViolation("endpoint_id", err))
+	}
+
+	return
+}
@@ -0,0 +1,48 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) FreezeAccount(ctx context.Context, req *pb.FreezeAccountRequest) (*pb.FreezeAccountResponse, error) {
+	authPayload, err := server.authorizeAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if violations := validateFreezeAccountRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.FreezeAccountTx(ctx, db.FreezeAccountTxParams{
+		AccountID: req.GetAccountId(),
+		Audit:     server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "account %d not found", req.GetAccountId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to freeze account: %s", err)
+	}
+
+	return &pb.FreezeAccountResponse{
+		Account: convertAccount(result.Account),
+	}, nil
+}
+
+func validateFreezeAccountRequest(req *pb.FreezeAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	return
+}
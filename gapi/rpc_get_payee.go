@@ -0,0 +1,37 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func (server *Server) GetPayee(ctx context.Context, req *pb.GetPayeeRequest) (*pb.GetPayeeResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateGetPayeeRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	payee, err := server.getOwnedPayee(ctx, req.GetPayeeId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetPayeeResponse{
+		Payee: convertPayee(payee),
+	}, nil
+}
+
+func validateGetPayeeRequest(req *pb.GetPayeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetPayeeId()); err != nil {
+		violations = append(violations, fieldViolation("payee_id", err))
+	}
+
+	return
+}
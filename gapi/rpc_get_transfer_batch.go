@@ -0,0 +1,44 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) GetTransferBatch(ctx context.Context, req *pb.GetTransferBatchRequest) (*pb.GetTransferBatchResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateGetTransferBatchRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	batch, err := server.getOwnedTransferBatch(ctx, req.GetTransferBatchId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := server.store.ListTransferBatchItems(ctx, batch.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list transfer batch items: %s", err)
+	}
+
+	return &pb.GetTransferBatchResponse{
+		TransferBatch: convertTransferBatch(batch, items),
+	}, nil
+}
+
+func validateGetTransferBatchRequest(req *pb.GetTransferBatchRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetTransferBatchId()); err != nil {
+		violations = append(violations, fieldViolation("transfer_batch_id", err))
+	}
+
+	return
+}
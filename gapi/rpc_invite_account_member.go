@@ -0,0 +1,99 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) InviteAccountMember(ctx context.Context, req *pb.InviteAccountMemberRequest) (*pb.InviteAccountMemberResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateInviteAccountMemberRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.getMemberAccount(ctx, req.GetAccountId(), authPayload.Username, db.AccountPermissionManage)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPersonalAccount(account); err != nil {
+		return nil, err
+	}
+
+	if req.GetUsername() == account.Owner {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+			fieldViolation("username", fmt.Errorf("must not be the owner of the account")),
+		})
+	}
+
+	arg := db.CreateAccountMemberParams{
+		AccountID: account.ID,
+		Username:  req.GetUsername(),
+		Role:      req.GetRole(),
+		InvitedBy: authPayload.Username,
+	}
+	if req.SpendLimit != nil {
+		arg.SpendLimit = sql.NullInt64{Int64: req.GetSpendLimit(), Valid: true}
+	}
+
+	result, err := server.store.InviteAccountMemberTx(ctx, db.InviteAccountMemberTxParams{
+		CreateAccountMemberParams: arg,
+		Audit:                     server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok { //nolint: errorlint
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				return nil, status.Errorf(codes.AlreadyExists, "%s is already a member of account %d", req.GetUsername(), account.ID)
+			case "foreign_key_violation":
+				return nil, status.Errorf(codes.NotFound, "user %s not found", req.GetUsername())
+			}
+		}
+		return nil, status.Errorf(codes.Internal, "failed to invite account member: %s", err)
+	}
+
+	return &pb.InviteAccountMemberResponse{
+		Member: convertAccountMember(result.Member),
+	}, nil
+}
+
+func validateInviteAccountMemberRequest(req *pb.InviteAccountMemberRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	if !db.IsSupportedAccountRole(req.GetRole()) {
+		violations = append(violations, fieldViolation("role",
+			fmt.Errorf("unsupported role %q, must be one of co_owner, viewer or spender", req.GetRole())))
+	}
+
+	switch {
+	case req.GetRole() == db.AccountRoleSpender && req.SpendLimit == nil:
+		violations = append(violations, fieldViolation("spend_limit", fmt.Errorf("is required for a spender")))
+	case req.GetRole() != db.AccountRoleSpender && req.SpendLimit != nil:
+		violations = append(violations, fieldViolation("spend_limit", fmt.Errorf("only applies to a spender")))
+	case req.SpendLimit != nil:
+		if err := val.ValidateAmount(req.GetSpendLimit()); err != nil {
+			violations = append(violations, fieldViolation("spend_limit", err))
+		}
+	}
+
+	return
+}
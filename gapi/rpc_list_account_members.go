@@ -0,0 +1,59 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListAccountMembers(ctx context.Context, req *pb.ListAccountMembersRequest) (*pb.ListAccountMembersResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateListAccountMembersRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.getMemberAccount(ctx, req.GetAccountId(), authPayload.Username, db.AccountPermissionView)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPersonalAccount(account); err != nil {
+		return nil, err
+	}
+
+	members, err := server.store.ListAccountMembers(ctx, account.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list account members: %s", err)
+	}
+
+	owner, err := db.AuthorizeAccount(ctx, server.store, account, account.Owner, db.AccountPermissionView, 0)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get account owner: %s", err)
+	}
+
+	response := &pb.ListAccountMembersResponse{
+		Members: []*pb.AccountMember{convertAccountMember(owner)},
+	}
+	for _, member := range members {
+		response.Members = append(response.Members, convertAccountMember(member))
+	}
+
+	return response, nil
+}
+
+func validateListAccountMembersRequest(req *pb.ListAccountMembersRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	return
+}
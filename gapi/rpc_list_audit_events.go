@@ -0,0 +1,127 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultAuditEventsPageSize = 20
+	maxAuditEventsPageSize     = 100
+)
+
+func (server *Server) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	if _, err := server.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if violations := validateListAuditEventsRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	pageSize := req.GetPageSize()
+	if pageSize == 0 {
+		pageSize = defaultAuditEventsPageSize
+	}
+
+	pageID := req.GetPageId()
+	if pageID == 0 {
+		pageID = 1
+	}
+
+	arg := db.ListAuditEventsParams{
+		Actor:      sql.NullString{String: req.GetActor(), Valid: req.Actor != nil},
+		Action:     sql.NullString{String: req.GetAction(), Valid: req.Action != nil},
+		TargetType: sql.NullString{String: req.GetTargetType(), Valid: req.TargetType != nil},
+		TargetID:   sql.NullString{String: req.GetTargetId(), Valid: req.TargetId != nil},
+		CreatedAfter: sql.NullTime{
+			Time:  req.GetCreatedAfter().AsTime(),
+			Valid: req.CreatedAfter != nil,
+		},
+		CreatedBefore: sql.NullTime{
+			Time:  req.GetCreatedBefore().AsTime(),
+			Valid: req.CreatedBefore != nil,
+		},
+		Limit:  pageSize,
+		Offset: (pageID - 1) * pageSize,
+	}
+
+	events, err := server.store.ListAuditEvents(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list audit events: %s", err)
+	}
+
+	response := &pb.ListAuditEventsResponse{
+		Events: make([]*pb.AuditEvent, 0, len(events)),
+	}
+	for _, event := range events {
+		pbEvent, err := convertAuditEvent(event)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert audit event %d: %s", event.ID, err)
+		}
+		response.Events = append(response.Events, pbEvent)
+	}
+
+	return response, nil
+}
+
+func validateListAuditEventsRequest(req *pb.ListAuditEventsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetPageId() < 0 {
+		violations = append(violations, fieldViolation("page_id", fmt.Errorf("must be a positive number")))
+	}
+
+	if req.GetPageSize() < 0 || req.GetPageSize() > maxAuditEventsPageSize {
+		violations = append(violations, fieldViolation("page_size", fmt.Errorf("must be between 1 and %d", maxAuditEventsPageSize)))
+	}
+
+	if req.CreatedAfter != nil && req.CreatedBefore != nil && !req.GetCreatedAfter().AsTime().Before(req.GetCreatedBefore().AsTime()) {
+		violations = append(violations, fieldViolation("created_before", fmt.Errorf("must be after created_after")))
+	}
+
+	return
+}
+
+func convertAuditEvent(event db.AuditEvent) (*pb.AuditEvent, error) {
+	before, err := auditState(event.Before)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := auditState(event.After)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AuditEvent{
+		Id:         event.ID,
+		Actor:      event.Actor,
+		Action:     event.Action,
+		TargetType: event.TargetType,
+		TargetId:   event.TargetID,
+		Before:     before,
+		After:      after,
+		ClientIp:   event.ClientIp,
+		UserAgent:  event.UserAgent,
+		RequestId:  event.RequestID,
+		CreatedAt:  timestamppb.New(event.CreatedAt),
+	}, nil
+}
+
+func auditState(state json.RawMessage) (*structpb.Struct, error) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(state, &fields); err != nil {
+		return nil, err
+	}
+
+	return structpb.NewStruct(fields)
+}
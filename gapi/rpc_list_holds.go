@@ -0,0 +1,78 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultHoldsPageSize = 20
+	maxHoldsPageSize     = 100
+)
+
+func (server *Server) ListHolds(ctx context.Context, req *pb.ListHoldsRequest) (*pb.ListHoldsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateListHoldsRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.getMemberAccount(ctx, req.GetAccountId(), authPayload.Username, db.AccountPermissionView)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := req.GetPageSize()
+	if pageSize == 0 {
+		pageSize = defaultHoldsPageSize
+	}
+
+	pageID := req.GetPageId()
+	if pageID == 0 {
+		pageID = 1
+	}
+
+	holds, err := server.store.ListHolds(ctx, db.ListHoldsParams{
+		AccountID: account.ID,
+		Limit:     pageSize,
+		Offset:    (pageID - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list holds: %s", err)
+	}
+
+	response := &pb.ListHoldsResponse{
+		Holds: make([]*pb.Hold, 0, len(holds)),
+	}
+	for _, hold := range holds {
+		response.Holds = append(response.Holds, convertHold(hold))
+	}
+
+	return response, nil
+}
+
+func validateListHoldsRequest(req *pb.ListHoldsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if req.GetPageId() < 0 {
+		violations = append(violations, fieldViolation("page_id", fmt.Errorf("must be a positive number")))
+	}
+
+	if req.GetPageSize() < 0 || req.GetPageSize() > maxHoldsPageSize {
+		violations = append(violations, fieldViolation("page_size", fmt.Errorf("must be between 1 and %d", maxHoldsPageSize)))
+	}
+
+	return
+}
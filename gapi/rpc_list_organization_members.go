@@ -0,0 +1,42 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListOrganizationMembers(
+	ctx context.Context, req *pb.ListOrganizationMembersRequest,
+) (*pb.ListOrganizationMembersResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if err := val.ValidateID(req.GetOrganizationId()); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("organization_id", err)})
+	}
+
+	if _, err := server.authorizeOrganization(ctx, req.GetOrganizationId(), authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	members, err := server.store.ListOrganizationMembers(ctx, req.GetOrganizationId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list organization members: %s", err)
+	}
+
+	response := &pb.ListOrganizationMembersResponse{
+		Members: make([]*pb.OrganizationMember, 0, len(members)),
+	}
+	for _, member := range members {
+		response.Members = append(response.Members, convertOrganizationMember(member))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,68 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPayeesPageSize = 20
+	maxPayeesPageSize     = 100
+)
+
+func (server *Server) ListPayees(ctx context.Context, req *pb.ListPayeesRequest) (*pb.ListPayeesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateListPayeesRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	pageSize := req.GetPageSize()
+	if pageSize == 0 {
+		pageSize = defaultPayeesPageSize
+	}
+
+	pageID := req.GetPageId()
+	if pageID == 0 {
+		pageID = 1
+	}
+
+	payees, err := server.store.ListPayees(ctx, db.ListPayeesParams{
+		Owner:  authPayload.Username,
+		Limit:  pageSize,
+		Offset: (pageID - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list payees: %s", err)
+	}
+
+	response := &pb.ListPayeesResponse{
+		Payees: make([]*pb.Payee, 0, len(payees)),
+	}
+	for _, payee := range payees {
+		response.Payees = append(response.Payees, convertPayee(payee))
+	}
+
+	return response, nil
+}
+
+func validateListPayeesRequest(req *pb.ListPayeesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetPageId() < 0 {
+		violations = append(violations, fieldViolation("page_id", fmt.Errorf("must be a positive number")))
+	}
+
+	if req.GetPageSize() < 0 || req.GetPageSize() > maxPayeesPageSize {
+		violations = append(violations, fieldViolation("page_size", fmt.Errorf("must be between 1 and %d", maxPayeesPageSize)))
+	}
+
+	return
+}
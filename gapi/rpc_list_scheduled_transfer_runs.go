@@ -0,0 +1,76 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListScheduledTransferRuns(
+	ctx context.Context, req *pb.ListScheduledTransferRunsRequest,
+) (*pb.ListScheduledTransferRunsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateListScheduledTransferRunsRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if _, err := server.getOwnedScheduledTransfer(ctx, req.GetScheduledTransferId(), authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	pageSize := req.GetPageSize()
+	if pageSize == 0 {
+		pageSize = defaultScheduledTransfersPageSize
+	}
+
+	pageID := req.GetPageId()
+	if pageID == 0 {
+		pageID = 1
+	}
+
+	runs, err := server.store.ListScheduledTransferRuns(ctx, db.ListScheduledTransferRunsParams{
+		ScheduledTransferID: req.GetScheduledTransferId(),
+		Limit:               pageSize,
+		Offset:              (pageID - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list scheduled transfer runs: %s", err)
+	}
+
+	response := &pb.ListScheduledTransferRunsResponse{
+		Runs: make([]*pb.ScheduledTransferRun, 0, len(runs)),
+	}
+	for _, run := range runs {
+		response.Runs = append(response.Runs, convertScheduledTransferRun(run))
+	}
+
+	return response, nil
+}
+
+func validateListScheduledTransferRunsRequest(
+	req *pb.ListScheduledTransferRunsRequest,
+) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetScheduledTransferId()); err != nil {
+		violations = append(violations, fieldViolation("scheduled_transfer_id", err))
+	}
+
+	if req.GetPageId() < 0 {
+		violations = append(violations, fieldViolation("page_id", fmt.Errorf("must be a positive number")))
+	}
+
+	if req.GetPageSize() < 0 || req.GetPageSize() > maxScheduledTransfersPageSize {
+		violations = append(violations, fieldViolation("page_size", fmt.Errorf("must be between 1 and %d", maxScheduledTransfersPageSize)))
+	}
+
+	return
+}
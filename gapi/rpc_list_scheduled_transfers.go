@@ -0,0 +1,72 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultScheduledTransfersPageSize = 20
+	maxScheduledTransfersPageSize     = 100
+)
+
+func (server *Server) ListScheduledTransfers(
+	ctx context.Context, req *pb.ListScheduledTransfersRequest,
+) (*pb.ListScheduledTransfersResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateListScheduledTransfersRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	pageSize := req.GetPageSize()
+	if pageSize == 0 {
+		pageSize = defaultScheduledTransfersPageSize
+	}
+
+	pageID := req.GetPageId()
+	if pageID == 0 {
+		pageID = 1
+	}
+
+	scheduledTransfers, err := server.store.ListScheduledTransfers(ctx, db.ListScheduledTransfersParams{
+		Owner:  authPayload.Username,
+		Limit:  pageSize,
+		Offset: (pageID - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list scheduled transfers: %s", err)
+	}
+
+	response := &pb.ListScheduledTransfersResponse{
+		ScheduledTransfers: make([]*pb.ScheduledTransfer, 0, len(scheduledTransfers)),
+	}
+	for _, scheduled := range scheduledTransfers {
+		response.ScheduledTransfers = append(response.ScheduledTransfers, convertScheduledTransfer(scheduled))
+	}
+
+	return response, nil
+}
+
+func validateListScheduledTransfersRequest(
+	req *pb.ListScheduledTransfersRequest,
+) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetPageId() < 0 {
+		violations = append(violations, fieldViolation("page_id", fmt.Errorf("must be a positive number")))
+	}
+
+	if req.GetPageSize() < 0 || req.GetPageSize() > maxScheduledTransfersPageSize {
+		violations = append(violations, fieldViolation("page_size", fmt.Errorf("must be between 1 and %d", maxScheduledTransfersPageSize)))
+	}
+
+	return
+}
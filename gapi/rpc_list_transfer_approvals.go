@@ -0,0 +1,93 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultTransferApprovalsPageSize = 20
+	maxTransferApprovalsPageSize     = 100
+)
+
+func (server *Server) ListTransferApprovals(
+	ctx context.Context, req *pb.ListTransferApprovalsRequest,
+) (*pb.ListTransferApprovalsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateListTransferApprovalsRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if _, err := server.authorizeOrganization(ctx, req.GetOrganizationId(), authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	approvalStatus := db.TransferApprovalPending
+	if req.Status != nil {
+		approvalStatus = req.GetStatus()
+	}
+
+	pageSize := req.GetPageSize()
+	if pageSize == 0 {
+		pageSize = defaultTransferApprovalsPageSize
+	}
+
+	pageID := req.GetPageId()
+	if pageID == 0 {
+		pageID = 1
+	}
+
+	approvals, err := server.store.ListTransferApprovals(ctx, db.ListTransferApprovalsParams{
+		OrganizationID: req.GetOrganizationId(),
+		Status:         approvalStatus,
+		Limit:          pageSize,
+		Offset:         (pageID - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list transfer approvals: %s", err)
+	}
+
+	response := &pb.ListTransferApprovalsResponse{
+		TransferApprovals: make([]*pb.TransferApproval, 0, len(approvals)),
+	}
+	for _, approval := range approvals {
+		response.TransferApprovals = append(response.TransferApprovals, convertTransferApproval(approval))
+	}
+
+	return response, nil
+}
+
+func validateListTransferApprovalsRequest(req *pb.ListTransferApprovalsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetOrganizationId()); err != nil {
+		violations = append(violations, fieldViolation("organization_id", err))
+	}
+
+	if req.Status != nil {
+		switch req.GetStatus() {
+		case db.TransferApprovalPending, db.TransferApprovalApproved, db.TransferApprovalRejected:
+		default:
+			violations = append(violations, fieldViolation("status", fmt.Errorf("unsupported transfer approval status")))
+		}
+	}
+
+	if req.GetPageId() < 0 {
+		violations = append(violations, fieldViolation("page_id", fmt.Errorf("must be a positive number")))
+	}
+
+	if req.GetPageSize() < 0 || req.GetPageSize() > maxTransferApprovalsPageSize {
+		violations = append(violations, fieldViolation("page_size", fmt.Errorf("must be between 1 and %d", maxTransferApprovalsPageSize)))
+	}
+
+	return
+}
@@ -0,0 +1,82 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultTransferReviewsPageSize = 20
+	maxTransferReviewsPageSize     = 100
+)
+
+func (server *Server) ListTransferReviews(
+	ctx context.Context, req *pb.ListTransferReviewsRequest,
+) (*pb.ListTransferReviewsResponse, error) {
+	if _, err := server.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if violations := validateListTransferReviewsRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	reviewStatus := db.TransferReviewPending
+	if req.Status != nil {
+		reviewStatus = req.GetStatus()
+	}
+
+	pageSize := req.GetPageSize()
+	if pageSize == 0 {
+		pageSize = defaultTransferReviewsPageSize
+	}
+
+	pageID := req.GetPageId()
+	if pageID == 0 {
+		pageID = 1
+	}
+
+	reviews, err := server.store.ListTransferReviews(ctx, db.ListTransferReviewsParams{
+		Status: reviewStatus,
+		Limit:  pageSize,
+		Offset: (pageID - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list transfer reviews: %s", err)
+	}
+
+	response := &pb.ListTransferReviewsResponse{
+		TransferReviews: make([]*pb.TransferReview, 0, len(reviews)),
+	}
+	for _, review := range reviews {
+		response.TransferReviews = append(response.TransferReviews, convertTransferReview(review))
+	}
+
+	return response, nil
+}
+
+func validateListTransferReviewsRequest(req *pb.ListTransferReviewsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.Status != nil {
+		switch req.GetStatus() {
+		case db.TransferReviewPending, db.TransferReviewApproved, db.TransferReviewRejected:
+		default:
+			violations = append(violations, fieldViolation("status", fmt.Errorf("unsupported transfer review status")))
+		}
+	}
+
+	if req.GetPageId() < 0 {
+		violations = append(violations, fieldViolation("page_id", fmt.Errorf("must be a positive number")))
+	}
+
+	if req.GetPageSize() < 0 || req.GetPageSize() > maxTransferReviewsPageSize {
+		violations = append(violations, fieldViolation("page_size", fmt.Errorf("must be between 1 and %d", maxTransferReviewsPageSize)))
+	}
+
+	return
+}
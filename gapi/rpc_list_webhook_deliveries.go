@@ -0,0 +1,81 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultWebhookDeliveriesPageSize = 20
+	maxWebhookDeliveriesPageSize     = 100
+)
+
+func (server *Server) ListWebhookDeliveries(
+	ctx context.Context, req *pb.ListWebhookDeliveriesRequest,
+) (*pb.ListWebhookDeliveriesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateListWebhookDeliveriesRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if _, err := server.getOwnedWebhookEndpoint(ctx, req.GetEndpointId(), authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	pageSize := req.GetPageSize()
+	if pageSize == 0 {
+		pageSize = defaultWebhookDeliveriesPageSize
+	}
+
+	pageID := req.GetPageId()
+	if pageID == 0 {
+		pageID = 1
+	}
+
+	deliveries, err := server.store.ListWebhookDeliveries(ctx, db.ListWebhookDeliveriesParams{
+		EndpointID: req.GetEndpointId(),
+		Limit:      pageSize,
+		Offset:     (pageID - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list webhook deliveries: %s", err)
+	}
+
+	response := &pb.ListWebhookDeliveriesResponse{
+		Deliveries: make([]*pb.WebhookDelivery, 0, len(deliveries)),
+	}
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, convertWebhookDelivery(delivery))
+	}
+
+	return response, nil
+}
+
+func validateListWebhookDeliveriesRequest(
+	req *pb.ListWebhookDeliveriesRequest,
+) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetEndpointId()); err != nil {
+		violations = append(violations, fieldViolation("endpoint_id", err))
+	}
+
+	if req.GetPageId() < 0 {
+		violations = append(violations, fieldViolation("page_id", fmt.Errorf("must be a positive number")))
+	}
+
+	if req.GetPageSize() < 0 || req.GetPageSize() > maxWebhookDeliveriesPageSize {
+		violations = append(violations, fieldViolation("page_size", fmt.Errorf("must be between 1 and %d", maxWebhookDeliveriesPageSize)))
+	}
+
+	return
+}
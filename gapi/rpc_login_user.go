@@ -2,82 +2,42 @@ package gapi
 
 import (
 	"context"
-	"database/sql"
 
 	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
 	"github.com/MathPeixoto/go-financial-system/pb"
-	"github.com/MathPeixoto/go-financial-system/util"
-	"github.com/MathPeixoto/go-financial-system/val"
-	"google.golang.org/genproto/googleapis/rpc/errdetails"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"github.com/MathPeixoto/go-financial-system/service"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.LoginUserResponse, error) {
-	if violations := validateLoginUserRequest(req); violations != nil {
-		return nil, invalidArgumentError(violations)
-	}
-
-	user, err := server.store.GetUser(ctx, req.GetUsername())
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, status.Errorf(codes.NotFound, "user not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to get the user")
-	}
-
-	if err := util.CheckPasswordHash(req.GetPassword(), user.HashedPassword); err != nil {
-		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
-	}
-
-	token, accessPayload, err := server.tokenMaker.CreateToken(user.Username, server.config.AccessTokenDuration)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create access token")
-	}
-
-	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(user.Username, server.config.RefreshTokenDuration)
+	session, err := server.service.LoginUser(ctx, server.extractMetadata(ctx).caller(""), service.LoginUserParams{
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create refresh token")
+		return nil, serviceError(err)
 	}
 
-	metadata := server.extractMedatada(ctx)
-
-	arg := db.CreateSessionParams{
-		ID:           refreshPayload.ID,
-		Username:     user.Username,
-		RefreshToken: refreshToken,
-		UserAgent:    metadata.UserAgent,
-		ClientIp:     metadata.ClientIP,
-		IsBlocked:    false,
-		ExpiresAt:    refreshPayload.ExpiresAt,
-	}
+	return convertSession(session), nil
+}
 
-	session, err := server.store.CreateSession(ctx, arg)
+// createLoginSession issues the access and refresh tokens of an authenticated user and records the session
+func (server *Server) createLoginSession(ctx context.Context, user db.User, metadata *Metadata) (*pb.LoginUserResponse, error) {
+	session, err := server.service.CreateSession(ctx, metadata.caller(""), user)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create user session")
-	}
-
-	userResponse := &pb.LoginUserResponse{
-		User:                  converter(user),
-		SessionId:             session.ID.String(),
-		AccessToken:           token,
-		AccessTokenExpiresAt:  timestamppb.New(accessPayload.ExpiresAt),
-		RefreshToken:          refreshToken,
-		RefreshTokenExpiresAt: timestamppb.New(session.ExpiresAt),
+		return nil, serviceError(err)
 	}
 
-	return userResponse, nil
+	return convertSession(session), nil
 }
 
-func validateLoginUserRequest(req *pb.LoginUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
-	if err := val.ValidateUsername(req.GetUsername()); err != nil {
-		violations = append(violations, fieldViolation("username", err))
-	}
-
-	if err := val.ValidatePassword(req.GetPassword()); err != nil {
-		violations = append(violations, fieldViolation("password", err))
+func convertSession(session service.Session) *pb.LoginUserResponse {
+	return &pb.LoginUserResponse{
+		User:                  converter(session.User),
+		SessionId:             session.ID.String(),
+		AccessToken:           session.AccessToken,
+		AccessTokenExpiresAt:  timestamppb.New(session.AccessTokenExpiresAt),
+		RefreshToken:          session.RefreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(session.RefreshTokenExpiresAt),
 	}
-
-	return
 }
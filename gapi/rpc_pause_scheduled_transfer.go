@@ -0,0 +1,21 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+)
+
+func (server *Server) PauseScheduledTransfer(
+	ctx context.Context, req *pb.PauseScheduledTransferRequest,
+) (*pb.PauseScheduledTransferResponse, error) {
+	scheduled, err := server.updateScheduledTransferStatus(ctx, req.GetScheduledTransferId(), db.ScheduledTransferPaused)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.PauseScheduledTransferResponse{
+		ScheduledTransfer: scheduled,
+	}, nil
+}
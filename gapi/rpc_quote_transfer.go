@@ -0,0 +1,45 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) QuoteTransfer(ctx context.Context, req *pb.QuoteTransferRequest) (*pb.QuoteTransferResponse, error) {
+	if _, err := server.authorizeUser(ctx); err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateQuoteTransferRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fee, err := server.store.QuoteTransferFee(ctx, req.GetCurrency(), req.GetAmount())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to quote transfer fee: %s", err)
+	}
+
+	return &pb.QuoteTransferResponse{
+		Amount:   req.GetAmount(),
+		Currency: req.GetCurrency(),
+		Fee:      fee.Amount,
+		Total:    req.GetAmount() + fee.Amount,
+	}, nil
+}
+
+func validateQuoteTransferRequest(req *pb.QuoteTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	return
+}
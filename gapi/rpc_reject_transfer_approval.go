@@ -0,0 +1,20 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/MathPeixoto/go-financial-system/pb"
+)
+
+func (server *Server) RejectTransferApproval(
+	ctx context.Context, req *pb.RejectTransferApprovalRequest,
+) (*pb.RejectTransferApprovalResponse, error) {
+	approval, err := server.decideTransferApproval(ctx, req.GetTransferApprovalId(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RejectTransferApprovalResponse{
+		TransferApproval: approval,
+	}, nil
+}
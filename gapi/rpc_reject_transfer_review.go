@@ -0,0 +1,20 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/MathPeixoto/go-financial-system/pb"
+)
+
+func (server *Server) RejectTransferReview(
+	ctx context.Context, req *pb.RejectTransferReviewRequest,
+) (*pb.RejectTransferReviewResponse, error) {
+	review, err := server.reviewTransfer(ctx, req.GetTransferReviewId(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RejectTransferReviewResponse{
+		TransferReview: review,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) RemoveAccountMember(ctx context.Context, req *pb.RemoveAccountMemberRequest) (*pb.RemoveAccountMemberResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateRemoveAccountMemberRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// members leave an account or decline its invitation on their own, the others need the owner
+	if req.GetUsername() != authPayload.Username {
+		_, err := server.getMemberAccount(ctx, req.GetAccountId(), authPayload.Username, db.AccountPermissionManage)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := server.store.RemoveAccountMemberTx(ctx, db.RemoveAccountMemberTxParams{
+		AccountID: req.GetAccountId(),
+		Username:  req.GetUsername(),
+		Audit:     server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "%s is not a member of account %d", req.GetUsername(), req.GetAccountId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to remove account member: %s", err)
+	}
+
+	return &pb.RemoveAccountMemberResponse{
+		Member: convertAccountMember(result.Member),
+	}, nil
+}
+
+func validateRemoveAccountMemberRequest(req *pb.RemoveAccountMemberRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	return
+}
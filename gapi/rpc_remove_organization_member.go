@@ -0,0 +1,66 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) RemoveOrganizationMember(
+	ctx context.Context, req *pb.RemoveOrganizationMemberRequest,
+) (*pb.RemoveOrganizationMemberResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateRemoveOrganizationMemberRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// members leave an organization on their own, the others need an admin
+	if req.GetUsername() != authPayload.Username {
+		_, err := server.authorizeOrganization(ctx, req.GetOrganizationId(), authPayload.Username, db.OrganizationRoleAdmin)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := server.store.RemoveOrganizationMemberTx(ctx, db.RemoveOrganizationMemberTxParams{
+		OrganizationID: req.GetOrganizationId(),
+		Username:       req.GetUsername(),
+		Audit:          server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, status.Errorf(codes.NotFound, "%s is not a member of organization %d", req.GetUsername(), req.GetOrganizationId())
+		case errors.Is(err, db.ErrLastOrganizationAdmin):
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to remove organization member: %s", err)
+	}
+
+	return &pb.RemoveOrganizationMemberResponse{
+		Member: convertOrganizationMember(result.Member),
+	}, nil
+}
+
+func validateRemoveOrganizationMemberRequest(req *pb.RemoveOrganizationMemberRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetOrganizationId()); err != nil {
+		violations = append(violations, fieldViolation("organization_id", err))
+	}
+
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	return
+}
@@ -0,0 +1,68 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ReplayWebhookDelivery(
+	ctx context.Context, req *pb.ReplayWebhookDeliveryRequest,
+) (*pb.ReplayWebhookDeliveryResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateReplayWebhookDeliveryRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	delivery, err := server.store.GetWebhookDelivery(ctx, req.GetDeliveryId())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "webhook delivery %d not found", req.GetDeliveryId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get webhook delivery: %s", err)
+	}
+
+	endpoint, err := server.getOwnedWebhookEndpoint(ctx, delivery.EndpointID, authPayload.Username)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.NotFound, "webhook delivery %d not found", req.GetDeliveryId())
+		}
+		return nil, err
+	}
+
+	if !endpoint.IsActive {
+		return nil, status.Errorf(codes.FailedPrecondition, "webhook endpoint %d is inactive", endpoint.ID)
+	}
+
+	result, err := server.store.ReplayWebhookDeliveryTx(ctx, db.ReplayWebhookDeliveryTxParams{
+		DeliveryID: delivery.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to replay webhook delivery: %s", err)
+	}
+
+	return &pb.ReplayWebhookDeliveryResponse{
+		Delivery: convertWebhookDelivery(result.Delivery),
+	}, nil
+}
+
+func validateReplayWebhookDeliveryRequest(
+	req *pb.ReplayWebhookDeliveryRequest,
+) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetDeliveryId()); err != nil {
+		violations = append(violations, fieldViolation("delivery_id", err))
+	}
+
+	return
+}
@@ -0,0 +1,21 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+)
+
+func (server *Server) ResumeScheduledTransfer(
+	ctx context.Context, req *pb.ResumeScheduledTransferRequest,
+) (*pb.ResumeScheduledTransferResponse, error) {
+	scheduled, err := server.updateScheduledTransferStatus(ctx, req.GetScheduledTransferId(), db.ScheduledTransferActive)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ResumeScheduledTransferResponse{
+		ScheduledTransfer: scheduled,
+	}, nil
+}
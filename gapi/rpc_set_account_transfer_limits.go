@@ -0,0 +1,103 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) SetAccountTransferLimits(
+	ctx context.Context, req *pb.SetAccountTransferLimitsRequest,
+) (*pb.SetAccountTransferLimitsResponse, error) {
+	authPayload, err := server.authorizeAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if violations := validateSetAccountTransferLimitsRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	limits := req.GetLimits()
+	if limits == nil {
+		limits = &pb.TransferLimits{}
+	}
+	result, err := server.store.SetAccountTransferLimitTx(ctx, db.SetAccountTransferLimitTxParams{
+		SetAccountTransferLimitParams: db.SetAccountTransferLimitParams{
+			AccountID: req.GetAccountId(),
+			MaxAmount: sql.NullInt64{
+				Int64: limits.GetMaxAmount(),
+				Valid: limits.MaxAmount != nil,
+			},
+			DailyAmount: sql.NullInt64{
+				Int64: limits.GetDailyAmount(),
+				Valid: limits.DailyAmount != nil,
+			},
+			MonthlyAmount: sql.NullInt64{
+				Int64: limits.GetMonthlyAmount(),
+				Valid: limits.MonthlyAmount != nil,
+			},
+			DailyCount: sql.NullInt32{
+				Int32: limits.GetDailyCount(),
+				Valid: limits.DailyCount != nil,
+			},
+		},
+		Audit: server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "account %d not found", req.GetAccountId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to set account transfer limits: %s", err)
+	}
+
+	return &pb.SetAccountTransferLimitsResponse{
+		AccountId: result.Limit.AccountID,
+		Limits: convertTransferLimits(db.TransferLimits{
+			MaxAmount:     result.Limit.MaxAmount,
+			DailyAmount:   result.Limit.DailyAmount,
+			MonthlyAmount: result.Limit.MonthlyAmount,
+			DailyCount:    result.Limit.DailyCount,
+		}),
+		EffectiveLimits: convertTransferLimits(result.Effective),
+	}, nil
+}
+
+func validateSetAccountTransferLimitsRequest(req *pb.SetAccountTransferLimitsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	limits := req.GetLimits()
+	if limits == nil {
+		return
+	}
+
+	amounts := []struct {
+		field string
+		value *int64
+	}{
+		{"limits.max_amount", limits.MaxAmount},
+		{"limits.daily_amount", limits.DailyAmount},
+		{"limits.monthly_amount", limits.MonthlyAmount},
+	}
+	for _, amount := range amounts {
+		if amount.value != nil && *amount.value < 0 {
+			violations = append(violations, fieldViolation(amount.field, fmt.Errorf("must not be negative")))
+		}
+	}
+
+	if limits.DailyCount != nil && limits.GetDailyCount() < 0 {
+		violations = append(violations, fieldViolation("limits.daily_count", fmt.Errorf("must not be negative")))
+	}
+
+	return
+}
@@ -0,0 +1,60 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdatePayee(ctx context.Context, req *pb.UpdatePayeeRequest) (*pb.UpdatePayeeResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateUpdatePayeeRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if _, err := server.getOwnedPayee(ctx, req.GetPayeeId(), authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.UpdatePayeeTx(ctx, db.UpdatePayeeTxParams{
+		UpdatePayeeParams: db.UpdatePayeeParams{
+			ID:       req.GetPayeeId(),
+			Nickname: req.GetNickname(),
+		},
+		Audit: server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok { //nolint: errorlint
+			if pqErr.Code.Name() == "unique_violation" {
+				return nil, status.Errorf(codes.AlreadyExists, "payee nickname already in use: %s", err)
+			}
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update payee: %s", err)
+	}
+
+	return &pb.UpdatePayeeResponse{
+		Payee: convertPayee(result.Payee),
+	}, nil
+}
+
+func validateUpdatePayeeRequest(req *pb.UpdatePayeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetPayeeId()); err != nil {
+		violations = append(violations, fieldViolation("payee_id", err))
+	}
+
+	if err := val.ValidateNickname(req.GetNickname()); err != nil {
+		violations = append(violations, fieldViolation("nickname", err))
+	}
+
+	return
+}
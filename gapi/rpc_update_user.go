@@ -2,16 +2,9 @@ package gapi
 
 import (
 	"context"
-	"database/sql"
-	"time"
 
-	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
 	"github.com/MathPeixoto/go-financial-system/pb"
-	"github.com/MathPeixoto/go-financial-system/util"
-	"github.com/MathPeixoto/go-financial-system/val"
-	"google.golang.org/genproto/googleapis/rpc/errdetails"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"github.com/MathPeixoto/go-financial-system/service"
 )
 
 func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
@@ -20,49 +13,15 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		return nil, unauthenticatedError(err)
 	}
 
-	if violations := validateUpdateUserRequest(req); violations != nil {
-		return nil, invalidArgumentError(violations)
-	}
-
-	if authPayload.Username != req.GetUsername() {
-		return nil, status.Errorf(codes.PermissionDenied, "Cannot update other user`s info")
-	}
-
-	arg := db.UpdateUserParams{
-		Username: req.GetUsername(),
-		FullName: sql.NullString{
-			String: req.GetFullName(),
-			Valid:  req.FullName != nil,
-		},
-		Email: sql.NullString{
-			String: req.GetEmail(),
-			Valid:  req.Email != nil,
-		},
-	}
-
-	if req.Password != nil {
-		hashedPassword, err := util.HashPassword(req.GetPassword())
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to hash the password: %s", err)
-		}
-
-		arg.HashedPassword = sql.NullString{
-			String: hashedPassword,
-			Valid:  true,
-		}
-
-		arg.PasswordChangedAt = sql.NullTime{
-			Time:  time.Now(),
-			Valid: true,
-		}
-	}
-
-	user, err := server.store.UpdateUser(ctx, arg)
+	user, err := server.service.UpdateUser(ctx, server.extractMetadata(ctx).caller(authPayload.Username),
+		service.UpdateUserParams{
+			Username: req.GetUsername(),
+			FullName: req.FullName,
+			Email:    req.Email,
+			Password: req.Password,
+		})
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, status.Errorf(codes.NotFound, "user not found: %s", err)
-		}
-		return nil, status.Errorf(codes.Internal, "failed to update user: %s", err)
+		return nil, serviceError(err)
 	}
 
 	userResponse := &pb.UpdateUserResponse{
@@ -71,29 +30,3 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 
 	return userResponse, nil
 }
-
-func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
-	if err := val.ValidateUsername(req.GetUsername()); err != nil {
-		violations = append(violations, fieldViolation("username", err))
-	}
-
-	if req.Password != nil {
-		if err := val.ValidatePassword(req.GetPassword()); err != nil {
-			violations = append(violations, fieldViolation("password", err))
-		}
-	}
-
-	if req.FullName != nil {
-		if err := val.ValidateFullName(req.GetFullName()); err != nil {
-			violations = append(violations, fieldViolation("full_name", err))
-		}
-	}
-
-	if req.Email != nil {
-		if err := val.ValidateEmail(req.GetEmail()); err != nil {
-			violations = append(violations, fieldViolation("email", err))
-		}
-	}
-
-	return
-}
@@ -0,0 +1,56 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func (server *Server) VoidHold(ctx context.Context, req *pb.VoidHoldRequest) (*pb.VoidHoldResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if violations := validateVoidHoldRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	hold, _, _, err := server.getParticipatingHold(ctx, req.GetHoldId(), authPayload.Username, db.AccountPermissionSpend)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.ReleaseHoldTx(ctx, db.ReleaseHoldTxParams{
+		ID:     hold.ID,
+		Status: db.HoldVoided,
+		Now:    time.Now(),
+		Audit:  server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		return nil, holdError(err, "void")
+	}
+
+	response := &pb.VoidHoldResponse{
+		Hold: convertHold(result.Hold),
+	}
+	// the payee must not learn the balance of the held account
+	_, err = db.AuthorizeAccount(ctx, server.store, result.Account, authPayload.Username, db.AccountPermissionView, 0)
+	if err == nil {
+		response.Account = convertAccount(result.Account)
+	}
+
+	return response, nil
+}
+
+func validateVoidHoldRequest(req *pb.VoidHoldRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(req.GetHoldId()); err != nil {
+		violations = append(violations, fieldViolation("hold_id", err))
+	}
+
+	return
+}
@@ -0,0 +1,74 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getOwnedScheduledTransfer returns a scheduled transfer of the user.
+// Scheduled transfers of other users are reported as not found, so that their IDs cannot be probed.
+func (server *Server) getOwnedScheduledTransfer(ctx context.Context, id int64, username string) (db.ScheduledTransfer, error) {
+	scheduled, err := server.store.GetScheduledTransfer(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return scheduled, status.Errorf(codes.NotFound, "scheduled transfer %d not found", id)
+		}
+		return scheduled, status.Errorf(codes.Internal, "failed to get scheduled transfer: %s", err)
+	}
+
+	if scheduled.Owner != username {
+		return scheduled, status.Errorf(codes.NotFound, "scheduled transfer %d not found", id)
+	}
+
+	return scheduled, nil
+}
+
+// validTransferAccount returns an account taking part in a transfer, checking its currency and status
+func (server *Server) validTransferAccount(ctx context.Context, accountID int64, currency string) (db.Account, error) {
+	account, err := server.service.GetTransferAccount(ctx, accountID, currency)
+	if err != nil {
+		return account, serviceError(err)
+	}
+
+	return account, nil
+}
+
+// updateScheduledTransferStatus pauses, resumes or cancels a scheduled transfer of the authenticated user
+func (server *Server) updateScheduledTransferStatus(ctx context.Context, id int64, newStatus string) (*pb.ScheduledTransfer, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if err := val.ValidateID(id); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("scheduled_transfer_id", err)})
+	}
+
+	if _, err := server.getOwnedScheduledTransfer(ctx, id, authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.UpdateScheduledTransferStatusTx(ctx, db.UpdateScheduledTransferStatusTxParams{
+		ID:     id,
+		Status: newStatus,
+		Now:    time.Now(),
+		Audit:  server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrScheduledTransferStatus) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update scheduled transfer: %s", err)
+	}
+
+	return convertScheduledTransfer(result.ScheduledTransfer), nil
+}
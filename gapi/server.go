@@ -1,7 +1,13 @@
 package gapi
 
 import (
+	"context"
 	"fmt"
+	"time"
+
+	"github.com/MathPeixoto/go-financial-system/oidc"
+	"github.com/MathPeixoto/go-financial-system/service"
+	"github.com/MathPeixoto/go-financial-system/tlsconfig"
 	"github.com/MathPeixoto/go-financial-system/worker"
 
 	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
@@ -12,10 +18,14 @@ import (
 
 type Server struct {
 	pb.UnimplementedBankServer
-	config      util.Config
-	store       db.Store
-	tokenMaker  token.Maker
-	distributor worker.TaskDistributor
+	config       util.Config
+	store        db.Store
+	tokenMaker   token.Maker
+	distributor  worker.TaskDistributor
+	oidcProvider *oidc.Provider
+	service      *service.Service
+	// clientPrincipals maps the identities of the client certificates of the service callers to their users
+	clientPrincipals map[string]string
 }
 
 func NewServer(config util.Config, store db.Store, distributor worker.TaskDistributor) (*Server, error) {
@@ -24,12 +34,39 @@ func NewServer(config util.Config, store db.Store, distributor worker.TaskDistri
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	clientPrincipals, err := tlsconfig.ParsePrincipals(config.TLSClientPrincipals)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read client principals: %w", err)
+	}
+
 	server := &Server{
-		config:      config,
-		store:       store,
-		tokenMaker:  tokenMaker,
-		distributor: distributor,
+		config:           config,
+		store:            store,
+		tokenMaker:       tokenMaker,
+		distributor:      distributor,
+		service:          service.New(config, store, tokenMaker),
+		clientPrincipals: clientPrincipals,
+	}
+
+	if config.OIDCIssuerURL != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		server.oidcProvider, err = oidc.NewProvider(ctx, oidc.Config{
+			IssuerURL:    config.OIDCIssuerURL,
+			ClientID:     config.OIDCClientID,
+			ClientSecret: config.OIDCClientSecret,
+			RedirectURL:  config.OIDCRedirectURL,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot create oidc provider: %w", err)
+		}
 	}
 
 	return server, nil
 }
+
+// TokenMaker returns the maker of the access tokens the server issues and verifies
+func (server *Server) TokenMaker() token.Maker {
+	return server.tokenMaker
+}
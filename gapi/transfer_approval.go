@@ -0,0 +1,51 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// decideTransferApproval approves or rejects a transfer waiting for approval on behalf of a member of its organization
+func (server *Server) decideTransferApproval(ctx context.Context, id int64, approve bool) (*pb.TransferApproval, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if err := val.ValidateID(id); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("transfer_approval_id", err)})
+	}
+
+	result, err := server.store.DecideTransferApprovalTx(ctx, db.DecideTransferApprovalTxParams{
+		ID:       id,
+		Approve:  approve,
+		Approver: authPayload.Username,
+		Audit:    server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		var limitErr *db.TransferLimitError
+		switch {
+		// approvals of other organizations are reported as not found, so that their IDs cannot be probed
+		case errors.Is(err, sql.ErrNoRows), errors.Is(err, db.ErrNotOrganizationMember):
+			return nil, status.Errorf(codes.NotFound, "transfer approval %d not found", id)
+		case errors.Is(err, db.ErrOrganizationPermission), errors.Is(err, db.ErrSelfApproval):
+			return nil, status.Errorf(codes.PermissionDenied, "%s", err)
+		case errors.As(err, &limitErr):
+			return nil, transferLimitError(limitErr)
+		case errors.Is(err, db.ErrTransferApprovalNotPending), errors.Is(err, db.ErrInsufficientFunds),
+			errors.Is(err, db.ErrAccountFrozen), errors.Is(err, db.ErrNotAccountMember), errors.Is(err, db.ErrAccountPermission):
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to decide transfer approval: %s", err)
+	}
+
+	return convertTransferApproval(result.TransferApproval), nil
+}
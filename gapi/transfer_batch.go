@@ -0,0 +1,170 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// maxTransferBatchCSVSize bounds the body of a CSV upload, well above maxTransferBatchItems rows
+	maxTransferBatchCSVSize = 1 << 20
+	transferBatchCSVField   = "file"
+)
+
+// getOwnedTransferBatch returns a transfer batch of the user.
+// Batches of other users are reported as not found, so that their IDs cannot be probed.
+func (server *Server) getOwnedTransferBatch(ctx context.Context, batchID int64, username string) (db.TransferBatch, error) {
+	batch, err := server.store.GetTransferBatch(ctx, batchID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return batch, status.Errorf(codes.NotFound, "transfer batch %d not found", batchID)
+		}
+		return batch, status.Errorf(codes.Internal, "failed to get transfer batch: %s", err)
+	}
+
+	if batch.Owner != username {
+		return batch, status.Errorf(codes.NotFound, "transfer batch %d not found", batchID)
+	}
+
+	return batch, nil
+}
+
+// TransferBatchCSVHandler returns the handler creating transfer batches from a CSV of to_account_id,amount rows,
+// posted either as the request body or as the file field of a multipart form. The other fields of
+// CreateTransferBatchRequest are read from the query parameters. The batch is created through client, with the
+// interceptors of the gRPC server, when the gateway proxies to it, or in-process when client is nil, in the local
+// mode, with the metadata the gateway forwards for the transcoded requests.
+func (server *Server) TransferBatchCSVHandler(client pb.BankClient) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.createTransferBatchCSV(w, r, client)
+	})
+}
+
+func (server *Server) createTransferBatchCSV(w http.ResponseWriter, r *http.Request, client pb.BankClient) {
+	if r.Method != http.MethodPost {
+		writeHTTPError(w, status.Errorf(codes.Unimplemented, "method %s is not supported", r.Method))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxTransferBatchCSVSize)
+	body, err := transferBatchCSVBody(r)
+	if err != nil {
+		writeHTTPError(w, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation(transferBatchCSVField, err)}))
+		return
+	}
+	defer body.Close()
+
+	items, err := parseTransferBatchCSV(body)
+	if err != nil {
+		writeHTTPError(w, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation(transferBatchCSVField, err)}))
+		return
+	}
+
+	query := r.URL.Query()
+	fromAccountID, err := strconv.ParseInt(query.Get("from_account_id"), 10, 64)
+	if err != nil {
+		writeHTTPError(w, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+			fieldViolation("from_account_id", fmt.Errorf("must be an integer")),
+		}))
+		return
+	}
+
+	req := &pb.CreateTransferBatchRequest{
+		FromAccountId: fromAccountID,
+		Currency:      query.Get("currency"),
+		Mode:          query.Get("mode"),
+		Items:         items,
+	}
+
+	var response *pb.CreateTransferBatchResponse
+	md := gatewayRequestMetadata(r)
+	if client != nil {
+		response, err = client.CreateTransferBatch(metadata.NewOutgoingContext(r.Context(), md), req)
+	} else {
+		response, err = server.CreateTransferBatch(metadata.NewIncomingContext(r.Context(), md), req)
+	}
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+
+	responseBody, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(response)
+	if err != nil {
+		writeHTTPError(w, status.Errorf(codes.Internal, "failed to marshal response"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(responseBody)
+}
+
+// transferBatchCSVBody returns the CSV of an upload, from the file field of a multipart form or the request body
+func transferBatchCSVBody(r *http.Request) (io.ReadCloser, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return r.Body, nil
+	}
+
+	file, _, err := r.FormFile(transferBatchCSVField)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the uploaded file: %w", err)
+	}
+
+	return file, nil
+}
+
+// parseTransferBatchCSV reads the to_account_id,amount rows of a batch, below an optional header row
+func parseTransferBatchCSV(r io.Reader) ([]*pb.CreateTransferBatchRequest_Item, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	var items []*pb.CreateTransferBatchRequest_Item
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv: %w", err)
+		}
+
+		if row == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "to_account_id") {
+			continue
+		}
+
+		if len(items) == maxTransferBatchItems {
+			return nil, fmt.Errorf("must contain from 1-%d transfers", maxTransferBatchItems)
+		}
+
+		toAccountID, err := strconv.ParseInt(strings.TrimSpace(record[0]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: to_account_id must be an integer", row)
+		}
+
+		amount, err := strconv.ParseInt(strings.TrimSpace(record[1]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: amount must be an integer", row)
+		}
+
+		items = append(items, &pb.CreateTransferBatchRequest_Item{ToAccountId: toAccountID, Amount: amount})
+	}
+
+	return items, nil
+}
@@ -0,0 +1,48 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// reviewTransfer approves or rejects a transfer pending review on behalf of an admin
+func (server *Server) reviewTransfer(ctx context.Context, id int64, approve bool) (*pb.TransferReview, error) {
+	authPayload, err := server.authorizeAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := val.ValidateID(id); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("transfer_review_id", err)})
+	}
+
+	result, err := server.store.ReviewTransferTx(ctx, db.ReviewTransferTxParams{
+		ID:       id,
+		Approve:  approve,
+		Reviewer: authPayload.Username,
+		Audit:    server.extractMetadata(ctx).audit(authPayload.Username),
+	})
+	if err != nil {
+		var limitErr *db.TransferLimitError
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, status.Errorf(codes.NotFound, "transfer review %d not found", id)
+		case errors.As(err, &limitErr):
+			return nil, transferLimitError(limitErr)
+		case errors.Is(err, db.ErrTransferReviewNotPending), errors.Is(err, db.ErrInsufficientFunds),
+			errors.Is(err, db.ErrAccountFrozen), errors.Is(err, db.ErrNotAccountMember), errors.Is(err, db.ErrAccountPermission):
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to review transfer: %s", err)
+	}
+
+	return convertTransferReview(result.TransferReview), nil
+}
@@ -0,0 +1,29 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getOwnedWebhookEndpoint returns a webhook endpoint registered by the user.
+// Endpoints of other users are reported as not found, so that their IDs cannot be probed.
+func (server *Server) getOwnedWebhookEndpoint(ctx context.Context, endpointID int64, username string) (db.WebhookEndpoint, error) {
+	endpoint, err := server.store.GetWebhookEndpoint(ctx, endpointID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return endpoint, status.Errorf(codes.NotFound, "webhook endpoint %d not found", endpointID)
+		}
+		return endpoint, status.Errorf(codes.Internal, "failed to get webhook endpoint: %s", err)
+	}
+
+	if endpoint.Owner != username {
+		return endpoint, status.Errorf(codes.NotFound, "webhook endpoint %d not found", endpointID)
+	}
+
+	return endpoint, nil
+}
@@ -0,0 +1,123 @@
+// Package health reports whether the servers are alive and ready to receive traffic,
+// over the grpc.health.v1 service and the /healthz and /readyz HTTP endpoints.
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	checkTimeout    = 2 * time.Second
+	refreshInterval = 5 * time.Second
+)
+
+// ErrShuttingDown is returned by the readiness check once the graceful shutdown has started
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// CheckFunc checks that a dependency needed to serve requests is available
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker runs the readiness checks of the dependencies of the servers
+type Checker struct {
+	services     []string
+	checks       []check
+	shuttingDown atomic.Bool
+	grpcHealth   *health.Server
+}
+
+// NewChecker creates a Checker reporting the serving status of the given gRPC services
+// besides the overall status of the server
+func NewChecker(services ...string) *Checker {
+	return &Checker{
+		services:   append([]string{""}, services...),
+		grpcHealth: health.NewServer(),
+	}
+}
+
+// AddCheck adds a check that must pass for the servers to be ready
+func (checker *Checker) AddCheck(name string, fn CheckFunc) {
+	checker.checks = append(checker.checks, check{name: name, fn: fn})
+}
+
+// Check runs all the checks concurrently and returns the error of each failing one by its name
+func (checker *Checker) Check(ctx context.Context) map[string]error {
+	if checker.shuttingDown.Load() {
+		return map[string]error{"shutdown": ErrShuttingDown}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	failures := make(map[string]error)
+
+	for _, c := range checker.checks {
+		wg.Add(1)
+		go func(c check) {
+			defer wg.Done()
+			if err := c.fn(ctx); err != nil {
+				mutex.Lock()
+				failures[c.name] = err
+				mutex.Unlock()
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	return failures
+}
+
+// HealthServer returns the grpc.health.v1 service, whose serving status is refreshed by Run
+func (checker *Checker) HealthServer() healthpb.HealthServer {
+	return checker.grpcHealth
+}
+
+// Run refreshes the gRPC serving status until the context is done, then flips it to not serving
+func (checker *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	checker.refresh(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			checker.Shutdown()
+			return
+		case <-ticker.C:
+			checker.refresh(ctx)
+		}
+	}
+}
+
+// Shutdown makes the servers report not ready for good, so that no new traffic is routed to them while they drain
+func (checker *Checker) Shutdown() {
+	checker.shuttingDown.Store(true)
+	// the health server ignores any later status update once it is shut down
+	checker.grpcHealth.Shutdown()
+}
+
+func (checker *Checker) refresh(ctx context.Context) {
+	status := healthpb.HealthCheckResponse_SERVING
+	for name, err := range checker.Check(ctx) {
+		log.Warn().Err(err).Str("check", name).Msg("readiness check failed")
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	for _, service := range checker.services {
+		checker.grpcHealth.SetServingStatus(service, status)
+	}
+}
@@ -0,0 +1,118 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func passingCheck(context.Context) error { return nil }
+
+func TestReadinessHandler(t *testing.T) {
+	errDown := errors.New("connection refused")
+
+	testCases := []struct {
+		name         string
+		checks       map[string]CheckFunc
+		shutdown     bool
+		expectedCode int
+		expectedBody readinessResponse
+	}{
+		{
+			name:         "OK",
+			checks:       map[string]CheckFunc{"database": passingCheck, "redis": passingCheck},
+			expectedCode: http.StatusOK,
+			expectedBody: readinessResponse{Status: statusOK, Checks: map[string]string{"database": statusOK, "redis": statusOK}},
+		},
+		{
+			name: "CheckFailed",
+			checks: map[string]CheckFunc{
+				"database": passingCheck,
+				"redis":    func(context.Context) error { return errDown },
+			},
+			expectedCode: http.StatusServiceUnavailable,
+			expectedBody: readinessResponse{Status: "unavailable", Checks: map[string]string{"database": statusOK, "redis": errDown.Error()}},
+		},
+		{
+			name:         "ShuttingDown",
+			checks:       map[string]CheckFunc{"database": passingCheck},
+			shutdown:     true,
+			expectedCode: http.StatusServiceUnavailable,
+			expectedBody: readinessResponse{
+				Status: "unavailable",
+				Checks: map[string]string{"database": statusOK, "shutdown": ErrShuttingDown.Error()},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := NewChecker()
+			for name, fn := range tc.checks {
+				checker.AddCheck(name, fn)
+			}
+			if tc.shutdown {
+				checker.Shutdown()
+			}
+
+			recorder := httptest.NewRecorder()
+			checker.ReadinessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			require.Equal(t, tc.expectedCode, recorder.Code)
+
+			var body readinessResponse
+			require.NoError(t, json.NewDecoder(recorder.Body).Decode(&body))
+			require.Equal(t, tc.expectedBody, body)
+		})
+	}
+}
+
+func TestLivenessHandler(t *testing.T) {
+	checker := NewChecker()
+	checker.AddCheck("database", func(context.Context) error { return errors.New("down") })
+	checker.Shutdown()
+
+	recorder := httptest.NewRecorder()
+	checker.LivenessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestChecker_Run(t *testing.T) {
+	var healthy bool
+	checker := NewChecker("pb.Bank")
+	checker.AddCheck("database", func(context.Context) error {
+		if !healthy {
+			return errors.New("down")
+		}
+		return nil
+	})
+
+	servingStatus := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		response, err := checker.HealthServer().Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return response.Status
+	}
+
+	checker.refresh(context.Background())
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(""))
+
+	healthy = true
+	checker.refresh(context.Background())
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus(""))
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus("pb.Bank"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	checker.Run(ctx)
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(""))
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus("pb.Bank"))
+
+	// the status can't be turned back to serving once shut down
+	checker.refresh(context.Background())
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(""))
+}
@@ -0,0 +1,49 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ErrMigrationDirty is returned when the last migration of the database failed halfway
+var ErrMigrationDirty = errors.New("database migration is dirty")
+
+// DatabaseCheck checks that the database answers a ping
+func DatabaseCheck(db *sql.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// RedisCheck checks that redis answers a ping
+func RedisCheck(client redis.UniversalClient) CheckFunc {
+	return func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	}
+}
+
+// MigrationCheck checks that the database schema is at least at the version the server was migrated to at startup.
+// A newer version is accepted, since it is applied by the pods of a rollout in progress.
+func MigrationCheck(db *sql.DB, version uint) CheckFunc {
+	return func(ctx context.Context) error {
+		var current uint
+		var dirty bool
+		err := db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&current, &dirty)
+		if err != nil {
+			return fmt.Errorf("cannot read migration version: %w", err)
+		}
+
+		if dirty {
+			return fmt.Errorf("%w at version %d", ErrMigrationDirty, current)
+		}
+		if current < version {
+			return fmt.Errorf("database is at migration version %d, expected at least %d", current, version)
+		}
+
+		return nil
+	}
+}
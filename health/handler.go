@@ -0,0 +1,48 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const statusOK = "ok"
+
+type readinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// LivenessHandler answers as long as the process serves HTTP requests, which is what a liveness probe needs to know:
+// a dependency being down must not get the container restarted.
+func (checker *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, readinessResponse{Status: statusOK})
+	})
+}
+
+// ReadinessHandler answers 200 when every check passes and 503 with the failing checks otherwise
+func (checker *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		failures := checker.Check(r.Context())
+
+		response := readinessResponse{Status: statusOK, Checks: make(map[string]string)}
+		for _, c := range checker.checks {
+			response.Checks[c.name] = statusOK
+		}
+
+		code := http.StatusOK
+		for name, err := range failures {
+			response.Status = "unavailable"
+			response.Checks[name] = err.Error()
+			code = http.StatusServiceUnavailable
+		}
+
+		writeJSON(w, code, response)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
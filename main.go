@@ -2,23 +2,36 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/MathPeixoto/go-financial-system/worker"
 	"github.com/hibiken/asynq"
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/MathPeixoto/go-financial-system/api"
+	"github.com/MathPeixoto/go-financial-system/clientip"
 	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
 	_ "github.com/MathPeixoto/go-financial-system/doc/statik"
 	"github.com/MathPeixoto/go-financial-system/gapi"
+	"github.com/MathPeixoto/go-financial-system/health"
+	"github.com/MathPeixoto/go-financial-system/mail"
+	"github.com/MathPeixoto/go-financial-system/metrics"
 	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/MathPeixoto/go-financial-system/ratelimit"
+	"github.com/MathPeixoto/go-financial-system/tlsconfig"
+	"github.com/MathPeixoto/go-financial-system/tracing"
 	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/MathPeixoto/go-financial-system/webhook"
+	"github.com/go-redis/redis/v8"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
@@ -28,10 +41,26 @@ import (
 	"github.com/rakyll/statik/fs"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
 )
 
+// bufconnSize is the buffer of the in-memory connection of the gateway to the gRPC server
+const bufconnSize = 1024 * 1024
+
+// interruptSignals trigger the graceful shutdown, SIGTERM being sent by Kubernetes before killing a pod
+var interruptSignals = []os.Signal{
+	os.Interrupt,
+	syscall.SIGTERM,
+}
+
 func main() {
 	// Load configuration from file "app.env"
 	config, err := util.LoadConfig("app.env")
@@ -43,6 +72,18 @@ func main() {
 	if config.Environment == "development" {
 		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 	}
+	// The code logging outside of a request logs through the global logger
+	zerolog.DefaultContextLogger = &log.Logger
+
+	// The context is canceled on the first interrupt signal, which shuts every component down
+	ctx, stop := signal.NotifyContext(context.Background(), interruptSignals...)
+	defer stop()
+
+	// Trace the requests through the servers, the database and the tasks they enqueue
+	tracerProvider, err := tracing.NewTracerProvider(ctx, config)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create tracer provider")
+	}
 
 	// Connect to the database using the specified database driver and source
 	var conn *sql.DB
@@ -51,8 +92,13 @@ func main() {
 		log.Fatal().Err(err).Msg("cannot connect to db")
 	}
 
+	// Expose the statistics of the connection pool
+	if err := metrics.RegisterDBStats(conn, "bank"); err != nil {
+		log.Fatal().Err(err).Msg("cannot register db metrics")
+	}
+
 	// run db migrations
-	runDBMigration(config.MigrationURL, config.DatabaseSource)
+	migrationVersion := runDBMigration(config.MigrationURL, config.DatabaseSource)
 
 	// Create a new store using the database connection
 	store := db.NewStore(conn)
@@ -62,25 +108,173 @@ func main() {
 		Addr: config.RedisAddress,
 	}
 	distributor := worker.NewRedisTaskDistributor(redisOpts)
+	redisClient := redisOpts.MakeRedisClient().(redis.UniversalClient)
+
+	// The servers are ready while the database, redis and the expected schema are available
+	checker := health.NewChecker(pb.Bank_ServiceDesc.ServiceName)
+	checker.AddCheck("database", health.DatabaseCheck(conn))
+	checker.AddCheck("redis", health.RedisCheck(redisClient))
+	checker.AddCheck("migration", health.MigrationCheck(conn, migrationVersion))
+
+	// The servers listen with TLS when a certificate is configured, and verify the client certificates
+	// against the client CA bundle when there is one
+	var tlsConfig *tls.Config
+	var certReloader *tlsconfig.Reloader
+	if config.TLSCertFile != "" {
+		certReloader, err = tlsconfig.NewReloader(config.TLSCertFile, config.TLSKeyFile, config.TLSClientCAFile)
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot load tls certificates")
+		}
+		tlsConfig = certReloader.ServerConfig(config.TLSRequireClientCert)
+	}
+
+	// The gRPC server and the gateway share a single server, and a single interceptor chain when the gateway proxies
+	server, err := gapi.NewServer(config, store, distributor)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create server")
+	}
+	gatewayEndpoint, gatewayDialOptions, bufListener := gatewayConnection(config, certReloader)
+
+	// The rate limits are shared by the replicas through redis, and enforced by each one alone while redis is down
+	rateLimiter, err := gapi.NewRateLimiter(config, server.TokenMaker(),
+		ratelimit.NewFallbackLimiter(ratelimit.NewRedisLimiter(redisClient), ratelimit.NewMemoryLimiter()))
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create rate limiter")
+	}
 
-	// Start the task processor in a new goroutine
-	go taskProcessor(redisOpts, store)
-	// Start the gateway server in a new goroutine
-	go runGatewayServer(config, store, distributor)
+	// Every component runs in the group and stops once the context is done,
+	// so that the failure of one of them shuts the others down too
+	waitGroup, ctx := errgroup.WithContext(ctx)
+
+	// Start the health checker, which turns the servers not ready as soon as the shutdown starts.
+	// The servers keep serving until the drain delay after it, so that the load balancers stop routing to them first.
+	drainCtx := runHealthChecker(ctx, waitGroup, config, checker)
+	// Start the certificate reloader, which swaps the certificates of the servers when their files change
+	if certReloader != nil {
+		runCertificateReloader(ctx, waitGroup, certReloader)
+	}
+	// Start the task processor
+	runTaskProcessor(ctx, waitGroup, config, redisOpts, store)
+	// Start the scheduler, which periodically enqueues the scans for due scheduled transfers and expired holds
+	// and the interest accrual and posting
+	runTaskScheduler(ctx, waitGroup, redisOpts)
+	// Start the outbox relay, which publishes the tasks written by committed transactions
+	runOutboxRelay(ctx, waitGroup, store, distributor)
+	// Start the gateway server
+	runGatewayServer(drainCtx, waitGroup, config, server, rateLimiter, tlsConfig, gatewayEndpoint, gatewayDialOptions)
 	// Start the gRPC server
-	runGrpcServer(config, store, distributor)
-}
+	runGrpcServer(drainCtx, waitGroup, config, server, checker, rateLimiter, tlsConfig, bufListener)
+	// Start the monitoring server of the probes and the metrics, which keeps reporting not ready during the drain
+	runMonitoringServer(drainCtx, waitGroup, config, checker)
+
+	err = waitGroup.Wait()
+
+	// The clients are released once the servers and the workers using them are drained
+	if closeErr := distributor.Close(); closeErr != nil {
+		log.Error().Err(closeErr).Msg("cannot close task distributor")
+	}
+	if closeErr := redisClient.Close(); closeErr != nil {
+		log.Error().Err(closeErr).Msg("cannot close redis client")
+	}
+	if closeErr := conn.Close(); closeErr != nil {
+		log.Error().Err(closeErr).Msg("cannot close db")
+	}
+	// The spans not exported yet are flushed
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer cancel()
+	if closeErr := tracerProvider.Shutdown(shutdownCtx); closeErr != nil {
+		log.Error().Err(closeErr).Msg("cannot shutdown tracer provider")
+	}
 
-func taskProcessor(redisOpt asynq.RedisClientOpt, store db.Store) {
-	processor := worker.NewRedisTaskProcessor(redisOpt, store)
-	log.Info().Msg("starting task processor")
-	err := processor.Start()
 	if err != nil {
-		log.Fatal().Err(err).Msg("cannot start task processor")
+		log.Fatal().Err(err).Msg("error from wait group")
 	}
+	log.Info().Msg("shutdown completed")
+}
+
+func runTaskProcessor(
+	ctx context.Context, waitGroup *errgroup.Group, config util.Config, redisOpt asynq.RedisClientOpt, store db.Store,
+) {
+	mailer := mail.NewGmailSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword)
+	webhookClient := webhook.NewClient(worker.WebhookTimeout, config.Environment == "development")
+	processor := worker.NewRedisTaskProcessor(redisOpt, store, mailer, webhookClient, config.ShutdownTimeout)
+
+	waitGroup.Go(func() error {
+		log.Info().Msg("starting task processor")
+		if err := processor.Start(); err != nil {
+			return fmt.Errorf("cannot start task processor: %w", err)
+		}
+
+		<-ctx.Done()
+		log.Info().Msg("graceful shutdown task processor")
+		processor.Shutdown()
+		log.Info().Msg("task processor is stopped")
+		return nil
+	})
+}
+
+func runTaskScheduler(ctx context.Context, waitGroup *errgroup.Group, redisOpt asynq.RedisClientOpt) {
+	waitGroup.Go(func() error {
+		scheduler, err := worker.NewScheduler(redisOpt)
+		if err != nil {
+			return fmt.Errorf("cannot create task scheduler: %w", err)
+		}
+
+		log.Info().Msg("starting task scheduler")
+		if err := scheduler.Start(); err != nil {
+			return fmt.Errorf("cannot start task scheduler: %w", err)
+		}
+
+		<-ctx.Done()
+		log.Info().Msg("graceful shutdown task scheduler")
+		scheduler.Shutdown()
+		log.Info().Msg("task scheduler is stopped")
+		return nil
+	})
+}
+
+func runOutboxRelay(ctx context.Context, waitGroup *errgroup.Group, store db.Store, distributor worker.TaskDistributor) {
+	waitGroup.Go(func() error {
+		log.Info().Msg("starting outbox relay")
+		worker.NewOutboxRelay(store, distributor).Run(ctx)
+		log.Info().Msg("outbox relay is stopped")
+		return nil
+	})
+}
+
+// runHealthChecker runs the checker until the context is done, and returns a context that is done
+// the drain delay after the checker turned the servers not ready
+func runHealthChecker(
+	ctx context.Context, waitGroup *errgroup.Group, config util.Config, checker *health.Checker,
+) context.Context {
+	drainCtx, cancel := context.WithCancel(context.Background())
+
+	waitGroup.Go(func() error {
+		defer cancel()
+
+		checker.Run(ctx)
+		log.Info().Msgf("health checker is stopped, servers are not ready and drain for %s", config.ShutdownDrainDelay)
+
+		time.Sleep(config.ShutdownDrainDelay)
+		return nil
+	})
+
+	return drainCtx
+}
+
+func runCertificateReloader(ctx context.Context, waitGroup *errgroup.Group, reloader *tlsconfig.Reloader) {
+	waitGroup.Go(func() error {
+		log.Info().Msg("starting certificate reloader")
+		if err := reloader.Watch(ctx); err != nil {
+			return fmt.Errorf("certificate reloader failed: %w", err)
+		}
+		log.Info().Msg("certificate reloader is stopped")
+		return nil
+	})
 }
 
-func runDBMigration(migrationURL, dbSource string) {
+// runDBMigration migrates the database up and returns the version it ends at
+func runDBMigration(migrationURL, dbSource string) uint {
 	migration, err := migrate.New(migrationURL, dbSource)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot create migration")
@@ -91,23 +285,46 @@ func runDBMigration(migrationURL, dbSource string) {
 		log.Fatal().Err(err).Msg("cannot run migration")
 	}
 
+	version, _, err := migration.Version()
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot read migration version")
+	}
+
 	log.Info().Msg("migration completed")
+	return version
 }
 
-// runGrpcServer starts a gRPC server and listens for incoming requests
-func runGrpcServer(config util.Config, store db.Store, distributor worker.TaskDistributor) {
-	// Create a new gapi server
-	server, err := gapi.NewServer(config, store, distributor)
-	if err != nil {
-		log.Fatal().Err(err).Msg("cannot create server")
-	}
+// runGrpcServer starts a gRPC server and listens for incoming requests until the context is done,
+// also on bufListener unless it is nil
+func runGrpcServer(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	config util.Config,
+	server *gapi.Server,
+	checker *health.Checker,
+	rateLimiter *gapi.RateLimiter,
+	tlsConfig *tls.Config,
+	bufListener *bufconn.Listener,
+) {
+	interceptors := grpc.ChainUnaryInterceptor(
+		otelgrpc.UnaryServerInterceptor(),
+		gapi.GrpcRequestID,
+		gapi.GrpcLogger,
+		gapi.GrpcMetrics,
+		rateLimiter.GrpcRateLimit,
+	)
 
-	grpcLogger := grpc.UnaryInterceptor(gapi.GrpcLogger)
+	serverOptions := []grpc.ServerOption{interceptors}
+	if tlsConfig != nil {
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
 
 	// Create a new gRPC server
-	grpcServer := grpc.NewServer(grpcLogger)
+	grpcServer := grpc.NewServer(serverOptions...)
 	// Register the bank server to the gRPC server
 	pb.RegisterBankServer(grpcServer, server)
+	// Register the standard health service, used by the gRPC probes and load balancers
+	healthpb.RegisterHealthServer(grpcServer, checker.HealthServer())
 	// Register the gRPC server to use reflection
 	reflection.Register(grpcServer)
 	// Listen for incoming requests at the specified address
@@ -116,21 +333,62 @@ func runGrpcServer(config util.Config, store db.Store, distributor worker.TaskDi
 		log.Fatal().Err(err).Msg("cannot create listener")
 	}
 
-	log.Info().Msgf("Starting gRPC server at %s", listener.Addr().String())
-	err = grpcServer.Serve(listener)
-	if err != nil {
-		log.Fatal().Err(err).Msg("cannot start gRPC server")
-	}
-}
+	waitGroup.Go(func() error {
+		log.Info().Msgf("Starting gRPC server at %s", listener.Addr().String())
+		err := grpcServer.Serve(listener)
+		if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			return fmt.Errorf("gRPC server failed to serve: %w", err)
+		}
+		return nil
+	})
 
-// runGatewayServer starts the HTTP gateway server for the bank service with the given configuration and database store.
-func runGatewayServer(config util.Config, store db.Store, distributor worker.TaskDistributor) {
-	// Create a new server using the provided configuration and store.
-	server, err := gapi.NewServer(config, store, distributor)
-	if err != nil {
-		log.Fatal().Err(err).Msg("cannot create server")
+	if bufListener != nil {
+		waitGroup.Go(func() error {
+			log.Info().Msg("Starting gRPC server on the in-memory connection of the gateway")
+			err := grpcServer.Serve(bufListener)
+			if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+				return fmt.Errorf("gRPC server failed to serve the gateway: %w", err)
+			}
+			return nil
+		})
 	}
 
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		log.Info().Msg("graceful shutdown gRPC server")
+
+		// GracefulStop waits for the pending RPCs without a deadline, so they are cut once the drain timeout expires
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-time.After(config.ShutdownTimeout):
+			log.Warn().Msg("gRPC server drain timeout expired, closing the pending RPCs")
+			grpcServer.Stop()
+		}
+
+		log.Info().Msg("gRPC server is stopped")
+		return nil
+	})
+}
+
+// runGatewayServer starts the HTTP gateway server for the bank service with the given configuration and server,
+// until the context is done. The gateway proxies the requests to the gRPC server at the endpoint unless it is empty,
+// in which case it calls the server in-process.
+func runGatewayServer(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	config util.Config,
+	server *gapi.Server,
+	rateLimiter *gapi.RateLimiter,
+	tlsConfig *tls.Config,
+	endpoint string,
+	dialOptions []grpc.DialOption,
+) {
 	// Define JSON options for the gRPC-JSON transcoder.
 	jsonOptions := runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
 		MarshalOptions: protojson.MarshalOptions{
@@ -144,14 +402,42 @@ func runGatewayServer(config util.Config, store db.Store, distributor worker.Tas
 	})
 
 	// Create a gRPC-JSON transcoder serve mux.
-	grpcMux := runtime.NewServeMux(jsonOptions)
+	// The route annotator records the path pattern of the requests for the metrics,
+	// and the gateway annotator forwards what the HTTP middlewares resolved about the requests to the server.
+	grpcMux := runtime.NewServeMux(
+		jsonOptions,
+		runtime.WithMetadata(gapi.HTTPRouteAnnotator),
+		runtime.WithMetadata(gapi.GatewayMetadataAnnotator),
+		runtime.WithIncomingHeaderMatcher(gapi.GatewayHeaderMatcher),
+	)
 
-	// Create a context with cancel function.
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	// The connection to the gRPC server outlives the context, so that the requests still draining are proxied.
+	connCtx, closeConn := context.WithCancel(context.Background())
+	var apiHandler, transferBatchCSVHandler http.Handler
+	var err error
+	if endpoint == "" {
+		// Register the handler server to the gRPC-JSON transcoder serve mux, rate limited like the API routes below.
+		err = pb.RegisterBankHandlerServer(ctx, grpcMux, server)
+		apiHandler = rateLimiter.HTTPRateLimit(grpcMux)
+		transferBatchCSVHandler = rateLimiter.HTTPRateLimit(server.TransferBatchCSVHandler(nil))
+	} else {
+		// Proxy the requests to the gRPC server, whose interceptors rate limit them.
+		var conn *grpc.ClientConn
+		conn, err = grpc.DialContext(connCtx, endpoint, dialOptions...)
+		if err != nil {
+			log.Panic().Err(err).Msg("cannot dial gRPC server")
+		}
+		go func() {
+			<-connCtx.Done()
+			if err := conn.Close(); err != nil {
+				log.Error().Err(err).Msg("cannot close the connection to the gRPC server")
+			}
+		}()
 
-	// Register the handler server to the gRPC-JSON transcoder serve mux.
-	err = pb.RegisterBankHandlerServer(ctx, grpcMux, server)
+		err = pb.RegisterBankHandler(connCtx, grpcMux, conn)
+		apiHandler = grpcMux
+		transferBatchCSVHandler = server.TransferBatchCSVHandler(pb.NewBankClient(conn))
+	}
 	if err != nil {
 		log.Panic().Err(err).Msg("cannot register handler server")
 	}
@@ -159,7 +445,12 @@ func runGatewayServer(config util.Config, store db.Store, distributor worker.Tas
 	// Create a new HTTP serve mux.
 	mux := http.NewServeMux()
 	// Mount the gRPC-JSON transcoder serve mux to the root path.
-	mux.Handle("/", grpcMux)
+	mux.Handle("/", apiHandler)
+	// Mount the OpenID Connect login flow, which needs browser redirects and cookies.
+	mux.Handle("/v1/oidc/login", rateLimiter.HTTPRateLimit(http.HandlerFunc(server.OIDCLogin)))
+	mux.Handle("/v1/oidc/callback", rateLimiter.HTTPRateLimit(http.HandlerFunc(server.OIDCCallback)))
+	// Mount the CSV upload of transfer batches, which the transcoder cannot decode.
+	mux.Handle("/v1/transfer_batches/csv", transferBatchCSVHandler)
 
 	// Create a new file system using Statik.
 	statikFS, err := fs.New()
@@ -172,24 +463,135 @@ func runGatewayServer(config util.Config, store db.Store, distributor worker.Tas
 	// Mount the Swagger documentation handler to the /swagger/ path.
 	mux.Handle("/swagger/", swaggerHandler)
 
-	// Create a listener on the HTTP server address.
-	listener, err := net.Listen("tcp", config.HTTPServerAddress)
+	// Only the proxies in front of the gateway, such as the load balancer, are trusted to forward the client IP.
+	trustedProxies, err := clientip.ParseTrustedProxies(config.TrustedProxies)
 	if err != nil {
-		log.Panic().Err(err).Msg("cannot create listener")
+		log.Panic().Err(err).Msg("cannot parse trusted proxies")
 	}
+	clientIP := gapi.HTTPClientIP(trustedProxies)
 
-	// Start serving HTTP requests using the created listener and HTTP serve mux with e seconds of timeout.
-	handler := gapi.HTTPLogger(mux)
-	sv := &http.Server{
+	// Start serving HTTP requests using the HTTP serve mux with 3 seconds of timeout.
+	handler := otelhttp.NewHandler(gapi.HTTPRequestID(clientIP(gapi.HTTPClientCertificate(gapi.HTTPLogger(gapi.HTTPMetrics(mux))))), "gateway")
+	httpServer := &http.Server{
+		Addr:         config.HTTPServerAddress,
 		ReadTimeout:  3 * time.Second,
 		WriteTimeout: 3 * time.Second,
 		Handler:      handler,
+		TLSConfig:    tlsConfig,
 	}
-	log.Info().Msgf("Starting HTTP gateway server at %s", listener.Addr().String())
-	err = sv.Serve(listener)
-	if err != nil {
-		log.Panic().Err(err).Msg("cannot start HTTP gateway server")
+
+	waitGroup.Go(func() error {
+		log.Info().Msgf("Starting HTTP gateway server at %s", httpServer.Addr)
+		var err error
+		if tlsConfig != nil {
+			// the certificate comes from the TLS config, which reloads it
+			err = httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("HTTP gateway server failed to serve: %w", err)
+		}
+		return nil
+	})
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		log.Info().Msg("graceful shutdown HTTP gateway server")
+
+		// the parent context is already done, so the drain gets a fresh one bounded by the timeout
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+		defer cancel()
+
+		err := httpServer.Shutdown(shutdownCtx)
+		closeConn()
+		if err != nil {
+			return fmt.Errorf("failed to shutdown HTTP gateway server: %w", err)
+		}
+
+		log.Info().Msg("HTTP gateway server is stopped")
+		return nil
+	})
+}
+
+// runMonitoringServer serves the liveness and readiness probes and the Prometheus metrics over plain HTTP,
+// apart from the gateway, so that the kubelet and the scraper reach them whether or not the gateway requires
+// TLS or client certificates, and they are not exposed along with the API.
+func runMonitoringServer(ctx context.Context, waitGroup *errgroup.Group, config util.Config, checker *health.Checker) {
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", checker.LivenessHandler())
+	mux.Handle("/readyz", checker.ReadinessHandler())
+	mux.Handle("/metrics", metrics.Handler())
+
+	httpServer := &http.Server{
+		Addr:         config.MonitoringAddress,
+		ReadTimeout:  3 * time.Second,
+		WriteTimeout: 10 * time.Second,
+		Handler:      mux,
+	}
+
+	waitGroup.Go(func() error {
+		log.Info().Msgf("Starting monitoring server at %s", httpServer.Addr)
+		err := httpServer.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("monitoring server failed to serve: %w", err)
+		}
+		return nil
+	})
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		log.Info().Msg("graceful shutdown monitoring server")
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+		defer cancel()
+
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("failed to shutdown monitoring server: %w", err)
+		}
+
+		log.Info().Msg("monitoring server is stopped")
+		return nil
+	})
+}
+
+// gatewayConnection returns the endpoint of the gRPC server the gateway proxies to in the configured mode
+// and the options to dial it, along with the in-memory listener the gRPC server must serve in the bufconn mode.
+// The endpoint is empty in the local mode, where the gateway calls the server in-process.
+func gatewayConnection(
+	config util.Config,
+	certReloader *tlsconfig.Reloader,
+) (string, []grpc.DialOption, *bufconn.Listener) {
+	transportCredentials := insecure.NewCredentials()
+	if certReloader != nil {
+		transportCredentials = credentials.NewTLS(certReloader.LoopbackConfig(config.TLSRequireClientCert))
+	}
+
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(transportCredentials),
+		// the spans of the server are children of the ones of the gateway
+		grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
 	}
+
+	switch config.GatewayMode {
+	case gapi.GatewayModeLocal:
+		return "", nil, nil
+	case gapi.GatewayModeEndpoint:
+		_, port, err := net.SplitHostPort(config.GrpcServerAddress)
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid gRPC server address")
+		}
+		return net.JoinHostPort("localhost", port), dialOptions, nil
+	case gapi.GatewayModeBufconn:
+		bufListener := bufconn.Listen(bufconnSize)
+		dialOptions = append(dialOptions, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return bufListener.DialContext(ctx)
+		}))
+		return "passthrough:///bufconn", dialOptions, bufListener
+	}
+
+	log.Fatal().Msgf("unknown gateway mode %q", config.GatewayMode)
+	return "", nil, nil
 }
 
 // runGinServer starts the Gin HTTP server with the provided config and store.
@@ -0,0 +1,153 @@
+// Package metrics defines the Prometheus metrics of the servers, the database, the task processor and the transfers.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "bank"
+
+// Statuses of the queries and of the tasks
+const (
+	statusSuccess = "success"
+	statusFailure = "failure"
+)
+
+var (
+	grpcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Number of gRPC requests by method and status code.",
+	}, []string{"method", "code"})
+	grpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the gRPC requests by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "code"})
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the HTTP requests by method, route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "code"})
+
+	queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Latency of the database queries by query name and status.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"query", "status"})
+
+	tasksProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "worker",
+		Name:      "tasks_processed_total",
+		Help:      "Number of tasks processed by task type, queue and status.",
+	}, []string{"task_type", "queue", "status"})
+	taskRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "worker",
+		Name:      "task_retries_total",
+		Help:      "Number of task runs that retry a failed one, by task type and queue.",
+	}, []string{"task_type", "queue"})
+	taskDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "worker",
+		Name:      "task_duration_seconds",
+		Help:      "Processing time of the tasks by task type and queue.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"task_type", "queue"})
+
+	transfers = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfers_total",
+		Help:      "Number of committed transfers by currency.",
+	}, []string{"currency"})
+	transferVolume = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfer_volume_total",
+		Help:      "Amount of the committed transfers by currency, in minor units.",
+	}, []string{"currency"})
+
+	rateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_requests_total",
+		Help:      "Number of requests rejected by the rate limiter, by the policy that rejected them.",
+	}, []string{"policy"})
+)
+
+// Handler serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// RegisterDBStats exposes the statistics of the connection pool of db
+func RegisterDBStats(db *sql.DB, dbName string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// ObserveGRPCRequest records a gRPC request of a full method name
+func ObserveGRPCRequest(method, code string, duration time.Duration) {
+	grpcRequests.WithLabelValues(method, code).Inc()
+	grpcDuration.WithLabelValues(method, code).Observe(duration.Seconds())
+}
+
+// ObserveHTTPRequest records an HTTP request of a route, which is a path pattern rather than a path,
+// so that the IDs in the paths don't make a time series each
+func ObserveHTTPRequest(method, route string, code int, duration time.Duration) {
+	statusCode := strconv.Itoa(code)
+	httpRequests.WithLabelValues(method, route, statusCode).Inc()
+	httpDuration.WithLabelValues(method, route, statusCode).Observe(duration.Seconds())
+}
+
+// ObserveQuery records a database query
+func ObserveQuery(name string, duration time.Duration, err error) {
+	queryDuration.WithLabelValues(name, status(err)).Observe(duration.Seconds())
+}
+
+// ObserveTask records a run of a task, retry being the number of times it was retried before this run
+func ObserveTask(taskType, queue string, retry int, duration time.Duration, err error) {
+	tasksProcessed.WithLabelValues(taskType, queue, status(err)).Inc()
+	if retry > 0 {
+		taskRetries.WithLabelValues(taskType, queue).Inc()
+	}
+	taskDuration.WithLabelValues(taskType, queue).Observe(duration.Seconds())
+}
+
+// ObserveTransfer records a committed transfer
+func ObserveTransfer(currency string, amount int64) {
+	transfers.WithLabelValues(currency).Inc()
+	transferVolume.WithLabelValues(currency).Add(float64(amount))
+}
+
+// ObserveRateLimited records a request rejected by a rate limit policy
+func ObserveRateLimited(policy string) {
+	rateLimited.WithLabelValues(policy).Inc()
+}
+
+func status(err error) string {
+	if err != nil {
+		return statusFailure
+	}
+	return statusSuccess
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObserveTask(t *testing.T) {
+	ObserveTask("task:test", "default", 0, time.Millisecond, nil)
+	ObserveTask("task:test", "default", 1, time.Millisecond, errors.New("failed"))
+	ObserveTask("task:test", "default", 2, time.Millisecond, nil)
+
+	require.Equal(t, 2.0, testutil.ToFloat64(tasksProcessed.WithLabelValues("task:test", "default", statusSuccess)))
+	require.Equal(t, 1.0, testutil.ToFloat64(tasksProcessed.WithLabelValues("task:test", "default", statusFailure)))
+	require.Equal(t, 2.0, testutil.ToFloat64(taskRetries.WithLabelValues("task:test", "default")))
+}
+
+func TestObserveTransfer(t *testing.T) {
+	ObserveTransfer("XTS", 150)
+	ObserveTransfer("XTS", 50)
+
+	require.Equal(t, 2.0, testutil.ToFloat64(transfers.WithLabelValues("XTS")))
+	require.Equal(t, 200.0, testutil.ToFloat64(transferVolume.WithLabelValues("XTS")))
+}
+
+func TestHandler(t *testing.T) {
+	ObserveHTTPRequest(http.MethodGet, "/v1/accounts/{id}", http.StatusOK, time.Millisecond)
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	body := recorder.Body.String()
+	require.True(t, strings.Contains(body, `bank_http_requests_total{code="200",method="GET",route="/v1/accounts/{id}"} 1`), body)
+}
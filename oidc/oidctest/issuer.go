@@ -0,0 +1,236 @@
+// Package oidctest provides a local OpenID Connect issuer for tests.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const keyID = "oidctest-key"
+
+// User is the identity the issuer authenticates on its authorization endpoint
+type User struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+type authRequest struct {
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	user          User
+}
+
+// Issuer is an OpenID Connect issuer serving discovery, JWKS, authorization and token endpoints
+type Issuer struct {
+	Server       *httptest.Server
+	ClientID     string
+	ClientSecret string
+	// User is the identity returned for the next authorization requests
+	User User
+
+	key   *rsa.PrivateKey
+	mu    sync.Mutex
+	codes map[string]authRequest
+}
+
+// NewIssuer starts an issuer accepting the given client credentials.
+func NewIssuer(clientID, clientSecret string) (*Issuer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	issuer := &Issuer{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		key:          key,
+		codes:        map[string]authRequest{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", issuer.discovery)
+	mux.HandleFunc("/jwks", issuer.jwks)
+	mux.HandleFunc("/authorize", issuer.authorize)
+	mux.HandleFunc("/token", issuer.token)
+	issuer.Server = httptest.NewServer(mux)
+
+	return issuer, nil
+}
+
+// URL returns the issuer identifier.
+func (issuer *Issuer) URL() string {
+	return issuer.Server.URL
+}
+
+// Close shuts down the issuer.
+func (issuer *Issuer) Close() {
+	issuer.Server.Close()
+}
+
+// SignIDToken signs arbitrary claims with the issuer key, to build tokens the happy path would not produce.
+func (issuer *Issuer) SignIDToken(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	return token.SignedString(issuer.key)
+}
+
+// Authorize follows an authorization URL as a signed in browser would and returns the code and state
+// sent back to the redirect URI.
+func (issuer *Issuer) Authorize(authCodeURL string) (code string, state string, err error) {
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+
+	resp, err := client.Get(authCodeURL)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusFound {
+		return "", "", fmt.Errorf("authorization endpoint returned status %d", resp.StatusCode)
+	}
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return "", "", err
+	}
+
+	return location.Query().Get("code"), location.Query().Get("state"), nil
+}
+
+func (issuer *Issuer) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, map[string]interface{}{
+		"issuer":                                issuer.URL(),
+		"authorization_endpoint":                issuer.URL() + "/authorize",
+		"token_endpoint":                        issuer.URL() + "/token",
+		"jwks_uri":                              issuer.URL() + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (issuer *Issuer) jwks(w http.ResponseWriter, _ *http.Request) {
+	publicKey := issuer.key.PublicKey
+	writeJSON(w, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}},
+	})
+}
+
+func (issuer *Issuer) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != issuer.ClientID || query.Get("response_type") != "code" ||
+		query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+
+	code := fmt.Sprintf("code-%d", time.Now().UnixNano())
+	issuer.mu.Lock()
+	issuer.codes[code] = authRequest{
+		clientID:      query.Get("client_id"),
+		redirectURI:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		user:          issuer.User,
+	}
+	issuer.mu.Unlock()
+
+	redirect, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	values := redirect.Query()
+	values.Set("code", code)
+	values.Set("state", query.Get("state"))
+	redirect.RawQuery = values.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (issuer *Issuer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		http.Error(w, "unsupported grant", http.StatusBadRequest)
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok || clientID != issuer.ClientID || clientSecret != issuer.ClientSecret {
+		http.Error(w, "invalid client", http.StatusUnauthorized)
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	issuer.mu.Lock()
+	request, ok := issuer.codes[code]
+	delete(issuer.codes, code)
+	issuer.mu.Unlock()
+
+	if !ok || request.redirectURI != r.PostForm.Get("redirect_uri") ||
+		challenge(r.PostForm.Get("code_verifier")) != request.codeChallenge {
+		http.Error(w, "invalid grant", http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	idToken, err := issuer.SignIDToken(jwt.MapClaims{
+		"iss":                issuer.URL(),
+		"sub":                request.user.Subject,
+		"aud":                request.clientID,
+		"iat":                now.Unix(),
+		"exp":                now.Add(time.Hour).Unix(),
+		"nonce":              request.nonce,
+		"email":              request.user.Email,
+		"email_verified":     request.user.EmailVerified,
+		"name":               request.user.Name,
+		"preferred_username": request.user.PreferredUsername,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"access_token": fmt.Sprintf("access-%d", now.UnixNano()),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func challenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,206 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MathPeixoto/go-financial-system/oidc/oidctest"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProvider(t *testing.T) (*Provider, *oidctest.Issuer) {
+	issuer, err := oidctest.NewIssuer(util.RandomString(8), util.RandomString(16))
+	require.NoError(t, err)
+	t.Cleanup(issuer.Close)
+
+	provider, err := NewProvider(context.Background(), Config{
+		IssuerURL:    issuer.URL(),
+		ClientID:     issuer.ClientID,
+		ClientSecret: issuer.ClientSecret,
+		RedirectURL:  "http://localhost:8080/v1/oidc/callback",
+	})
+	require.NoError(t, err)
+	require.Equal(t, issuer.URL(), provider.Issuer())
+
+	return provider, issuer
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	provider, issuer := newTestProvider(t)
+	issuer.User = oidctest.User{
+		Subject:       util.RandomString(10),
+		Email:         util.RandomEmail(),
+		EmailVerified: true,
+		Name:          "Random Name",
+	}
+
+	state, err := RandomValue()
+	require.NoError(t, err)
+	nonce, err := RandomValue()
+	require.NoError(t, err)
+	verifier, err := RandomValue()
+	require.NoError(t, err)
+
+	code, returnedState, err := issuer.Authorize(provider.AuthCodeURL(state, nonce, CodeChallenge(verifier)))
+	require.NoError(t, err)
+	require.NotEmpty(t, code)
+	require.Equal(t, state, returnedState)
+
+	token, err := provider.Exchange(context.Background(), code, verifier)
+	require.NoError(t, err)
+	require.NotEmpty(t, token.IDToken)
+
+	claims, err := provider.VerifyIDToken(context.Background(), token.IDToken, nonce)
+	require.NoError(t, err)
+	require.Equal(t, issuer.User.Subject, claims.Subject)
+	require.Equal(t, issuer.User.Email, claims.Email)
+	require.True(t, claims.EmailVerified)
+	require.Equal(t, issuer.User.Name, claims.Name)
+}
+
+func TestExchangeWrongCodeVerifier(t *testing.T) {
+	provider, issuer := newTestProvider(t)
+
+	verifier, err := RandomValue()
+	require.NoError(t, err)
+
+	code, _, err := issuer.Authorize(provider.AuthCodeURL("state", "nonce", CodeChallenge(verifier)))
+	require.NoError(t, err)
+
+	token, err := provider.Exchange(context.Background(), code, verifier+"x")
+	require.Error(t, err)
+	require.Nil(t, token)
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	provider, issuer := newTestProvider(t)
+	now := time.Now()
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   issuer.URL(),
+			"sub":   "subject",
+			"aud":   issuer.ClientID,
+			"exp":   now.Add(time.Minute).Unix(),
+			"nonce": "nonce",
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		claims func() jwt.MapClaims
+		nonce  string
+		check  func(t *testing.T, claims *Claims, err error)
+	}{
+		{
+			name:   "OK",
+			claims: validClaims,
+			nonce:  "nonce",
+			check: func(t *testing.T, claims *Claims, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "subject", claims.Subject)
+			},
+		},
+		{
+			name: "AudienceList",
+			claims: func() jwt.MapClaims {
+				claims := validClaims()
+				claims["aud"] = []string{"other", issuer.ClientID}
+				return claims
+			},
+			nonce: "nonce",
+			check: func(t *testing.T, claims *Claims, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "WrongAudience",
+			claims: func() jwt.MapClaims {
+				claims := validClaims()
+				claims["aud"] = "other"
+				return claims
+			},
+			nonce: "nonce",
+			check: func(t *testing.T, claims *Claims, err error) {
+				require.ErrorIs(t, err, ErrInvalidIDToken)
+			},
+		},
+		{
+			name: "WrongIssuer",
+			claims: func() jwt.MapClaims {
+				claims := validClaims()
+				claims["iss"] = "https://evil.example.com"
+				return claims
+			},
+			nonce: "nonce",
+			check: func(t *testing.T, claims *Claims, err error) {
+				require.ErrorIs(t, err, ErrInvalidIDToken)
+			},
+		},
+		{
+			name: "Expired",
+			claims: func() jwt.MapClaims {
+				claims := validClaims()
+				claims["exp"] = now.Add(-time.Minute).Unix()
+				return claims
+			},
+			nonce: "nonce",
+			check: func(t *testing.T, claims *Claims, err error) {
+				require.ErrorIs(t, err, ErrInvalidIDToken)
+			},
+		},
+		{
+			name: "MissingExpiration",
+			claims: func() jwt.MapClaims {
+				claims := validClaims()
+				delete(claims, "exp")
+				return claims
+			},
+			nonce: "nonce",
+			check: func(t *testing.T, claims *Claims, err error) {
+				require.ErrorIs(t, err, ErrInvalidIDToken)
+			},
+		},
+		{
+			name:   "WrongNonce",
+			claims: validClaims,
+			nonce:  "other",
+			check: func(t *testing.T, claims *Claims, err error) {
+				require.ErrorIs(t, err, ErrInvalidIDToken)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			rawIDToken, err := issuer.SignIDToken(tc.claims())
+			require.NoError(t, err)
+
+			claims, err := provider.VerifyIDToken(context.Background(), rawIDToken, tc.nonce)
+			tc.check(t, claims, err)
+		})
+	}
+}
+
+func TestVerifyIDTokenForeignSignature(t *testing.T) {
+	provider, issuer := newTestProvider(t)
+	_, otherIssuer := newTestProvider(t)
+
+	rawIDToken, err := otherIssuer.SignIDToken(jwt.MapClaims{
+		"iss":   issuer.URL(),
+		"sub":   "subject",
+		"aud":   issuer.ClientID,
+		"exp":   time.Now().Add(time.Minute).Unix(),
+		"nonce": "nonce",
+	})
+	require.NoError(t, err)
+
+	// both issuers use the same key ID, so a foreign signature must fail verification
+	claims, err := provider.VerifyIDToken(context.Background(), rawIDToken, "nonce")
+	require.ErrorIs(t, err, ErrInvalidIDToken)
+	require.Nil(t, claims)
+}
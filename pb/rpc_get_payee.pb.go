@@ -0,0 +1,212 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v4.22.0
+// source: rpc_get_payee.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetPayeeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PayeeId int64 `protobuf:"varint,1,opt,name=payee_id,json=payeeId,proto3" json:"payee_id,omitempty"`
+}
+
+func (x *GetPayeeRequest) Reset() {
+	*x = GetPayeeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_get_payee_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPayeeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPayeeRequest) ProtoMessage() {}
+
+func (x *GetPayeeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_payee_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPayeeRequest.ProtoReflect.Descriptor instead.
+func (*GetPayeeRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_get_payee_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetPayeeRequest) GetPayeeId() int64 {
+	if x != nil {
+		return x.PayeeId
+	}
+	return 0
+}
+
+type GetPayeeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Payee *Payee `protobuf:"bytes,1,opt,name=payee,proto3" json:"payee,omitempty"`
+}
+
+func (x *GetPayeeResponse) Reset() {
+	*x = GetPayeeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_get_payee_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPayeeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPayeeResponse) ProtoMessage() {}
+
+func (x *GetPayeeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_payee_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPayeeResponse.ProtoReflect.Descriptor instead.
+func (*GetPayeeResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_get_payee_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetPayeeResponse) GetPayee() *Payee {
+	if x != nil {
+		return x.Payee
+	}
+	return nil
+}
+
+var File_rpc_get_payee_proto protoreflect.FileDescriptor
+
+var file_rpc_get_payee_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x72, 0x70, 0x63, 0x5f, 0x67, 0x65, 0x74, 0x5f, 0x70, 0x61, 0x79, 0x65, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x0b, 0x70, 0x61, 0x79, 0x65, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2c, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x50, 0x61, 0x79,
+	0x65, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x61, 0x79,
+	0x65, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x70, 0x61, 0x79,
+	0x65, 0x65, 0x49, 0x64, 0x22, 0x33, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x50, 0x61, 0x79, 0x65, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x05, 0x70, 0x61, 0x79, 0x65,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x61, 0x79,
+	0x65, 0x65, 0x52, 0x05, 0x70, 0x61, 0x79, 0x65, 0x65, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4d, 0x61, 0x74, 0x68, 0x50, 0x65, 0x69, 0x78,
+	0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x2d, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c,
+	0x2d, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_rpc_get_payee_proto_rawDescOnce sync.Once
+	file_rpc_get_payee_proto_rawDescData = file_rpc_get_payee_proto_rawDesc
+)
+
+func file_rpc_get_payee_proto_rawDescGZIP() []byte {
+	file_rpc_get_payee_proto_rawDescOnce.Do(func() {
+		file_rpc_get_payee_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_get_payee_proto_rawDescData)
+	})
+	return file_rpc_get_payee_proto_rawDescData
+}
+
+var file_rpc_get_payee_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_get_payee_proto_goTypes = []interface{}{
+	(*GetPayeeRequest)(nil),  // 0: pb.GetPayeeRequest
+	(*GetPayeeResponse)(nil), // 1: pb.GetPayeeResponse
+	(*Payee)(nil),            // 2: pb.Payee
+}
+var file_rpc_get_payee_proto_depIdxs = []int32{
+	2, // 0: pb.GetPayeeResponse.payee:type_name -> pb.Payee
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_get_payee_proto_init() }
+func file_rpc_get_payee_proto_init() {
+	if File_rpc_get_payee_proto != nil {
+		return
+	}
+	file_payee_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_get_payee_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPayeeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_get_payee_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPayeeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_get_payee_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_get_payee_proto_goTypes,
+		DependencyIndexes: file_rpc_get_payee_proto_depIdxs,
+		MessageInfos:      file_rpc_get_payee_proto_msgTypes,
+	}.Build()
+	File_rpc_get_payee_proto = out.File
+	file_rpc_get_payee_proto_rawDesc = nil
+	file_rpc_get_payee_proto_goTypes = nil
+	file_rpc_get_payee_proto_depIdxs = nil
+}
@@ -0,0 +1,216 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v4.22.0
+// source: rpc_get_transfer_batch.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetTransferBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransferBatchId int64 `protobuf:"varint,1,opt,name=transfer_batch_id,json=transferBatchId,proto3" json:"transfer_batch_id,omitempty"`
+}
+
+func (x *GetTransferBatchRequest) Reset() {
+	*x = GetTransferBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_get_transfer_batch_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTransferBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferBatchRequest) ProtoMessage() {}
+
+func (x *GetTransferBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_transfer_batch_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferBatchRequest.ProtoReflect.Descriptor instead.
+func (*GetTransferBatchRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_get_transfer_batch_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetTransferBatchRequest) GetTransferBatchId() int64 {
+	if x != nil {
+		return x.TransferBatchId
+	}
+	return 0
+}
+
+type GetTransferBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransferBatch *TransferBatch `protobuf:"bytes,1,opt,name=transfer_batch,json=transferBatch,proto3" json:"transfer_batch,omitempty"`
+}
+
+func (x *GetTransferBatchResponse) Reset() {
+	*x = GetTransferBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_get_transfer_batch_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTransferBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferBatchResponse) ProtoMessage() {}
+
+func (x *GetTransferBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_transfer_batch_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferBatchResponse.ProtoReflect.Descriptor instead.
+func (*GetTransferBatchResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_get_transfer_batch_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetTransferBatchResponse) GetTransferBatch() *TransferBatch {
+	if x != nil {
+		return x.TransferBatch
+	}
+	return nil
+}
+
+var File_rpc_get_transfer_batch_proto protoreflect.FileDescriptor
+
+var file_rpc_get_transfer_batch_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x72, 0x70, 0x63, 0x5f, 0x67, 0x65, 0x74, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02,
+	0x70, 0x62, 0x1a, 0x14, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x45, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x22,
+	0x54, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x0e, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x4d, 0x61, 0x74, 0x68, 0x50, 0x65, 0x69, 0x78, 0x6f, 0x74, 0x6f, 0x2f,
+	0x67, 0x6f, 0x2d, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x2d, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_get_transfer_batch_proto_rawDescOnce sync.Once
+	file_rpc_get_transfer_batch_proto_rawDescData = file_rpc_get_transfer_batch_proto_rawDesc
+)
+
+func file_rpc_get_transfer_batch_proto_rawDescGZIP() []byte {
+	file_rpc_get_transfer_batch_proto_rawDescOnce.Do(func() {
+		file_rpc_get_transfer_batch_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_get_transfer_batch_proto_rawDescData)
+	})
+	return file_rpc_get_transfer_batch_proto_rawDescData
+}
+
+var file_rpc_get_transfer_batch_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_get_transfer_batch_proto_goTypes = []interface{}{
+	(*GetTransferBatchRequest)(nil),  // 0: pb.GetTransferBatchRequest
+	(*GetTransferBatchResponse)(nil), // 1: pb.GetTransferBatchResponse
+	(*TransferBatch)(nil),            // 2: pb.TransferBatch
+}
+var file_rpc_get_transfer_batch_proto_depIdxs = []int32{
+	2, // 0: pb.GetTransferBatchResponse.transfer_batch:type_name -> pb.TransferBatch
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_get_transfer_batch_proto_init() }
+func file_rpc_get_transfer_batch_proto_init() {
+	if File_rpc_get_transfer_batch_proto != nil {
+		return
+	}
+	file_transfer_batch_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_get_transfer_batch_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTransferBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_get_transfer_batch_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTransferBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_get_transfer_batch_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_get_transfer_batch_proto_goTypes,
+		DependencyIndexes: file_rpc_get_transfer_batch_proto_depIdxs,
+		MessageInfos:      file_rpc_get_transfer_batch_proto_msgTypes,
+	}.Build()
+	File_rpc_get_transfer_batch_proto = out.File
+	file_rpc_get_transfer_batch_proto_rawDesc = nil
+	file_rpc_get_transfer_batch_proto_goTypes = nil
+	file_rpc_get_transfer_batch_proto_depIdxs = nil
+}
@@ -0,0 +1,237 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v4.22.0
+// source: rpc_list_transfer_reviews.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListTransferReviewsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   *string `protobuf:"bytes,1,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	PageId   int32   `protobuf:"varint,2,opt,name=page_id,json=pageId,proto3" json:"page_id,omitempty"`
+	PageSize int32   `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+}
+
+func (x *ListTransferReviewsRequest) Reset() {
+	*x = ListTransferReviewsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_list_transfer_reviews_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTransferReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransferReviewsRequest) ProtoMessage() {}
+
+func (x *ListTransferReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_transfer_reviews_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransferReviewsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransferReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_list_transfer_reviews_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListTransferReviewsRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *ListTransferReviewsRequest) GetPageId() int32 {
+	if x != nil {
+		return x.PageId
+	}
+	return 0
+}
+
+func (x *ListTransferReviewsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListTransferReviewsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransferReviews []*TransferReview `protobuf:"bytes,1,rep,name=transfer_reviews,json=transferReviews,proto3" json:"transfer_reviews,omitempty"`
+}
+
+func (x *ListTransferReviewsResponse) Reset() {
+	*x = ListTransferReviewsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_list_transfer_reviews_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTransferReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransferReviewsResponse) ProtoMessage() {}
+
+func (x *ListTransferReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_transfer_reviews_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransferReviewsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransferReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_list_transfer_reviews_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListTransferReviewsResponse) GetTransferReviews() []*TransferReview {
+	if x != nil {
+		return x.TransferReviews
+	}
+	return nil
+}
+
+var File_rpc_list_transfer_reviews_proto protoreflect.FileDescriptor
+
+var file_rpc_list_transfer_reviews_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x72, 0x70, 0x63, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x15, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f,
+	0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x7a, 0x0a, 0x1a,
+	0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x76, 0x69,
+	0x65, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x88, 0x01, 0x01, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x61, 0x67, 0x65, 0x49, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x09, 0x0a,
+	0x07, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x5c, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52,
+	0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52,
+	0x65, 0x76, 0x69, 0x65, 0x77, 0x73, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4d, 0x61, 0x74, 0x68, 0x50, 0x65, 0x69, 0x78, 0x6f, 0x74, 0x6f,
+	0x2f, 0x67, 0x6f, 0x2d, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x2d, 0x73, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_list_transfer_reviews_proto_rawDescOnce sync.Once
+	file_rpc_list_transfer_reviews_proto_rawDescData = file_rpc_list_transfer_reviews_proto_rawDesc
+)
+
+func file_rpc_list_transfer_reviews_proto_rawDescGZIP() []byte {
+	file_rpc_list_transfer_reviews_proto_rawDescOnce.Do(func() {
+		file_rpc_list_transfer_reviews_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_list_transfer_reviews_proto_rawDescData)
+	})
+	return file_rpc_list_transfer_reviews_proto_rawDescData
+}
+
+var file_rpc_list_transfer_reviews_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_list_transfer_reviews_proto_goTypes = []interface{}{
+	(*ListTransferReviewsRequest)(nil),  // 0: pb.ListTransferReviewsRequest
+	(*ListTransferReviewsResponse)(nil), // 1: pb.ListTransferReviewsResponse
+	(*TransferReview)(nil),              // 2: pb.TransferReview
+}
+var file_rpc_list_transfer_reviews_proto_depIdxs = []int32{
+	2, // 0: pb.ListTransferReviewsResponse.transfer_reviews:type_name -> pb.TransferReview
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_list_transfer_reviews_proto_init() }
+func file_rpc_list_transfer_reviews_proto_init() {
+	if File_rpc_list_transfer_reviews_proto != nil {
+		return
+	}
+	file_transfer_review_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_list_transfer_reviews_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTransferReviewsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_list_transfer_reviews_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTransferReviewsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_rpc_list_transfer_reviews_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_list_transfer_reviews_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_list_transfer_reviews_proto_goTypes,
+		DependencyIndexes: file_rpc_list_transfer_reviews_proto_depIdxs,
+		MessageInfos:      file_rpc_list_transfer_reviews_proto_msgTypes,
+	}.Build()
+	File_rpc_list_transfer_reviews_proto = out.File
+	file_rpc_list_transfer_reviews_proto_rawDesc = nil
+	file_rpc_list_transfer_reviews_proto_goTypes = nil
+	file_rpc_list_transfer_reviews_proto_depIdxs = nil
+}
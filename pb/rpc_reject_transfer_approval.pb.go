@@ -0,0 +1,219 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v4.22.0
+// source: rpc_reject_transfer_approval.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RejectTransferApprovalRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransferApprovalId int64 `protobuf:"varint,1,opt,name=transfer_approval_id,json=transferApprovalId,proto3" json:"transfer_approval_id,omitempty"`
+}
+
+func (x *RejectTransferApprovalRequest) Reset() {
+	*x = RejectTransferApprovalRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_reject_transfer_approval_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectTransferApprovalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectTransferApprovalRequest) ProtoMessage() {}
+
+func (x *RejectTransferApprovalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_reject_transfer_approval_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectTransferApprovalRequest.ProtoReflect.Descriptor instead.
+func (*RejectTransferApprovalRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_reject_transfer_approval_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RejectTransferApprovalRequest) GetTransferApprovalId() int64 {
+	if x != nil {
+		return x.TransferApprovalId
+	}
+	return 0
+}
+
+type RejectTransferApprovalResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransferApproval *TransferApproval `protobuf:"bytes,1,opt,name=transfer_approval,json=transferApproval,proto3" json:"transfer_approval,omitempty"`
+}
+
+func (x *RejectTransferApprovalResponse) Reset() {
+	*x = RejectTransferApprovalResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_reject_transfer_approval_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectTransferApprovalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectTransferApprovalResponse) ProtoMessage() {}
+
+func (x *RejectTransferApprovalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_reject_transfer_approval_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectTransferApprovalResponse.ProtoReflect.Descriptor instead.
+func (*RejectTransferApprovalResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_reject_transfer_approval_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RejectTransferApprovalResponse) GetTransferApproval() *TransferApproval {
+	if x != nil {
+		return x.TransferApproval
+	}
+	return nil
+}
+
+var File_rpc_reject_transfer_approval_proto protoreflect.FileDescriptor
+
+var file_rpc_reject_transfer_approval_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x72, 0x70, 0x63, 0x5f, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x17, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x5f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x51, 0x0a, 0x1d, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x61,
+	0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x12, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76,
+	0x61, 0x6c, 0x49, 0x64, 0x22, 0x63, 0x0a, 0x1e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x5f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41,
+	0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4d, 0x61, 0x74, 0x68, 0x50, 0x65, 0x69, 0x78,
+	0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x2d, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c,
+	0x2d, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_rpc_reject_transfer_approval_proto_rawDescOnce sync.Once
+	file_rpc_reject_transfer_approval_proto_rawDescData = file_rpc_reject_transfer_approval_proto_rawDesc
+)
+
+func file_rpc_reject_transfer_approval_proto_rawDescGZIP() []byte {
+	file_rpc_reject_transfer_approval_proto_rawDescOnce.Do(func() {
+		file_rpc_reject_transfer_approval_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_reject_transfer_approval_proto_rawDescData)
+	})
+	return file_rpc_reject_transfer_approval_proto_rawDescData
+}
+
+var file_rpc_reject_transfer_approval_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_reject_transfer_approval_proto_goTypes = []interface{}{
+	(*RejectTransferApprovalRequest)(nil),  // 0: pb.RejectTransferApprovalRequest
+	(*RejectTransferApprovalResponse)(nil), // 1: pb.RejectTransferApprovalResponse
+	(*TransferApproval)(nil),               // 2: pb.TransferApproval
+}
+var file_rpc_reject_transfer_approval_proto_depIdxs = []int32{
+	2, // 0: pb.RejectTransferApprovalResponse.transfer_approval:type_name -> pb.TransferApproval
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_reject_transfer_approval_proto_init() }
+func file_rpc_reject_transfer_approval_proto_init() {
+	if File_rpc_reject_transfer_approval_proto != nil {
+		return
+	}
+	file_transfer_approval_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_reject_transfer_approval_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RejectTransferApprovalRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_reject_transfer_approval_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RejectTransferApprovalResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_reject_transfer_approval_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_reject_transfer_approval_proto_goTypes,
+		DependencyIndexes: file_rpc_reject_transfer_approval_proto_depIdxs,
+		MessageInfos:      file_rpc_reject_transfer_approval_proto_msgTypes,
+	}.Build()
+	File_rpc_reject_transfer_approval_proto = out.File
+	file_rpc_reject_transfer_approval_proto_rawDesc = nil
+	file_rpc_reject_transfer_approval_proto_goTypes = nil
+	file_rpc_reject_transfer_approval_proto_depIdxs = nil
+}
@@ -0,0 +1,217 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v4.22.0
+// source: rpc_reject_transfer_review.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RejectTransferReviewRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransferReviewId int64 `protobuf:"varint,1,opt,name=transfer_review_id,json=transferReviewId,proto3" json:"transfer_review_id,omitempty"`
+}
+
+func (x *RejectTransferReviewRequest) Reset() {
+	*x = RejectTransferReviewRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_reject_transfer_review_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectTransferReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectTransferReviewRequest) ProtoMessage() {}
+
+func (x *RejectTransferReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_reject_transfer_review_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectTransferReviewRequest.ProtoReflect.Descriptor instead.
+func (*RejectTransferReviewRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_reject_transfer_review_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RejectTransferReviewRequest) GetTransferReviewId() int64 {
+	if x != nil {
+		return x.TransferReviewId
+	}
+	return 0
+}
+
+type RejectTransferReviewResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransferReview *TransferReview `protobuf:"bytes,1,opt,name=transfer_review,json=transferReview,proto3" json:"transfer_review,omitempty"`
+}
+
+func (x *RejectTransferReviewResponse) Reset() {
+	*x = RejectTransferReviewResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_reject_transfer_review_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectTransferReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectTransferReviewResponse) ProtoMessage() {}
+
+func (x *RejectTransferReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_reject_transfer_review_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectTransferReviewResponse.ProtoReflect.Descriptor instead.
+func (*RejectTransferReviewResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_reject_transfer_review_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RejectTransferReviewResponse) GetTransferReview() *TransferReview {
+	if x != nil {
+		return x.TransferReview
+	}
+	return nil
+}
+
+var File_rpc_reject_transfer_review_proto protoreflect.FileDescriptor
+
+var file_rpc_reject_transfer_review_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x72, 0x70, 0x63, 0x5f, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x15, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x5f, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4b, 0x0a,
+	0x1b, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52,
+	0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x12,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x49, 0x64, 0x22, 0x5b, 0x0a, 0x1c, 0x52, 0x65,
+	0x6a, 0x65, 0x63, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x76, 0x69,
+	0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0f, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4d, 0x61, 0x74, 0x68, 0x50, 0x65, 0x69, 0x78, 0x6f, 0x74,
+	0x6f, 0x2f, 0x67, 0x6f, 0x2d, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x2d, 0x73,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_reject_transfer_review_proto_rawDescOnce sync.Once
+	file_rpc_reject_transfer_review_proto_rawDescData = file_rpc_reject_transfer_review_proto_rawDesc
+)
+
+func file_rpc_reject_transfer_review_proto_rawDescGZIP() []byte {
+	file_rpc_reject_transfer_review_proto_rawDescOnce.Do(func() {
+		file_rpc_reject_transfer_review_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_reject_transfer_review_proto_rawDescData)
+	})
+	return file_rpc_reject_transfer_review_proto_rawDescData
+}
+
+var file_rpc_reject_transfer_review_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_reject_transfer_review_proto_goTypes = []interface{}{
+	(*RejectTransferReviewRequest)(nil),  // 0: pb.RejectTransferReviewRequest
+	(*RejectTransferReviewResponse)(nil), // 1: pb.RejectTransferReviewResponse
+	(*TransferReview)(nil),               // 2: pb.TransferReview
+}
+var file_rpc_reject_transfer_review_proto_depIdxs = []int32{
+	2, // 0: pb.RejectTransferReviewResponse.transfer_review:type_name -> pb.TransferReview
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_reject_transfer_review_proto_init() }
+func file_rpc_reject_transfer_review_proto_init() {
+	if File_rpc_reject_transfer_review_proto != nil {
+		return
+	}
+	file_transfer_review_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_reject_transfer_review_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RejectTransferReviewRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_reject_transfer_review_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RejectTransferReviewResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_reject_transfer_review_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_reject_transfer_review_proto_goTypes,
+		DependencyIndexes: file_rpc_reject_transfer_review_proto_depIdxs,
+		MessageInfos:      file_rpc_reject_transfer_review_proto_msgTypes,
+	}.Build()
+	File_rpc_reject_transfer_review_proto = out.File
+	file_rpc_reject_transfer_review_proto_rawDesc = nil
+	file_rpc_reject_transfer_review_proto_goTypes = nil
+	file_rpc_reject_transfer_review_proto_depIdxs = nil
+}
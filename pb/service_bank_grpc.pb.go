@@ -25,6 +25,47 @@ type BankClient interface {
 	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
 	LoginUser(ctx context.Context, in *LoginUserRequest, opts ...grpc.CallOption) (*LoginUserResponse, error)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	CreateWebhookEndpoint(ctx context.Context, in *CreateWebhookEndpointRequest, opts ...grpc.CallOption) (*CreateWebhookEndpointResponse, error)
+	DeleteWebhookEndpoint(ctx context.Context, in *DeleteWebhookEndpointRequest, opts ...grpc.CallOption) (*DeleteWebhookEndpointResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	ReplayWebhookDelivery(ctx context.Context, in *ReplayWebhookDeliveryRequest, opts ...grpc.CallOption) (*ReplayWebhookDeliveryResponse, error)
+	FreezeAccount(ctx context.Context, in *FreezeAccountRequest, opts ...grpc.CallOption) (*FreezeAccountResponse, error)
+	CreateScheduledTransfer(ctx context.Context, in *CreateScheduledTransferRequest, opts ...grpc.CallOption) (*CreateScheduledTransferResponse, error)
+	ListScheduledTransfers(ctx context.Context, in *ListScheduledTransfersRequest, opts ...grpc.CallOption) (*ListScheduledTransfersResponse, error)
+	ListScheduledTransferRuns(ctx context.Context, in *ListScheduledTransferRunsRequest, opts ...grpc.CallOption) (*ListScheduledTransferRunsResponse, error)
+	PauseScheduledTransfer(ctx context.Context, in *PauseScheduledTransferRequest, opts ...grpc.CallOption) (*PauseScheduledTransferResponse, error)
+	ResumeScheduledTransfer(ctx context.Context, in *ResumeScheduledTransferRequest, opts ...grpc.CallOption) (*ResumeScheduledTransferResponse, error)
+	CancelScheduledTransfer(ctx context.Context, in *CancelScheduledTransferRequest, opts ...grpc.CallOption) (*CancelScheduledTransferResponse, error)
+	CreateHold(ctx context.Context, in *CreateHoldRequest, opts ...grpc.CallOption) (*CreateHoldResponse, error)
+	CaptureHold(ctx context.Context, in *CaptureHoldRequest, opts ...grpc.CallOption) (*CaptureHoldResponse, error)
+	VoidHold(ctx context.Context, in *VoidHoldRequest, opts ...grpc.CallOption) (*VoidHoldResponse, error)
+	ListHolds(ctx context.Context, in *ListHoldsRequest, opts ...grpc.CallOption) (*ListHoldsResponse, error)
+	QuoteTransfer(ctx context.Context, in *QuoteTransferRequest, opts ...grpc.CallOption) (*QuoteTransferResponse, error)
+	CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*CreateTransferResponse, error)
+	SetAccountTransferLimits(ctx context.Context, in *SetAccountTransferLimitsRequest, opts ...grpc.CallOption) (*SetAccountTransferLimitsResponse, error)
+	ListTransferReviews(ctx context.Context, in *ListTransferReviewsRequest, opts ...grpc.CallOption) (*ListTransferReviewsResponse, error)
+	ApproveTransferReview(ctx context.Context, in *ApproveTransferReviewRequest, opts ...grpc.CallOption) (*ApproveTransferReviewResponse, error)
+	RejectTransferReview(ctx context.Context, in *RejectTransferReviewRequest, opts ...grpc.CallOption) (*RejectTransferReviewResponse, error)
+	CreatePayee(ctx context.Context, in *CreatePayeeRequest, opts ...grpc.CallOption) (*CreatePayeeResponse, error)
+	GetPayee(ctx context.Context, in *GetPayeeRequest, opts ...grpc.CallOption) (*GetPayeeResponse, error)
+	ListPayees(ctx context.Context, in *ListPayeesRequest, opts ...grpc.CallOption) (*ListPayeesResponse, error)
+	UpdatePayee(ctx context.Context, in *UpdatePayeeRequest, opts ...grpc.CallOption) (*UpdatePayeeResponse, error)
+	DeletePayee(ctx context.Context, in *DeletePayeeRequest, opts ...grpc.CallOption) (*DeletePayeeResponse, error)
+	CreateTransferBatch(ctx context.Context, in *CreateTransferBatchRequest, opts ...grpc.CallOption) (*CreateTransferBatchResponse, error)
+	GetTransferBatch(ctx context.Context, in *GetTransferBatchRequest, opts ...grpc.CallOption) (*GetTransferBatchResponse, error)
+	InviteAccountMember(ctx context.Context, in *InviteAccountMemberRequest, opts ...grpc.CallOption) (*InviteAccountMemberResponse, error)
+	AcceptAccountMember(ctx context.Context, in *AcceptAccountMemberRequest, opts ...grpc.CallOption) (*AcceptAccountMemberResponse, error)
+	ListAccountMembers(ctx context.Context, in *ListAccountMembersRequest, opts ...grpc.CallOption) (*ListAccountMembersResponse, error)
+	RemoveAccountMember(ctx context.Context, in *RemoveAccountMemberRequest, opts ...grpc.CallOption) (*RemoveAccountMemberResponse, error)
+	CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*CreateOrganizationResponse, error)
+	AddOrganizationMember(ctx context.Context, in *AddOrganizationMemberRequest, opts ...grpc.CallOption) (*AddOrganizationMemberResponse, error)
+	ListOrganizationMembers(ctx context.Context, in *ListOrganizationMembersRequest, opts ...grpc.CallOption) (*ListOrganizationMembersResponse, error)
+	RemoveOrganizationMember(ctx context.Context, in *RemoveOrganizationMemberRequest, opts ...grpc.CallOption) (*RemoveOrganizationMemberResponse, error)
+	CreateOrganizationAccount(ctx context.Context, in *CreateOrganizationAccountRequest, opts ...grpc.CallOption) (*CreateOrganizationAccountResponse, error)
+	ListTransferApprovals(ctx context.Context, in *ListTransferApprovalsRequest, opts ...grpc.CallOption) (*ListTransferApprovalsResponse, error)
+	ApproveTransferApproval(ctx context.Context, in *ApproveTransferApprovalRequest, opts ...grpc.CallOption) (*ApproveTransferApprovalResponse, error)
+	RejectTransferApproval(ctx context.Context, in *RejectTransferApprovalRequest, opts ...grpc.CallOption) (*RejectTransferApprovalResponse, error)
 }
 
 type bankClient struct {
@@ -62,6 +103,375 @@ func (c *bankClient) LoginUser(ctx context.Context, in *LoginUserRequest, opts .
 	return out, nil
 }
 
+func (c *bankClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) CreateWebhookEndpoint(ctx context.Context, in *CreateWebhookEndpointRequest, opts ...grpc.CallOption) (*CreateWebhookEndpointResponse, error) {
+	out := new(CreateWebhookEndpointResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/CreateWebhookEndpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) DeleteWebhookEndpoint(ctx context.Context, in *DeleteWebhookEndpointRequest, opts ...grpc.CallOption) (*DeleteWebhookEndpointResponse, error) {
+	out := new(DeleteWebhookEndpointResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/DeleteWebhookEndpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/ListWebhookDeliveries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) ReplayWebhookDelivery(ctx context.Context, in *ReplayWebhookDeliveryRequest, opts ...grpc.CallOption) (*ReplayWebhookDeliveryResponse, error) {
+	out := new(ReplayWebhookDeliveryResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/ReplayWebhookDelivery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) FreezeAccount(ctx context.Context, in *FreezeAccountRequest, opts ...grpc.CallOption) (*FreezeAccountResponse, error) {
+	out := new(FreezeAccountResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/FreezeAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) CreateScheduledTransfer(ctx context.Context, in *CreateScheduledTransferRequest, opts ...grpc.CallOption) (*CreateScheduledTransferResponse, error) {
+	out := new(CreateScheduledTransferResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/CreateScheduledTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) ListScheduledTransfers(ctx context.Context, in *ListScheduledTransfersRequest, opts ...grpc.CallOption) (*ListScheduledTransfersResponse, error) {
+	out := new(ListScheduledTransfersResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/ListScheduledTransfers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) ListScheduledTransferRuns(ctx context.Context, in *ListScheduledTransferRunsRequest, opts ...grpc.CallOption) (*ListScheduledTransferRunsResponse, error) {
+	out := new(ListScheduledTransferRunsResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/ListScheduledTransferRuns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) PauseScheduledTransfer(ctx context.Context, in *PauseScheduledTransferRequest, opts ...grpc.CallOption) (*PauseScheduledTransferResponse, error) {
+	out := new(PauseScheduledTransferResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/PauseScheduledTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) ResumeScheduledTransfer(ctx context.Context, in *ResumeScheduledTransferRequest, opts ...grpc.CallOption) (*ResumeScheduledTransferResponse, error) {
+	out := new(ResumeScheduledTransferResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/ResumeScheduledTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) CancelScheduledTransfer(ctx context.Context, in *CancelScheduledTransferRequest, opts ...grpc.CallOption) (*CancelScheduledTransferResponse, error) {
+	out := new(CancelScheduledTransferResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/CancelScheduledTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) CreateHold(ctx context.Context, in *CreateHoldRequest, opts ...grpc.CallOption) (*CreateHoldResponse, error) {
+	out := new(CreateHoldResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/CreateHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) CaptureHold(ctx context.Context, in *CaptureHoldRequest, opts ...grpc.CallOption) (*CaptureHoldResponse, error) {
+	out := new(CaptureHoldResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/CaptureHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) VoidHold(ctx context.Context, in *VoidHoldRequest, opts ...grpc.CallOption) (*VoidHoldResponse, error) {
+	out := new(VoidHoldResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/VoidHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) ListHolds(ctx context.Context, in *ListHoldsRequest, opts ...grpc.CallOption) (*ListHoldsResponse, error) {
+	out := new(ListHoldsResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/ListHolds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) QuoteTransfer(ctx context.Context, in *QuoteTransferRequest, opts ...grpc.CallOption) (*QuoteTransferResponse, error) {
+	out := new(QuoteTransferResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/QuoteTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*CreateTransferResponse, error) {
+	out := new(CreateTransferResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/CreateTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) SetAccountTransferLimits(ctx context.Context, in *SetAccountTransferLimitsRequest, opts ...grpc.CallOption) (*SetAccountTransferLimitsResponse, error) {
+	out := new(SetAccountTransferLimitsResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/SetAccountTransferLimits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) ListTransferReviews(ctx context.Context, in *ListTransferReviewsRequest, opts ...grpc.CallOption) (*ListTransferReviewsResponse, error) {
+	out := new(ListTransferReviewsResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/ListTransferReviews", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) ApproveTransferReview(ctx context.Context, in *ApproveTransferReviewRequest, opts ...grpc.CallOption) (*ApproveTransferReviewResponse, error) {
+	out := new(ApproveTransferReviewResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/ApproveTransferReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) RejectTransferReview(ctx context.Context, in *RejectTransferReviewRequest, opts ...grpc.CallOption) (*RejectTransferReviewResponse, error) {
+	out := new(RejectTransferReviewResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/RejectTransferReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) CreatePayee(ctx context.Context, in *CreatePayeeRequest, opts ...grpc.CallOption) (*CreatePayeeResponse, error) {
+	out := new(CreatePayeeResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/CreatePayee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) GetPayee(ctx context.Context, in *GetPayeeRequest, opts ...grpc.CallOption) (*GetPayeeResponse, error) {
+	out := new(GetPayeeResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/GetPayee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) ListPayees(ctx context.Context, in *ListPayeesRequest, opts ...grpc.CallOption) (*ListPayeesResponse, error) {
+	out := new(ListPayeesResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/ListPayees", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) UpdatePayee(ctx context.Context, in *UpdatePayeeRequest, opts ...grpc.CallOption) (*UpdatePayeeResponse, error) {
+	out := new(UpdatePayeeResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/UpdatePayee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) DeletePayee(ctx context.Context, in *DeletePayeeRequest, opts ...grpc.CallOption) (*DeletePayeeResponse, error) {
+	out := new(DeletePayeeResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/DeletePayee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) CreateTransferBatch(ctx context.Context, in *CreateTransferBatchRequest, opts ...grpc.CallOption) (*CreateTransferBatchResponse, error) {
+	out := new(CreateTransferBatchResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/CreateTransferBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) GetTransferBatch(ctx context.Context, in *GetTransferBatchRequest, opts ...grpc.CallOption) (*GetTransferBatchResponse, error) {
+	out := new(GetTransferBatchResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/GetTransferBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) InviteAccountMember(ctx context.Context, in *InviteAccountMemberRequest, opts ...grpc.CallOption) (*InviteAccountMemberResponse, error) {
+	out := new(InviteAccountMemberResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/InviteAccountMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) AcceptAccountMember(ctx context.Context, in *AcceptAccountMemberRequest, opts ...grpc.CallOption) (*AcceptAccountMemberResponse, error) {
+	out := new(AcceptAccountMemberResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/AcceptAccountMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) ListAccountMembers(ctx context.Context, in *ListAccountMembersRequest, opts ...grpc.CallOption) (*ListAccountMembersResponse, error) {
+	out := new(ListAccountMembersResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/ListAccountMembers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) RemoveAccountMember(ctx context.Context, in *RemoveAccountMemberRequest, opts ...grpc.CallOption) (*RemoveAccountMemberResponse, error) {
+	out := new(RemoveAccountMemberResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/RemoveAccountMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*CreateOrganizationResponse, error) {
+	out := new(CreateOrganizationResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/CreateOrganization", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) AddOrganizationMember(ctx context.Context, in *AddOrganizationMemberRequest, opts ...grpc.CallOption) (*AddOrganizationMemberResponse, error) {
+	out := new(AddOrganizationMemberResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/AddOrganizationMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) ListOrganizationMembers(ctx context.Context, in *ListOrganizationMembersRequest, opts ...grpc.CallOption) (*ListOrganizationMembersResponse, error) {
+	out := new(ListOrganizationMembersResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/ListOrganizationMembers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) RemoveOrganizationMember(ctx context.Context, in *RemoveOrganizationMemberRequest, opts ...grpc.CallOption) (*RemoveOrganizationMemberResponse, error) {
+	out := new(RemoveOrganizationMemberResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/RemoveOrganizationMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) CreateOrganizationAccount(ctx context.Context, in *CreateOrganizationAccountRequest, opts ...grpc.CallOption) (*CreateOrganizationAccountResponse, error) {
+	out := new(CreateOrganizationAccountResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/CreateOrganizationAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) ListTransferApprovals(ctx context.Context, in *ListTransferApprovalsRequest, opts ...grpc.CallOption) (*ListTransferApprovalsResponse, error) {
+	out := new(ListTransferApprovalsResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/ListTransferApprovals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) ApproveTransferApproval(ctx context.Context, in *ApproveTransferApprovalRequest, opts ...grpc.CallOption) (*ApproveTransferApprovalResponse, error) {
+	out := new(ApproveTransferApprovalResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/ApproveTransferApproval", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankClient) RejectTransferApproval(ctx context.Context, in *RejectTransferApprovalRequest, opts ...grpc.CallOption) (*RejectTransferApprovalResponse, error) {
+	out := new(RejectTransferApprovalResponse)
+	err := c.cc.Invoke(ctx, "/pb.Bank/RejectTransferApproval", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BankServer is the server API for Bank service.
 // All implementations must embed UnimplementedBankServer
 // for forward compatibility
@@ -69,85 +479,987 @@ type BankServer interface {
 	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
 	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
 	LoginUser(context.Context, *LoginUserRequest) (*LoginUserResponse, error)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	CreateWebhookEndpoint(context.Context, *CreateWebhookEndpointRequest) (*CreateWebhookEndpointResponse, error)
+	DeleteWebhookEndpoint(context.Context, *DeleteWebhookEndpointRequest) (*DeleteWebhookEndpointResponse, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	ReplayWebhookDelivery(context.Context, *ReplayWebhookDeliveryRequest) (*ReplayWebhookDeliveryResponse, error)
+	FreezeAccount(context.Context, *FreezeAccountRequest) (*FreezeAccountResponse, error)
+	CreateScheduledTransfer(context.Context, *CreateScheduledTransferRequest) (*CreateScheduledTransferResponse, error)
+	ListScheduledTransfers(context.Context, *ListScheduledTransfersRequest) (*ListScheduledTransfersResponse, error)
+	ListScheduledTransferRuns(context.Context, *ListScheduledTransferRunsRequest) (*ListScheduledTransferRunsResponse, error)
+	PauseScheduledTransfer(context.Context, *PauseScheduledTransferRequest) (*PauseScheduledTransferResponse, error)
+	ResumeScheduledTransfer(context.Context, *ResumeScheduledTransferRequest) (*ResumeScheduledTransferResponse, error)
+	CancelScheduledTransfer(context.Context, *CancelScheduledTransferRequest) (*CancelScheduledTransferResponse, error)
+	CreateHold(context.Context, *CreateHoldRequest) (*CreateHoldResponse, error)
+	CaptureHold(context.Context, *CaptureHoldRequest) (*CaptureHoldResponse, error)
+	VoidHold(context.Context, *VoidHoldRequest) (*VoidHoldResponse, error)
+	ListHolds(context.Context, *ListHoldsRequest) (*ListHoldsResponse, error)
+	QuoteTransfer(context.Context, *QuoteTransferRequest) (*QuoteTransferResponse, error)
+	CreateTransfer(context.Context, *CreateTransferRequest) (*CreateTransferResponse, error)
+	SetAccountTransferLimits(context.Context, *SetAccountTransferLimitsRequest) (*SetAccountTransferLimitsResponse, error)
+	ListTransferReviews(context.Context, *ListTransferReviewsRequest) (*ListTransferReviewsResponse, error)
+	ApproveTransferReview(context.Context, *ApproveTransferReviewRequest) (*ApproveTransferReviewResponse, error)
+	RejectTransferReview(context.Context, *RejectTransferReviewRequest) (*RejectTransferReviewResponse, error)
+	CreatePayee(context.Context, *CreatePayeeRequest) (*CreatePayeeResponse, error)
+	GetPayee(context.Context, *GetPayeeRequest) (*GetPayeeResponse, error)
+	ListPayees(context.Context, *ListPayeesRequest) (*ListPayeesResponse, error)
+	UpdatePayee(context.Context, *UpdatePayeeRequest) (*UpdatePayeeResponse, error)
+	DeletePayee(context.Context, *DeletePayeeRequest) (*DeletePayeeResponse, error)
+	CreateTransferBatch(context.Context, *CreateTransferBatchRequest) (*CreateTransferBatchResponse, error)
+	GetTransferBatch(context.Context, *GetTransferBatchRequest) (*GetTransferBatchResponse, error)
+	InviteAccountMember(context.Context, *InviteAccountMemberRequest) (*InviteAccountMemberResponse, error)
+	AcceptAccountMember(context.Context, *AcceptAccountMemberRequest) (*AcceptAccountMemberResponse, error)
+	ListAccountMembers(context.Context, *ListAccountMembersRequest) (*ListAccountMembersResponse, error)
+	RemoveAccountMember(context.Context, *RemoveAccountMemberRequest) (*RemoveAccountMemberResponse, error)
+	CreateOrganization(context.Context, *CreateOrganizationRequest) (*CreateOrganizationResponse, error)
+	AddOrganizationMember(context.Context, *AddOrganizationMemberRequest) (*AddOrganizationMemberResponse, error)
+	ListOrganizationMembers(context.Context, *ListOrganizationMembersRequest) (*ListOrganizationMembersResponse, error)
+	RemoveOrganizationMember(context.Context, *RemoveOrganizationMemberRequest) (*RemoveOrganizationMemberResponse, error)
+	CreateOrganizationAccount(context.Context, *CreateOrganizationAccountRequest) (*CreateOrganizationAccountResponse, error)
+	ListTransferApprovals(context.Context, *ListTransferApprovalsRequest) (*ListTransferApprovalsResponse, error)
+	ApproveTransferApproval(context.Context, *ApproveTransferApprovalRequest) (*ApproveTransferApprovalResponse, error)
+	RejectTransferApproval(context.Context, *RejectTransferApprovalRequest) (*RejectTransferApprovalResponse, error)
 	mustEmbedUnimplementedBankServer()
 }
 
-// UnimplementedBankServer must be embedded to have forward compatible implementations.
-type UnimplementedBankServer struct {
+// UnimplementedBankServer must be embedded to have forward compatible implementations.
+type UnimplementedBankServer struct {
+}
+
+func (UnimplementedBankServer) CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedBankServer) UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+}
+func (UnimplementedBankServer) LoginUser(context.Context, *LoginUserRequest) (*LoginUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoginUser not implemented")
+}
+func (UnimplementedBankServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedBankServer) CreateWebhookEndpoint(context.Context, *CreateWebhookEndpointRequest) (*CreateWebhookEndpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhookEndpoint not implemented")
+}
+func (UnimplementedBankServer) DeleteWebhookEndpoint(context.Context, *DeleteWebhookEndpointRequest) (*DeleteWebhookEndpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhookEndpoint not implemented")
+}
+func (UnimplementedBankServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedBankServer) ReplayWebhookDelivery(context.Context, *ReplayWebhookDeliveryRequest) (*ReplayWebhookDeliveryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayWebhookDelivery not implemented")
+}
+func (UnimplementedBankServer) FreezeAccount(context.Context, *FreezeAccountRequest) (*FreezeAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreezeAccount not implemented")
+}
+func (UnimplementedBankServer) CreateScheduledTransfer(context.Context, *CreateScheduledTransferRequest) (*CreateScheduledTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateScheduledTransfer not implemented")
+}
+func (UnimplementedBankServer) ListScheduledTransfers(context.Context, *ListScheduledTransfersRequest) (*ListScheduledTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledTransfers not implemented")
+}
+func (UnimplementedBankServer) ListScheduledTransferRuns(context.Context, *ListScheduledTransferRunsRequest) (*ListScheduledTransferRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledTransferRuns not implemented")
+}
+func (UnimplementedBankServer) PauseScheduledTransfer(context.Context, *PauseScheduledTransferRequest) (*PauseScheduledTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseScheduledTransfer not implemented")
+}
+func (UnimplementedBankServer) ResumeScheduledTransfer(context.Context, *ResumeScheduledTransferRequest) (*ResumeScheduledTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeScheduledTransfer not implemented")
+}
+func (UnimplementedBankServer) CancelScheduledTransfer(context.Context, *CancelScheduledTransferRequest) (*CancelScheduledTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduledTransfer not implemented")
+}
+func (UnimplementedBankServer) CreateHold(context.Context, *CreateHoldRequest) (*CreateHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateHold not implemented")
+}
+func (UnimplementedBankServer) CaptureHold(context.Context, *CaptureHoldRequest) (*CaptureHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CaptureHold not implemented")
+}
+func (UnimplementedBankServer) VoidHold(context.Context, *VoidHoldRequest) (*VoidHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VoidHold not implemented")
+}
+func (UnimplementedBankServer) ListHolds(context.Context, *ListHoldsRequest) (*ListHoldsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHolds not implemented")
+}
+func (UnimplementedBankServer) QuoteTransfer(context.Context, *QuoteTransferRequest) (*QuoteTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteTransfer not implemented")
+}
+func (UnimplementedBankServer) CreateTransfer(context.Context, *CreateTransferRequest) (*CreateTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTransfer not implemented")
+}
+func (UnimplementedBankServer) SetAccountTransferLimits(context.Context, *SetAccountTransferLimitsRequest) (*SetAccountTransferLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAccountTransferLimits not implemented")
+}
+func (UnimplementedBankServer) ListTransferReviews(context.Context, *ListTransferReviewsRequest) (*ListTransferReviewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransferReviews not implemented")
+}
+func (UnimplementedBankServer) ApproveTransferReview(context.Context, *ApproveTransferReviewRequest) (*ApproveTransferReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveTransferReview not implemented")
+}
+func (UnimplementedBankServer) RejectTransferReview(context.Context, *RejectTransferReviewRequest) (*RejectTransferReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectTransferReview not implemented")
+}
+func (UnimplementedBankServer) CreatePayee(context.Context, *CreatePayeeRequest) (*CreatePayeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePayee not implemented")
+}
+func (UnimplementedBankServer) GetPayee(context.Context, *GetPayeeRequest) (*GetPayeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPayee not implemented")
+}
+func (UnimplementedBankServer) ListPayees(context.Context, *ListPayeesRequest) (*ListPayeesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPayees not implemented")
+}
+func (UnimplementedBankServer) UpdatePayee(context.Context, *UpdatePayeeRequest) (*UpdatePayeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePayee not implemented")
+}
+func (UnimplementedBankServer) DeletePayee(context.Context, *DeletePayeeRequest) (*DeletePayeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePayee not implemented")
+}
+func (UnimplementedBankServer) CreateTransferBatch(context.Context, *CreateTransferBatchRequest) (*CreateTransferBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTransferBatch not implemented")
+}
+func (UnimplementedBankServer) GetTransferBatch(context.Context, *GetTransferBatchRequest) (*GetTransferBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransferBatch not implemented")
+}
+func (UnimplementedBankServer) InviteAccountMember(context.Context, *InviteAccountMemberRequest) (*InviteAccountMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteAccountMember not implemented")
+}
+func (UnimplementedBankServer) AcceptAccountMember(context.Context, *AcceptAccountMemberRequest) (*AcceptAccountMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptAccountMember not implemented")
+}
+func (UnimplementedBankServer) ListAccountMembers(context.Context, *ListAccountMembersRequest) (*ListAccountMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccountMembers not implemented")
+}
+func (UnimplementedBankServer) RemoveAccountMember(context.Context, *RemoveAccountMemberRequest) (*RemoveAccountMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAccountMember not implemented")
+}
+func (UnimplementedBankServer) CreateOrganization(context.Context, *CreateOrganizationRequest) (*CreateOrganizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrganization not implemented")
+}
+func (UnimplementedBankServer) AddOrganizationMember(context.Context, *AddOrganizationMemberRequest) (*AddOrganizationMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddOrganizationMember not implemented")
+}
+func (UnimplementedBankServer) ListOrganizationMembers(context.Context, *ListOrganizationMembersRequest) (*ListOrganizationMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrganizationMembers not implemented")
+}
+func (UnimplementedBankServer) RemoveOrganizationMember(context.Context, *RemoveOrganizationMemberRequest) (*RemoveOrganizationMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveOrganizationMember not implemented")
+}
+func (UnimplementedBankServer) CreateOrganizationAccount(context.Context, *CreateOrganizationAccountRequest) (*CreateOrganizationAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrganizationAccount not implemented")
+}
+func (UnimplementedBankServer) ListTransferApprovals(context.Context, *ListTransferApprovalsRequest) (*ListTransferApprovalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransferApprovals not implemented")
+}
+func (UnimplementedBankServer) ApproveTransferApproval(context.Context, *ApproveTransferApprovalRequest) (*ApproveTransferApprovalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveTransferApproval not implemented")
+}
+func (UnimplementedBankServer) RejectTransferApproval(context.Context, *RejectTransferApprovalRequest) (*RejectTransferApprovalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectTransferApproval not implemented")
+}
+func (UnimplementedBankServer) mustEmbedUnimplementedBankServer() {}
+
+// UnsafeBankServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BankServer will
+// result in compilation errors.
+type UnsafeBankServer interface {
+	mustEmbedUnimplementedBankServer()
+}
+
+func RegisterBankServer(s grpc.ServiceRegistrar, srv BankServer) {
+	s.RegisterService(&Bank_ServiceDesc, srv)
+}
+
+func _Bank_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/CreateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/UpdateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_LoginUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).LoginUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/LoginUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).LoginUser(ctx, req.(*LoginUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_CreateWebhookEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).CreateWebhookEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/CreateWebhookEndpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).CreateWebhookEndpoint(ctx, req.(*CreateWebhookEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_DeleteWebhookEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).DeleteWebhookEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/DeleteWebhookEndpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).DeleteWebhookEndpoint(ctx, req.(*DeleteWebhookEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/ListWebhookDeliveries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_ReplayWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayWebhookDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).ReplayWebhookDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/ReplayWebhookDelivery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).ReplayWebhookDelivery(ctx, req.(*ReplayWebhookDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_FreezeAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreezeAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).FreezeAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/FreezeAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).FreezeAccount(ctx, req.(*FreezeAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_CreateScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateScheduledTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).CreateScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/CreateScheduledTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).CreateScheduledTransfer(ctx, req.(*CreateScheduledTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_ListScheduledTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).ListScheduledTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/ListScheduledTransfers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).ListScheduledTransfers(ctx, req.(*ListScheduledTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_ListScheduledTransferRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledTransferRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).ListScheduledTransferRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/ListScheduledTransferRuns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).ListScheduledTransferRuns(ctx, req.(*ListScheduledTransferRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_PauseScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseScheduledTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).PauseScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/PauseScheduledTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).PauseScheduledTransfer(ctx, req.(*PauseScheduledTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_ResumeScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeScheduledTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).ResumeScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/ResumeScheduledTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).ResumeScheduledTransfer(ctx, req.(*ResumeScheduledTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_CancelScheduledTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).CancelScheduledTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/CancelScheduledTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).CancelScheduledTransfer(ctx, req.(*CancelScheduledTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_CreateHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).CreateHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/CreateHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).CreateHold(ctx, req.(*CreateHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_CaptureHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).CaptureHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/CaptureHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).CaptureHold(ctx, req.(*CaptureHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_VoidHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoidHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).VoidHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/VoidHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).VoidHold(ctx, req.(*VoidHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_ListHolds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHoldsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).ListHolds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/ListHolds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).ListHolds(ctx, req.(*ListHoldsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_QuoteTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).QuoteTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/QuoteTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).QuoteTransfer(ctx, req.(*QuoteTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_CreateTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).CreateTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/CreateTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).CreateTransfer(ctx, req.(*CreateTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_SetAccountTransferLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAccountTransferLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).SetAccountTransferLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/SetAccountTransferLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).SetAccountTransferLimits(ctx, req.(*SetAccountTransferLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_ListTransferReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransferReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).ListTransferReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/ListTransferReviews",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).ListTransferReviews(ctx, req.(*ListTransferReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_ApproveTransferReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveTransferReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).ApproveTransferReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/ApproveTransferReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).ApproveTransferReview(ctx, req.(*ApproveTransferReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_RejectTransferReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectTransferReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).RejectTransferReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/RejectTransferReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).RejectTransferReview(ctx, req.(*RejectTransferReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_CreatePayee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePayeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).CreatePayee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/CreatePayee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).CreatePayee(ctx, req.(*CreatePayeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_GetPayee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPayeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).GetPayee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/GetPayee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).GetPayee(ctx, req.(*GetPayeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_ListPayees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPayeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).ListPayees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/ListPayees",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).ListPayees(ctx, req.(*ListPayeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_UpdatePayee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePayeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).UpdatePayee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/UpdatePayee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).UpdatePayee(ctx, req.(*UpdatePayeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_DeletePayee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePayeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).DeletePayee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/DeletePayee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).DeletePayee(ctx, req.(*DeletePayeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_CreateTransferBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransferBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).CreateTransferBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/CreateTransferBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).CreateTransferBatch(ctx, req.(*CreateTransferBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (UnimplementedBankServer) CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+func _Bank_GetTransferBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).GetTransferBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/GetTransferBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).GetTransferBatch(ctx, req.(*GetTransferBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedBankServer) UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+
+func _Bank_InviteAccountMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteAccountMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).InviteAccountMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/InviteAccountMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).InviteAccountMember(ctx, req.(*InviteAccountMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedBankServer) LoginUser(context.Context, *LoginUserRequest) (*LoginUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LoginUser not implemented")
+
+func _Bank_AcceptAccountMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptAccountMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).AcceptAccountMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/AcceptAccountMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).AcceptAccountMember(ctx, req.(*AcceptAccountMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedBankServer) mustEmbedUnimplementedBankServer() {}
 
-// UnsafeBankServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to BankServer will
-// result in compilation errors.
-type UnsafeBankServer interface {
-	mustEmbedUnimplementedBankServer()
+func _Bank_ListAccountMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).ListAccountMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/ListAccountMembers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).ListAccountMembers(ctx, req.(*ListAccountMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterBankServer(s grpc.ServiceRegistrar, srv BankServer) {
-	s.RegisterService(&Bank_ServiceDesc, srv)
+func _Bank_RemoveAccountMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveAccountMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).RemoveAccountMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/RemoveAccountMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).RemoveAccountMember(ctx, req.(*RemoveAccountMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Bank_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateUserRequest)
+func _Bank_CreateOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrganizationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BankServer).CreateUser(ctx, in)
+		return srv.(BankServer).CreateOrganization(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Bank/CreateUser",
+		FullMethod: "/pb.Bank/CreateOrganization",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BankServer).CreateUser(ctx, req.(*CreateUserRequest))
+		return srv.(BankServer).CreateOrganization(ctx, req.(*CreateOrganizationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Bank_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateUserRequest)
+func _Bank_AddOrganizationMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddOrganizationMemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BankServer).UpdateUser(ctx, in)
+		return srv.(BankServer).AddOrganizationMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Bank/UpdateUser",
+		FullMethod: "/pb.Bank/AddOrganizationMember",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BankServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+		return srv.(BankServer).AddOrganizationMember(ctx, req.(*AddOrganizationMemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Bank_LoginUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoginUserRequest)
+func _Bank_ListOrganizationMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrganizationMembersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BankServer).LoginUser(ctx, in)
+		return srv.(BankServer).ListOrganizationMembers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Bank/LoginUser",
+		FullMethod: "/pb.Bank/ListOrganizationMembers",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BankServer).LoginUser(ctx, req.(*LoginUserRequest))
+		return srv.(BankServer).ListOrganizationMembers(ctx, req.(*ListOrganizationMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_RemoveOrganizationMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveOrganizationMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).RemoveOrganizationMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/RemoveOrganizationMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).RemoveOrganizationMember(ctx, req.(*RemoveOrganizationMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_CreateOrganizationAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrganizationAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).CreateOrganizationAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/CreateOrganizationAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).CreateOrganizationAccount(ctx, req.(*CreateOrganizationAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_ListTransferApprovals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransferApprovalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).ListTransferApprovals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/ListTransferApprovals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).ListTransferApprovals(ctx, req.(*ListTransferApprovalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_ApproveTransferApproval_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveTransferApprovalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).ApproveTransferApproval(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/ApproveTransferApproval",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).ApproveTransferApproval(ctx, req.(*ApproveTransferApprovalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bank_RejectTransferApproval_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectTransferApprovalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServer).RejectTransferApproval(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Bank/RejectTransferApproval",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServer).RejectTransferApproval(ctx, req.(*RejectTransferApprovalRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -171,6 +1483,170 @@ var Bank_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LoginUser",
 			Handler:    _Bank_LoginUser_Handler,
 		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _Bank_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "CreateWebhookEndpoint",
+			Handler:    _Bank_CreateWebhookEndpoint_Handler,
+		},
+		{
+			MethodName: "DeleteWebhookEndpoint",
+			Handler:    _Bank_DeleteWebhookEndpoint_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _Bank_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "ReplayWebhookDelivery",
+			Handler:    _Bank_ReplayWebhookDelivery_Handler,
+		},
+		{
+			MethodName: "FreezeAccount",
+			Handler:    _Bank_FreezeAccount_Handler,
+		},
+		{
+			MethodName: "CreateScheduledTransfer",
+			Handler:    _Bank_CreateScheduledTransfer_Handler,
+		},
+		{
+			MethodName: "ListScheduledTransfers",
+			Handler:    _Bank_ListScheduledTransfers_Handler,
+		},
+		{
+			MethodName: "ListScheduledTransferRuns",
+			Handler:    _Bank_ListScheduledTransferRuns_Handler,
+		},
+		{
+			MethodName: "PauseScheduledTransfer",
+			Handler:    _Bank_PauseScheduledTransfer_Handler,
+		},
+		{
+			MethodName: "ResumeScheduledTransfer",
+			Handler:    _Bank_ResumeScheduledTransfer_Handler,
+		},
+		{
+			MethodName: "CancelScheduledTransfer",
+			Handler:    _Bank_CancelScheduledTransfer_Handler,
+		},
+		{
+			MethodName: "CreateHold",
+			Handler:    _Bank_CreateHold_Handler,
+		},
+		{
+			MethodName: "CaptureHold",
+			Handler:    _Bank_CaptureHold_Handler,
+		},
+		{
+			MethodName: "VoidHold",
+			Handler:    _Bank_VoidHold_Handler,
+		},
+		{
+			MethodName: "ListHolds",
+			Handler:    _Bank_ListHolds_Handler,
+		},
+		{
+			MethodName: "QuoteTransfer",
+			Handler:    _Bank_QuoteTransfer_Handler,
+		},
+		{
+			MethodName: "CreateTransfer",
+			Handler:    _Bank_CreateTransfer_Handler,
+		},
+		{
+			MethodName: "SetAccountTransferLimits",
+			Handler:    _Bank_SetAccountTransferLimits_Handler,
+		},
+		{
+			MethodName: "ListTransferReviews",
+			Handler:    _Bank_ListTransferReviews_Handler,
+		},
+		{
+			MethodName: "ApproveTransferReview",
+			Handler:    _Bank_ApproveTransferReview_Handler,
+		},
+		{
+			MethodName: "RejectTransferReview",
+			Handler:    _Bank_RejectTransferReview_Handler,
+		},
+		{
+			MethodName: "CreatePayee",
+			Handler:    _Bank_CreatePayee_Handler,
+		},
+		{
+			MethodName: "GetPayee",
+			Handler:    _Bank_GetPayee_Handler,
+		},
+		{
+			MethodName: "ListPayees",
+			Handler:    _Bank_ListPayees_Handler,
+		},
+		{
+			MethodName: "UpdatePayee",
+			Handler:    _Bank_UpdatePayee_Handler,
+		},
+		{
+			MethodName: "DeletePayee",
+			Handler:    _Bank_DeletePayee_Handler,
+		},
+		{
+			MethodName: "CreateTransferBatch",
+			Handler:    _Bank_CreateTransferBatch_Handler,
+		},
+		{
+			MethodName: "GetTransferBatch",
+			Handler:    _Bank_GetTransferBatch_Handler,
+		},
+		{
+			MethodName: "InviteAccountMember",
+			Handler:    _Bank_InviteAccountMember_Handler,
+		},
+		{
+			MethodName: "AcceptAccountMember",
+			Handler:    _Bank_AcceptAccountMember_Handler,
+		},
+		{
+			MethodName: "ListAccountMembers",
+			Handler:    _Bank_ListAccountMembers_Handler,
+		},
+		{
+			MethodName: "RemoveAccountMember",
+			Handler:    _Bank_RemoveAccountMember_Handler,
+		},
+		{
+			MethodName: "CreateOrganization",
+			Handler:    _Bank_CreateOrganization_Handler,
+		},
+		{
+			MethodName: "AddOrganizationMember",
+			Handler:    _Bank_AddOrganizationMember_Handler,
+		},
+		{
+			MethodName: "ListOrganizationMembers",
+			Handler:    _Bank_ListOrganizationMembers_Handler,
+		},
+		{
+			MethodName: "RemoveOrganizationMember",
+			Handler:    _Bank_RemoveOrganizationMember_Handler,
+		},
+		{
+			MethodName: "CreateOrganizationAccount",
+			Handler:    _Bank_CreateOrganizationAccount_Handler,
+		},
+		{
+			MethodName: "ListTransferApprovals",
+			Handler:    _Bank_ListTransferApprovals_Handler,
+		},
+		{
+			MethodName: "ApproveTransferApproval",
+			Handler:    _Bank_ApproveTransferApproval_Handler,
+		},
+		{
+			MethodName: "RejectTransferApproval",
+			Handler:    _Bank_RejectTransferApproval_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "service_bank.proto",
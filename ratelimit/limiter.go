@@ -0,0 +1,96 @@
+// Package ratelimit throttles the requests of the clients with token buckets,
+// shared by the replicas through redis and kept in memory when redis is unavailable.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Limit allows a number of requests per period. The bucket holds up to Requests tokens
+// and refills evenly over the period, so that the requests can burst up to the whole allowance.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// ParseLimit parses a limit written as "<requests>/<period>", like "5/1m".
+// An empty string is the zero limit, which allows every request.
+func ParseLimit(value string) (Limit, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Limit{}, nil
+	}
+
+	requests, period, found := strings.Cut(value, "/")
+	if !found {
+		return Limit{}, fmt.Errorf("invalid limit %q: expected <requests>/<period>", value)
+	}
+
+	var limit Limit
+	var err error
+	limit.Requests, err = strconv.Atoi(requests)
+	if err != nil || limit.Requests <= 0 {
+		return Limit{}, fmt.Errorf("invalid limit %q: requests must be a positive integer", value)
+	}
+
+	limit.Period, err = time.ParseDuration(period)
+	if err != nil || limit.Period <= 0 {
+		return Limit{}, fmt.Errorf("invalid limit %q: period must be a positive duration", value)
+	}
+
+	return limit, nil
+}
+
+// IsZero reports whether the limit allows every request
+func (limit Limit) IsZero() bool {
+	return limit.Requests <= 0 || limit.Period <= 0
+}
+
+func (limit Limit) String() string {
+	return fmt.Sprintf("%d/%s", limit.Requests, limit.Period)
+}
+
+// interval is the time it takes to refill one token
+func (limit Limit) interval() time.Duration {
+	return limit.Period / time.Duration(limit.Requests)
+}
+
+// Result is the outcome of taking a token from a bucket
+type Result struct {
+	Allowed bool
+	// Remaining is the number of whole tokens left in the bucket
+	Remaining int
+	// RetryAfter is how long a rejected client has to wait for the next token
+	RetryAfter time.Duration
+}
+
+// Limiter takes a token from the bucket of a key, creating it full if it doesn't exist
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// FallbackLimiter limits with the primary limiter, and with the fallback one while the primary fails
+type FallbackLimiter struct {
+	primary  Limiter
+	fallback Limiter
+}
+
+func NewFallbackLimiter(primary Limiter, fallback Limiter) *FallbackLimiter {
+	return &FallbackLimiter{primary: primary, fallback: fallback}
+}
+
+func (limiter *FallbackLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	result, err := limiter.primary.Allow(ctx, key, limit)
+	if err == nil {
+		return result, nil
+	}
+
+	log.Ctx(ctx).Warn().Err(err).Str("key", key).Msg("rate limiter failed, falling back")
+	return limiter.fallback.Allow(ctx, key, limit)
+}
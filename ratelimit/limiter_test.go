@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimit(t *testing.T) {
+	limit, err := ParseLimit(" 5/1m ")
+	require.NoError(t, err)
+	require.Equal(t, Limit{Requests: 5, Period: time.Minute}, limit)
+	require.Equal(t, 12*time.Second, limit.interval())
+
+	limit, err = ParseLimit("")
+	require.NoError(t, err)
+	require.True(t, limit.IsZero())
+
+	for _, value := range []string{"5", "five/1m", "0/1m", "5/minute", "5/0s"} {
+		_, err := ParseLimit(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return now }
+	limit := Limit{Requests: 2, Period: time.Minute}
+
+	for i := 1; i >= 0; i-- {
+		result, err := limiter.Allow(context.Background(), "key", limit)
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+		require.Equal(t, i, result.Remaining)
+	}
+
+	result, err := limiter.Allow(context.Background(), "key", limit)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+	require.Equal(t, 30*time.Second, result.RetryAfter)
+
+	// the other keys have their own bucket
+	result, err = limiter.Allow(context.Background(), "other", limit)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+
+	// a token is refilled every 30 seconds
+	now = now.Add(20 * time.Second)
+	result, err = limiter.Allow(context.Background(), "key", limit)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+	require.Equal(t, 10*time.Second, result.RetryAfter)
+
+	now = now.Add(10 * time.Second)
+	result, err = limiter.Allow(context.Background(), "key", limit)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+	require.Equal(t, 0, result.Remaining)
+}
+
+func TestMemoryLimiter_sweep(t *testing.T) {
+	now := time.Now()
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return now }
+	limit := Limit{Requests: 1, Period: time.Second}
+
+	_, err := limiter.Allow(context.Background(), "key", limit)
+	require.NoError(t, err)
+	require.Len(t, limiter.buckets, 1)
+
+	now = now.Add(sweepInterval)
+	_, err = limiter.Allow(context.Background(), "other", limit)
+	require.NoError(t, err)
+	require.Len(t, limiter.buckets, 1)
+	require.Contains(t, limiter.buckets, "other")
+}
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(context.Context, string, Limit) (Result, error) {
+	return Result{}, errors.New("connection refused")
+}
+
+func TestFallbackLimiter(t *testing.T) {
+	fallback := NewMemoryLimiter()
+	limiter := NewFallbackLimiter(failingLimiter{}, fallback)
+	limit := Limit{Requests: 1, Period: time.Minute}
+
+	result, err := limiter.Allow(context.Background(), "key", limit)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+
+	result, err = limiter.Allow(context.Background(), "key", limit)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+}
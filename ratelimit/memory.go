@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the memory limiter drops the buckets that have refilled
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	// fullAt is when the bucket is full again, after which it is the same as a missing one
+	fullAt time.Time
+}
+
+// MemoryLimiter keeps the buckets in the memory of the process, so each replica limits on its own
+type MemoryLimiter struct {
+	mutex   sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+	sweptAt time.Time
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (limiter *MemoryLimiter) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	if limit.IsZero() {
+		return Result{Allowed: true}, nil
+	}
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	now := limiter.now()
+	limiter.sweep(now)
+
+	capacity := float64(limit.Requests)
+	interval := limit.interval()
+
+	b, ok := limiter.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updatedAt: now}
+		limiter.buckets[key] = b
+	}
+
+	b.tokens += float64(now.Sub(b.updatedAt)) / float64(interval)
+	if b.tokens > capacity {
+		b.tokens = capacity
+	}
+	b.updatedAt = now
+
+	result := Result{}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - b.tokens) * float64(interval))
+	}
+	result.Remaining = int(b.tokens)
+	b.fullAt = now.Add(time.Duration((capacity - b.tokens) * float64(interval)))
+
+	return result, nil
+}
+
+func (limiter *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(limiter.sweptAt) < sweepInterval {
+		return
+	}
+
+	for key, b := range limiter.buckets {
+		if !now.Before(b.fullAt) {
+			delete(limiter.buckets, key)
+		}
+	}
+	limiter.sweptAt = now
+}
@@ -0,0 +1,141 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+)
+
+// keyPrefix namespaces the buckets in redis
+const keyPrefix = "ratelimit"
+
+// Names of the policies, also used to label the rejections
+const (
+	PolicyIP     = "ip"
+	PolicyUser   = "user"
+	PolicyMethod = "method"
+)
+
+// Caller identifies who makes a request and to which method
+type Caller struct {
+	// Method is the short name of the RPC, like "LoginUser", empty for the requests not mapped to an RPC
+	Method string
+	// Username is empty for the unauthenticated requests
+	Username string
+	ClientIP string
+}
+
+// Policies are the limits a request is checked against. Every request is limited by the IP of the client,
+// the authenticated ones also by the user, and the requests to a configured method by the method for the user,
+// or for the IP when unauthenticated.
+type Policies struct {
+	IP      Limit
+	User    Limit
+	Methods map[string]Limit
+}
+
+// NewPolicies reads the policies from the config. The method limits are written as a comma-separated
+// list of "<method>=<limit>", like "LoginUser=5/1m,CreateTransfer=30/1m".
+func NewPolicies(config util.Config) (Policies, error) {
+	var policies Policies
+	var err error
+
+	policies.IP, err = ParseLimit(config.RateLimitIP)
+	if err != nil {
+		return Policies{}, fmt.Errorf("invalid ip rate limit: %w", err)
+	}
+
+	policies.User, err = ParseLimit(config.RateLimitUser)
+	if err != nil {
+		return Policies{}, fmt.Errorf("invalid user rate limit: %w", err)
+	}
+
+	policies.Methods = make(map[string]Limit)
+	for _, entry := range strings.Split(config.RateLimitMethods, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		method, value, found := strings.Cut(entry, "=")
+		method = strings.TrimSpace(method)
+		if !found || method == "" {
+			return Policies{}, fmt.Errorf("invalid method rate limit %q: expected <method>=<limit>", entry)
+		}
+
+		limit, err := ParseLimit(value)
+		if err != nil {
+			return Policies{}, fmt.Errorf("invalid rate limit of method %s: %w", method, err)
+		}
+		policies.Methods[method] = limit
+	}
+
+	return policies, nil
+}
+
+// MethodNames returns the methods with a limit, sorted
+func (policies Policies) MethodNames() []string {
+	names := make([]string, 0, len(policies.Methods))
+	for name := range policies.Methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Rejection is the policy that rejected a request
+type Rejection struct {
+	Policy string
+	Result Result
+}
+
+// Allow takes a token from the bucket of every policy that applies to the caller.
+// It returns the rejection with the longest wait, or nil when every policy allows the request.
+func (policies Policies) Allow(ctx context.Context, limiter Limiter, caller Caller) (*Rejection, error) {
+	type check struct {
+		policy string
+		key    string
+		limit  Limit
+	}
+
+	var checks []check
+	if caller.ClientIP != "" {
+		checks = append(checks, check{PolicyIP, key(PolicyIP, caller.ClientIP), policies.IP})
+	}
+	if caller.Username != "" {
+		checks = append(checks, check{PolicyUser, key(PolicyUser, caller.Username), policies.User})
+	}
+	if limit, ok := policies.Methods[caller.Method]; ok {
+		subject := []string{PolicyIP, caller.ClientIP}
+		if caller.Username != "" {
+			subject = []string{PolicyUser, caller.Username}
+		}
+		checks = append(checks, check{PolicyMethod, key(append([]string{PolicyMethod, caller.Method}, subject...)...), limit})
+	}
+
+	var rejection *Rejection
+	for _, c := range checks {
+		if c.limit.IsZero() {
+			continue
+		}
+
+		result, err := limiter.Allow(ctx, c.key, c.limit)
+		if err != nil {
+			return nil, fmt.Errorf("cannot check %s rate limit: %w", c.policy, err)
+		}
+
+		if !result.Allowed && (rejection == nil || result.RetryAfter > rejection.Result.RetryAfter) {
+			rejection = &Rejection{Policy: c.policy, Result: result}
+		}
+	}
+
+	return rejection, nil
+}
+
+func key(parts ...string) string {
+	return keyPrefix + ":" + strings.Join(parts, ":")
+}
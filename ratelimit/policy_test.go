@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPolicies(t *testing.T) {
+	policies, err := NewPolicies(util.Config{
+		RateLimitIP:      "100/1m",
+		RateLimitMethods: "LoginUser=5/1m, CreateTransfer=30/1m,",
+	})
+	require.NoError(t, err)
+	require.Equal(t, Limit{Requests: 100, Period: time.Minute}, policies.IP)
+	require.True(t, policies.User.IsZero())
+	require.Equal(t, []string{"CreateTransfer", "LoginUser"}, policies.MethodNames())
+	require.Equal(t, Limit{Requests: 5, Period: time.Minute}, policies.Methods["LoginUser"])
+
+	for _, config := range []util.Config{
+		{RateLimitUser: "100"},
+		{RateLimitMethods: "LoginUser"},
+		{RateLimitMethods: "=5/1m"},
+		{RateLimitMethods: "LoginUser=5"},
+	} {
+		_, err := NewPolicies(config)
+		require.Error(t, err)
+	}
+}
+
+func TestPolicies_Allow(t *testing.T) {
+	policies := Policies{
+		IP:      Limit{Requests: 3, Period: time.Minute},
+		User:    Limit{Requests: 10, Period: time.Minute},
+		Methods: map[string]Limit{"LoginUser": {Requests: 1, Period: time.Hour}},
+	}
+	now := time.Now()
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// the method is limited by IP for the unauthenticated callers
+	rejection, err := policies.Allow(ctx, limiter, Caller{Method: "LoginUser", ClientIP: "10.0.0.1"})
+	require.NoError(t, err)
+	require.Nil(t, rejection)
+
+	rejection, err = policies.Allow(ctx, limiter, Caller{Method: "LoginUser", ClientIP: "10.0.0.1"})
+	require.NoError(t, err)
+	require.NotNil(t, rejection)
+	require.Equal(t, PolicyMethod, rejection.Policy)
+	require.Equal(t, time.Hour, rejection.Result.RetryAfter)
+
+	// and by user for the authenticated ones
+	rejection, err = policies.Allow(ctx, limiter, Caller{Method: "LoginUser", Username: "alice", ClientIP: "10.0.0.1"})
+	require.NoError(t, err)
+	require.Nil(t, rejection)
+
+	// the IP has used its 3 requests
+	rejection, err = policies.Allow(ctx, limiter, Caller{Method: "GetPayee", Username: "bob", ClientIP: "10.0.0.1"})
+	require.NoError(t, err)
+	require.NotNil(t, rejection)
+	require.Equal(t, PolicyIP, rejection.Policy)
+
+	rejection, err = policies.Allow(ctx, limiter, Caller{Method: "GetPayee", Username: "bob", ClientIP: "10.0.0.2"})
+	require.NoError(t, err)
+	require.Nil(t, rejection)
+}
+
+func TestPolicies_AllowWithoutLimits(t *testing.T) {
+	rejection, err := Policies{}.Allow(context.Background(), failingLimiter{}, Caller{Method: "LoginUser", ClientIP: "10.0.0.1"})
+	require.NoError(t, err)
+	require.Nil(t, rejection)
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// tokenBucketScript refills and takes a token from the bucket hash at KEYS[1] atomically,
+// on the clock of redis so that the replicas agree on it.
+// ARGV[1] is the capacity of the bucket and ARGV[2] the microseconds it takes to refill one token.
+// It returns whether the token was taken, the whole tokens left and the microseconds until the next one.
+// The times are kept in milliseconds, as the numbers are stored with 14 significant digits.
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2]) / 1000
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000 + tonumber(clock[2]) / 1000
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated_at')
+local tokens = tonumber(bucket[1])
+local updated_at = tonumber(bucket[2])
+if tokens == nil or updated_at == nil then
+	tokens = capacity
+	updated_at = now
+end
+
+tokens = math.min(capacity, tokens + math.max(0, now - updated_at) / interval)
+
+local allowed = 0
+local retry_after = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry_after = math.ceil((1 - tokens) * interval * 1000)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated_at', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.max(1, math.ceil((capacity - tokens) * interval)))
+
+return {allowed, math.floor(tokens), retry_after}
+`)
+
+// RedisLimiter keeps the buckets in redis, so that the limits hold across the replicas
+type RedisLimiter struct {
+	client redis.UniversalClient
+}
+
+func NewRedisLimiter(client redis.UniversalClient) *RedisLimiter {
+	return &RedisLimiter{client: client}
+}
+
+func (limiter *RedisLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	if limit.IsZero() {
+		return Result{Allowed: true}, nil
+	}
+
+	values, err := tokenBucketScript.Run(ctx, limiter.client, []string{key},
+		limit.Requests, limit.interval().Microseconds()).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to run token bucket script: %w", err)
+	}
+	if len(values) != 3 {
+		return Result{}, fmt.Errorf("unexpected token bucket script result: %v", values)
+	}
+
+	return Result{
+		Allowed:    values[0] == 1,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Microsecond,
+	}, nil
+}
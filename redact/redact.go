@@ -0,0 +1,156 @@
+// Package redact masks the values of the fields annotated as sensitive in the API, so that they are never logged.
+// The proto fields are annotated with the (sensitive) option.
+package redact
+
+import (
+	"encoding/json"
+
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Mask replaces the values of the sensitive fields
+const Mask = "[REDACTED]"
+
+// sensitiveNames are the JSON and proto names of the sensitive fields of all the messages of the API,
+// used to mask the JSON documents whose message isn't known, such as the gateway bodies and the task payloads
+var sensitiveNames = loadSensitiveNames()
+
+// Message returns the JSON encoding of a message with the values of its sensitive fields masked
+func Message(message proto.Message) ([]byte, error) {
+	masked := proto.Clone(message)
+	maskMessage(masked.ProtoReflect())
+
+	return protojson.MarshalOptions{UseProtoNames: true}.Marshal(masked)
+}
+
+// JSON returns a JSON document with the values of the fields named after a sensitive field masked.
+// A document that isn't valid JSON is returned as null, since it can't be inspected.
+func JSON(data []byte) []byte {
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return []byte("null")
+	}
+
+	masked, err := json.Marshal(maskJSON(document))
+	if err != nil {
+		return []byte("null")
+	}
+	return masked
+}
+
+// IsSensitive reports whether a field is annotated as sensitive
+func IsSensitive(field protoreflect.FieldDescriptor) bool {
+	options := field.Options()
+	if options == nil || !proto.HasExtension(options, pb.E_Sensitive) {
+		return false
+	}
+	return proto.GetExtension(options, pb.E_Sensitive).(bool)
+}
+
+func maskMessage(message protoreflect.Message) {
+	// the fields of a Struct aren't declared, so they are masked after the names of the sensitive fields like JSON
+	if document, ok := message.Interface().(*structpb.Struct); ok {
+		maskStruct(document)
+		return
+	}
+
+	var sensitive []protoreflect.FieldDescriptor
+
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case IsSensitive(field):
+			sensitive = append(sensitive, field)
+		case field.IsList() && field.Message() != nil:
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				maskMessage(list.Get(i).Message())
+			}
+		case field.IsMap() && field.MapValue().Message() != nil:
+			value.Map().Range(func(_ protoreflect.MapKey, item protoreflect.Value) bool {
+				maskMessage(item.Message())
+				return true
+			})
+		case field.Message() != nil && !field.IsMap():
+			maskMessage(value.Message())
+		}
+		return true
+	})
+
+	// the fields are changed once the range is over, a masked string keeping track of the field being set
+	for _, field := range sensitive {
+		if field.Kind() == protoreflect.StringKind && field.Cardinality() != protoreflect.Repeated {
+			message.Set(field, protoreflect.ValueOfString(Mask))
+		} else {
+			message.Clear(field)
+		}
+	}
+}
+
+func maskStruct(document *structpb.Struct) {
+	for key, field := range document.GetFields() {
+		if sensitiveNames[key] {
+			document.Fields[key] = structpb.NewStringValue(Mask)
+			continue
+		}
+		maskValue(field)
+	}
+}
+
+func maskValue(value *structpb.Value) {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		maskStruct(kind.StructValue)
+	case *structpb.Value_ListValue:
+		for _, item := range kind.ListValue.GetValues() {
+			maskValue(item)
+		}
+	}
+}
+
+func maskJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if sensitiveNames[key] {
+				v[key] = Mask
+				continue
+			}
+			v[key] = maskJSON(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = maskJSON(item)
+		}
+	}
+	return value
+}
+
+func loadSensitiveNames() map[string]bool {
+	names := make(map[string]bool)
+
+	var addMessages func(messages protoreflect.MessageDescriptors)
+	addMessages = func(messages protoreflect.MessageDescriptors) {
+		for i := 0; i < messages.Len(); i++ {
+			message := messages.Get(i)
+			fields := message.Fields()
+			for j := 0; j < fields.Len(); j++ {
+				if field := fields.Get(j); IsSensitive(field) {
+					names[string(field.Name())] = true
+					names[field.JSONName()] = true
+				}
+			}
+			addMessages(message.Messages())
+		}
+	}
+
+	protoregistry.GlobalFiles.RangeFilesByPackage("pb", func(file protoreflect.FileDescriptor) bool {
+		addMessages(file.Messages())
+		return true
+	})
+	return names
+}
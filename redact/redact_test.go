@@ -0,0 +1,115 @@
+package redact
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/MathPeixoto/go-financial-system/pb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestMessage(t *testing.T) {
+	response := &pb.LoginUserResponse{
+		User: &pb.User{
+			Username: "alice",
+			Email:    "alice@email.com",
+		},
+		SessionId:    "session",
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+	}
+
+	encoded, err := Message(response)
+	require.NoError(t, err)
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, Mask, decoded["access_token"])
+	require.Equal(t, Mask, decoded["refresh_token"])
+	require.Equal(t, "session", decoded["session_id"])
+
+	user := decoded["user"].(map[string]interface{})
+	require.Equal(t, Mask, user["email"])
+	require.Equal(t, "alice", user["username"])
+
+	// the logged message is a copy
+	require.Equal(t, "access", response.AccessToken)
+	require.Equal(t, "alice@email.com", response.User.Email)
+}
+
+func TestMessage_OptionalField(t *testing.T) {
+	encoded, err := Message(&pb.UpdateUserRequest{Username: "alice", Password: proto.String("secret")})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"username":"alice","password":"[REDACTED]"}`, string(encoded))
+
+	// an unset field stays unset
+	encoded, err = Message(&pb.UpdateUserRequest{Username: "alice"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"username":"alice"}`, string(encoded))
+}
+
+func TestMessage_Struct(t *testing.T) {
+	before, err := structpb.NewStruct(map[string]interface{}{
+		"username": "alice",
+		"email":    "alice@email.com",
+		"members":  []interface{}{map[string]interface{}{"email": "bob@email.com"}},
+	})
+	require.NoError(t, err)
+
+	encoded, err := Message(&pb.ListAuditEventsResponse{
+		Events: []*pb.AuditEvent{{Action: "update_user", Before: before}},
+	})
+	require.NoError(t, err)
+	require.NotContains(t, string(encoded), "@email.com")
+
+	var decoded struct {
+		Events []struct {
+			Before map[string]interface{} `json:"before"`
+		} `json:"events"`
+	}
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, "alice", decoded.Events[0].Before["username"])
+	require.Equal(t, Mask, decoded.Events[0].Before["email"])
+
+	// the logged message is a copy
+	require.Equal(t, "alice@email.com", before.Fields["email"].GetStringValue())
+}
+
+func TestJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			name:     "ProtoNames",
+			data:     `{"user":{"username":"alice","email":"alice@email.com"},"access_token":"access"}`,
+			expected: `{"user":{"username":"alice","email":"[REDACTED]"},"access_token":"[REDACTED]"}`,
+		},
+		{
+			name:     "JSONNames",
+			data:     `[{"refreshToken":"refresh","amount":10}]`,
+			expected: `[{"refreshToken":"[REDACTED]","amount":10}]`,
+		},
+		{
+			name:     "NotJSON",
+			data:     `password=secret`,
+			expected: `null`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.JSONEq(t, tc.expected, string(JSON([]byte(tc.data))))
+		})
+	}
+}
+
+func TestIsSensitive(t *testing.T) {
+	fields := (&pb.CreateUserRequest{}).ProtoReflect().Descriptor().Fields()
+	require.True(t, IsSensitive(fields.ByName("password")))
+	require.True(t, IsSensitive(fields.ByName("email")))
+	require.False(t, IsSensitive(fields.ByName("username")))
+}
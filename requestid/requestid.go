@@ -0,0 +1,93 @@
+// Package requestid identifies every request with an ID, carried through the context along with a logger including it,
+// so that the logs of the servers, the store and the tasks enqueued by a request can be correlated.
+package requestid
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// Header is the HTTP header of the request ID, in the requests and the responses
+	Header = "X-Request-Id"
+	// MetadataKey is the gRPC metadata key of the request ID, in the requests and the response trailers
+	MetadataKey = "x-request-id"
+	// maxLength bounds the size of a request ID given by a client
+	maxLength = 128
+	// payloadKey is the field of the JSON payload of a task carrying the ID of the request that enqueued it
+	payloadKey = "request_id"
+)
+
+type contextKey struct{}
+
+// Resolve returns the request ID given by a client when it is valid, or a new one
+func Resolve(id string) string {
+	if isValid(id) {
+		return id
+	}
+	return uuid.NewString()
+}
+
+// NewContext returns ctx carrying id and a logger including it, returned by log.Ctx
+func NewContext(ctx context.Context, id string) context.Context {
+	logger := log.With().Str("request_id", id).Logger()
+	return logger.WithContext(context.WithValue(ctx, contextKey{}, id))
+}
+
+// FromContext returns the request ID carried by ctx, or an empty string
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// SetUsername adds the username of the authenticated user to the logger of the request carried by ctx
+func SetUsername(ctx context.Context, username string) {
+	// the logger of a context without request ID is the global one, which must not be updated
+	if FromContext(ctx) == "" {
+		return
+	}
+
+	log.Ctx(ctx).UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Str("username", username)
+	})
+}
+
+// InjectPayload adds the request ID carried by ctx to the JSON object payload of a task
+func InjectPayload(ctx context.Context, payload []byte) []byte {
+	id := FromContext(ctx)
+	if id == "" {
+		return payload
+	}
+	return util.AddPayloadField(payload, payloadKey, id)
+}
+
+// FromPayload returns the request ID carried by the payload of a task, or an empty string
+// for the tasks that weren't enqueued by a request, such as the periodic ones
+func FromPayload(payload []byte) string {
+	var fields struct {
+		RequestID string `json:"request_id"`
+	}
+	if err := json.Unmarshal(payload, &fields); err != nil || !isValid(fields.RequestID) {
+		return ""
+	}
+	return fields.RequestID
+}
+
+func isValid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+
+	for _, c := range id {
+		// printable ASCII, so that the ID can't forge log lines or headers
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package requestid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolve(t *testing.T) {
+	require.Equal(t, "abc-123", Resolve("abc-123"))
+
+	for _, id := range []string{"", "has space", "line\nbreak", strings.Repeat("a", maxLength+1)} {
+		resolved := Resolve(id)
+		require.NotEqual(t, id, resolved)
+		require.Len(t, resolved, 36)
+	}
+}
+
+func TestNewContext(t *testing.T) {
+	var buf bytes.Buffer
+	global := log.Logger
+	log.Logger = zerolog.New(&buf)
+	defer func() { log.Logger = global }()
+
+	ctx := NewContext(context.Background(), "abc-123")
+	require.Equal(t, "abc-123", FromContext(ctx))
+
+	SetUsername(ctx, "alice")
+	log.Ctx(ctx).Info().Msg("handled")
+
+	var line map[string]string
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	require.Equal(t, "abc-123", line["request_id"])
+	require.Equal(t, "alice", line["username"])
+}
+
+func TestSetUsername_NoRequest(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	ctx := logger.WithContext(context.Background())
+
+	SetUsername(ctx, "alice")
+	log.Ctx(ctx).Info().Msg("handled")
+	require.NotContains(t, buf.String(), "alice")
+}
+
+func TestPayload(t *testing.T) {
+	ctx := NewContext(context.Background(), "abc-123")
+
+	payload := InjectPayload(ctx, []byte(`{"delivery_id":1}`))
+	require.JSONEq(t, `{"delivery_id":1,"request_id":"abc-123"}`, string(payload))
+	require.Equal(t, "abc-123", FromPayload(payload))
+
+	// a payload keeps the ID of the request that enqueued it first
+	require.Equal(t, payload, InjectPayload(NewContext(context.Background(), "other"), payload))
+
+	require.Equal(t, []byte(`{"delivery_id":1}`), InjectPayload(context.Background(), []byte(`{"delivery_id":1}`)))
+	require.Empty(t, FromPayload(nil))
+	require.Empty(t, FromPayload([]byte(`{"request_id":"has space"}`)))
+}
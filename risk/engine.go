@@ -0,0 +1,121 @@
+// Package risk screens transfers against fraud rules before they are executed.
+package risk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+)
+
+// Decision is the outcome of screening a transfer, ordered by severity
+type Decision int
+
+const (
+	// Allow executes the transfer
+	Allow Decision = iota
+	// Review parks the transfer until an admin approves or rejects it
+	Review
+	// Deny refuses the transfer
+	Deny
+)
+
+func (d Decision) String() string {
+	switch d {
+	case Allow:
+		return "allow"
+	case Review:
+		return "review"
+	case Deny:
+		return "deny"
+	}
+
+	return fmt.Sprintf("Decision(%d)", int(d))
+}
+
+// Transfer is a transfer requested by a user, screened before it is executed
+type Transfer struct {
+	Username    string
+	FromAccount db.Account
+	ToAccount   db.Account
+	Amount      int64
+	Now         time.Time
+}
+
+// Result is the verdict of a single rule on a transfer
+type Result struct {
+	Rule     string
+	Decision Decision
+	Score    int
+	Reason   string
+}
+
+// Rule evaluates a risk signal of a transfer
+type Rule interface {
+	Name() string
+	Evaluate(ctx context.Context, transfer Transfer) (Result, error)
+}
+
+// Assessment combines the results of all the rules of an engine on a transfer
+type Assessment struct {
+	Decision Decision
+	// Score is the sum of the scores of the rules that did not allow the transfer
+	Score int
+	// Results are the results of the rules that did not allow the transfer
+	Results []Result
+}
+
+// Reasons returns why each rule flagged the transfer
+func (assessment Assessment) Reasons() []string {
+	reasons := make([]string, 0, len(assessment.Results))
+	for _, result := range assessment.Results {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", result.Rule, result.Reason))
+	}
+
+	return reasons
+}
+
+// Engine evaluates every rule on a transfer and keeps the most severe decision.
+// A transfer is also denied when the sum of the scores reaches the deny score, unless it is zero.
+type Engine struct {
+	rules     []Rule
+	denyScore int
+}
+
+// NewEngine creates an engine evaluating the given rules
+func NewEngine(denyScore int, rules ...Rule) *Engine {
+	return &Engine{
+		rules:     rules,
+		denyScore: denyScore,
+	}
+}
+
+// Screen evaluates a transfer against the rules of the engine
+func (engine *Engine) Screen(ctx context.Context, transfer Transfer) (Assessment, error) {
+	var assessment Assessment
+
+	for _, rule := range engine.rules {
+		result, err := rule.Evaluate(ctx, transfer)
+		if err != nil {
+			return assessment, fmt.Errorf("rule %s: %w", rule.Name(), err)
+		}
+
+		if result.Decision == Allow {
+			continue
+		}
+
+		result.Rule = rule.Name()
+		assessment.Results = append(assessment.Results, result)
+		assessment.Score += result.Score
+		if result.Decision > assessment.Decision {
+			assessment.Decision = result.Decision
+		}
+	}
+
+	if engine.denyScore > 0 && assessment.Score >= engine.denyScore {
+		assessment.Decision = Deny
+	}
+
+	return assessment, nil
+}
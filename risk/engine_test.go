@@ -0,0 +1,92 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type stubRule struct {
+	name   string
+	result Result
+	err    error
+}
+
+func (rule stubRule) Name() string {
+	return rule.name
+}
+
+func (rule stubRule) Evaluate(context.Context, Transfer) (Result, error) {
+	return rule.result, rule.err
+}
+
+func TestEngine_Screen(t *testing.T) {
+	allow := stubRule{name: "allow", result: Result{Decision: Allow}}
+	review := stubRule{name: "review", result: Result{Decision: Review, Score: 40, Reason: "suspicious"}}
+	deny := stubRule{name: "deny", result: Result{Decision: Deny, Score: 10, Reason: "fraudulent"}}
+
+	testCases := []struct {
+		name      string
+		engine    *Engine
+		decision  Decision
+		score     int
+		reasons   []string
+		expectErr bool
+	}{
+		{
+			name:     "NoRules",
+			engine:   NewEngine(0),
+			decision: Allow,
+			reasons:  []string{},
+		},
+		{
+			name:     "Allow",
+			engine:   NewEngine(0, allow),
+			decision: Allow,
+			reasons:  []string{},
+		},
+		{
+			name:     "Review",
+			engine:   NewEngine(0, allow, review),
+			decision: Review,
+			score:    40,
+			reasons:  []string{"review: suspicious"},
+		},
+		{
+			name:     "MostSevereDecision",
+			engine:   NewEngine(0, deny, review),
+			decision: Deny,
+			score:    50,
+			reasons:  []string{"deny: fraudulent", "review: suspicious"},
+		},
+		{
+			name:     "DenyScore",
+			engine:   NewEngine(80, review, review),
+			decision: Deny,
+			score:    80,
+			reasons:  []string{"review: suspicious", "review: suspicious"},
+		},
+		{
+			name:      "RuleError",
+			engine:    NewEngine(0, review, stubRule{name: "broken", err: errors.New("unavailable")}),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assessment, err := tc.engine.Screen(context.Background(), Transfer{})
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.decision, assessment.Decision)
+			require.Equal(t, tc.score, assessment.Score)
+			require.Equal(t, tc.reasons, assessment.Reasons())
+		})
+	}
+}
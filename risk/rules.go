@@ -0,0 +1,200 @@
+package risk
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/util"
+)
+
+// Store is the history the rules look up, implemented by db.Store
+type Store interface {
+	CountTransfersBetween(ctx context.Context, arg db.CountTransfersBetweenParams) (int64, error)
+	CountOutgoingTransfersSince(ctx context.Context, arg db.CountOutgoingTransfersSinceParams) (int64, error)
+	GetLatestSession(ctx context.Context, username string) (db.Session, error)
+	CountSessionsFromIP(ctx context.Context, arg db.CountSessionsFromIPParams) (int64, error)
+	CountSessionsBefore(ctx context.Context, arg db.CountSessionsBeforeParams) (int64, error)
+}
+
+// Scores of the rules when they flag a transfer
+const (
+	newPayeeScore      = 40
+	velocityScore      = 30
+	velocitySpikeScore = 60
+	newLoginIPScore    = 50
+)
+
+// velocitySpikeFactor multiplies the transfer count of the velocity rule past which transfers are denied
+const velocitySpikeFactor = 2
+
+// ConfiguredRules returns the rules enabled by the configuration. A rule is disabled while its threshold is zero.
+func ConfiguredRules(store Store, config util.Config) []Rule {
+	var rules []Rule
+
+	if config.RiskNewPayeeAmount > 0 {
+		rules = append(rules, NewPayeeRule(store, config.RiskNewPayeeAmount))
+	}
+
+	if config.RiskVelocityCount > 0 && config.RiskVelocityWindow > 0 {
+		rules = append(rules, NewVelocityRule(store, config.RiskVelocityCount, config.RiskVelocityWindow))
+	}
+
+	if config.RiskNewIPAmount > 0 && config.RiskNewIPWindow > 0 {
+		rules = append(rules, NewLoginIPRule(store, config.RiskNewIPAmount, config.RiskNewIPWindow))
+	}
+
+	return rules
+}
+
+// PayeeRule flags the first transfer from an account to another one when it is at least minAmount
+type PayeeRule struct {
+	store     Store
+	minAmount int64
+}
+
+func NewPayeeRule(store Store, minAmount int64) *PayeeRule {
+	return &PayeeRule{store: store, minAmount: minAmount}
+}
+
+func (rule *PayeeRule) Name() string {
+	return "new_payee"
+}
+
+func (rule *PayeeRule) Evaluate(ctx context.Context, transfer Transfer) (Result, error) {
+	if transfer.Amount < rule.minAmount {
+		return Result{Decision: Allow}, nil
+	}
+
+	count, err := rule.store.CountTransfersBetween(ctx, db.CountTransfersBetweenParams{
+		FromAccountID: transfer.FromAccount.ID,
+		ToAccountID:   transfer.ToAccount.ID,
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	if count > 0 {
+		return Result{Decision: Allow}, nil
+	}
+
+	return Result{
+		Decision: Review,
+		Score:    newPayeeScore,
+		Reason:   fmt.Sprintf("first transfer of %d or more to account %d", rule.minAmount, transfer.ToAccount.ID),
+	}, nil
+}
+
+// VelocityRule flags an account sending more than maxCount transfers within window,
+// and denies the transfers once it sends twice as many
+type VelocityRule struct {
+	store    Store
+	maxCount int
+	window   time.Duration
+}
+
+func NewVelocityRule(store Store, maxCount int, window time.Duration) *VelocityRule {
+	return &VelocityRule{store: store, maxCount: maxCount, window: window}
+}
+
+func (rule *VelocityRule) Name() string {
+	return "velocity"
+}
+
+func (rule *VelocityRule) Evaluate(ctx context.Context, transfer Transfer) (Result, error) {
+	count, err := rule.store.CountOutgoingTransfersSince(ctx, db.CountOutgoingTransfersSinceParams{
+		FromAccountID: transfer.FromAccount.ID,
+		Since:         transfer.Now.Add(-rule.window),
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	// the screened transfer counts as well
+	count++
+	switch {
+	case count > int64(velocitySpikeFactor*rule.maxCount):
+		return Result{
+			Decision: Deny,
+			Score:    velocitySpikeScore,
+			Reason:   fmt.Sprintf("%d transfers within %s, more than twice the %d allowed", count, rule.window, rule.maxCount),
+		}, nil
+	case count > int64(rule.maxCount):
+		return Result{
+			Decision: Review,
+			Score:    velocityScore,
+			Reason:   fmt.Sprintf("%d transfers within %s, more than the %d allowed", count, rule.window, rule.maxCount),
+		}, nil
+	}
+
+	return Result{Decision: Allow}, nil
+}
+
+// LoginIPRule flags a transfer of at least minAmount requested within window of a login
+// from an IP address the user never logged in from before
+type LoginIPRule struct {
+	store     Store
+	minAmount int64
+	window    time.Duration
+}
+
+func NewLoginIPRule(store Store, minAmount int64, window time.Duration) *LoginIPRule {
+	return &LoginIPRule{store: store, minAmount: minAmount, window: window}
+}
+
+func (rule *LoginIPRule) Name() string {
+	return "new_login_ip"
+}
+
+func (rule *LoginIPRule) Evaluate(ctx context.Context, transfer Transfer) (Result, error) {
+	if transfer.Amount < rule.minAmount {
+		return Result{Decision: Allow}, nil
+	}
+
+	session, err := rule.store.GetLatestSession(ctx, transfer.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Result{Decision: Allow}, nil
+		}
+		return Result{}, err
+	}
+
+	if session.CreatedAt.Before(transfer.Now.Add(-rule.window)) {
+		return Result{Decision: Allow}, nil
+	}
+
+	// the first login of a user has no history to compare its address with
+	previous, err := rule.store.CountSessionsBefore(ctx, db.CountSessionsBeforeParams{
+		Username: transfer.Username,
+		Before:   session.CreatedAt,
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	if previous == 0 {
+		return Result{Decision: Allow}, nil
+	}
+
+	known, err := rule.store.CountSessionsFromIP(ctx, db.CountSessionsFromIPParams{
+		Username: transfer.Username,
+		ClientIp: session.ClientIp,
+		Before:   session.CreatedAt,
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	if known > 0 {
+		return Result{Decision: Allow}, nil
+	}
+
+	return Result{
+		Decision: Review,
+		Score:    newLoginIPScore,
+		Reason:   fmt.Sprintf("transfer of %d or more after a login from new IP address %s", rule.minAmount, session.ClientIp),
+	}, nil
+}
@@ -0,0 +1,178 @@
+package risk
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/MathPeixoto/go-financial-system/db/mock"
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func randomTransfer(amount int64) Transfer {
+	return Transfer{
+		Username:    util.RandomOwner(),
+		FromAccount: db.Account{ID: util.RandomInt(1, 1000)},
+		ToAccount:   db.Account{ID: util.RandomInt(1001, 2000)},
+		Amount:      amount,
+		Now:         time.Now(),
+	}
+}
+
+func TestConfiguredRules(t *testing.T) {
+	require.Empty(t, ConfiguredRules(nil, util.Config{}))
+
+	rules := ConfiguredRules(nil, util.Config{
+		RiskNewPayeeAmount: 100,
+		RiskVelocityCount:  5,
+		RiskVelocityWindow: time.Hour,
+		RiskNewIPAmount:    100,
+		RiskNewIPWindow:    time.Hour,
+	})
+	require.Len(t, rules, 3)
+}
+
+func TestPayeeRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	rule := NewPayeeRule(store, 100)
+
+	small := randomTransfer(99)
+	result, err := rule.Evaluate(context.Background(), small)
+	require.NoError(t, err)
+	require.Equal(t, Allow, result.Decision)
+
+	transfer := randomTransfer(100)
+	arg := db.CountTransfersBetweenParams{FromAccountID: transfer.FromAccount.ID, ToAccountID: transfer.ToAccount.ID}
+	store.EXPECT().CountTransfersBetween(gomock.Any(), gomock.Eq(arg)).Times(1).Return(int64(0), nil)
+	result, err = rule.Evaluate(context.Background(), transfer)
+	require.NoError(t, err)
+	require.Equal(t, Review, result.Decision)
+	require.Equal(t, newPayeeScore, result.Score)
+
+	store.EXPECT().CountTransfersBetween(gomock.Any(), gomock.Eq(arg)).Times(1).Return(int64(2), nil)
+	result, err = rule.Evaluate(context.Background(), transfer)
+	require.NoError(t, err)
+	require.Equal(t, Allow, result.Decision)
+}
+
+func TestVelocityRule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		count    int64
+		decision Decision
+	}{
+		{name: "UnderLimit", count: 2, decision: Allow},
+		{name: "AtLimit", count: 4, decision: Allow},
+		{name: "OverLimit", count: 5, decision: Review},
+		{name: "Spike", count: 10, decision: Deny},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			rule := NewVelocityRule(store, 5, time.Hour)
+
+			transfer := randomTransfer(100)
+			store.EXPECT().CountOutgoingTransfersSince(gomock.Any(), gomock.Eq(db.CountOutgoingTransfersSinceParams{
+				FromAccountID: transfer.FromAccount.ID,
+				Since:         transfer.Now.Add(-time.Hour),
+			})).Times(1).Return(tc.count, nil)
+
+			result, err := rule.Evaluate(context.Background(), transfer)
+			require.NoError(t, err)
+			require.Equal(t, tc.decision, result.Decision)
+		})
+	}
+}
+
+func TestLoginIPRule(t *testing.T) {
+	transfer := randomTransfer(500)
+	recentSession := db.Session{
+		Username:  transfer.Username,
+		ClientIp:  "203.0.113.7",
+		CreatedAt: transfer.Now.Add(-10 * time.Minute),
+	}
+
+	testCases := []struct {
+		name       string
+		transfer   Transfer
+		buildStubs func(store *mockdb.MockStore)
+		decision   Decision
+	}{
+		{
+			name:       "SmallAmount",
+			transfer:   randomTransfer(499),
+			buildStubs: func(store *mockdb.MockStore) {},
+			decision:   Allow,
+		},
+		{
+			name:     "NoSession",
+			transfer: transfer,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLatestSession(gomock.Any(), gomock.Eq(transfer.Username)).Times(1).Return(db.Session{}, sql.ErrNoRows)
+			},
+			decision: Allow,
+		},
+		{
+			name:     "OldLogin",
+			transfer: transfer,
+			buildStubs: func(store *mockdb.MockStore) {
+				session := recentSession
+				session.CreatedAt = transfer.Now.Add(-2 * time.Hour)
+				store.EXPECT().GetLatestSession(gomock.Any(), gomock.Eq(transfer.Username)).Times(1).Return(session, nil)
+			},
+			decision: Allow,
+		},
+		{
+			name:     "FirstLogin",
+			transfer: transfer,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLatestSession(gomock.Any(), gomock.Eq(transfer.Username)).Times(1).Return(recentSession, nil)
+				store.EXPECT().CountSessionsBefore(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+			},
+			decision: Allow,
+		},
+		{
+			name:     "KnownIP",
+			transfer: transfer,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLatestSession(gomock.Any(), gomock.Eq(transfer.Username)).Times(1).Return(recentSession, nil)
+				store.EXPECT().CountSessionsBefore(gomock.Any(), gomock.Any()).Times(1).Return(int64(3), nil)
+				store.EXPECT().CountSessionsFromIP(gomock.Any(), gomock.Eq(db.CountSessionsFromIPParams{
+					Username: transfer.Username,
+					ClientIp: recentSession.ClientIp,
+					Before:   recentSession.CreatedAt,
+				})).Times(1).Return(int64(1), nil)
+			},
+			decision: Allow,
+		},
+		{
+			name:     "NewIP",
+			transfer: transfer,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLatestSession(gomock.Any(), gomock.Eq(transfer.Username)).Times(1).Return(recentSession, nil)
+				store.EXPECT().CountSessionsBefore(gomock.Any(), gomock.Any()).Times(1).Return(int64(3), nil)
+				store.EXPECT().CountSessionsFromIP(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+			},
+			decision: Review,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			result, err := NewLoginIPRule(store, 500, time.Hour).Evaluate(context.Background(), tc.transfer)
+			require.NoError(t, err)
+			require.Equal(t, tc.decision, result.Decision)
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"github.com/lib/pq"
+)
+
+// Bounds of the page size when listing accounts
+const (
+	minAccountsPageSize = 5
+	maxAccountsPageSize = 10
+)
+
+type CreateAccountParams struct {
+	Currency string
+	// Product defaults to a checking account
+	Product string
+}
+
+// CreateAccount opens an account of the caller
+func (service *Service) CreateAccount(ctx context.Context, caller Caller, arg CreateAccountParams) (db.Account, error) {
+	if arg.Product == "" {
+		arg.Product = util.CheckingProduct
+	}
+
+	if violations := validateCreateAccountParams(arg); violations != nil {
+		return db.Account{}, invalidArgumentError(violations)
+	}
+
+	result, err := service.store.CreateAccountTx(ctx, db.CreateAccountTxParams{
+		CreateAccountParams: db.CreateAccountParams{
+			Owner:    caller.Username,
+			Currency: arg.Currency,
+			Balance:  0,
+			Product:  arg.Product,
+		},
+		Audit: caller.audit(),
+	})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			if pqErr.Code.Name() == "foreign_key_violation" {
+				return db.Account{}, newError(NotFound, "user %s not found", caller.Username)
+			}
+		}
+		return db.Account{}, internalError(err, "failed to create account")
+	}
+
+	return result.Account, nil
+}
+
+func validateCreateAccountParams(arg CreateAccountParams) (violations []FieldViolation) {
+	if err := val.ValidateCurrency(arg.Currency); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if !util.IsSupportedAccountProduct(arg.Product) {
+		violations = append(violations, fieldViolation("product", fmt.Errorf("unsupported product %q", arg.Product)))
+	}
+
+	return
+}
+
+// GetAccount returns an account the caller is a member of
+func (service *Service) GetAccount(ctx context.Context, caller Caller, accountID int64) (db.Account, error) {
+	if err := val.ValidateID(accountID); err != nil {
+		return db.Account{}, invalidArgumentError([]FieldViolation{fieldViolation("id", err)})
+	}
+
+	return service.GetMemberAccount(ctx, accountID, caller.Username, db.AccountPermissionView)
+}
+
+// ListAccounts returns a page of the accounts the caller owns, counting the pages from 1
+func (service *Service) ListAccounts(ctx context.Context, caller Caller, pageID, pageSize int32) ([]db.Account, error) {
+	var violations []FieldViolation
+	if pageID < 1 {
+		violations = append(violations, fieldViolation("page_id", errors.New("must be a positive number")))
+	}
+	if pageSize < minAccountsPageSize || pageSize > maxAccountsPageSize {
+		violations = append(violations, fieldViolation("page_size",
+			fmt.Errorf("must be from %d to %d", minAccountsPageSize, maxAccountsPageSize)))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	accounts, err := service.store.ListAccounts(ctx, db.ListAccountsParams{
+		Owner:  caller.Username,
+		Limit:  pageSize,
+		Offset: (pageID - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, internalError(err, "failed to list accounts")
+	}
+
+	return accounts, nil
+}
+
+// AddAccountBalance deposits amount into an account the caller can spend amount from
+func (service *Service) AddAccountBalance(ctx context.Context, caller Caller, accountID, amount int64) (db.Account, error) {
+	var violations []FieldViolation
+	if err := val.ValidateID(accountID); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	if err := val.ValidateAmount(amount); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+	if violations != nil {
+		return db.Account{}, invalidArgumentError(violations)
+	}
+
+	account, err := service.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return db.Account{}, newError(NotFound, "account %d not found", accountID)
+		}
+		return db.Account{}, internalError(err, "failed to get account")
+	}
+
+	// depositing needs the right to move the amount, so that the spending limit of a role caps the deposits too
+	if _, err := service.AuthorizeAccount(ctx, account, caller.Username, db.AccountPermissionSpend, amount); err != nil {
+		return db.Account{}, err
+	}
+
+	result, err := service.store.AddAccountBalanceTx(ctx, db.AddAccountBalanceTxParams{
+		AddAccountBalanceParams: db.AddAccountBalanceParams{
+			ID:     accountID,
+			Amount: amount,
+		},
+		Audit: caller.audit(),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrAccountFrozen) {
+			return db.Account{}, newError(PermissionDenied, "account %d is frozen", accountID)
+		}
+		return db.Account{}, internalError(err, "failed to add account balance")
+	}
+
+	return result.Account, nil
+}
+
+// DeleteAccount deletes an account the caller can manage
+func (service *Service) DeleteAccount(ctx context.Context, caller Caller, accountID int64) error {
+	if err := val.ValidateID(accountID); err != nil {
+		return invalidArgumentError([]FieldViolation{fieldViolation("id", err)})
+	}
+
+	if _, err := service.GetMemberAccount(ctx, accountID, caller.Username, db.AccountPermissionManage); err != nil {
+		return err
+	}
+
+	_, err := service.store.DeleteAccountTx(ctx, db.DeleteAccountTxParams{
+		AccountID: accountID,
+		Audit:     caller.audit(),
+	})
+	if err != nil {
+		return internalError(err, "failed to delete account")
+	}
+
+	return nil
+}
+
+// AuthorizeAccount checks that the user is a member of an account whose role grants permission, for amount when spending
+func (service *Service) AuthorizeAccount(
+	ctx context.Context, account db.Account, username, permission string, amount int64,
+) (db.AccountMember, error) {
+	member, err := db.AuthorizeAccount(ctx, service.store, account, username, permission, amount)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrNotAccountMember):
+			return member, newError(PermissionDenied, "account %d doesn't belong to the authenticated user", account.ID)
+		case errors.Is(err, db.ErrAccountPermission):
+			return member, newError(PermissionDenied, "%s", err)
+		}
+		return member, internalError(err, "failed to get account member")
+	}
+
+	return member, nil
+}
+
+// GetMemberAccount returns an account when the user is a member whose role grants permission on it
+func (service *Service) GetMemberAccount(ctx context.Context, accountID int64, username, permission string) (db.Account, error) {
+	account, err := service.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return account, newError(NotFound, "account %d not found", accountID)
+		}
+		return account, internalError(err, "failed to get account")
+	}
+
+	if _, err := service.AuthorizeAccount(ctx, account, username, permission, 0); err != nil {
+		return account, err
+	}
+
+	return account, nil
+}
+
+// RequiresApproval reports whether a transfer of amount from account must wait for the approval of another member
+// of the organization owning the account
+func (service *Service) RequiresApproval(ctx context.Context, account db.Account, amount int64) (bool, error) {
+	required, err := db.RequiresApproval(ctx, service.store, account, amount)
+	if err != nil {
+		return false, internalError(err, "failed to get organization")
+	}
+
+	return required, nil
+}
@@ -0,0 +1,481 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	mockdb "github.com/MathPeixoto/go-financial-system/db/mock"
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccount(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	testCases := []struct {
+		name       string
+		accountID  int64
+		caller     string
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, got db.Account, err error)
+	}{
+		{
+			name:      "OK",
+			accountID: account.ID,
+			caller:    user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+			},
+			check: func(t *testing.T, got db.Account, err error) {
+				require.NoError(t, err)
+				require.Equal(t, account, got)
+			},
+		},
+		{
+			name:       "InvalidID",
+			accountID:  -1,
+			caller:     user.Username,
+			buildStubs: func(store *mockdb.MockStore) {},
+			check: func(t *testing.T, got db.Account, err error) {
+				requireViolations(t, err, "id")
+			},
+		},
+		{
+			name:      "NotFound",
+			accountID: account.ID,
+			caller:    user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, got db.Account, err error) {
+				requireKind(t, err, NotFound)
+			},
+		},
+		{
+			name:      "NotMember",
+			accountID: account.ID,
+			caller:    "wrong_user",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Eq(db.GetAccountMemberParams{
+					AccountID: account.ID,
+					Username:  "wrong_user",
+				})).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, got db.Account, err error) {
+				requireKind(t, err, PermissionDenied)
+			},
+		},
+		{
+			name:      "ViewerMember",
+			accountID: account.ID,
+			caller:    "viewer",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{
+					AccountID: account.ID,
+					Username:  "viewer",
+					Role:      db.AccountRoleViewer,
+					Status:    db.AccountMemberActive,
+				}, nil)
+			},
+			check: func(t *testing.T, got db.Account, err error) {
+				require.NoError(t, err)
+				require.Equal(t, account, got)
+			},
+		},
+		{
+			name:      "InternalError",
+			accountID: account.ID,
+			caller:    user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrConnDone)
+			},
+			check: func(t *testing.T, got db.Account, err error) {
+				requireKind(t, err, Internal)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(gomock.NewController(t))
+			testCase.buildStubs(store)
+
+			got, err := newTestService(t, store).GetAccount(context.Background(), Caller{Username: testCase.caller}, testCase.accountID)
+			testCase.check(t, got, err)
+		})
+	}
+}
+
+func TestCreateAccount(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	testCases := []struct {
+		name       string
+		arg        CreateAccountParams
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, got db.Account, err error)
+	}{
+		{
+			name: "OK",
+			arg:  CreateAccountParams{Currency: account.Currency},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateAccountTxParams{
+					CreateAccountParams: db.CreateAccountParams{
+						Owner:    account.Owner,
+						Balance:  0,
+						Currency: account.Currency,
+						Product:  util.CheckingProduct,
+					},
+					Audit: db.AuditParams{Actor: user.Username},
+				}
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.CreateAccountTxResult{Account: account}, nil)
+			},
+			check: func(t *testing.T, got db.Account, err error) {
+				require.NoError(t, err)
+				require.Equal(t, account, got)
+			},
+		},
+		{
+			name: "Savings",
+			arg:  CreateAccountParams{Currency: account.Currency, Product: util.SavingsProduct},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateAccountTxParams{
+					CreateAccountParams: db.CreateAccountParams{
+						Owner:    account.Owner,
+						Balance:  0,
+						Currency: account.Currency,
+						Product:  util.SavingsProduct,
+					},
+					Audit: db.AuditParams{Actor: user.Username},
+				}
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.CreateAccountTxResult{Account: account}, nil)
+			},
+			check: func(t *testing.T, got db.Account, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:       "InvalidProduct",
+			arg:        CreateAccountParams{Currency: account.Currency, Product: "invalid-product"},
+			buildStubs: func(store *mockdb.MockStore) {},
+			check: func(t *testing.T, got db.Account, err error) {
+				requireViolations(t, err, "product")
+			},
+		},
+		{
+			name:       "InvalidCurrency",
+			arg:        CreateAccountParams{Currency: "invalid-currency"},
+			buildStubs: func(store *mockdb.MockStore) {},
+			check: func(t *testing.T, got db.Account, err error) {
+				requireViolations(t, err, "currency")
+			},
+		},
+		{
+			name: "UserNotFound",
+			arg:  CreateAccountParams{Currency: account.Currency},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.CreateAccountTxResult{}, &pq.Error{Code: "23503"})
+			},
+			check: func(t *testing.T, got db.Account, err error) {
+				requireKind(t, err, NotFound)
+			},
+		},
+		{
+			name: "InternalError",
+			arg:  CreateAccountParams{Currency: account.Currency},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.CreateAccountTxResult{}, sql.ErrConnDone)
+			},
+			check: func(t *testing.T, got db.Account, err error) {
+				requireKind(t, err, Internal)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(gomock.NewController(t))
+			testCase.buildStubs(store)
+
+			got, err := newTestService(t, store).CreateAccount(context.Background(), Caller{Username: user.Username}, testCase.arg)
+			testCase.check(t, got, err)
+		})
+	}
+}
+
+func TestListAccounts(t *testing.T) {
+	user, _ := randomUser(t)
+
+	n := 5
+	accounts := make([]db.Account, n)
+	for i := 0; i < n; i++ {
+		accounts[i] = randomAccount(user.Username)
+	}
+
+	testCases := []struct {
+		name       string
+		pageID     int32
+		pageSize   int32
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, got []db.Account, err error)
+	}{
+		{
+			name:     "OK",
+			pageID:   2,
+			pageSize: int32(n),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListAccountsParams{
+					Owner:  user.Username,
+					Limit:  int32(n),
+					Offset: int32(n),
+				}
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Eq(arg)).Times(1).Return(accounts, nil)
+			},
+			check: func(t *testing.T, got []db.Account, err error) {
+				require.NoError(t, err)
+				require.Equal(t, accounts, got)
+			},
+		},
+		{
+			name:     "InvalidPage",
+			pageID:   0,
+			pageSize: 20,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, got []db.Account, err error) {
+				requireViolations(t, err, "page_id", "page_size")
+			},
+		},
+		{
+			name:     "InternalError",
+			pageID:   1,
+			pageSize: int32(n),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{}, sql.ErrConnDone)
+			},
+			check: func(t *testing.T, got []db.Account, err error) {
+				requireKind(t, err, Internal)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(gomock.NewController(t))
+			testCase.buildStubs(store)
+
+			got, err := newTestService(t, store).ListAccounts(context.Background(), Caller{Username: user.Username},
+				testCase.pageID, testCase.pageSize)
+			testCase.check(t, got, err)
+		})
+	}
+}
+
+func TestAddAccountBalance(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	amount := util.RandomMoney()
+
+	arg := db.AddAccountBalanceTxParams{
+		AddAccountBalanceParams: db.AddAccountBalanceParams{
+			ID:     account.ID,
+			Amount: amount,
+		},
+		Audit: db.AuditParams{Actor: user.Username},
+	}
+
+	updatedAccount := account
+	updatedAccount.Balance += amount
+
+	sharedAccount := randomAccount("other_user")
+	sharedAccount.ID = account.ID + 1
+
+	testCases := []struct {
+		name       string
+		accountID  int64
+		amount     int64
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, got db.Account, err error)
+	}{
+		{
+			name:      "OK",
+			accountID: account.ID,
+			amount:    amount,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().AddAccountBalanceTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.AddAccountBalanceTxResult{Account: updatedAccount}, nil)
+			},
+			check: func(t *testing.T, got db.Account, err error) {
+				require.NoError(t, err)
+				require.Equal(t, updatedAccount, got)
+			},
+		},
+		{
+			name:       "InvalidID",
+			accountID:  -1,
+			amount:     amount,
+			buildStubs: func(store *mockdb.MockStore) {},
+			check: func(t *testing.T, got db.Account, err error) {
+				requireViolations(t, err, "id")
+			},
+		},
+		{
+			name:       "InvalidAmount",
+			accountID:  account.ID,
+			amount:     -1,
+			buildStubs: func(store *mockdb.MockStore) {},
+			check: func(t *testing.T, got db.Account, err error) {
+				requireViolations(t, err, "amount")
+			},
+		},
+		{
+			name:      "OverSpendLimit",
+			accountID: sharedAccount.ID,
+			amount:    amount,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(sharedAccount.ID)).Times(1).Return(sharedAccount, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{
+					AccountID:  sharedAccount.ID,
+					Username:   user.Username,
+					Role:       db.AccountRoleSpender,
+					Status:     db.AccountMemberActive,
+					SpendLimit: sql.NullInt64{Int64: amount - 1, Valid: true},
+				}, nil)
+				store.EXPECT().AddAccountBalanceTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, got db.Account, err error) {
+				requireKind(t, err, PermissionDenied)
+			},
+		},
+		{
+			name:      "FrozenAccount",
+			accountID: account.ID,
+			amount:    amount,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().AddAccountBalanceTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.AddAccountBalanceTxResult{}, fmt.Errorf("account %d: %w", account.ID, db.ErrAccountFrozen))
+			},
+			check: func(t *testing.T, got db.Account, err error) {
+				requireKind(t, err, PermissionDenied)
+			},
+		},
+		{
+			name:      "InternalError",
+			accountID: account.ID,
+			amount:    amount,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().AddAccountBalanceTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.AddAccountBalanceTxResult{}, sql.ErrConnDone)
+			},
+			check: func(t *testing.T, got db.Account, err error) {
+				requireKind(t, err, Internal)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(gomock.NewController(t))
+			testCase.buildStubs(store)
+
+			got, err := newTestService(t, store).AddAccountBalance(context.Background(), Caller{Username: user.Username},
+				testCase.accountID, testCase.amount)
+			testCase.check(t, got, err)
+		})
+	}
+}
+
+func TestDeleteAccount(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	testCases := []struct {
+		name       string
+		accountID  int64
+		caller     string
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, err error)
+	}{
+		{
+			name:      "OK",
+			accountID: account.ID,
+			caller:    user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().DeleteAccountTx(gomock.Any(), gomock.Eq(db.DeleteAccountTxParams{
+					AccountID: account.ID,
+					Audit:     db.AuditParams{Actor: user.Username},
+				})).Times(1).Return(db.DeleteAccountTxResult{Account: account}, nil)
+			},
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:       "InvalidID",
+			accountID:  -1,
+			caller:     user.Username,
+			buildStubs: func(store *mockdb.MockStore) {},
+			check: func(t *testing.T, err error) {
+				requireViolations(t, err, "id")
+			},
+		},
+		{
+			name:      "CoOwner",
+			accountID: account.ID,
+			caller:    "co_owner",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{
+					AccountID: account.ID,
+					Username:  "co_owner",
+					Role:      db.AccountRoleCoOwner,
+					Status:    db.AccountMemberActive,
+				}, nil)
+				store.EXPECT().DeleteAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, err error) {
+				requireKind(t, err, PermissionDenied)
+			},
+		},
+		{
+			name:      "InternalError",
+			accountID: account.ID,
+			caller:    user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().DeleteAccountTx(gomock.Any(), gomock.Any()).Times(1).Return(db.DeleteAccountTxResult{}, sql.ErrConnDone)
+			},
+			check: func(t *testing.T, err error) {
+				requireKind(t, err, Internal)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(gomock.NewController(t))
+			testCase.buildStubs(store)
+
+			err := newTestService(t, store).DeleteAccount(context.Background(), Caller{Username: testCase.caller}, testCase.accountID)
+			testCase.check(t, err)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Kind classifies the errors of the service, which the transports map to their status codes
+type Kind int
+
+const (
+	// Internal is an unexpected failure, such as an unavailable database
+	Internal Kind = iota
+	// InvalidArgument rejects a request whose fields are invalid
+	InvalidArgument
+	// NotFound reports a missing resource, or one the caller cannot know about
+	NotFound
+	// AlreadyExists rejects creating a resource that conflicts with an existing one
+	AlreadyExists
+	// Unauthenticated rejects a caller whose credentials are missing or invalid
+	Unauthenticated
+	// PermissionDenied rejects a caller that is not allowed to perform an operation
+	PermissionDenied
+	// FailedPrecondition rejects an operation the current state of a resource doesn't allow
+	FailedPrecondition
+	// LimitExceeded rejects an operation exceeding a limit of an account
+	LimitExceeded
+)
+
+func (k Kind) String() string {
+	switch k {
+	case InvalidArgument:
+		return "invalid argument"
+	case NotFound:
+		return "not found"
+	case AlreadyExists:
+		return "already exists"
+	case Unauthenticated:
+		return "unauthenticated"
+	case PermissionDenied:
+		return "permission denied"
+	case FailedPrecondition:
+		return "failed precondition"
+	case LimitExceeded:
+		return "limit exceeded"
+	}
+	return "internal"
+}
+
+// FieldViolation describes why a field of a request is invalid
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// Error is an error of the service, whose kind decides how the transports report it
+type Error struct {
+	Kind    Kind
+	Message string
+	// Violations lists the invalid fields of an InvalidArgument error
+	Violations []FieldViolation
+	// Err is the underlying error, such as the database error of an Internal one
+	Err error
+}
+
+func (e *Error) Error() string {
+	if len(e.Violations) == 0 {
+		return e.Message
+	}
+
+	violations := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		violations[i] = violation.Field + ": " + violation.Description
+	}
+	return e.Message + ": " + strings.Join(violations, "; ")
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// KindOf returns the kind of an error of the service, Internal for any other error
+func KindOf(err error) Kind {
+	var serviceErr *Error
+	if errors.As(err, &serviceErr) {
+		return serviceErr.Kind
+	}
+	return Internal
+}
+
+func newError(kind Kind, format string, args ...any) error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+// internalError wraps an unexpected failure, with the operation that failed
+func internalError(err error, operation string) error {
+	return &Error{Kind: Internal, Message: fmt.Sprintf("%s: %s", operation, err), Err: err}
+}
+
+func invalidArgumentError(violations []FieldViolation) error {
+	return &Error{Kind: InvalidArgument, Message: "invalid argument", Violations: violations}
+}
+
+func fieldViolation(field string, err error) FieldViolation {
+	return FieldViolation{Field: field, Description: err.Error()}
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	err := invalidArgumentError([]FieldViolation{
+		{Field: "username", Description: "must not be empty"},
+		{Field: "email", Description: "invalid email"},
+	})
+	require.EqualError(t, err, "invalid argument: username: must not be empty; email: invalid email")
+	require.Equal(t, InvalidArgument, KindOf(err))
+
+	err = internalError(sql.ErrConnDone, "failed to get account")
+	require.EqualError(t, err, "failed to get account: "+sql.ErrConnDone.Error())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.Equal(t, Internal, KindOf(err))
+
+	wrapped := fmt.Errorf("cannot transfer: %w", newError(NotFound, "account %d not found", 1))
+	require.Equal(t, NotFound, KindOf(wrapped))
+
+	require.Equal(t, Internal, KindOf(sql.ErrConnDone))
+}
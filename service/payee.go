@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+)
+
+// GetOwnedPayee returns a payee of the address book of the user.
+// Payees of other users are reported as not found, so that their IDs cannot be probed.
+func (service *Service) GetOwnedPayee(ctx context.Context, payeeID int64, username string) (db.Payee, error) {
+	payee, err := service.store.GetPayee(ctx, payeeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return payee, newError(NotFound, "payee %d not found", payeeID)
+		}
+		return payee, internalError(err, "failed to get payee")
+	}
+
+	if payee.Owner != username {
+		return payee, newError(NotFound, "payee %d not found", payeeID)
+	}
+
+	return payee, nil
+}
+
+// CheckPayeeCooldown refuses a transfer of the cooldown amount or more to a payee added within the cooldown,
+// until a transfer to the payee succeeds
+func (service *Service) CheckPayeeCooldown(payee db.Payee, amount int64, now time.Time) error {
+	if service.config.PayeeCooldown <= 0 || payee.IsVerified || amount < service.config.PayeeCooldownAmount {
+		return nil
+	}
+
+	if endsAt := payee.CreatedAt.Add(service.config.PayeeCooldown); now.Before(endsAt) {
+		return newError(FailedPrecondition,
+			"payee %d was added recently and cannot receive %d or more until %s",
+			payee.ID, service.config.PayeeCooldownAmount, endsAt.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
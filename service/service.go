@@ -0,0 +1,46 @@
+// Package service implements the operations on the users, their sessions, accounts and transfers,
+// shared by the Gin API and the gRPC server which only adapt them to their transport.
+package service
+
+import (
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/risk"
+	"github.com/MathPeixoto/go-financial-system/token"
+	"github.com/MathPeixoto/go-financial-system/util"
+)
+
+// Service validates and authorizes the requests of the callers and performs them on the store
+type Service struct {
+	config     util.Config
+	store      db.Store
+	tokenMaker token.Maker
+	riskEngine *risk.Engine
+}
+
+// New creates a Service issuing its tokens with tokenMaker
+func New(config util.Config, store db.Store, tokenMaker token.Maker) *Service {
+	return &Service{
+		config:     config,
+		store:      store,
+		tokenMaker: tokenMaker,
+		riskEngine: risk.NewEngine(config.RiskDenyScore, risk.ConfiguredRules(store, config)...),
+	}
+}
+
+// Caller is the user performing an operation, empty when unauthenticated, and the client the request comes from
+type Caller struct {
+	Username  string
+	ClientIP  string
+	UserAgent string
+	RequestID string
+}
+
+// audit returns the audit log parameters of an operation performed by the caller
+func (caller Caller) audit() db.AuditParams {
+	return db.AuditParams{
+		Actor:     caller.Username,
+		ClientIP:  caller.ClientIP,
+		UserAgent: caller.UserAgent,
+		RequestID: caller.RequestID,
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"github.com/google/uuid"
+)
+
+// Session is a login session of a user, with the tokens issued for it
+type Session struct {
+	ID                    uuid.UUID
+	User                  db.User
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+// AccessToken is an access token renewed from the refresh token of a session
+type AccessToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+type LoginUserParams struct {
+	Username string
+	Password string
+}
+
+// LoginUser checks the password of a user and opens a session for it
+func (service *Service) LoginUser(ctx context.Context, caller Caller, arg LoginUserParams) (Session, error) {
+	if violations := validateLoginUserParams(arg); violations != nil {
+		return Session{}, invalidArgumentError(violations)
+	}
+
+	user, err := service.store.GetUser(ctx, arg.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Session{}, newError(NotFound, "user not found")
+		}
+		return Session{}, internalError(err, "failed to get the user")
+	}
+
+	if err := util.CheckPasswordHash(arg.Password, user.HashedPassword); err != nil {
+		return Session{}, newError(PermissionDenied, "permission denied")
+	}
+
+	return service.CreateSession(ctx, caller, user)
+}
+
+func validateLoginUserParams(arg LoginUserParams) (violations []FieldViolation) {
+	if err := val.ValidateUsername(arg.Username); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	if err := val.ValidatePassword(arg.Password); err != nil {
+		violations = append(violations, fieldViolation("password", err))
+	}
+
+	return
+}
+
+// CreateSession issues the access and refresh tokens of an authenticated user and records the session
+func (service *Service) CreateSession(ctx context.Context, caller Caller, user db.User) (Session, error) {
+	accessToken, accessPayload, err := service.tokenMaker.CreateToken(user.Username, service.config.AccessTokenDuration)
+	if err != nil {
+		return Session{}, internalError(err, "failed to create access token")
+	}
+
+	refreshToken, refreshPayload, err := service.tokenMaker.CreateToken(user.Username, service.config.RefreshTokenDuration)
+	if err != nil {
+		return Session{}, internalError(err, "failed to create refresh token")
+	}
+
+	caller.Username = user.Username
+	result, err := service.store.CreateSessionTx(ctx, db.CreateSessionTxParams{
+		CreateSessionParams: db.CreateSessionParams{
+			ID:           refreshPayload.ID,
+			Username:     user.Username,
+			RefreshToken: refreshToken,
+			UserAgent:    caller.UserAgent,
+			ClientIp:     caller.ClientIP,
+			IsBlocked:    false,
+			ExpiresAt:    refreshPayload.ExpiresAt,
+		},
+		Audit: caller.audit(),
+	})
+	if err != nil {
+		return Session{}, internalError(err, "failed to create user session")
+	}
+
+	return Session{
+		ID:                    result.Session.ID,
+		User:                  user,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessPayload.ExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: result.Session.ExpiresAt,
+	}, nil
+}
+
+// RenewAccessToken issues a new access token from the refresh token of a valid session
+func (service *Service) RenewAccessToken(ctx context.Context, refreshToken string) (AccessToken, error) {
+	if refreshToken == "" {
+		return AccessToken{}, invalidArgumentError([]FieldViolation{
+			fieldViolation("refresh_token", errors.New("must not be empty")),
+		})
+	}
+
+	refreshPayload, err := service.tokenMaker.VerifyToken(refreshToken)
+	if err != nil {
+		return AccessToken{}, newError(Unauthenticated, "invalid refresh token: %s", err)
+	}
+
+	session, err := service.store.GetSession(ctx, refreshPayload.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return AccessToken{}, newError(NotFound, "session not found")
+		}
+		return AccessToken{}, internalError(err, "failed to get session")
+	}
+
+	if session.IsBlocked {
+		return AccessToken{}, newError(Unauthenticated, "session %s is blocked", session.ID)
+	}
+
+	if session.Username != refreshPayload.Username {
+		return AccessToken{}, newError(Unauthenticated, "session %s does not belong to user %s", session.ID, refreshPayload.Username)
+	}
+
+	if session.RefreshToken != refreshToken {
+		return AccessToken{}, newError(Unauthenticated, "refresh token does not match")
+	}
+
+	if session.ExpiresAt.Before(time.Now()) {
+		return AccessToken{}, newError(Unauthenticated, "session is expired")
+	}
+
+	accessToken, accessPayload, err := service.tokenMaker.CreateToken(refreshPayload.Username, service.config.AccessTokenDuration)
+	if err != nil {
+		return AccessToken{}, internalError(err, "failed to create access token")
+	}
+
+	return AccessToken{
+		Token:     accessToken,
+		ExpiresAt: accessPayload.ExpiresAt,
+	}, nil
+}
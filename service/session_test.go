@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/MathPeixoto/go-financial-system/db/mock"
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginUser(t *testing.T) {
+	user, password := randomUser(t)
+	caller := Caller{ClientIP: "203.0.113.7", UserAgent: "test-agent", RequestID: "request-id"}
+
+	testCases := []struct {
+		name       string
+		arg        LoginUserParams
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, session Session, err error)
+	}{
+		{
+			name: "OK",
+			arg:  LoginUserParams{Username: user.Username, Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateSessionTxParams) (db.CreateSessionTxResult, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.Equal(t, caller.ClientIP, arg.ClientIp)
+						require.Equal(t, caller.UserAgent, arg.UserAgent)
+						require.Equal(t, db.AuditParams{
+							Actor:     user.Username,
+							ClientIP:  caller.ClientIP,
+							UserAgent: caller.UserAgent,
+							RequestID: caller.RequestID,
+						}, arg.Audit)
+
+						return db.CreateSessionTxResult{Session: db.Session{
+							ID:           arg.ID,
+							Username:     arg.Username,
+							RefreshToken: arg.RefreshToken,
+							ExpiresAt:    arg.ExpiresAt,
+						}}, nil
+					})
+			},
+			check: func(t *testing.T, session Session, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user, session.User)
+				require.NotEmpty(t, session.ID)
+				require.NotEmpty(t, session.AccessToken)
+				require.NotEmpty(t, session.RefreshToken)
+				require.True(t, session.RefreshTokenExpiresAt.After(session.AccessTokenExpiresAt))
+			},
+		},
+		{
+			name:       "InvalidPassword",
+			arg:        LoginUserParams{Username: user.Username, Password: "123"},
+			buildStubs: func(store *mockdb.MockStore) {},
+			check: func(t *testing.T, session Session, err error) {
+				requireViolations(t, err, "password")
+			},
+		},
+		{
+			name: "InternalError",
+			arg:  LoginUserParams{Username: user.Username, Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(db.User{}, sql.ErrConnDone)
+			},
+			check: func(t *testing.T, session Session, err error) {
+				requireKind(t, err, Internal)
+			},
+		},
+		{
+			name: "NotFound",
+			arg:  LoginUserParams{Username: user.Username, Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(db.User{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, session Session, err error) {
+				requireKind(t, err, NotFound)
+			},
+		},
+		{
+			name: "WrongPassword",
+			arg:  LoginUserParams{Username: user.Username, Password: "wrong password"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, session Session, err error) {
+				requireKind(t, err, PermissionDenied)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(gomock.NewController(t))
+			testCase.buildStubs(store)
+
+			session, err := newTestService(t, store).LoginUser(context.Background(), caller, testCase.arg)
+			testCase.check(t, session, err)
+		})
+	}
+}
+
+func TestRenewAccessToken(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name         string
+		refreshToken func(t *testing.T, service *Service) string
+		buildStubs   func(store *mockdb.MockStore, refreshToken string)
+		check        func(t *testing.T, accessToken AccessToken, err error)
+	}{
+		{
+			name:         "OK",
+			refreshToken: validRefreshToken(user.Username, time.Hour),
+			buildStubs: func(store *mockdb.MockStore, refreshToken string) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.Session{Username: user.Username, RefreshToken: refreshToken, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+			},
+			check: func(t *testing.T, accessToken AccessToken, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, accessToken.Token)
+				require.WithinDuration(t, time.Now().Add(time.Minute), accessToken.ExpiresAt, time.Second)
+			},
+		},
+		{
+			name: "Empty",
+			refreshToken: func(t *testing.T, service *Service) string {
+				return ""
+			},
+			buildStubs: func(store *mockdb.MockStore, refreshToken string) {},
+			check: func(t *testing.T, accessToken AccessToken, err error) {
+				requireViolations(t, err, "refresh_token")
+			},
+		},
+		{
+			name:         "ExpiredToken",
+			refreshToken: validRefreshToken(user.Username, -time.Minute),
+			buildStubs:   func(store *mockdb.MockStore, refreshToken string) {},
+			check: func(t *testing.T, accessToken AccessToken, err error) {
+				requireKind(t, err, Unauthenticated)
+			},
+		},
+		{
+			name:         "SessionNotFound",
+			refreshToken: validRefreshToken(user.Username, time.Hour),
+			buildStubs: func(store *mockdb.MockStore, refreshToken string) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(1).Return(db.Session{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, accessToken AccessToken, err error) {
+				requireKind(t, err, NotFound)
+			},
+		},
+		{
+			name:         "BlockedSession",
+			refreshToken: validRefreshToken(user.Username, time.Hour),
+			buildStubs: func(store *mockdb.MockStore, refreshToken string) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.Session{Username: user.Username, RefreshToken: refreshToken, IsBlocked: true, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+			},
+			check: func(t *testing.T, accessToken AccessToken, err error) {
+				requireKind(t, err, Unauthenticated)
+			},
+		},
+		{
+			name:         "MismatchedToken",
+			refreshToken: validRefreshToken(user.Username, time.Hour),
+			buildStubs: func(store *mockdb.MockStore, refreshToken string) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.Session{Username: user.Username, RefreshToken: "other token", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+			},
+			check: func(t *testing.T, accessToken AccessToken, err error) {
+				requireKind(t, err, Unauthenticated)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(gomock.NewController(t))
+			service := newTestService(t, store)
+
+			refreshToken := testCase.refreshToken(t, service)
+			testCase.buildStubs(store, refreshToken)
+
+			accessToken, err := service.RenewAccessToken(context.Background(), refreshToken)
+			testCase.check(t, accessToken, err)
+		})
+	}
+}
+
+func validRefreshToken(username string, duration time.Duration) func(t *testing.T, service *Service) string {
+	return func(t *testing.T, service *Service) string {
+		refreshToken, _, err := service.tokenMaker.CreateToken(username, duration)
+		require.NoError(t, err)
+		return refreshToken
+	}
+}
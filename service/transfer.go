@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/risk"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/MathPeixoto/go-financial-system/val"
+)
+
+// CreateTransferParams describes a transfer to either an account or a payee of the caller
+type CreateTransferParams struct {
+	FromAccountID int64
+	ToAccountID   int64
+	PayeeID       int64
+	Amount        int64
+	Currency      string
+}
+
+// CreateTransferResult is the executed transfer, or the approval or the review the transfer waits for
+type CreateTransferResult struct {
+	Transfer *db.TransferTxResult
+	// TransferApprovalID is set when the transfer waits for the approval of another member of the organization
+	// owning the from account
+	TransferApprovalID int64
+	// TransferReviewID is set when the risk screening holds the transfer for review
+	TransferReviewID int64
+}
+
+// CreateTransfer moves money from an account the caller can spend from, unless the transfer needs an approval
+// or the risk screening holds it for review
+func (service *Service) CreateTransfer(ctx context.Context, caller Caller, arg CreateTransferParams) (CreateTransferResult, error) {
+	if violations := validateCreateTransferParams(arg); violations != nil {
+		return CreateTransferResult{}, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := service.GetTransferAccount(ctx, arg.FromAccountID, arg.Currency)
+	if err != nil {
+		return CreateTransferResult{}, err
+	}
+
+	if _, err := service.AuthorizeAccount(ctx, fromAccount, caller.Username, db.AccountPermissionSpend, arg.Amount); err != nil {
+		return CreateTransferResult{}, err
+	}
+
+	now := time.Now()
+	toAccountID := arg.ToAccountID
+	if arg.PayeeID != 0 {
+		payee, err := service.GetOwnedPayee(ctx, arg.PayeeID, caller.Username)
+		if err != nil {
+			return CreateTransferResult{}, err
+		}
+
+		if payee.AccountID == fromAccount.ID {
+			return CreateTransferResult{}, invalidArgumentError([]FieldViolation{
+				fieldViolation("payee_id", fmt.Errorf("must not be the account of from_account_id")),
+			})
+		}
+
+		if err := service.CheckPayeeCooldown(payee, arg.Amount, now); err != nil {
+			return CreateTransferResult{}, err
+		}
+		toAccountID = payee.AccountID
+	}
+
+	toAccount, err := service.GetTransferAccount(ctx, toAccountID, arg.Currency)
+	if err != nil {
+		return CreateTransferResult{}, err
+	}
+
+	// the transfers waiting for approval are screened too, as they are executed without it once approved
+	assessment, err := service.riskEngine.Screen(ctx, risk.Transfer{
+		Username:    caller.Username,
+		FromAccount: fromAccount,
+		ToAccount:   toAccount,
+		Amount:      arg.Amount,
+		Now:         now,
+	})
+	if err != nil {
+		return CreateTransferResult{}, internalError(err, "failed to screen transfer")
+	}
+
+	if assessment.Decision == risk.Deny {
+		return CreateTransferResult{}, newError(PermissionDenied, "transfer denied by the risk screening")
+	}
+
+	requiresApproval, err := service.RequiresApproval(ctx, fromAccount, arg.Amount)
+	if err != nil {
+		return CreateTransferResult{}, err
+	}
+
+	if requiresApproval {
+		if assessment.Decision == risk.Review {
+			return CreateTransferResult{}, newError(PermissionDenied,
+				"transfer requires a review by the risk screening and can't wait for approval")
+		}
+
+		approval, err := service.store.CreateTransferApprovalTx(ctx, db.CreateTransferApprovalTxParams{
+			CreateTransferApprovalParams: db.CreateTransferApprovalParams{
+				OrganizationID: fromAccount.OrganizationID.Int64,
+				Initiator:      caller.Username,
+				FromAccountID:  fromAccount.ID,
+				ToAccountID:    toAccount.ID,
+				Amount:         arg.Amount,
+				Currency:       arg.Currency,
+			},
+			Audit: caller.audit(),
+		})
+		if err != nil {
+			return CreateTransferResult{}, internalError(err, "failed to request transfer approval")
+		}
+
+		return CreateTransferResult{TransferApprovalID: approval.TransferApproval.ID}, nil
+	}
+
+	if assessment.Decision == risk.Review {
+		review, err := service.store.CreateTransferReviewTx(ctx, db.CreateTransferReviewTxParams{
+			CreateTransferReviewParams: db.CreateTransferReviewParams{
+				Owner:         caller.Username,
+				FromAccountID: fromAccount.ID,
+				ToAccountID:   toAccount.ID,
+				Amount:        arg.Amount,
+				Currency:      arg.Currency,
+				Score:         int32(assessment.Score),
+				Reasons:       assessment.Reasons(),
+			},
+			Audit: caller.audit(),
+		})
+		if err != nil {
+			return CreateTransferResult{}, internalError(err, "failed to hold transfer for review")
+		}
+
+		return CreateTransferResult{TransferReviewID: review.TransferReview.ID}, nil
+	}
+
+	result, err := service.store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        arg.Amount,
+		PayeeID:       arg.PayeeID,
+		Audit:         caller.audit(),
+	})
+	if err != nil {
+		var limitErr *db.TransferLimitError
+		if errors.As(err, &limitErr) {
+			return CreateTransferResult{}, &Error{Kind: LimitExceeded, Message: limitErr.Error(), Err: limitErr}
+		}
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			return CreateTransferResult{}, &Error{Kind: FailedPrecondition, Message: err.Error(), Err: err}
+		}
+		return CreateTransferResult{}, internalError(err, "failed to create transfer")
+	}
+
+	return CreateTransferResult{Transfer: &result}, nil
+}
+
+// ScreenTransfer screens a transfer that can't be held for review, such as a scheduled transfer,
+// and rejects it unless the risk engine allows it
+func (service *Service) ScreenTransfer(
+	ctx context.Context, caller Caller, fromAccount, toAccount db.Account, amount int64,
+) error {
+	assessment, err := service.riskEngine.Screen(ctx, risk.Transfer{
+		Username:    caller.Username,
+		FromAccount: fromAccount,
+		ToAccount:   toAccount,
+		Amount:      amount,
+		Now:         time.Now(),
+	})
+	if err != nil {
+		return internalError(err, "failed to screen transfer")
+	}
+
+	switch assessment.Decision {
+	case risk.Deny:
+		return newError(PermissionDenied, "transfer denied by the risk screening")
+	case risk.Review:
+		return newError(PermissionDenied, "transfer requires a review by the risk screening, create it as a single transfer")
+	}
+
+	return nil
+}
+
+// ScreenHold screens a hold, which can't be held for review as it is captured later, and rejects it
+// unless the risk engine allows it. A hold to the account of a payee of the caller is subject to its cooldown.
+func (service *Service) ScreenHold(
+	ctx context.Context, caller Caller, fromAccount, toAccount db.Account, amount int64,
+) error {
+	payee, err := service.store.GetPayeeByAccount(ctx, db.GetPayeeByAccountParams{
+		Owner:     caller.Username,
+		AccountID: toAccount.ID,
+	})
+	switch {
+	case err == nil:
+		if err := service.CheckPayeeCooldown(payee, amount, time.Now()); err != nil {
+			return err
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return internalError(err, "failed to get payee")
+	}
+
+	return service.ScreenTransfer(ctx, caller, fromAccount, toAccount, amount)
+}
+
+// ScreenTransferBatch screens every item of a batch from fromAccount before it is executed, rejecting the batch
+// unless the risk engine allows all of them. The items to missing accounts are left for the batch to fail.
+func (service *Service) ScreenTransferBatch(
+	ctx context.Context, caller Caller, fromAccount db.Account, items []db.TransferBatchItemParams,
+) error {
+	toAccounts := make(map[int64]db.Account)
+	for i, item := range items {
+		toAccount, ok := toAccounts[item.ToAccountID]
+		if !ok {
+			var err error
+			toAccount, err = service.store.GetAccount(ctx, item.ToAccountID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return internalError(err, "failed to get account")
+			}
+			toAccounts[item.ToAccountID] = toAccount
+		}
+
+		if err := service.ScreenTransfer(ctx, caller, fromAccount, toAccount, item.Amount); err != nil {
+			var serviceErr *Error
+			if errors.As(err, &serviceErr) && serviceErr.Kind == PermissionDenied {
+				return newError(PermissionDenied, "items[%d]: %s", i, serviceErr.Message)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateCreateTransferParams(arg CreateTransferParams) (violations []FieldViolation) {
+	if err := val.ValidateID(arg.FromAccountID); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	switch {
+	case arg.ToAccountID != 0 && arg.PayeeID != 0:
+		violations = append(violations, fieldViolation("payee_id", fmt.Errorf("must not be set along with to_account_id")))
+	case arg.PayeeID != 0:
+		if err := val.ValidateID(arg.PayeeID); err != nil {
+			violations = append(violations, fieldViolation("payee_id", err))
+		}
+	default:
+		if err := val.ValidateID(arg.ToAccountID); err != nil {
+			violations = append(violations, fieldViolation("to_account_id", err))
+		} else if arg.ToAccountID == arg.FromAccountID {
+			violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("must differ from from_account_id")))
+		}
+	}
+
+	if err := val.ValidateAmount(arg.Amount); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := val.ValidateCurrency(arg.Currency); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	return
+}
+
+// GetTransfer returns a transfer from or to an account the caller can view
+func (service *Service) GetTransfer(ctx context.Context, caller Caller, transferID int64) (db.Transfer, error) {
+	if err := val.ValidateID(transferID); err != nil {
+		return db.Transfer{}, invalidArgumentError([]FieldViolation{fieldViolation("id", err)})
+	}
+
+	transfer, err := service.store.GetTransfer(ctx, transferID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return transfer, newError(NotFound, "transfer %d not found", transferID)
+		}
+		return transfer, internalError(err, "failed to get transfer")
+	}
+
+	for _, accountID := range []int64{transfer.FromAccountID, transfer.ToAccountID} {
+		allowed, err := service.canViewAccount(ctx, caller, accountID)
+		if err != nil {
+			return db.Transfer{}, err
+		}
+		if allowed {
+			return transfer, nil
+		}
+	}
+
+	// the transfers of other users are reported as missing, not to reveal which IDs exist
+	return db.Transfer{}, newError(NotFound, "transfer %d not found", transferID)
+}
+
+// canViewAccount reports whether the caller is a member allowed to view an account, a deleted account allowing nobody
+func (service *Service) canViewAccount(ctx context.Context, caller Caller, accountID int64) (bool, error) {
+	account, err := service.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, internalError(err, "failed to get account")
+	}
+
+	if _, err := db.AuthorizeAccount(ctx, service.store, account, caller.Username, db.AccountPermissionView, 0); err != nil {
+		if db.IsAccountAccessError(err) {
+			return false, nil
+		}
+		return false, internalError(err, "failed to get account member")
+	}
+
+	return true, nil
+}
+
+// GetTransferAccount returns an account taking part in a transfer, checking its currency and status
+func (service *Service) GetTransferAccount(ctx context.Context, accountID int64, currency string) (db.Account, error) {
+	account, err := service.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return account, newError(NotFound, "account %d not found", accountID)
+		}
+		return account, internalError(err, "failed to get account")
+	}
+
+	if account.Currency != currency {
+		return account, newError(InvalidArgument, "account %d currency mismatch: %s vs %s",
+			accountID, account.Currency, currency)
+	}
+
+	if account.Status == util.FrozenAccountStatus {
+		return account, newError(PermissionDenied, "account %d is frozen", accountID)
+	}
+
+	return account, nil
+}
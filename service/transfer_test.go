@@ -0,0 +1,827 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/MathPeixoto/go-financial-system/db/mock"
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/risk"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTransfer(t *testing.T) {
+	userOne, _ := randomUser(t)
+	userTwo, _ := randomUser(t)
+	userThree, _ := randomUser(t)
+
+	accountOne := brlAccount(userOne.Username)
+	accountTwo := brlAccount(userTwo.Username)
+	accountThree := usdAccount(userThree.Username)
+	accountTwo.ID = accountOne.ID + 1
+	accountThree.ID = accountOne.ID + 2
+
+	validParams := CreateTransferParams{
+		FromAccountID: accountOne.ID,
+		ToAccountID:   accountTwo.ID,
+		Amount:        1000,
+		Currency:      util.BRL,
+	}
+
+	transferTxParams := db.TransferTxParams{
+		FromAccountID: accountOne.ID,
+		ToAccountID:   accountTwo.ID,
+		Amount:        validParams.Amount,
+		Audit:         db.AuditParams{Actor: userOne.Username},
+	}
+	transferTxResult := createTransferTx(transferTxParams)
+
+	organizationAccount := accountOne
+	organizationAccount.OrganizationID = sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true}
+
+	payee := db.Payee{
+		ID:         util.RandomInt(1, 1000),
+		Owner:      userOne.Username,
+		AccountID:  accountTwo.ID,
+		IsVerified: true,
+	}
+
+	testCases := []struct {
+		name       string
+		caller     string
+		arg        CreateTransferParams
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, result CreateTransferResult, err error)
+	}{
+		{
+			name:   "OK",
+			caller: userOne.Username,
+			arg:    validParams,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountOne.ID)).Times(1).Return(accountOne, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountTwo.ID)).Times(1).Return(accountTwo, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Eq(transferTxParams)).Times(1).Return(transferTxResult, nil)
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, &transferTxResult, result.Transfer)
+				require.Zero(t, result.TransferApprovalID)
+				require.Zero(t, result.TransferReviewID)
+			},
+		},
+		{
+			name:   "OK - Payee",
+			caller: userOne.Username,
+			arg: CreateTransferParams{
+				FromAccountID: accountOne.ID,
+				PayeeID:       payee.ID,
+				Amount:        validParams.Amount,
+				Currency:      util.BRL,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := transferTxParams
+				arg.PayeeID = payee.ID
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountOne.ID)).Times(1).Return(accountOne, nil)
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(payee, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountTwo.ID)).Times(1).Return(accountTwo, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(transferTxResult, nil)
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result.Transfer)
+			},
+		},
+		{
+			name:   "Payee of another user",
+			caller: userTwo.Username,
+			arg: CreateTransferParams{
+				FromAccountID: accountTwo.ID,
+				PayeeID:       payee.ID,
+				Amount:        validParams.Amount,
+				Currency:      util.BRL,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountTwo.ID)).Times(1).Return(accountTwo, nil)
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(payee, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				requireKind(t, err, NotFound)
+			},
+		},
+		{
+			name:       "InvalidCurrency",
+			caller:     userOne.Username,
+			arg:        CreateTransferParams{FromAccountID: accountOne.ID, ToAccountID: accountTwo.ID, Amount: 1000, Currency: "ABC"},
+			buildStubs: func(store *mockdb.MockStore) {},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				requireViolations(t, err, "currency")
+			},
+		},
+		{
+			name:       "SameAccount",
+			caller:     userOne.Username,
+			arg:        CreateTransferParams{FromAccountID: accountOne.ID, ToAccountID: accountOne.ID, Amount: 1000, Currency: util.BRL},
+			buildStubs: func(store *mockdb.MockStore) {},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				requireViolations(t, err, "to_account_id")
+			},
+		},
+		{
+			name:   "FromAccountNotFound",
+			caller: userOne.Username,
+			arg:    validParams,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountOne.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				requireKind(t, err, NotFound)
+			},
+		},
+		{
+			name:   "ToAccountNotFound",
+			caller: userOne.Username,
+			arg:    validParams,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountOne.ID)).Times(1).Return(accountOne, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountTwo.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				requireKind(t, err, NotFound)
+			},
+		},
+		{
+			name:   "FrozenToAccount",
+			caller: userOne.Username,
+			arg:    validParams,
+			buildStubs: func(store *mockdb.MockStore) {
+				frozenAccount := accountTwo
+				frozenAccount.Status = util.FrozenAccountStatus
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountOne.ID)).Times(1).Return(accountOne, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountTwo.ID)).Times(1).Return(frozenAccount, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				requireKind(t, err, PermissionDenied)
+			},
+		},
+		{
+			name:   "CurrencyMismatch",
+			caller: userOne.Username,
+			arg:    CreateTransferParams{FromAccountID: accountOne.ID, ToAccountID: accountThree.ID, Amount: 1000, Currency: util.USD},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountOne.ID)).Times(1).Return(accountOne, nil)
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				requireKind(t, err, InvalidArgument)
+			},
+		},
+		{
+			name:   "InternalError - Could not get account",
+			caller: userOne.Username,
+			arg:    validParams,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountOne.ID)).Times(1).Return(db.Account{}, sql.ErrConnDone)
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				requireKind(t, err, Internal)
+			},
+		},
+		{
+			name:   "InternalError - Could not create transfer",
+			caller: userOne.Username,
+			arg:    validParams,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountOne.ID)).Times(1).Return(accountOne, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountTwo.ID)).Times(1).Return(accountTwo, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Eq(transferTxParams)).Times(1).Return(db.TransferTxResult{}, sql.ErrConnDone)
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				requireKind(t, err, Internal)
+			},
+		},
+		{
+			name:   "TransferLimitExceeded",
+			caller: userOne.Username,
+			arg:    validParams,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountOne.ID)).Times(1).Return(accountOne, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountTwo.ID)).Times(1).Return(accountTwo, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Eq(transferTxParams)).Times(1).Return(db.TransferTxResult{}, &db.TransferLimitError{
+					AccountID: accountOne.ID,
+					Limit:     db.TransferLimitDailyAmount,
+					Allowed:   validParams.Amount,
+					Remaining: 0,
+				})
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				requireKind(t, err, LimitExceeded)
+
+				var limitErr *db.TransferLimitError
+				require.ErrorAs(t, err, &limitErr)
+				require.Equal(t, accountOne.ID, limitErr.AccountID)
+			},
+		},
+		{
+			name:   "InsufficientFunds",
+			caller: userOne.Username,
+			arg:    validParams,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountOne.ID)).Times(1).Return(accountOne, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountTwo.ID)).Times(1).Return(accountTwo, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Eq(transferTxParams)).Times(1).Return(db.TransferTxResult{}, db.ErrInsufficientFunds)
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				requireKind(t, err, FailedPrecondition)
+				require.ErrorIs(t, err, db.ErrInsufficientFunds)
+			},
+		},
+		{
+			name:   "OrganizationTransferWaitingForApproval",
+			caller: userTwo.Username,
+			arg:    validParams,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountOne.ID)).Times(1).Return(organizationAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountTwo.ID)).Times(1).Return(accountTwo, nil)
+				store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Eq(db.GetOrganizationMemberParams{
+					OrganizationID: organizationAccount.OrganizationID.Int64,
+					Username:       userTwo.Username,
+				})).Times(1).Return(db.OrganizationMember{
+					OrganizationID: organizationAccount.OrganizationID.Int64,
+					Username:       userTwo.Username,
+					Role:           db.OrganizationRoleInitiator,
+				}, nil)
+				store.EXPECT().GetOrganization(gomock.Any(), gomock.Eq(organizationAccount.OrganizationID.Int64)).Times(1).
+					Return(db.Organization{ID: organizationAccount.OrganizationID.Int64, ApprovalThreshold: validParams.Amount - 1}, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateTransferApprovalTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ any, arg db.CreateTransferApprovalTxParams) (db.CreateTransferApprovalTxResult, error) {
+						require.Equal(t, userTwo.Username, arg.Initiator)
+						require.Equal(t, organizationAccount.OrganizationID.Int64, arg.OrganizationID)
+						require.Equal(t, validParams.Amount, arg.Amount)
+						return db.CreateTransferApprovalTxResult{TransferApproval: db.TransferApproval{ID: 9}}, nil
+					})
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				require.NoError(t, err)
+				require.Nil(t, result.Transfer)
+				require.Equal(t, int64(9), result.TransferApprovalID)
+			},
+		},
+		{
+			name:   "NotAccountMember",
+			caller: userTwo.Username,
+			arg:    validParams,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountOne.ID)).Times(1).Return(accountOne, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Eq(db.GetAccountMemberParams{
+					AccountID: accountOne.ID,
+					Username:  userTwo.Username,
+				})).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				requireKind(t, err, PermissionDenied)
+			},
+		},
+		{
+			name:   "SpenderAboveItsLimit",
+			caller: userTwo.Username,
+			arg:    validParams,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountOne.ID)).Times(1).Return(accountOne, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{
+					AccountID:  accountOne.ID,
+					Username:   userTwo.Username,
+					Role:       db.AccountRoleSpender,
+					SpendLimit: sql.NullInt64{Int64: validParams.Amount - 1, Valid: true},
+					Status:     db.AccountMemberActive,
+				}, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				requireKind(t, err, PermissionDenied)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(gomock.NewController(t))
+			testCase.buildStubs(store)
+
+			result, err := newTestService(t, store).CreateTransfer(context.Background(), Caller{Username: testCase.caller}, testCase.arg)
+			testCase.check(t, result, err)
+		})
+	}
+}
+
+func TestCreateTransferPayeeCooldown(t *testing.T) {
+	user, _ := randomUser(t)
+	fromAccount := brlAccount(user.Username)
+	toAccount := brlAccount(util.RandomOwner())
+	toAccount.ID = fromAccount.ID + 1
+
+	payee := db.Payee{
+		ID:        util.RandomInt(1, 1000),
+		Owner:     user.Username,
+		AccountID: toAccount.ID,
+		CreatedAt: time.Now().Add(-time.Minute),
+	}
+
+	store := mockdb.NewMockStore(gomock.NewController(t))
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+	store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(payee, nil)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	service := newTestService(t, store)
+	service.config.PayeeCooldown = time.Hour
+	service.config.PayeeCooldownAmount = 100
+
+	_, err := service.CreateTransfer(context.Background(), Caller{Username: user.Username}, CreateTransferParams{
+		FromAccountID: fromAccount.ID,
+		PayeeID:       payee.ID,
+		Amount:        100,
+		Currency:      util.BRL,
+	})
+	requireKind(t, err, FailedPrecondition)
+}
+
+func TestCreateTransferRiskScreening(t *testing.T) {
+	userOne, _ := randomUser(t)
+	userTwo, _ := randomUser(t)
+
+	accountOne := brlAccount(userOne.Username)
+	accountTwo := brlAccount(userTwo.Username)
+	accountTwo.ID = accountOne.ID + 1
+
+	arg := CreateTransferParams{
+		FromAccountID: accountOne.ID,
+		ToAccountID:   accountTwo.ID,
+		Amount:        1000,
+		Currency:      util.BRL,
+	}
+
+	transferTxParams := db.TransferTxParams{
+		FromAccountID: accountOne.ID,
+		ToAccountID:   accountTwo.ID,
+		Amount:        arg.Amount,
+		Audit:         db.AuditParams{Actor: userOne.Username},
+	}
+
+	countParams := db.CountTransfersBetweenParams{
+		FromAccountID: accountOne.ID,
+		ToAccountID:   accountTwo.ID,
+	}
+
+	testCases := []struct {
+		name       string
+		denyScore  int
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, result CreateTransferResult, err error)
+	}{
+		{
+			name: "KnownPayee",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountTransfersBetween(gomock.Any(), gomock.Eq(countParams)).Times(1).Return(int64(1), nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Eq(transferTxParams)).Times(1).Return(createTransferTx(transferTxParams), nil)
+				store.EXPECT().CreateTransferReviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result.Transfer)
+			},
+		},
+		{
+			name: "NewPayeeHeldForReview",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountTransfersBetween(gomock.Any(), gomock.Eq(countParams)).Times(1).Return(int64(0), nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateTransferReviewTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ any, arg db.CreateTransferReviewTxParams) (db.CreateTransferReviewTxResult, error) {
+						require.Equal(t, userOne.Username, arg.Owner)
+						require.Equal(t, transferTxParams.Amount, arg.Amount)
+						require.NotZero(t, arg.Score)
+						require.Len(t, arg.Reasons, 1)
+						return db.CreateTransferReviewTxResult{TransferReview: db.TransferReview{ID: 7}}, nil
+					})
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				require.NoError(t, err)
+				require.Nil(t, result.Transfer)
+				require.Equal(t, int64(7), result.TransferReviewID)
+			},
+		},
+		{
+			name:      "DeniedByScore",
+			denyScore: 1,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountTransfersBetween(gomock.Any(), gomock.Eq(countParams)).Times(1).Return(int64(0), nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateTransferReviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				requireKind(t, err, PermissionDenied)
+			},
+		},
+		{
+			name: "CouldNotScreenTransfer",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountTransfersBetween(gomock.Any(), gomock.Eq(countParams)).Times(1).Return(int64(0), sql.ErrConnDone)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, result CreateTransferResult, err error) {
+				requireKind(t, err, Internal)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(gomock.NewController(t))
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountOne.ID)).Times(1).Return(accountOne, nil)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(accountTwo.ID)).Times(1).Return(accountTwo, nil)
+			testCase.buildStubs(store)
+
+			service := newTestService(t, store)
+			service.riskEngine = risk.NewEngine(testCase.denyScore, risk.NewPayeeRule(store, arg.Amount))
+
+			result, err := service.CreateTransfer(context.Background(), Caller{Username: userOne.Username}, arg)
+			testCase.check(t, result, err)
+		})
+	}
+}
+
+func TestScreenTransferBatch(t *testing.T) {
+	user, _ := randomUser(t)
+	fromAccount := brlAccount(user.Username)
+	knownAccount := brlAccount(util.RandomOwner())
+	knownAccount.ID = fromAccount.ID + 1
+	newAccount := brlAccount(util.RandomOwner())
+	newAccount.ID = fromAccount.ID + 2
+	missingID := fromAccount.ID + 3
+
+	minAmount := int64(500)
+
+	testCases := []struct {
+		name       string
+		items      []db.TransferBatchItemParams
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			items: []db.TransferBatchItemParams{
+				{ToAccountID: knownAccount.ID, Amount: minAmount},
+				{ToAccountID: knownAccount.ID, Amount: minAmount},
+				{ToAccountID: newAccount.ID, Amount: minAmount - 1},
+				{ToAccountID: missingID, Amount: minAmount},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(knownAccount.ID)).Times(1).Return(knownAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(newAccount.ID)).Times(1).Return(newAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(missingID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().CountTransfersBetween(gomock.Any(), gomock.Eq(db.CountTransfersBetweenParams{
+					FromAccountID: fromAccount.ID,
+					ToAccountID:   knownAccount.ID,
+				})).Times(2).Return(int64(1), nil)
+			},
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "ItemHeldForReview",
+			items: []db.TransferBatchItemParams{
+				{ToAccountID: knownAccount.ID, Amount: minAmount},
+				{ToAccountID: newAccount.ID, Amount: minAmount},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(knownAccount.ID)).Times(1).Return(knownAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(newAccount.ID)).Times(1).Return(newAccount, nil)
+				store.EXPECT().CountTransfersBetween(gomock.Any(), gomock.Eq(db.CountTransfersBetweenParams{
+					FromAccountID: fromAccount.ID,
+					ToAccountID:   knownAccount.ID,
+				})).Times(1).Return(int64(1), nil)
+				store.EXPECT().CountTransfersBetween(gomock.Any(), gomock.Eq(db.CountTransfersBetweenParams{
+					FromAccountID: fromAccount.ID,
+					ToAccountID:   newAccount.ID,
+				})).Times(1).Return(int64(0), nil)
+			},
+			check: func(t *testing.T, err error) {
+				requireKind(t, err, PermissionDenied)
+				require.Contains(t, err.Error(), "items[1]")
+			},
+		},
+		{
+			name:  "CouldNotGetAccount",
+			items: []db.TransferBatchItemParams{{ToAccountID: knownAccount.ID, Amount: minAmount}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(knownAccount.ID)).Times(1).Return(db.Account{}, sql.ErrConnDone)
+			},
+			check: func(t *testing.T, err error) {
+				requireKind(t, err, Internal)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(gomock.NewController(t))
+			testCase.buildStubs(store)
+
+			service := newTestService(t, store)
+			service.riskEngine = risk.NewEngine(0, risk.NewPayeeRule(store, minAmount))
+
+			err := service.ScreenTransferBatch(context.Background(), Caller{Username: user.Username}, fromAccount, testCase.items)
+			testCase.check(t, err)
+		})
+	}
+}
+
+func TestCreateTransferApprovalRiskScreening(t *testing.T) {
+	user, _ := randomUser(t)
+	fromAccount := brlAccount(user.Username)
+	fromAccount.OrganizationID = sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true}
+	toAccount := brlAccount(util.RandomOwner())
+	toAccount.ID = fromAccount.ID + 1
+
+	arg := CreateTransferParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        1000,
+		Currency:      util.BRL,
+	}
+
+	testCases := []struct {
+		name       string
+		denyScore  int
+		buildStubs func(store *mockdb.MockStore)
+	}{
+		{
+			name:      "Denied",
+			denyScore: 1,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrganization(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name: "HeldForReview",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOrganization(gomock.Any(), gomock.Eq(fromAccount.OrganizationID.Int64)).Times(1).
+					Return(db.Organization{ID: fromAccount.OrganizationID.Int64, ApprovalThreshold: arg.Amount - 1}, nil)
+				store.EXPECT().CreateTransferReviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(gomock.NewController(t))
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+			store.EXPECT().GetOrganizationMember(gomock.Any(), gomock.Any()).Times(1).Return(db.OrganizationMember{
+				OrganizationID: fromAccount.OrganizationID.Int64,
+				Username:       user.Username,
+				Role:           db.OrganizationRoleInitiator,
+			}, nil)
+			store.EXPECT().CountTransfersBetween(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+			store.EXPECT().CreateTransferApprovalTx(gomock.Any(), gomock.Any()).Times(0)
+			store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			testCase.buildStubs(store)
+
+			service := newTestService(t, store)
+			service.riskEngine = risk.NewEngine(testCase.denyScore, risk.NewPayeeRule(store, arg.Amount))
+
+			_, err := service.CreateTransfer(context.Background(), Caller{Username: user.Username}, arg)
+			requireKind(t, err, PermissionDenied)
+		})
+	}
+}
+
+func TestScreenHold(t *testing.T) {
+	user, _ := randomUser(t)
+	fromAccount := brlAccount(user.Username)
+	toAccount := brlAccount(util.RandomOwner())
+	toAccount.ID = fromAccount.ID + 1
+
+	payeeParams := db.GetPayeeByAccountParams{
+		Owner:     user.Username,
+		AccountID: toAccount.ID,
+	}
+	countParams := db.CountTransfersBetweenParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+	}
+
+	minAmount := int64(500)
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayeeByAccount(gomock.Any(), gomock.Eq(payeeParams)).Times(1).Return(db.Payee{}, sql.ErrNoRows)
+				store.EXPECT().CountTransfersBetween(gomock.Any(), gomock.Eq(countParams)).Times(1).Return(int64(1), nil)
+			},
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "PayeeCooldown",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayeeByAccount(gomock.Any(), gomock.Eq(payeeParams)).Times(1).Return(db.Payee{
+					ID:        util.RandomInt(1, 1000),
+					Owner:     user.Username,
+					AccountID: toAccount.ID,
+					CreatedAt: time.Now().Add(-time.Minute),
+				}, nil)
+				store.EXPECT().CountTransfersBetween(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, err error) {
+				requireKind(t, err, FailedPrecondition)
+			},
+		},
+		{
+			name: "HeldForReview",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayeeByAccount(gomock.Any(), gomock.Eq(payeeParams)).Times(1).Return(db.Payee{}, sql.ErrNoRows)
+				store.EXPECT().CountTransfersBetween(gomock.Any(), gomock.Eq(countParams)).Times(1).Return(int64(0), nil)
+			},
+			check: func(t *testing.T, err error) {
+				requireKind(t, err, PermissionDenied)
+			},
+		},
+		{
+			name: "CouldNotGetPayee",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPayeeByAccount(gomock.Any(), gomock.Eq(payeeParams)).Times(1).Return(db.Payee{}, sql.ErrConnDone)
+			},
+			check: func(t *testing.T, err error) {
+				requireKind(t, err, Internal)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(gomock.NewController(t))
+			testCase.buildStubs(store)
+
+			service := newTestService(t, store)
+			service.config.PayeeCooldown = time.Hour
+			service.config.PayeeCooldownAmount = 100
+			service.riskEngine = risk.NewEngine(0, risk.NewPayeeRule(store, minAmount))
+
+			err := service.ScreenHold(context.Background(), Caller{Username: user.Username}, fromAccount, toAccount, minAmount)
+			testCase.check(t, err)
+		})
+	}
+}
+
+func TestGetTransfer(t *testing.T) {
+	userOne, _ := randomUser(t)
+	userTwo, _ := randomUser(t)
+	fromAccount := randomAccount(userOne.Username)
+	toAccount := randomAccount(userTwo.Username)
+	toAccount.ID = fromAccount.ID + 1
+
+	transfer := db.Transfer{
+		ID:            util.RandomInt(1, 1000),
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        util.RandomMoney(),
+	}
+
+	testCases := []struct {
+		name       string
+		caller     string
+		id         int64
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, got db.Transfer, err error)
+	}{
+		{
+			name:   "OK",
+			caller: userOne.Username,
+			id:     transfer.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+			},
+			check: func(t *testing.T, got db.Transfer, err error) {
+				require.NoError(t, err)
+				require.Equal(t, transfer, got)
+			},
+		},
+		{
+			name:   "OKRecipient",
+			caller: userTwo.Username,
+			id:     transfer.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+			},
+			check: func(t *testing.T, got db.Transfer, err error) {
+				require.NoError(t, err)
+				require.Equal(t, transfer, got)
+			},
+		},
+		{
+			name:   "OtherUser",
+			caller: "other_user",
+			id:     transfer.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(2).Return(db.AccountMember{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, got db.Transfer, err error) {
+				requireKind(t, err, NotFound)
+				require.Empty(t, got)
+			},
+		},
+		{
+			name:   "NotFound",
+			caller: userOne.Username,
+			id:     transfer.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(db.Transfer{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, got db.Transfer, err error) {
+				requireKind(t, err, NotFound)
+			},
+		},
+		{
+			name:       "InvalidID",
+			caller:     userOne.Username,
+			id:         -1,
+			buildStubs: func(store *mockdb.MockStore) {},
+			check: func(t *testing.T, got db.Transfer, err error) {
+				requireViolations(t, err, "id")
+			},
+		},
+		{
+			name:   "InternalError",
+			caller: userOne.Username,
+			id:     transfer.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(db.Transfer{}, sql.ErrConnDone)
+			},
+			check: func(t *testing.T, got db.Transfer, err error) {
+				requireKind(t, err, Internal)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(gomock.NewController(t))
+			testCase.buildStubs(store)
+
+			got, err := newTestService(t, store).GetTransfer(context.Background(), Caller{Username: testCase.caller}, testCase.id)
+			testCase.check(t, got, err)
+		})
+	}
+}
+
+func createTransferTx(arg db.TransferTxParams) db.TransferTxResult {
+	return db.TransferTxResult{
+		Transfer: db.Transfer{
+			ID:            util.RandomInt(1, 1000),
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   arg.ToAccountID,
+			Amount:        arg.Amount,
+		},
+		FromAccount: db.Account{
+			ID:       arg.FromAccountID,
+			Balance:  1000 - arg.Amount,
+			Currency: util.BRL,
+		},
+		ToAccount: db.Account{
+			ID:       arg.ToAccountID,
+			Balance:  1000 + arg.Amount,
+			Currency: util.BRL,
+		},
+		FromEntry: db.Entry{
+			ID:        util.RandomInt(1, 1000),
+			AccountID: arg.FromAccountID,
+			Amount:    -arg.Amount,
+		},
+		ToEntry: db.Entry{
+			ID:        util.RandomInt(1, 1000),
+			AccountID: arg.ToAccountID,
+			Amount:    arg.Amount,
+		},
+	}
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/MathPeixoto/go-financial-system/val"
+	"github.com/MathPeixoto/go-financial-system/worker"
+	"github.com/lib/pq"
+)
+
+type CreateUserParams struct {
+	Username string
+	Password string
+	FullName string
+	Email    string
+}
+
+// CreateUser registers a user and queues the email verifying its address
+func (service *Service) CreateUser(ctx context.Context, caller Caller, arg CreateUserParams) (db.User, error) {
+	if violations := validateCreateUserParams(arg); violations != nil {
+		return db.User{}, invalidArgumentError(violations)
+	}
+
+	hashedPassword, err := util.HashPassword(arg.Password)
+	if err != nil {
+		return db.User{}, internalError(err, "failed to hash the password")
+	}
+
+	result, err := service.store.CreateUserTx(ctx, db.CreateUserTxParams{
+		CreateUserParams: db.CreateUserParams{
+			Username:       arg.Username,
+			HashedPassword: hashedPassword,
+			FullName:       arg.FullName,
+			Email:          arg.Email,
+		},
+		AfterCreate: func(user *db.User) ([]db.CreateOutboxMessageParams, error) {
+			payload := &worker.PayloadSendVerifyEmail{
+				Username: user.Username,
+			}
+			message, err := worker.NewOutboxMessage(worker.TaskSendVerifyEmail, payload,
+				worker.QueueCritical, 10, time.Now().Add(10*time.Second))
+			if err != nil {
+				return nil, err
+			}
+			return []db.CreateOutboxMessageParams{message}, nil
+		},
+		Audit: caller.audit(),
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return db.User{}, newError(AlreadyExists, "user already exists: %s", err)
+		}
+		return db.User{}, internalError(err, "failed to create user")
+	}
+
+	return result.User, nil
+}
+
+func validateCreateUserParams(arg CreateUserParams) (violations []FieldViolation) {
+	if err := val.ValidateUsername(arg.Username); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	if err := val.ValidatePassword(arg.Password); err != nil {
+		violations = append(violations, fieldViolation("password", err))
+	}
+
+	if err := val.ValidateFullName(arg.FullName); err != nil {
+		violations = append(violations, fieldViolation("full_name", err))
+	}
+
+	if err := val.ValidateEmail(arg.Email); err != nil {
+		violations = append(violations, fieldViolation("email", err))
+	}
+
+	return
+}
+
+// UpdateUserParams holds the fields of a user to update, nil for the ones kept
+type UpdateUserParams struct {
+	Username string
+	FullName *string
+	Email    *string
+	Password *string
+}
+
+// UpdateUser updates the profile of the caller
+func (service *Service) UpdateUser(ctx context.Context, caller Caller, arg UpdateUserParams) (db.User, error) {
+	if violations := validateUpdateUserParams(arg); violations != nil {
+		return db.User{}, invalidArgumentError(violations)
+	}
+
+	if caller.Username != arg.Username {
+		return db.User{}, newError(PermissionDenied, "Cannot update other user`s info")
+	}
+
+	params := db.UpdateUserParams{
+		Username: arg.Username,
+		FullName: nullString(arg.FullName),
+		Email:    nullString(arg.Email),
+	}
+
+	if arg.Password != nil {
+		hashedPassword, err := util.HashPassword(*arg.Password)
+		if err != nil {
+			return db.User{}, internalError(err, "failed to hash the password")
+		}
+
+		params.HashedPassword = sql.NullString{
+			String: hashedPassword,
+			Valid:  true,
+		}
+
+		params.PasswordChangedAt = sql.NullTime{
+			Time:  time.Now(),
+			Valid: true,
+		}
+	}
+
+	result, err := service.store.UpdateUserTx(ctx, db.UpdateUserTxParams{
+		UpdateUserParams: params,
+		Audit:            caller.audit(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return db.User{}, newError(NotFound, "user not found: %s", err)
+		}
+		return db.User{}, internalError(err, "failed to update user")
+	}
+
+	return result.User, nil
+}
+
+func validateUpdateUserParams(arg UpdateUserParams) (violations []FieldViolation) {
+	if err := val.ValidateUsername(arg.Username); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	if arg.Password != nil {
+		if err := val.ValidatePassword(*arg.Password); err != nil {
+			violations = append(violations, fieldViolation("password", err))
+		}
+	}
+
+	if arg.FullName != nil {
+		if err := val.ValidateFullName(*arg.FullName); err != nil {
+			violations = append(violations, fieldViolation("full_name", err))
+		}
+	}
+
+	if arg.Email != nil {
+		if err := val.ValidateEmail(*arg.Email); err != nil {
+			violations = append(violations, fieldViolation("email", err))
+		}
+	}
+
+	return
+}
+
+func nullString(value *string) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *value, Valid: true}
+}
+
+// isUniqueViolation reports whether err is a postgres error of a unique constraint
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation"
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+
+	mockdb "github.com/MathPeixoto/go-financial-system/db/mock"
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/MathPeixoto/go-financial-system/worker"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+type eqCreateUserTxParamsMatcher struct {
+	arg      db.CreateUserParams
+	password string
+	user     db.User
+}
+
+func (e eqCreateUserTxParamsMatcher) Matches(x interface{}) bool {
+	actualArg, ok := x.(db.CreateUserTxParams)
+	if !ok {
+		return false
+	}
+
+	err := util.CheckPasswordHash(e.password, actualArg.HashedPassword)
+	if err != nil {
+		return false
+	}
+
+	e.arg.HashedPassword = actualArg.HashedPassword
+	if !reflect.DeepEqual(e.arg, actualArg.CreateUserParams) {
+		return false
+	}
+
+	// the verify email task is written to the outbox along with the user
+	messages, err := actualArg.AfterCreate(&e.user)
+	return err == nil && len(messages) == 1 && messages[0].TaskType == worker.TaskSendVerifyEmail
+}
+
+func (e eqCreateUserTxParamsMatcher) String() string {
+	return fmt.Sprintf("matches arg %v and password %v", e.arg, e.password)
+}
+
+func eqCreateUserTxParams(arg db.CreateUserParams, password string, user db.User) gomock.Matcher {
+	return eqCreateUserTxParamsMatcher{arg, password, user}
+}
+
+func TestCreateUser(t *testing.T) {
+	user, password := randomUser(t)
+
+	validParams := CreateUserParams{
+		Username: user.Username,
+		Password: password,
+		FullName: user.FullName,
+		Email:    user.Email,
+	}
+
+	testCases := []struct {
+		name       string
+		arg        CreateUserParams
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, got db.User, err error)
+	}{
+		{
+			name: "OK",
+			arg:  validParams,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateUserParams{
+					Username: user.Username,
+					FullName: user.FullName,
+					Email:    user.Email,
+				}
+
+				store.EXPECT().
+					CreateUserTx(gomock.Any(), eqCreateUserTxParams(arg, password, user)).
+					Times(1).
+					Return(db.CreateUserTxResult{User: user}, nil)
+			},
+			check: func(t *testing.T, got db.User, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user, got)
+			},
+		},
+		{
+			name: "InternalError",
+			arg:  validParams,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreateUserTxResult{}, sql.ErrConnDone)
+			},
+			check: func(t *testing.T, got db.User, err error) {
+				requireKind(t, err, Internal)
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+		{
+			name: "DuplicateUsername",
+			arg:  validParams,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreateUserTxResult{}, &pq.Error{Code: "23505"})
+			},
+			check: func(t *testing.T, got db.User, err error) {
+				requireKind(t, err, AlreadyExists)
+			},
+		},
+		{
+			name: "InvalidUsername",
+			arg: CreateUserParams{
+				Username: "",
+				Password: password,
+				FullName: user.FullName,
+				Email:    user.Email,
+			},
+			buildStubs: func(store *mockdb.MockStore) {},
+			check: func(t *testing.T, got db.User, err error) {
+				requireViolations(t, err, "username")
+			},
+		},
+		{
+			name: "InvalidEmail",
+			arg: CreateUserParams{
+				Username: user.Username,
+				Password: password,
+				FullName: user.FullName,
+				Email:    "invalid-email",
+			},
+			buildStubs: func(store *mockdb.MockStore) {},
+			check: func(t *testing.T, got db.User, err error) {
+				requireViolations(t, err, "email")
+			},
+		},
+		{
+			name: "InvalidPassword",
+			arg: CreateUserParams{
+				Username: user.Username,
+				Password: "123",
+				FullName: user.FullName,
+				Email:    user.Email,
+			},
+			buildStubs: func(store *mockdb.MockStore) {},
+			check: func(t *testing.T, got db.User, err error) {
+				requireViolations(t, err, "password")
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(gomock.NewController(t))
+			testCase.buildStubs(store)
+
+			got, err := newTestService(t, store).CreateUser(context.Background(), Caller{}, testCase.arg)
+			testCase.check(t, got, err)
+		})
+	}
+}
+
+func TestUpdateUser(t *testing.T) {
+	user, _ := randomUser(t)
+	newFullName := util.RandomOwner()
+	newPassword := util.RandomString(8)
+	invalidEmail := "invalid-email"
+
+	testCases := []struct {
+		name       string
+		caller     string
+		arg        UpdateUserParams
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, got db.User, err error)
+	}{
+		{
+			name:   "OK",
+			caller: user.Username,
+			arg: UpdateUserParams{
+				Username: user.Username,
+				FullName: &newFullName,
+				Password: &newPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ any, arg db.UpdateUserTxParams) (db.UpdateUserTxResult, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.Equal(t, sql.NullString{String: newFullName, Valid: true}, arg.FullName)
+						require.False(t, arg.Email.Valid)
+						require.NoError(t, util.CheckPasswordHash(newPassword, arg.HashedPassword.String))
+						require.True(t, arg.PasswordChangedAt.Valid)
+						require.Equal(t, user.Username, arg.Audit.Actor)
+
+						updated := user
+						updated.FullName = newFullName
+						return db.UpdateUserTxResult{User: updated}, nil
+					})
+			},
+			check: func(t *testing.T, got db.User, err error) {
+				require.NoError(t, err)
+				require.Equal(t, newFullName, got.FullName)
+			},
+		},
+		{
+			name:   "OtherUser",
+			caller: "other_user",
+			arg: UpdateUserParams{
+				Username: user.Username,
+				FullName: &newFullName,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, got db.User, err error) {
+				requireKind(t, err, PermissionDenied)
+			},
+		},
+		{
+			name:   "InvalidEmail",
+			caller: user.Username,
+			arg: UpdateUserParams{
+				Username: user.Username,
+				Email:    &invalidEmail,
+			},
+			buildStubs: func(store *mockdb.MockStore) {},
+			check: func(t *testing.T, got db.User, err error) {
+				requireViolations(t, err, "email")
+			},
+		},
+		{
+			name:   "NotFound",
+			caller: user.Username,
+			arg: UpdateUserParams{
+				Username: user.Username,
+				FullName: &newFullName,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.UpdateUserTxResult{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, got db.User, err error) {
+				requireKind(t, err, NotFound)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := mockdb.NewMockStore(gomock.NewController(t))
+			testCase.buildStubs(store)
+
+			got, err := newTestService(t, store).UpdateUser(context.Background(), Caller{Username: testCase.caller}, testCase.arg)
+			testCase.check(t, got, err)
+		})
+	}
+}
+
+// requireViolations checks that err rejects exactly the fields
+func requireViolations(t *testing.T, err error, fields ...string) {
+	requireKind(t, err, InvalidArgument)
+
+	var serviceErr *Error
+	require.ErrorAs(t, err, &serviceErr)
+
+	got := make([]string, len(serviceErr.Violations))
+	for i, violation := range serviceErr.Violations {
+		got[i] = violation.Field
+	}
+	require.Equal(t, fields, got)
+}
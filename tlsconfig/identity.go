@@ -0,0 +1,44 @@
+package tlsconfig
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strings"
+)
+
+// Identity returns the identity of a client certificate: its first URI SAN, like a SPIFFE ID,
+// else its first DNS SAN, else its common name
+func Identity(certificate *x509.Certificate) string {
+	if len(certificate.URIs) > 0 {
+		return certificate.URIs[0].String()
+	}
+
+	if len(certificate.DNSNames) > 0 {
+		return certificate.DNSNames[0]
+	}
+
+	return certificate.Subject.CommonName
+}
+
+// ParsePrincipals parses the users the service callers act as, written as a comma-separated list of
+// "<certificate identity>=<username>", like "spiffe://bank/scheduler=scheduler"
+func ParsePrincipals(value string) (map[string]string, error) {
+	principals := make(map[string]string)
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		// the identities can contain an equal sign, unlike the usernames
+		separator := strings.LastIndex(entry, "=")
+		if separator <= 0 || separator == len(entry)-1 {
+			return nil, fmt.Errorf("invalid principal %q: expected <certificate identity>=<username>", entry)
+		}
+
+		principals[strings.TrimSpace(entry[:separator])] = strings.TrimSpace(entry[separator+1:])
+	}
+
+	return principals, nil
+}
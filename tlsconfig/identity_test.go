@@ -0,0 +1,46 @@
+package tlsconfig
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentity(t *testing.T) {
+	spiffeID, err := url.Parse("spiffe://bank/ns/default/sa/scheduler")
+	require.NoError(t, err)
+
+	certificate := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "scheduler"},
+		DNSNames: []string{"scheduler.default.svc"},
+		URIs:     []*url.URL{spiffeID},
+	}
+	require.Equal(t, "spiffe://bank/ns/default/sa/scheduler", Identity(certificate))
+
+	certificate.URIs = nil
+	require.Equal(t, "scheduler.default.svc", Identity(certificate))
+
+	certificate.DNSNames = nil
+	require.Equal(t, "scheduler", Identity(certificate))
+}
+
+func TestParsePrincipals(t *testing.T) {
+	principals, err := ParsePrincipals(" spiffe://bank/scheduler?env=prod=scheduler, reconciler = reconciler ,")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"spiffe://bank/scheduler?env=prod": "scheduler",
+		"reconciler":                       "reconciler",
+	}, principals)
+
+	principals, err = ParsePrincipals("")
+	require.NoError(t, err)
+	require.Empty(t, principals)
+
+	for _, value := range []string{"scheduler", "=scheduler", "scheduler="} {
+		_, err := ParsePrincipals(value)
+		require.Error(t, err, value)
+	}
+}
@@ -0,0 +1,214 @@
+// Package tlsconfig configures the TLS of the servers from certificate files reloaded when they change,
+// and identifies the clients by their certificates.
+package tlsconfig
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+)
+
+// reloadDelay lets the writes of an update settle before the files are reloaded, as a single update
+// of a certificate and its key triggers several events
+const reloadDelay = 500 * time.Millisecond
+
+// Reloader holds the certificate of the servers and the CA bundle verifying the client certificates,
+// swapped without restarting the servers when the files change
+type Reloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mutex       sync.RWMutex
+	certificate *tls.Certificate
+	clientCAs   *x509.CertPool
+}
+
+// NewReloader loads the certificate and its key, and the client CA bundle unless clientCAFile is empty
+func NewReloader(certFile, keyFile, clientCAFile string) (*Reloader, error) {
+	reloader := &Reloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+
+	if err := reloader.Reload(); err != nil {
+		return nil, err
+	}
+
+	return reloader, nil
+}
+
+// Reload reads the files again. The current certificates are kept if any file is invalid.
+func (reloader *Reloader) Reload() error {
+	certificate, err := tls.LoadX509KeyPair(reloader.certFile, reloader.keyFile)
+	if err != nil {
+		return fmt.Errorf("cannot load certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if reloader.clientCAFile != "" {
+		bundle, err := os.ReadFile(reloader.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("cannot read client CA bundle: %w", err)
+		}
+
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(bundle) {
+			return fmt.Errorf("no certificate found in client CA bundle %s", reloader.clientCAFile)
+		}
+	}
+
+	reloader.mutex.Lock()
+	reloader.certificate = &certificate
+	reloader.clientCAs = clientCAs
+	reloader.mutex.Unlock()
+
+	return nil
+}
+
+// ServerConfig returns the TLS config of a server presenting the current certificate.
+// With a client CA bundle, the client certificates are verified against it, and are required if requireClientCert.
+func (reloader *Reloader) ServerConfig(requireClientCert bool) *tls.Config {
+	config := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.getCertificate,
+	}
+
+	if reloader.clientCAFile != "" {
+		config.ClientAuth = tls.RequestClientCert
+		if requireClientCert {
+			config.ClientAuth = tls.RequireAnyClientCert
+		}
+		// the client certificates are verified here rather than by ClientCAs,
+		// which cannot be swapped once the servers use the config
+		config.VerifyConnection = reloader.verifyClientCertificate
+	}
+
+	return config
+}
+
+// LoopbackConfig returns the TLS config of the connections of the process to its own servers, like the gateway
+// proxying to the gRPC server. The servers are trusted by their current certificate rather than a CA, since
+// they are dialed at an address their certificate isn't issued for. When the servers require a client certificate,
+// the same certificate is presented, which must then also be valid for client authentication.
+func (reloader *Reloader) LoopbackConfig(requireClientCert bool) *tls.Config {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// the chain and the name are not verified, the certificate is compared instead
+		InsecureSkipVerify:    true, //nolint:gosec
+		VerifyPeerCertificate: reloader.verifyOwnCertificate,
+	}
+
+	if requireClientCert {
+		config.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return reloader.getCertificate(nil)
+		}
+	}
+
+	return config
+}
+
+// Watch reloads the files whenever they change until the context is done. It watches the directories of the files,
+// since Kubernetes updates the files of a mounted secret by swapping a symlink next to them.
+func (reloader *Reloader) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("cannot create file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	directories := make(map[string]bool)
+	for _, file := range []string{reloader.certFile, reloader.keyFile, reloader.clientCAFile} {
+		if file == "" || directories[filepath.Dir(file)] {
+			continue
+		}
+
+		directories[filepath.Dir(file)] = true
+		if err := watcher.Add(filepath.Dir(file)); err != nil {
+			return fmt.Errorf("cannot watch %s: %w", filepath.Dir(file), err)
+		}
+	}
+
+	timer := time.NewTimer(reloadDelay)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			timer.Reset(reloadDelay)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Warn().Err(err).Msg("certificate watcher failed")
+		case <-timer.C:
+			if err := reloader.Reload(); err != nil {
+				log.Error().Err(err).Msg("cannot reload certificates, keeping the current ones")
+				continue
+			}
+			log.Info().Msg("certificates reloaded")
+		}
+	}
+}
+
+func (reloader *Reloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	reloader.mutex.RLock()
+	defer reloader.mutex.RUnlock()
+
+	return reloader.certificate, nil
+}
+
+// verifyOwnCertificate checks that a server presents the current certificate of the process
+func (reloader *Reloader) verifyOwnCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	certificate, _ := reloader.getCertificate(nil)
+	if len(rawCerts) == 0 || !bytes.Equal(rawCerts[0], certificate.Certificate[0]) {
+		return errors.New("server does not present the certificate of this process")
+	}
+
+	return nil
+}
+
+// verifyClientCertificate verifies the certificate chain sent by a client against the client CA bundle.
+// A client without certificate is let through, unless the config requires one.
+func (reloader *Reloader) verifyClientCertificate(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return nil
+	}
+
+	reloader.mutex.RLock()
+	clientCAs := reloader.clientCAs
+	reloader.mutex.RUnlock()
+
+	intermediates := x509.NewCertPool()
+	for _, certificate := range state.PeerCertificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         clientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return fmt.Errorf("invalid client certificate: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	pem         []byte
+}
+
+func newTestCA(t *testing.T) testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return testCA{
+		certificate: certificate,
+		key:         key,
+		pem:         pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// issue returns a certificate signed by the CA in PEM, with its key, and as a tls.Certificate
+func (ca testCA) issue(t *testing.T, commonName string, usage x509.ExtKeyUsage) ([]byte, []byte, tls.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serialNumber, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.certificate, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	return certPEM, keyPEM, certificate
+}
+
+type testFiles struct {
+	cert     string
+	key      string
+	clientCA string
+}
+
+func writeTestFiles(t *testing.T, dir string, certPEM, keyPEM, clientCAPEM []byte) testFiles {
+	files := testFiles{
+		cert:     filepath.Join(dir, "tls.crt"),
+		key:      filepath.Join(dir, "tls.key"),
+		clientCA: filepath.Join(dir, "ca.crt"),
+	}
+
+	require.NoError(t, os.WriteFile(files.cert, certPEM, 0600))
+	require.NoError(t, os.WriteFile(files.key, keyPEM, 0600))
+	require.NoError(t, os.WriteFile(files.clientCA, clientCAPEM, 0600))
+
+	return files
+}
+
+// handshake connects a client to a server using serverConfig, and returns the certificate the server presented
+// and the error of the server handshake
+func handshake(t *testing.T, serverConfig *tls.Config, roots *x509.CertPool, clientCertificates ...tls.Certificate) (*x509.Certificate, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		defer conn.Close()
+		serverErr <- tls.Server(conn, serverConfig).Handshake()
+	}()
+
+	conn, err := tls.Dial("tcp", listener.Addr().String(), &tls.Config{
+		RootCAs:      roots,
+		ServerName:   "localhost",
+		Certificates: clientCertificates,
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	return conn.ConnectionState().PeerCertificates[0], <-serverErr
+}
+
+func TestReloader_ServerConfig(t *testing.T) {
+	ca := newTestCA(t)
+	otherCA := newTestCA(t)
+	certPEM, keyPEM, _ := ca.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+	_, _, clientCertificate := ca.issue(t, "scheduler", x509.ExtKeyUsageClientAuth)
+	_, _, serverUsageCertificate := ca.issue(t, "scheduler", x509.ExtKeyUsageServerAuth)
+	_, _, otherClientCertificate := otherCA.issue(t, "scheduler", x509.ExtKeyUsageClientAuth)
+
+	files := writeTestFiles(t, t.TempDir(), certPEM, keyPEM, ca.pem)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.certificate)
+
+	reloader, err := NewReloader(files.cert, files.key, files.clientCA)
+	require.NoError(t, err)
+
+	optional := reloader.ServerConfig(false)
+	served, err := handshake(t, optional, roots, clientCertificate)
+	require.NoError(t, err)
+	require.Equal(t, "localhost", served.Subject.CommonName)
+
+	_, err = handshake(t, optional, roots)
+	require.NoError(t, err)
+
+	_, err = handshake(t, optional, roots, otherClientCertificate)
+	require.Error(t, err)
+
+	_, err = handshake(t, optional, roots, serverUsageCertificate)
+	require.Error(t, err)
+
+	required := reloader.ServerConfig(true)
+	_, err = handshake(t, required, roots, clientCertificate)
+	require.NoError(t, err)
+
+	_, err = handshake(t, required, roots)
+	require.Error(t, err)
+}
+
+func TestReloader_WithoutClientCA(t *testing.T) {
+	ca := newTestCA(t)
+	certPEM, keyPEM, _ := ca.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+	files := writeTestFiles(t, t.TempDir(), certPEM, keyPEM, nil)
+
+	reloader, err := NewReloader(files.cert, files.key, "")
+	require.NoError(t, err)
+
+	config := reloader.ServerConfig(true)
+	require.Equal(t, tls.NoClientCert, config.ClientAuth)
+	require.Nil(t, config.VerifyConnection)
+}
+
+func TestReloader_Reload(t *testing.T) {
+	ca := newTestCA(t)
+	otherCA := newTestCA(t)
+	certPEM, keyPEM, _ := ca.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+	_, _, otherClientCertificate := otherCA.issue(t, "scheduler", x509.ExtKeyUsageClientAuth)
+	files := writeTestFiles(t, t.TempDir(), certPEM, keyPEM, ca.pem)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.certificate)
+
+	reloader, err := NewReloader(files.cert, files.key, files.clientCA)
+	require.NoError(t, err)
+	config := reloader.ServerConfig(false)
+
+	served, err := handshake(t, config, roots)
+	require.NoError(t, err)
+
+	// the servers pick the new certificate and CA bundle up without a new config
+	newCertPEM, newKeyPEM, _ := ca.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+	writeTestFiles(t, filepath.Dir(files.cert), newCertPEM, newKeyPEM, otherCA.pem)
+	require.NoError(t, reloader.Reload())
+
+	reloaded, err := handshake(t, config, roots, otherClientCertificate)
+	require.NoError(t, err)
+	require.NotEqual(t, served.SerialNumber, reloaded.SerialNumber)
+
+	// an invalid update keeps the current certificates
+	require.NoError(t, os.WriteFile(files.key, []byte("invalid"), 0600))
+	require.Error(t, reloader.Reload())
+
+	kept, err := handshake(t, config, roots)
+	require.NoError(t, err)
+	require.Equal(t, reloaded.SerialNumber, kept.SerialNumber)
+}
+
+func TestReloader_Watch(t *testing.T) {
+	ca := newTestCA(t)
+	certPEM, keyPEM, _ := ca.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+	files := writeTestFiles(t, t.TempDir(), certPEM, keyPEM, ca.pem)
+
+	reloader, err := NewReloader(files.cert, files.key, files.clientCA)
+	require.NoError(t, err)
+	served, err := reloader.getCertificate(nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error)
+	go func() {
+		stopped <- reloader.Watch(ctx)
+	}()
+
+	// the watcher may not have started yet, so the files are written until it reloads them
+	require.Eventually(t, func() bool {
+		newCertPEM, newKeyPEM, _ := ca.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+		writeTestFiles(t, filepath.Dir(files.cert), newCertPEM, newKeyPEM, ca.pem)
+
+		current, err := reloader.getCertificate(nil)
+		return err == nil && current != served
+	}, 10*time.Second, reloadDelay*2)
+
+	cancel()
+	require.NoError(t, <-stopped)
+}
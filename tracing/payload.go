@@ -0,0 +1,39 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// payloadKey is the field of the JSON payload of a task carrying the trace context of the request that enqueued it
+const payloadKey = "trace_context"
+
+// InjectPayload adds the trace context of ctx to the JSON object payload of a task,
+// so that the span processing the task continues the trace of the request that enqueued it.
+// The payload is returned unchanged when ctx has no span, when it isn't a JSON object or already carries a trace context.
+func InjectPayload(ctx context.Context, payload []byte) []byte {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return payload
+	}
+
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return util.AddPayloadField(payload, payloadKey, carrier)
+}
+
+// ExtractPayload returns ctx with the remote span of the trace context carried by the payload of a task, if any
+func ExtractPayload(ctx context.Context, payload []byte) context.Context {
+	var fields struct {
+		TraceContext propagation.MapCarrier `json:"trace_context"`
+	}
+	if err := json.Unmarshal(payload, &fields); err != nil || fields.TraceContext == nil {
+		return ctx
+	}
+
+	return otel.GetTextMapPropagator().Extract(ctx, fields.TraceContext)
+}
@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInjectExtractPayload(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "request")
+	defer span.End()
+
+	payload := []byte(`{"delivery_id":1}`)
+	injected := InjectPayload(ctx, payload)
+	require.NotEqual(t, payload, injected)
+
+	// the fields of the payload are kept for the processors
+	var decoded struct {
+		DeliveryID int64 `json:"delivery_id"`
+	}
+	require.NoError(t, json.Unmarshal(injected, &decoded))
+	require.Equal(t, int64(1), decoded.DeliveryID)
+
+	extracted := trace.SpanContextFromContext(ExtractPayload(context.Background(), injected))
+	require.True(t, extracted.IsRemote())
+	require.Equal(t, span.SpanContext().TraceID(), extracted.TraceID())
+	require.Equal(t, span.SpanContext().SpanID(), extracted.SpanID())
+
+	// a payload published again keeps the trace context of the request that enqueued it
+	_, other := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "relay")
+	defer other.End()
+	require.Equal(t, injected, InjectPayload(trace.ContextWithSpan(context.Background(), other), injected))
+}
+
+func TestInjectPayload_Unchanged(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "request")
+	defer span.End()
+
+	testCases := []struct {
+		name    string
+		ctx     context.Context
+		payload []byte
+	}{
+		{"NoSpan", context.Background(), []byte(`{"delivery_id":1}`)},
+		{"NilPayload", ctx, nil},
+		{"NotAnObject", ctx, []byte(`[1,2]`)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.payload, InjectPayload(tc.ctx, tc.payload))
+			require.False(t, trace.SpanContextFromContext(ExtractPayload(context.Background(), tc.payload)).IsValid())
+		})
+	}
+}
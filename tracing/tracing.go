@@ -0,0 +1,91 @@
+// Package tracing sets up OpenTelemetry tracing and carries the trace context of a request into the tasks it enqueues.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exporters of the spans, chosen by the TRACING_EXPORTER config
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+const (
+	serviceName         = "bank"
+	instrumentationName = "github.com/MathPeixoto/go-financial-system"
+)
+
+// Tracer returns the tracer of the spans started by the bank itself
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// NewTracerProvider creates the tracer provider exporting the spans to the exporter of the config
+// and installs it globally along with the W3C trace context propagator.
+// The spans are still created but not exported when no exporter is configured.
+// The provider must be shut down to flush the spans that are not exported yet.
+func NewTracerProvider(ctx context.Context, config util.Config) (*sdktrace.TracerProvider, error) {
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		attribute.String("deployment.environment", config.Environment),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create tracing resource: %w", err)
+	}
+
+	options := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+
+	switch config.TracingExporter {
+	case ExporterOTLP:
+		clientOptions := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.OTLPEndpoint)}
+		if config.OTLPInsecure {
+			clientOptions = append(clientOptions, otlptracegrpc.WithInsecure())
+		}
+
+		exporter, err := otlptracegrpc.New(ctx, clientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create OTLP exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, fmt.Errorf("cannot create stdout exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case "":
+	default:
+		return nil, fmt.Errorf("unsupported tracing exporter %q", config.TracingExporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider, nil
+}
+
+// End ends a span, recording err as its status when not nil
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
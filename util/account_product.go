@@ -0,0 +1,16 @@
+package util
+
+// Constants for all account products
+const (
+	CheckingProduct = "checking"
+	SavingsProduct  = "savings"
+)
+
+// IsSupportedAccountProduct checks if the account product is valid
+func IsSupportedAccountProduct(product string) bool {
+	switch product {
+	case CheckingProduct, SavingsProduct:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,7 @@
+package util
+
+// Constants for all account statuses
+const (
+	ActiveAccountStatus = "active"
+	FrozenAccountStatus = "frozen"
+)
@@ -6,6 +6,12 @@ import (
 	"github.com/spf13/viper"
 )
 
+const (
+	defaultShutdownTimeout    = 20 * time.Second
+	defaultShutdownDrainDelay = 10 * time.Second
+	defaultGatewayMode        = "local"
+)
+
 // Config contains all the configuration for the application
 // The values are read by viper from a config file or environment variables
 type Config struct {
@@ -22,12 +28,46 @@ type Config struct {
 	EmailSenderName      string        `mapstructure:"EMAIL_SENDER_NAME"`
 	EmailSenderAddress   string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
 	EmailSenderPassword  string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	OIDCIssuerURL        string        `mapstructure:"OIDC_ISSUER_URL"`
+	OIDCClientID         string        `mapstructure:"OIDC_CLIENT_ID"`
+	OIDCClientSecret     string        `mapstructure:"OIDC_CLIENT_SECRET"`
+	OIDCRedirectURL      string        `mapstructure:"OIDC_REDIRECT_URL"`
+	RiskNewPayeeAmount   int64         `mapstructure:"RISK_NEW_PAYEE_AMOUNT"`
+	RiskVelocityCount    int           `mapstructure:"RISK_VELOCITY_COUNT"`
+	RiskVelocityWindow   time.Duration `mapstructure:"RISK_VELOCITY_WINDOW"`
+	RiskNewIPAmount      int64         `mapstructure:"RISK_NEW_IP_AMOUNT"`
+	RiskNewIPWindow      time.Duration `mapstructure:"RISK_NEW_IP_WINDOW"`
+	RiskDenyScore        int           `mapstructure:"RISK_DENY_SCORE"`
+	PayeeCooldown        time.Duration `mapstructure:"PAYEE_COOLDOWN"`
+	PayeeCooldownAmount  int64         `mapstructure:"PAYEE_COOLDOWN_AMOUNT"`
+	ShutdownTimeout      time.Duration `mapstructure:"SHUTDOWN_TIMEOUT"`
+	ShutdownDrainDelay   time.Duration `mapstructure:"SHUTDOWN_DRAIN_DELAY"`
+	TracingExporter      string        `mapstructure:"TRACING_EXPORTER"`
+	OTLPEndpoint         string        `mapstructure:"OTLP_ENDPOINT"`
+	OTLPInsecure         bool          `mapstructure:"OTLP_INSECURE"`
+	RateLimitIP          string        `mapstructure:"RATE_LIMIT_IP"`
+	RateLimitUser        string        `mapstructure:"RATE_LIMIT_USER"`
+	RateLimitMethods     string        `mapstructure:"RATE_LIMIT_METHODS"`
+	TLSCertFile          string        `mapstructure:"TLS_CERT_FILE"`
+	TLSKeyFile           string        `mapstructure:"TLS_KEY_FILE"`
+	TLSClientCAFile      string        `mapstructure:"TLS_CLIENT_CA_FILE"`
+	TLSRequireClientCert bool          `mapstructure:"TLS_REQUIRE_CLIENT_CERT"`
+	TLSClientPrincipals  string        `mapstructure:"TLS_CLIENT_PRINCIPALS"`
+	GatewayMode          string        `mapstructure:"GATEWAY_MODE"`
+	TrustedProxies       string        `mapstructure:"TRUSTED_PROXIES"`
+	MonitoringAddress    string        `mapstructure:"MONITORING_ADDRESS"`
 }
 
 // LoadConfig loads the configuration from a config file or environment variables
 func LoadConfig(path string) (Config, error) {
 	viper.SetConfigFile(path)
 	viper.AutomaticEnv()
+	// a config without it must still drain the servers on shutdown
+	viper.SetDefault("SHUTDOWN_TIMEOUT", defaultShutdownTimeout)
+	// nor must it stop the servers before the load balancers see them not ready
+	viper.SetDefault("SHUTDOWN_DRAIN_DELAY", defaultShutdownDrainDelay)
+	// the gateway calls the server in-process unless configured to proxy to it
+	viper.SetDefault("GATEWAY_MODE", defaultGatewayMode)
 
 	var cfg Config
 	err := viper.ReadInConfig()
@@ -0,0 +1,27 @@
+package util
+
+import "encoding/json"
+
+// AddPayloadField adds a field to a JSON object payload and returns it.
+// The payload is returned unchanged when it isn't a JSON object or already has the field.
+func AddPayloadField(payload []byte, key string, value interface{}) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil || fields == nil {
+		return payload
+	}
+	if _, ok := fields[key]; ok {
+		return payload
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return payload
+	}
+	fields[key] = encoded
+
+	added, err := json.Marshal(fields)
+	if err != nil {
+		return payload
+	}
+	return added
+}
@@ -0,0 +1,39 @@
+package util
+
+import "time"
+
+// Constants for all supported recurrences of scheduled transfers
+const (
+	OnceRecurrence    = "once"
+	DailyRecurrence   = "daily"
+	WeeklyRecurrence  = "weekly"
+	MonthlyRecurrence = "monthly"
+)
+
+// IsSupportedRecurrence checks if the recurrence is valid
+func IsSupportedRecurrence(recurrence string) bool {
+	switch recurrence {
+	case OnceRecurrence, DailyRecurrence, WeeklyRecurrence, MonthlyRecurrence:
+		return true
+	}
+	return false
+}
+
+// NextOccurrence returns the occurrence following t, or the zero time when the recurrence does not repeat.
+// Monthly occurrences keep the day of the month, falling back to the last day of shorter months.
+func NextOccurrence(recurrence string, t time.Time) time.Time {
+	switch recurrence {
+	case DailyRecurrence:
+		return t.AddDate(0, 0, 1)
+	case WeeklyRecurrence:
+		return t.AddDate(0, 0, 7)
+	case MonthlyRecurrence:
+		next := t.AddDate(0, 1, 0)
+		if next.Day() != t.Day() {
+			// AddDate normalizes Jan 31 + 1 month to Mar 3, so step back to the end of February
+			next = next.AddDate(0, 0, -next.Day())
+		}
+		return next
+	}
+	return time.Time{}
+}
@@ -0,0 +1,37 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextOccurrence(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		recurrence string
+		from       time.Time
+		next       time.Time
+	}{
+		{OnceRecurrence, date(2023, time.January, 15), time.Time{}},
+		{DailyRecurrence, date(2023, time.December, 31), date(2024, time.January, 1)},
+		{WeeklyRecurrence, date(2023, time.February, 25), date(2023, time.March, 4)},
+		{MonthlyRecurrence, date(2023, time.January, 15), date(2023, time.February, 15)},
+		{MonthlyRecurrence, date(2023, time.January, 31), date(2023, time.February, 28)},
+		{MonthlyRecurrence, date(2024, time.January, 31), date(2024, time.February, 29)},
+		{MonthlyRecurrence, date(2023, time.March, 31), date(2023, time.April, 30)},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.next, NextOccurrence(tc.recurrence, tc.from), "%s from %s", tc.recurrence, tc.from)
+	}
+}
+
+func TestIsSupportedRecurrence(t *testing.T) {
+	require.True(t, IsSupportedRecurrence(MonthlyRecurrence))
+	require.False(t, IsSupportedRecurrence("yearly"))
+}
@@ -2,8 +2,13 @@ package val
 
 import (
 	"fmt"
+	"net"
 	"net/mail"
+	"net/url"
 	"regexp"
+
+	"github.com/MathPeixoto/go-financial-system/util"
+	"github.com/MathPeixoto/go-financial-system/webhook"
 )
 
 var (
@@ -62,3 +67,80 @@ func ValidateEmail(email string) error {
 
 	return nil
 }
+
+// ValidateWebhookURL Function named ValidateWebhookURL responsible for validating if a webhook endpoint URL is valid.
+// Unless allowInsecure, meant for development, the URL must be https and not name a local or private host.
+func ValidateWebhookURL(value string, allowInsecure bool) error {
+	if err := ValidateString(value, 10, 2048); err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+
+	endpoint, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+
+	if (endpoint.Scheme != "https" && endpoint.Scheme != "http") || endpoint.Host == "" {
+		return fmt.Errorf("invalid url: must be an absolute http or https url")
+	}
+
+	if allowInsecure {
+		return nil
+	}
+
+	if endpoint.Scheme != "https" {
+		return fmt.Errorf("invalid url: must be an https url")
+	}
+
+	host := endpoint.Hostname()
+	if ip := net.ParseIP(host); host == "localhost" || (ip != nil && !webhook.IsPublicIP(ip)) {
+		return fmt.Errorf("invalid url: must not be a local or private host")
+	}
+
+	return nil
+}
+
+// ValidateID Function named ValidateID responsible for validating if an identifier is valid
+func ValidateID(id int64) error {
+	if id < 1 {
+		return fmt.Errorf("must be a positive number")
+	}
+
+	return nil
+}
+
+// ValidateAmount Function named ValidateAmount responsible for validating if a transfer amount is valid
+func ValidateAmount(amount int64) error {
+	if amount < 1 {
+		return fmt.Errorf("must be a positive number")
+	}
+
+	return nil
+}
+
+// ValidateCurrency Function named ValidateCurrency responsible for validating if a currency is supported
+func ValidateCurrency(currency string) error {
+	if !util.IsSupportedCurrency(currency) {
+		return fmt.Errorf("unsupported currency %q", currency)
+	}
+
+	return nil
+}
+
+// ValidateRecurrence Function named ValidateRecurrence responsible for validating if a recurrence is supported
+func ValidateRecurrence(recurrence string) error {
+	if !util.IsSupportedRecurrence(recurrence) {
+		return fmt.Errorf("unsupported recurrence %q, must be one of once, daily, weekly or monthly", recurrence)
+	}
+
+	return nil
+}
+
+// ValidateNickname Function named ValidateNickname responsible for validating if a payee nickname is valid
+func ValidateNickname(nickname string) error {
+	if err := ValidateString(nickname, 1, 50); err != nil {
+		return fmt.Errorf("invalid nickname: %w", err)
+	}
+
+	return nil
+}
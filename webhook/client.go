@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrNonPublicAddress = errors.New("webhook endpoint resolves to a non-public address")
+	ErrInsecureScheme   = errors.New("webhook endpoint must use https")
+)
+
+// nonPublicNetworks are the shared, reserved and translation ranges not covered by the net.IP predicates
+var nonPublicNetworks = parseCIDRs(
+	"0.0.0.0/8",
+	"100.64.0.0/10",
+	"192.0.0.0/24",
+	"192.0.2.0/24",
+	"198.18.0.0/15",
+	"198.51.100.0/24",
+	"203.0.113.0/24",
+	"240.0.0.0/4",
+	"64:ff9b::/96",
+	"2001:db8::/32",
+)
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+// IsPublicIP reports whether ip is a routable address of the internet, as opposed to the loopback, private,
+// link-local (including the cloud metadata endpoint), multicast and reserved ones
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewClient creates the client posting to the webhook endpoints. It doesn't follow redirects, and unless allowInsecure,
+// which is meant for development, it only posts over https and refuses to connect to non-public addresses.
+// The addresses are checked once resolved, when connecting, so that a host resolving to another address than
+// when the endpoint was registered cannot reach the internal network.
+func NewClient(timeout time.Duration, allowInsecure bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+	}
+	if !allowInsecure {
+		dialer.Control = controlPublicAddress
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would connect on our behalf, out of reach of the address check
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: &schemeTransport{next: transport, allowInsecure: allowInsecure},
+		Timeout:   timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func controlPublicAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrNonPublicAddress, host)
+	}
+
+	return nil
+}
+
+// schemeTransport refuses the plain http requests, unless allowInsecure
+type schemeTransport struct {
+	next          http.RoundTripper
+	allowInsecure bool
+}
+
+func (transport *schemeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme != "https" && !transport.allowInsecure {
+		return nil, ErrInsecureScheme
+	}
+
+	return transport.next.RoundTrip(req)
+}
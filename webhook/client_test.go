@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	testCases := []struct {
+		ip     string
+		public bool
+	}{
+		{ip: "8.8.8.8", public: true},
+		{ip: "2606:4700:4700::1111", public: true},
+		{ip: "127.0.0.1"},
+		{ip: "10.1.2.3"},
+		{ip: "172.16.0.1"},
+		{ip: "192.168.1.1"},
+		{ip: "169.254.169.254"},
+		{ip: "100.64.0.1"},
+		{ip: "0.0.0.0"},
+		{ip: "::1"},
+		{ip: "fe80::1"},
+		{ip: "fd00::1"},
+		{ip: "::ffff:127.0.0.1"},
+		{ip: "224.0.0.1"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.ip, func(t *testing.T) {
+			require.Equal(t, testCase.public, IsPublicIP(net.ParseIP(testCase.ip)))
+		})
+	}
+}
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/target", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	t.Run("NonPublicAddress", func(t *testing.T) {
+		client := NewClient(time.Second, false)
+		// the scheme is checked before connecting, so go through the dialer directly
+		transport := client.Transport.(*schemeTransport).next
+		req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+		require.NoError(t, err)
+
+		_, err = transport.RoundTrip(req)
+		require.ErrorIs(t, err, ErrNonPublicAddress)
+	})
+
+	t.Run("InsecureScheme", func(t *testing.T) {
+		_, err := NewClient(time.Second, false).Post(server.URL, "application/json", nil)
+		require.ErrorIs(t, err, ErrInsecureScheme)
+	})
+
+	t.Run("NoRedirect", func(t *testing.T) {
+		resp, err := NewClient(time.Second, true).Post(server.URL+"/redirect", "application/json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+	})
+
+	t.Run("AllowInsecure", func(t *testing.T) {
+		resp, err := NewClient(time.Second, true).Post(server.URL, "application/json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
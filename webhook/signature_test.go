@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	secret, err := NewSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, len(secretPrefix)+64)
+
+	body := []byte(`{"type": "transfer.completed"}`)
+	now := time.Now()
+
+	testCases := []struct {
+		name   string
+		secret string
+		header string
+		body   []byte
+		err    error
+	}{
+		{
+			name:   "OK",
+			secret: secret,
+			header: Sign(secret, now, body),
+			body:   body,
+		},
+		{
+			name:   "WrongSecret",
+			secret: secret + "x",
+			header: Sign(secret, now, body),
+			body:   body,
+			err:    ErrInvalidSignature,
+		},
+		{
+			name:   "TamperedBody",
+			secret: secret,
+			header: Sign(secret, now, body),
+			body:   []byte(`{"type": "account.frozen"}`),
+			err:    ErrInvalidSignature,
+		},
+		{
+			name:   "Expired",
+			secret: secret,
+			header: Sign(secret, now.Add(-10*time.Minute), body),
+			body:   body,
+			err:    ErrExpiredSignature,
+		},
+		{
+			name:   "MalformedHeader",
+			secret: secret,
+			header: "v1",
+			body:   body,
+			err:    ErrInvalidSignature,
+		},
+		{
+			name:   "MissingTimestamp",
+			secret: secret,
+			header: "v1=abc",
+			body:   body,
+			err:    ErrInvalidSignature,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			err := Verify(tc.secret, tc.header, tc.body, 5*time.Minute)
+			if tc.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
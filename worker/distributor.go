@@ -2,11 +2,20 @@ package worker
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/MathPeixoto/go-financial-system/redact"
+	"github.com/MathPeixoto/go-financial-system/requestid"
+	"github.com/MathPeixoto/go-financial-system/tracing"
 	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
 )
 
 type TaskDistributor interface {
+	DistributeTask(ctx context.Context, taskType string, payload []byte, opts ...asynq.Option) error
 	DistributeTaskSendVerifyEmail(ctx context.Context, payload *PayloadSendVerifyEmail, opts ...asynq.Option) error
+	// Close releases the connection to the queue
+	Close() error
 }
 
 type RedisDistributor struct {
@@ -18,3 +27,27 @@ func NewRedisTaskDistributor(redisOpt asynq.RedisConnOpt) TaskDistributor {
 		client: asynq.NewClient(redisOpt),
 	}
 }
+
+// DistributeTask enqueues a task whose payload is already encoded, adding the trace context and the request ID of ctx to it
+func (r *RedisDistributor) DistributeTask(ctx context.Context, taskType string, payload []byte, opts ...asynq.Option) error {
+	payload = requestid.InjectPayload(ctx, tracing.InjectPayload(ctx, payload))
+	task := asynq.NewTask(taskType, payload, opts...)
+	info, err := r.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Str("type", task.Type()).
+		RawJSON("payload", redact.JSON(task.Payload())).
+		Str("task_id", info.ID).
+		Str("queue", info.Queue).
+		Int("max retries", info.MaxRetry).
+		Msg("task sent to queue")
+
+	return nil
+}
+
+func (r *RedisDistributor) Close() error {
+	return r.client.Close()
+}
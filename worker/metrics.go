@@ -0,0 +1,22 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/MathPeixoto/go-financial-system/metrics"
+	"github.com/hibiken/asynq"
+)
+
+// taskMetrics records the throughput, the failures and the retries of the tasks by task type and queue
+func taskMetrics(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		queue, _ := asynq.GetQueueName(ctx)
+		retry, _ := asynq.GetRetryCount(ctx)
+
+		startTime := time.Now()
+		err := next.ProcessTask(ctx, task)
+		metrics.ObserveTask(task.Type(), queue, retry, time.Since(startTime), err)
+		return err
+	})
+}
@@ -0,0 +1,102 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	outboxRelayInterval  = time.Second
+	outboxRelayBatchSize = 100
+)
+
+// NewOutboxMessage builds an outbox message that the relay publishes as a task once the transaction writing it commits
+func NewOutboxMessage(
+	taskType string, payload interface{}, queue string, maxRetry int, processAt time.Time,
+) (db.CreateOutboxMessageParams, error) {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return db.CreateOutboxMessageParams{}, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return db.CreateOutboxMessageParams{
+		TaskType:  taskType,
+		Payload:   jsonPayload,
+		Queue:     queue,
+		MaxRetry:  int32(maxRetry),
+		ProcessAt: processAt,
+	}, nil
+}
+
+// OutboxRelay publishes the pending outbox messages through a TaskDistributor
+type OutboxRelay struct {
+	store       db.Store
+	distributor TaskDistributor
+}
+
+func NewOutboxRelay(store db.Store, distributor TaskDistributor) *OutboxRelay {
+	return &OutboxRelay{
+		store:       store,
+		distributor: distributor,
+	}
+}
+
+// Run polls the outbox until the context is done
+func (relay *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(outboxRelayInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := relay.RelayPending(ctx); err != nil {
+				log.Error().Err(err).Msg("cannot relay outbox messages")
+			}
+		}
+	}
+}
+
+// RelayPending publishes batches of pending messages until none is left to publish
+func (relay *OutboxRelay) RelayPending(ctx context.Context) error {
+	for {
+		result, err := relay.store.RelayOutboxTx(ctx, db.RelayOutboxTxParams{
+			Limit:   outboxRelayBatchSize,
+			Publish: func(message db.Outbox) error { return relay.publish(ctx, message) },
+		})
+		if err != nil {
+			return err
+		}
+
+		if result.Failed > 0 {
+			log.Warn().Int("sent", result.Sent).Int("failed", result.Failed).Msg("outbox messages failed to publish")
+		}
+
+		if result.Sent+result.Failed < outboxRelayBatchSize {
+			return nil
+		}
+	}
+}
+
+func (relay *OutboxRelay) publish(ctx context.Context, message db.Outbox) error {
+	err := relay.distributor.DistributeTask(ctx, message.TaskType, message.Payload,
+		// the task ID deduplicates a message published again because marking it as sent failed
+		asynq.TaskID(fmt.Sprintf("outbox:%d", message.ID)),
+		asynq.Queue(message.Queue),
+		asynq.MaxRetry(int(message.MaxRetry)),
+		asynq.ProcessAt(message.ProcessAt),
+	)
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		return nil
+	}
+
+	return err
+}
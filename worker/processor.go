@@ -3,8 +3,12 @@ package worker
 import (
 	"context"
 	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/mail"
+	"github.com/MathPeixoto/go-financial-system/redact"
 	"github.com/hibiken/asynq"
 	"github.com/rs/zerolog/log"
+	"net/http"
+	"time"
 )
 
 const (
@@ -14,21 +18,50 @@ const (
 
 type TaskProcessor interface {
 	Start() error
+	// Shutdown stops pulling tasks and waits for the active ones up to the shutdown timeout of the processor
+	Shutdown()
 	ProcessSendVerifyEmail(ctx context.Context, task *asynq.Task) error
+	ProcessDeliverWebhook(ctx context.Context, task *asynq.Task) error
+	ProcessRunDueScheduledTransfers(ctx context.Context, task *asynq.Task) error
+	ProcessSendScheduledTransferFailed(ctx context.Context, task *asynq.Task) error
+	ProcessExpireHolds(ctx context.Context, task *asynq.Task) error
+	ProcessAccrueInterest(ctx context.Context, task *asynq.Task) error
+	ProcessPostInterest(ctx context.Context, task *asynq.Task) error
 }
 
 type RedisTaskProcessor struct {
-	server *asynq.Server
-	store  db.Store
+	server        *asynq.Server
+	store         db.Store
+	mailer        mail.EmailSender
+	webhookClient *http.Client
 }
 
 func (r *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
+	mux.Use(taskRequestID, taskTracing, taskMetrics)
 	mux.HandleFunc(TaskSendVerifyEmail, r.ProcessSendVerifyEmail)
+	mux.HandleFunc(db.TaskDeliverWebhook, r.ProcessDeliverWebhook)
+	mux.HandleFunc(TaskRunDueScheduledTransfers, r.ProcessRunDueScheduledTransfers)
+	mux.HandleFunc(db.TaskSendScheduledTransferFailed, r.ProcessSendScheduledTransferFailed)
+	mux.HandleFunc(TaskExpireHolds, r.ProcessExpireHolds)
+	mux.HandleFunc(TaskAccrueInterest, r.ProcessAccrueInterest)
+	mux.HandleFunc(TaskPostInterest, r.ProcessPostInterest)
 	return r.server.Start(mux)
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store) TaskProcessor {
+func (r *RedisTaskProcessor) Shutdown() {
+	r.server.Shutdown()
+}
+
+// NewRedisTaskProcessor creates a task processor, whose shutdown waits up to shutdownTimeout for the active tasks,
+// which are then pushed back to their queue to run again. The webhooks are posted with webhookClient.
+func NewRedisTaskProcessor(
+	redisOpt asynq.RedisClientOpt,
+	store db.Store,
+	mailer mail.EmailSender,
+	webhookClient *http.Client,
+	shutdownTimeout time.Duration,
+) TaskProcessor {
 	return &RedisTaskProcessor{
 		server: asynq.NewServer(
 			redisOpt,
@@ -38,14 +71,18 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store) TaskPr
 					QueueDefault:  5,
 				},
 				ErrorHandler: asynq.ErrorHandlerFunc(func(ctx context.Context, task *asynq.Task, err error) {
-					log.Error().
+					// the error handler gets the context of the task before the middlewares
+					log.Ctx(taskContext(ctx, task)).Error().
 						Err(err).
 						Str("type", task.Type()).
-						Bytes("payload", task.Payload()).
+						RawJSON("payload", redact.JSON(task.Payload())).
 						Msg("Error processing task")
 				}),
-				Logger: NewLogger(),
+				Logger:          NewLogger(),
+				ShutdownTimeout: shutdownTimeout,
 			}),
-		store: store,
+		store:         store,
+		mailer:        mailer,
+		webhookClient: webhookClient,
 	}
 }
@@ -0,0 +1,24 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/MathPeixoto/go-financial-system/requestid"
+	"github.com/hibiken/asynq"
+)
+
+// taskRequestID carries the ID of the request that enqueued a task with its logger through the context of the task
+func taskRequestID(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		return next.ProcessTask(taskContext(ctx, task), task)
+	})
+}
+
+// taskContext returns ctx carrying the request ID of a task, which is its task ID when it wasn't enqueued by a request
+func taskContext(ctx context.Context, task *asynq.Task) context.Context {
+	id := requestid.FromPayload(task.Payload())
+	if id == "" {
+		id, _ = asynq.GetTaskID(ctx)
+	}
+	return requestid.NewContext(ctx, id)
+}
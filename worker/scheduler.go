@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// periodicTasks are enqueued by the scheduler on a cron spec, in UTC
+var periodicTasks = []struct {
+	taskType string
+	cronspec string
+	// unique keeps a slow run from piling up with the next ones
+	unique time.Duration
+	// maxRetry is zero for the scans, whose next run comes soon, and retries the tasks that run daily or monthly
+	maxRetry int
+}{
+	{TaskRunDueScheduledTransfers, "@every 1m", time.Minute, 0},
+	{TaskExpireHolds, "@every 1m", time.Minute, 0},
+	{TaskAccrueInterest, "@daily", time.Hour, 5},
+	{TaskPostInterest, "@monthly", time.Hour, 5},
+}
+
+// NewScheduler creates an asynq scheduler enqueueing the periodic tasks of the worker
+func NewScheduler(redisOpt asynq.RedisConnOpt) (*asynq.Scheduler, error) {
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Logger: NewLogger(),
+	})
+
+	for _, periodic := range periodicTasks {
+		_, err := scheduler.Register(
+			periodic.cronspec,
+			asynq.NewTask(periodic.taskType, nil),
+			asynq.Queue(QueueDefault),
+			asynq.MaxRetry(periodic.maxRetry),
+			asynq.Unique(periodic.unique),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to register periodic task %s: %w", periodic.taskType, err)
+		}
+	}
+
+	return scheduler, nil
+}
@@ -0,0 +1,86 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskAccrueInterest = "task:accrue_interest"
+
+const endOfDayBalancesLimit = 100
+
+// ProcessAccrueInterest accrues the interest of every day, in UTC, up to the previous one, on the end-of-day balance
+// of the accounts whose product pays interest. The days left unaccrued by the runs that were missed or failed
+// are accrued in order, stopping at the first one that fails so that it is retried first on the next run.
+func (r *RedisTaskProcessor) ProcessAccrueInterest(ctx context.Context, task *asynq.Task) error {
+	firstDay, err := r.store.GetFirstUnaccruedDay(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the first unaccrued day: %w", err)
+	}
+
+	yesterday := startOfDay(time.Now()).AddDate(0, 0, -1)
+	for day := startOfDay(firstDay); !day.After(yesterday); day = day.AddDate(0, 0, 1) {
+		if err := r.accrueInterest(ctx, day); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// accrueInterest accrues the interest of a day for the accounts that have not accrued it yet.
+// An account failing to accrue does not stop the others, it is retried on the next run.
+func (r *RedisTaskProcessor) accrueInterest(ctx context.Context, day time.Time) error {
+	var accrued, failed int
+	for {
+		balances, err := r.store.ListEndOfDayBalances(ctx, db.ListEndOfDayBalancesParams{
+			EndOfDay:    day.AddDate(0, 0, 1),
+			AccrualDate: day,
+			Limit:       endOfDayBalancesLimit,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list end-of-day balances: %w", err)
+		}
+
+		for _, balance := range balances {
+			_, err := r.store.AccrueInterest(ctx, db.AccrueInterestParams{
+				AccountID:             balance.AccountID,
+				AccrualDate:           day,
+				Balance:               balance.Balance,
+				AnnualInterestRateBps: balance.AnnualInterestRateBps,
+			})
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				failed++
+				log.Ctx(ctx).Error().Err(err).Int64("account_id", balance.AccountID).Msg("failed to accrue interest")
+				continue
+			}
+			accrued++
+		}
+
+		// accounts that failed would be listed again, so they wait for the next run
+		if failed > 0 || len(balances) < endOfDayBalancesLimit {
+			break
+		}
+	}
+
+	log.Ctx(ctx).Info().Time("accrual_date", day).Int("accounts", accrued).Msg("interest accrued")
+
+	if failed > 0 {
+		return fmt.Errorf("failed to accrue interest of %d accounts", failed)
+	}
+
+	return nil
+}
+
+// startOfDay returns the midnight, in UTC, starting the day of t
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
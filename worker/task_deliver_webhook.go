@@ -0,0 +1,132 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/MathPeixoto/go-financial-system/webhook"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+// WebhookTimeout bounds a delivery attempt, from connecting to the endpoint to reading its response
+const WebhookTimeout = 10 * time.Second
+
+// webhookEvent is the body posted to webhook endpoints
+type webhookEvent struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// ProcessDeliverWebhook posts a domain event to a webhook endpoint and records the attempt in the delivery log.
+// A failed attempt returns an error, so that asynq retries it with an exponential backoff.
+func (r *RedisTaskProcessor) ProcessDeliverWebhook(ctx context.Context, task *asynq.Task) error {
+	var payload db.PayloadDeliverWebhook
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	delivery, err := r.store.GetWebhookDelivery(ctx, payload.DeliveryID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	endpoint, err := r.store.GetWebhookEndpoint(ctx, delivery.EndpointID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+
+	if !endpoint.IsActive {
+		_, err = r.store.UpdateWebhookDeliveryAttempt(ctx, db.UpdateWebhookDeliveryAttemptParams{
+			ID:        delivery.ID,
+			Status:    db.WebhookDeliveryFailed,
+			LastError: "webhook endpoint is inactive",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update webhook delivery: %w", err)
+		}
+		return nil
+	}
+
+	event, err := r.store.GetDomainEvent(ctx, delivery.EventID)
+	if err != nil {
+		return fmt.Errorf("failed to get domain event: %w", err)
+	}
+
+	responseStatus, deliveryErr := r.postWebhook(ctx, endpoint, delivery, event)
+
+	arg := db.UpdateWebhookDeliveryAttemptParams{
+		ID:             delivery.ID,
+		Status:         db.WebhookDeliverySucceeded,
+		ResponseStatus: int32(responseStatus),
+	}
+	if deliveryErr != nil {
+		arg.Status = db.WebhookDeliveryFailed
+		arg.LastError = deliveryErr.Error()
+	}
+
+	if _, err := r.store.UpdateWebhookDeliveryAttempt(ctx, arg); err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	if deliveryErr != nil {
+		return fmt.Errorf("failed to deliver webhook: %w", deliveryErr)
+	}
+
+	log.Ctx(ctx).Info().
+		Str("type", task.Type()).
+		Int64("delivery_id", delivery.ID).
+		Str("event", event.EventType).
+		Int("status", responseStatus).
+		Msg("webhook delivered")
+
+	return nil
+}
+
+// postWebhook sends the signed event and returns the response status, which must be 2xx for a successful delivery
+func (r *RedisTaskProcessor) postWebhook(
+	ctx context.Context, endpoint db.WebhookEndpoint, delivery db.WebhookDelivery, event db.DomainEvent,
+) (int, error) {
+	body, err := json.Marshal(webhookEvent{
+		ID:        event.ID,
+		Type:      event.EventType,
+		CreatedAt: event.CreatedAt,
+		Data:      event.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, WebhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.EventHeader, event.EventType)
+	req.Header.Set(webhook.DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(endpoint.Secret, time.Now(), body))
+
+	resp, err := r.webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// the response body is never recorded, as the delivery log is shown to the owner of the endpoint
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskExpireHolds = "task:expire_holds"
+
+const (
+	expiredHoldsLimit = 100
+	// holdExpiryActor is recorded in the audit log as the actor expiring holds
+	holdExpiryActor = "system"
+)
+
+// ProcessExpireHolds releases the pending holds past their expiry, restoring the available balance of their accounts.
+// A hold failing to expire does not stop the others, it is retried on the next run.
+func (r *RedisTaskProcessor) ProcessExpireHolds(ctx context.Context, task *asynq.Task) error {
+	now := time.Now()
+
+	holds, err := r.store.ListExpiredHolds(ctx, db.ListExpiredHoldsParams{
+		ExpiredAt: now,
+		Limit:     expiredHoldsLimit,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list expired holds: %w", err)
+	}
+
+	var failed int
+	for _, hold := range holds {
+		_, err := r.store.ReleaseHoldTx(ctx, db.ReleaseHoldTxParams{
+			ID:     hold.ID,
+			Status: db.HoldExpired,
+			Now:    now,
+			Audit:  db.AuditParams{Actor: holdExpiryActor},
+		})
+		if err != nil {
+			failed++
+			log.Ctx(ctx).Error().Err(err).Int64("hold_id", hold.ID).Msg("failed to expire hold")
+			continue
+		}
+
+		log.Ctx(ctx).Info().Int64("hold_id", hold.ID).Int64("account_id", hold.AccountID).Msg("hold expired")
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to expire %d of %d holds", failed, len(holds))
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskPostInterest = "task:post_interest"
+
+const (
+	unpostedInterestLimit = 100
+	// interestPostingActor is recorded in the audit log as the actor posting interest
+	interestPostingActor = "system"
+)
+
+// ProcessPostInterest posts the interest accrued in the previous month, in UTC, to each account.
+// It accrues the last day of the month first, since the daily accrual may not have run for it yet.
+// Each account is posted at most once per month, so a run can be retried safely.
+func (r *RedisTaskProcessor) ProcessPostInterest(ctx context.Context, task *asynq.Task) error {
+	today := startOfDay(time.Now())
+	periodEnd := today.AddDate(0, 0, 1-today.Day())
+	periodStart := periodEnd.AddDate(0, -1, 0)
+
+	err := r.accrueInterest(ctx, periodEnd.AddDate(0, 0, -1))
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	var afterAccountID int64
+	for {
+		accountIDs, err := r.store.ListAccountsWithUnpostedInterest(ctx, db.ListAccountsWithUnpostedInterestParams{
+			PeriodEnd:      periodEnd,
+			AfterAccountID: afterAccountID,
+			PeriodStart:    periodStart,
+			Limit:          unpostedInterestLimit,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list accounts with unposted interest: %w", err)
+		}
+
+		for _, accountID := range accountIDs {
+			afterAccountID = accountID
+
+			result, err := r.store.PostInterestTx(ctx, db.PostInterestTxParams{
+				AccountID:   accountID,
+				PeriodStart: periodStart,
+				PeriodEnd:   periodEnd,
+				Audit:       db.AuditParams{Actor: interestPostingActor},
+			})
+			if err != nil {
+				if errors.Is(err, db.ErrInterestAlreadyPosted) {
+					continue
+				}
+				// the accruals of a frozen account are kept unposted, and posted with a later period once it is unfrozen
+				if errors.Is(err, db.ErrAccountFrozen) {
+					log.Ctx(ctx).Warn().Int64("account_id", accountID).Msg("interest of frozen account not posted")
+					continue
+				}
+				failed++
+				log.Ctx(ctx).Error().Err(err).Int64("account_id", accountID).Msg("failed to post interest")
+				continue
+			}
+
+			log.Ctx(ctx).Info().
+				Int64("account_id", accountID).
+				Int64("posting_id", result.Posting.ID).
+				Int64("amount", result.Posting.Amount).
+				Msg("interest posted")
+		}
+
+		// the accounts are listed by ID after the last one, so that the accounts that failed wait for the next run
+		if len(accountIDs) < unpostedInterestLimit {
+			break
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to post interest of %d accounts", failed)
+	}
+
+	return nil
+}
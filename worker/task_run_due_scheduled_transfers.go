@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskRunDueScheduledTransfers = "task:run_due_scheduled_transfers"
+
+const dueScheduledTransfersLimit = 100
+
+// ProcessRunDueScheduledTransfers executes the scheduled transfers that are due.
+// A transfer failing to execute does not stop the others, it is retried on the next scan.
+func (r *RedisTaskProcessor) ProcessRunDueScheduledTransfers(ctx context.Context, task *asynq.Task) error {
+	now := time.Now()
+
+	scheduledTransfers, err := r.store.ListDueScheduledTransfers(ctx, db.ListDueScheduledTransfersParams{
+		DueAt: now,
+		Limit: dueScheduledTransfersLimit,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list due scheduled transfers: %w", err)
+	}
+
+	var failed int
+	for _, scheduled := range scheduledTransfers {
+		result, err := r.store.ExecuteScheduledTransferTx(ctx, db.ExecuteScheduledTransferTxParams{
+			ID:  scheduled.ID,
+			Now: now,
+		})
+		if err != nil {
+			failed++
+			log.Ctx(ctx).Error().Err(err).Int64("scheduled_transfer_id", scheduled.ID).Msg("failed to execute scheduled transfer")
+			continue
+		}
+
+		if result.Run.ID != 0 {
+			log.Ctx(ctx).Info().
+				Int64("scheduled_transfer_id", scheduled.ID).
+				Int64("run_id", result.Run.ID).
+				Str("status", result.Run.Status).
+				Msg("scheduled transfer executed")
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to execute %d of %d due scheduled transfers", failed, len(scheduledTransfers))
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	db "github.com/MathPeixoto/go-financial-system/db/sqlc"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+// ProcessSendScheduledTransferFailed emails the owner of a scheduled transfer whose run failed
+func (r *RedisTaskProcessor) ProcessSendScheduledTransferFailed(ctx context.Context, task *asynq.Task) error {
+	var payload db.PayloadSendScheduledTransferFailed
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	run, err := r.store.GetScheduledTransferRun(ctx, payload.RunID)
+	if err != nil {
+		return fmt.Errorf("failed to get scheduled transfer run: %w", err)
+	}
+
+	scheduled, err := r.store.GetScheduledTransfer(ctx, run.ScheduledTransferID)
+	if err != nil {
+		return fmt.Errorf("failed to get scheduled transfer: %w", err)
+	}
+
+	user, err := r.store.GetUser(ctx, scheduled.Owner)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	subject := "Your scheduled transfer could not be executed"
+	content := fmt.Sprintf(`Hello %s,<br/>
+	Your scheduled transfer of %d %s from account %d to account %d due on %s could not be executed: %s.<br/>
+	`, user.FullName, scheduled.Amount, scheduled.Currency, scheduled.FromAccountID, scheduled.ToAccountID,
+		run.ScheduledFor.Format("2006-01-02 15:04 MST"), run.Error)
+
+	if err := r.mailer.SendEmail(subject, content, []string{user.Email}, nil, nil, nil); err != nil {
+		return fmt.Errorf("failed to send scheduled transfer failed email: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Str("type", task.Type()).
+		Int64("run_id", run.ID).
+		Str("username", user.Username).
+		Msg("scheduled transfer failure notified")
+
+	return nil
+}
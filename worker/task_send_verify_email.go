@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/MathPeixoto/go-financial-system/redact"
 	"github.com/hibiken/asynq"
 	"github.com/rs/zerolog/log"
 )
@@ -23,21 +24,7 @@ func (r *RedisDistributor) DistributeTaskSendVerifyEmail(
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	task := asynq.NewTask(TaskSendVerifyEmail, jsonPayload, opts...)
-	info, err := r.client.EnqueueContext(ctx, task)
-	if err != nil {
-		return fmt.Errorf("failed to enqueue task: %w", err)
-	}
-
-	log.Info().
-		Str("type", task.Type()).
-		Bytes("payload", task.Payload()).
-		Str("task_id", info.ID).
-		Str("queue", info.Queue).
-		Int("max retries", info.MaxRetry).
-		Msg("task sent to queue")
-
-	return nil
+	return r.DistributeTask(ctx, TaskSendVerifyEmail, jsonPayload, opts...)
 }
 
 func (r *RedisTaskProcessor) ProcessSendVerifyEmail(ctx context.Context, task *asynq.Task) error {
@@ -53,10 +40,10 @@ func (r *RedisTaskProcessor) ProcessSendVerifyEmail(ctx context.Context, task *a
 
 	// TODO send email
 
-	log.Info().
+	log.Ctx(ctx).Info().
 		Str("type", task.Type()).
-		Bytes("payload", task.Payload()).
-		Str("email", user.Email).
+		RawJSON("payload", redact.JSON(task.Payload())).
+		Str("username", user.Username).
 		Msg("task received")
 
 	return nil
@@ -0,0 +1,34 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/MathPeixoto/go-financial-system/tracing"
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// taskTracing traces the processing of a task as a child of the span of the request that enqueued it,
+// whose trace context is carried by the payload of the task
+func taskTracing(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		queue, _ := asynq.GetQueueName(ctx)
+		retry, _ := asynq.GetRetryCount(ctx)
+		taskID, _ := asynq.GetTaskID(ctx)
+
+		ctx = tracing.ExtractPayload(ctx, task.Payload())
+		ctx, span := tracing.Tracer().Start(ctx, task.Type(),
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				attribute.String("task.id", taskID),
+				attribute.String("task.queue", queue),
+				attribute.Int("task.retry", retry),
+			),
+		)
+
+		err := next.ProcessTask(ctx, task)
+		tracing.End(span, err)
+		return err
+	})
+}